@@ -0,0 +1,413 @@
+package business
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// User recorded in the history of the Istio config changed by the canary controller
+const canaryControllerUser = "kiali-canary-controller"
+
+// ConfigMap of the Kiali namespace holding the lease of the canary controller
+const canaryControllerLease = "kiali-canary-controller-lease"
+
+var canaryController *CanaryController
+var canaryControllerLock sync.Mutex
+
+// CanaryController progressively shifts the traffic of the services opted in with the kiali.io/canary annotation to
+// their canary version. Before each step it checks the error rate and the p99 response time of the canary, and rolls
+// the traffic back to the stable version when one of them crosses its threshold. The VirtualService and
+// DestinationRule of the service are generated with the weighted routing wizard, and every decision is recorded as
+// a Kubernetes event of the service.
+//
+// The controller acts with the Kiali ServiceAccount permissions, so only the services of the namespaces opted in by
+// the configuration are reconciled, and by a single Kiali replica: the one holding the controller lease.
+type CanaryController struct {
+	// Returns the business layer used for a reconciliation, with the Kiali ServiceAccount permissions
+	getLayer func() (*Layer, error)
+	interval time.Duration
+	lease    *ConfigMapLease
+	now      func() time.Time
+	stop     chan struct{}
+}
+
+// canarySettings are the step weight and thresholds of a service
+type canarySettings struct {
+	stepWeight    int
+	maxErrorRate  float64
+	maxP99Latency float64
+}
+
+// StartCanaryController starts the canary controller in background when it's enabled in the configuration
+func StartCanaryController() {
+	conf := config.Get().CanaryController
+	if !conf.Enabled {
+		return
+	}
+	canaryControllerLock.Lock()
+	defer canaryControllerLock.Unlock()
+	if canaryController != nil {
+		return
+	}
+
+	// Interval is validated when Kiali starts
+	interval, _ := time.ParseDuration(conf.Interval)
+	canaryController = newCanaryController(kialiSALayerLoader(), interval)
+	// The lease expires when its holder missed a few reconciliations
+	canaryController.lease = NewConfigMapLease(getKialiSAClient, config.Get().Deployment.Namespace, canaryControllerLease, 3*interval)
+	go canaryController.run()
+	log.Infof("Canary controller started, services of namespaces %v are reconciled every %s", conf.Namespaces, conf.Interval)
+}
+
+// StopCanaryController stops the canary controller, if it was started
+func StopCanaryController() {
+	canaryControllerLock.Lock()
+	defer canaryControllerLock.Unlock()
+	if canaryController != nil {
+		close(canaryController.stop)
+		canaryController = nil
+	}
+}
+
+func newCanaryController(getLayer func() (*Layer, error), interval time.Duration) *CanaryController {
+	return &CanaryController{getLayer: getLayer, interval: interval, now: time.Now, stop: make(chan struct{})}
+}
+
+func (in *CanaryController) run() {
+	ticker := time.NewTicker(in.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-in.stop:
+			return
+		case <-ticker.C:
+			if in.lease.Acquire() {
+				in.Reconcile()
+			}
+		}
+	}
+}
+
+// Reconcile takes the next step of the canary of every opted-in service of the namespaces of the configuration
+func (in *CanaryController) Reconcile() {
+	layer, err := in.getLayer()
+	if err != nil {
+		log.Errorf("Canary controller could not be initialized: %s", err)
+		return
+	}
+	for _, ns := range config.Get().CanaryController.Namespaces {
+		var services []core_v1.Service
+		if IsNamespaceCached(ns) {
+			services, err = kialiCache.GetServices(ns, nil)
+		} else {
+			services, err = layer.k8s.GetServices(ns, nil)
+		}
+		if err != nil {
+			log.Errorf("Canary controller could not list the services of namespace %s: %s", ns, err)
+			continue
+		}
+		for i := range services {
+			if _, found := services[i].Annotations[models.CanaryAnnotation]; found {
+				in.reconcileService(layer, &services[i])
+			}
+		}
+	}
+}
+
+func (in *CanaryController) reconcileService(layer *Layer, svc *core_v1.Service) {
+	canary := svc.Annotations[models.CanaryAnnotation]
+	settings, err := getCanarySettings(svc)
+	if err != nil {
+		log.Warningf("Canary of service %s/%s is ignored: %s", svc.Namespace, svc.Name, err)
+		return
+	}
+
+	weight := 0
+	vs, err := layer.k8s.GetIstioObject(svc.Namespace, kubernetes.VirtualServices, svc.Name)
+	if err == nil {
+		meta := vs.GetObjectMeta()
+		if meta.Labels[models.WizardLabel] != models.WizardWeightedRouting {
+			log.Warningf("Canary of service %s/%s is ignored: its VirtualService was not generated by the %s wizard", svc.Namespace, svc.Name, models.WizardWeightedRouting)
+			return
+		}
+		if strings.HasPrefix(meta.Annotations[models.CanaryStatusAnnotation], canary+"/") {
+			// Already promoted or rolled back
+			return
+		}
+		weight = wizardRouteWeights(vs)[canary]
+	} else if !errors2.IsNotFound(err) {
+		log.Errorf("Canary controller could not get the VirtualService of service %s/%s: %s", svc.Namespace, svc.Name, err)
+		return
+	}
+
+	stable, err := getCanaryStableVersion(layer, svc, canary)
+	if err != nil {
+		log.Warningf("Canary of service %s/%s is ignored: %s", svc.Namespace, svc.Name, err)
+		return
+	}
+
+	next := nextCanaryWeight(weight, settings.stepWeight)
+	message := fmt.Sprintf("Shifted %d%% of the traffic to canary version %s", next, canary)
+	if weight > 0 {
+		errorRate, p99, err := in.getCanaryMetrics(layer, svc, canary)
+		if err != nil {
+			// Never step without knowing how the canary behaves
+			log.Errorf("Canary controller could not get the metrics of service %s/%s: %s", svc.Namespace, svc.Name, err)
+			return
+		}
+		if errorRate > settings.maxErrorRate || p99 > settings.maxP99Latency {
+			breach := fmt.Sprintf("error rate %.2f%% (max %.2f%%), p99 %.0fms (max %.0fms)", errorRate, settings.maxErrorRate, p99, settings.maxP99Latency)
+			if in.shiftTraffic(layer, svc, stable, canary, 0) && in.setCanaryStatus(layer, svc, canary, models.CanaryRolledBack) {
+				in.recordEvent(layer, svc, core_v1.EventTypeWarning, models.CanaryEventRolledBack,
+					fmt.Sprintf("Rolled back canary version %s at %d%%, all the traffic goes to version %s: %s", canary, weight, stable, breach))
+			}
+			return
+		}
+		observed := fmt.Sprintf("error rate %.2f%%, p99 %.0fms", errorRate, p99)
+		if weight >= 100 {
+			if in.setCanaryStatus(layer, svc, canary, models.CanaryPromoted) {
+				in.recordEvent(layer, svc, core_v1.EventTypeNormal, models.CanaryEventPromoted,
+					fmt.Sprintf("Promoted canary version %s, it receives all the traffic: %s", canary, observed))
+			}
+			return
+		}
+		message += ": " + observed
+	}
+	if in.shiftTraffic(layer, svc, stable, canary, next) {
+		in.recordEvent(layer, svc, core_v1.EventTypeNormal, models.CanaryEventProgressing, message)
+	}
+}
+
+// shiftTraffic applies the weighted routing wizard splitting the traffic between the stable and canary versions.
+// Failures are recorded as events.
+func (in *CanaryController) shiftTraffic(layer *Layer, svc *core_v1.Service, stable, canary string, weight int) bool {
+	wizard := models.TrafficWizard{
+		Type: models.WizardWeightedRouting,
+		Routes: []models.TrafficWizardRoute{
+			{Version: stable, Weight: 100 - weight},
+			{Version: canary, Weight: weight},
+		},
+	}
+	result, err := layer.IstioConfig.ApplyTrafficWizard(svc.Namespace, svc.Name, wizard, false, canaryControllerUser)
+	if err == nil && !result.Import.Applied {
+		err = fmt.Errorf("the generated VirtualService or DestinationRule are not valid")
+	}
+	if err != nil {
+		in.recordEvent(layer, svc, core_v1.EventTypeWarning, models.CanaryEventFailed,
+			fmt.Sprintf("Could not shift %d%% of the traffic to canary version %s: %s", weight, canary, err))
+		return false
+	}
+	return true
+}
+
+// setCanaryStatus annotates the VirtualService of the service with the final status of the canary
+func (in *CanaryController) setCanaryStatus(layer *Layer, svc *core_v1.Service, canary, status string) bool {
+	patch, _ := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{models.CanaryStatusAnnotation: canary + "/" + status},
+		},
+	})
+	api := kubernetes.ResourceTypesToAPI[kubernetes.VirtualServices]
+	if _, err := layer.k8s.UpdateIstioObject(api, svc.Namespace, kubernetes.VirtualServices, svc.Name, string(patch)); err != nil {
+		in.recordEvent(layer, svc, core_v1.EventTypeWarning, models.CanaryEventFailed,
+			fmt.Sprintf("Could not set the %s status of canary version %s: %s", status, canary, err))
+		return false
+	}
+	return true
+}
+
+// getCanaryMetrics returns the percentage of 5xx responses of the canary version of the service and the p99 response
+// time of its slowest workload, in milliseconds. Both are zero when the canary had no traffic.
+func (in *CanaryController) getCanaryMetrics(layer *Layer, svc *core_v1.Service, canary string) (float64, float64, error) {
+	queryTime := in.now()
+	// Go durations like 1m30s are not valid in PromQL
+	rateInterval := fmt.Sprintf("%ds", int(in.interval.Seconds()))
+
+	rates, err := layer.Svc.prom.GetServiceRequestRates(svc.Namespace, svc.Name, rateInterval, queryTime)
+	if err != nil {
+		return 0, 0, err
+	}
+	total, errors := 0.0, 0.0
+	for _, sample := range rates {
+		if string(sample.Metric["destination_version"]) != canary {
+			continue
+		}
+		total += float64(sample.Value)
+		if strings.HasPrefix(string(sample.Metric["response_code"]), "5") {
+			errors += float64(sample.Value)
+		}
+	}
+	errorRate := 0.0
+	if total > 0 {
+		errorRate = 100 * errors / total
+	}
+
+	selector := labels.Set{}
+	for k, v := range svc.Spec.Selector {
+		selector[k] = v
+	}
+	selector[config.Get().IstioLabels.VersionLabelName] = canary
+	workloads, err := fetchWorkloads(layer, svc.Namespace, selector.String())
+	if err != nil {
+		return 0, 0, err
+	}
+	queries := []models.MetricsStatsQuery{}
+	for _, wl := range workloads {
+		queries = append(queries, models.MetricsStatsQuery{
+			Target:    models.Target{Namespace: svc.Namespace, Name: wl.Name, Kind: "workload"},
+			QueryTime: queryTime,
+			Interval:  rateInterval,
+			Direction: "inbound",
+			Quantiles: []string{p99Quantile},
+		})
+	}
+	stats, err := NewMetricsService(layer.Svc.prom).GetStats(queries)
+	if err != nil {
+		return 0, 0, err
+	}
+	p99 := 0.0
+	for _, s := range stats {
+		for _, rt := range s.ResponseTimes {
+			if rt.Name == p99Quantile && rt.Value > p99 {
+				p99 = rt.Value
+			}
+		}
+	}
+	return errorRate, p99, nil
+}
+
+// recordEvent records a decision of the controller as an event of the service
+func (in *CanaryController) recordEvent(layer *Layer, svc *core_v1.Service, eventType, reason, message string) {
+	now := meta_v1.NewTime(in.now())
+	event := &core_v1.Event{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", svc.Name, now.UnixNano()),
+			Namespace: svc.Namespace,
+		},
+		InvolvedObject: core_v1.ObjectReference{
+			APIVersion:      "v1",
+			Kind:            "Service",
+			Namespace:       svc.Namespace,
+			Name:            svc.Name,
+			UID:             svc.UID,
+			ResourceVersion: svc.ResourceVersion,
+		},
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Source:         core_v1.EventSource{Component: "kiali"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	log.Infof("Canary of service %s/%s: %s", svc.Namespace, svc.Name, message)
+	if _, err := layer.k8s.CreateEvent(svc.Namespace, event); err != nil {
+		log.Errorf("Canary controller could not record event %s of service %s/%s: %s", reason, svc.Namespace, svc.Name, err)
+	}
+}
+
+// getCanarySettings returns the settings of the configuration, overridden by the annotations of the service
+func getCanarySettings(svc *core_v1.Service) (canarySettings, error) {
+	conf := config.Get().CanaryController
+	settings := canarySettings{stepWeight: conf.StepWeight, maxErrorRate: conf.MaxErrorRate, maxP99Latency: conf.MaxP99Latency}
+	if value, found := svc.Annotations[models.CanaryStepWeightAnnotation]; found {
+		stepWeight, err := strconv.Atoi(value)
+		if err != nil || stepWeight <= 0 || stepWeight > 100 {
+			return settings, fmt.Errorf("%s must be between 1 and 100", models.CanaryStepWeightAnnotation)
+		}
+		settings.stepWeight = stepWeight
+	}
+	for annotation, setting := range map[string]*float64{
+		models.CanaryMaxErrorRateAnnotation:  &settings.maxErrorRate,
+		models.CanaryMaxP99LatencyAnnotation: &settings.maxP99Latency,
+	} {
+		if value, found := svc.Annotations[annotation]; found {
+			threshold, err := strconv.ParseFloat(value, 64)
+			if err != nil || threshold < 0 {
+				return settings, fmt.Errorf("%s must be a positive number", annotation)
+			}
+			*setting = threshold
+		}
+	}
+	return settings, nil
+}
+
+// getCanaryStableVersion returns the version receiving the traffic not sent to the canary: the one of the
+// kiali.io/canary-stable annotation, or the only other version of the service
+func getCanaryStableVersion(layer *Layer, svc *core_v1.Service, canary string) (string, error) {
+	versions, err := layer.IstioConfig.getServiceVersions(svc.Namespace, svc.Name)
+	if err != nil {
+		return "", err
+	}
+	others := []string{}
+	hasCanary := false
+	for _, v := range versions {
+		if v == canary {
+			hasCanary = true
+		} else {
+			others = append(others, v)
+		}
+	}
+	if !hasCanary {
+		return "", fmt.Errorf("canary version %s has no workloads", canary)
+	}
+	if stable, found := svc.Annotations[models.CanaryStableAnnotation]; found {
+		for _, v := range others {
+			if v == stable {
+				return stable, nil
+			}
+		}
+		return "", fmt.Errorf("stable version %s has no workloads", stable)
+	}
+	if len(others) != 1 {
+		return "", fmt.Errorf("%d versions besides the canary, the stable one must be set with %s", len(others), models.CanaryStableAnnotation)
+	}
+	return others[0], nil
+}
+
+// wizardRouteWeights returns the weight of each version in the default route of a VirtualService generated by the
+// weighted routing wizard
+func wizardRouteWeights(vs kubernetes.IstioObject) map[string]int {
+	weights := map[string]int{}
+	http, _ := vs.GetSpec()["http"].([]interface{})
+	if len(http) == 0 {
+		return weights
+	}
+	defaultRoute, _ := http[len(http)-1].(map[string]interface{})
+	routes, _ := defaultRoute["route"].([]interface{})
+	for _, r := range routes {
+		route, _ := r.(map[string]interface{})
+		destination, _ := route["destination"].(map[string]interface{})
+		subset, _ := destination["subset"].(string)
+		switch weight := route["weight"].(type) {
+		case float64:
+			weights[subset] = int(weight)
+		case int64:
+			weights[subset] = int(weight)
+		case int:
+			weights[subset] = weight
+		}
+	}
+	return weights
+}
+
+func nextCanaryWeight(weight, stepWeight int) int {
+	if weight+stepWeight > 100 {
+		return 100
+	}
+	return weight + stepWeight
+}
@@ -0,0 +1,173 @@
+package business
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+var canaryTime = time.Date(2021, 06, 01, 0, 0, 0, 0, time.UTC)
+
+func fakeCanaryController(t *testing.T, annotations string) (*CanaryController, *kubernetes.MemoryClient, *prometheustest.PromClientMock) {
+	conf := config.NewConfig()
+	conf.CanaryController.Namespaces = []string{"bookinfo"}
+	config.Set(conf)
+	k8s := kubernetes.NewMemoryClient()
+	k8s.AddConfigMap(core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: conf.ExternalServices.Istio.ConfigMapName, Namespace: conf.IstioNamespace}})
+	err := k8s.LoadYAML(strings.NewReader(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: bookinfo
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: reviews
+  annotations:
+`+annotations+`
+spec:
+  selector:
+    app: reviews
+  ports:
+  - name: http
+    port: 9080
+---
+`+reviewsPods), "bookinfo")
+	assert.NoError(t, err)
+
+	prom := new(prometheustest.PromClientMock)
+	controller := newCanaryController(func() (*Layer, error) {
+		layer := NewWithBackends(k8s, prom, nil)
+		layer.IstioConfig.history = NewMemoryHistoryStore(10)
+		return layer, nil
+	}, time.Minute)
+	controller.now = func() time.Time { return canaryTime }
+	return controller, k8s, prom
+}
+
+// mockCanaryMetrics returns the rates of the canary and stable versions, and the p99 of the canary workloads
+func mockCanaryMetrics(prom *prometheustest.PromClientMock, canaryErrors float64, p99 float64) {
+	sample := func(version, code string, value float64) *model.Sample {
+		return &model.Sample{
+			Metric: model.Metric{"destination_service_name": "reviews", "destination_version": model.LabelValue(version), "response_code": model.LabelValue(code)},
+			Value:  model.SampleValue(value),
+		}
+	}
+	prom.ExpectedCalls = nil
+	prom.On("GetServiceRequestRates", "bookinfo", "reviews", "60s", canaryTime).Return(model.Vector{
+		sample("v1", "503", 5),
+		sample("v1", "200", 5),
+		sample("v2", "200", 10-canaryErrors),
+		sample("v2", "500", canaryErrors),
+	}, nil)
+	prom.On("FetchHistogramValues", "istio_request_duration_milliseconds", mock.AnythingOfType("string"), "", "60s", false, []string{"0.99"}, canaryTime).
+		Return(map[string]model.Vector{"0.99": {&model.Sample{Value: model.SampleValue(p99)}}}, nil)
+}
+
+func canaryWeights(t *testing.T, k8s *kubernetes.MemoryClient) map[string]int {
+	vs, err := k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.NoError(t, err)
+	return wizardRouteWeights(vs)
+}
+
+func canaryEventReasons(k8s *kubernetes.MemoryClient) []string {
+	reasons := []string{}
+	for _, e := range k8s.GetEvents("bookinfo") {
+		reasons = append(reasons, e.Reason)
+	}
+	return reasons
+}
+
+func TestCanaryControllerPromotes(t *testing.T) {
+	assert := assert.New(t)
+	controller, k8s, prom := fakeCanaryController(t, `    kiali.io/canary: v2
+    kiali.io/canary-step-weight: "60"`)
+
+	// First step doesn't need metrics
+	controller.Reconcile()
+	assert.Equal(map[string]int{"v1": 40, "v2": 60}, canaryWeights(t, k8s))
+	prom.AssertNotCalled(t, "GetServiceRequestRates", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// Errors of the stable version don't count
+	mockCanaryMetrics(prom, 0, 120)
+	controller.Reconcile()
+	assert.Equal(map[string]int{"v1": 0, "v2": 100}, canaryWeights(t, k8s))
+
+	controller.Reconcile()
+	vs, _ := k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.Equal("v2/"+models.CanaryPromoted, vs.GetObjectMeta().Annotations[models.CanaryStatusAnnotation])
+
+	// Nothing else happens once promoted
+	controller.Reconcile()
+	assert.Equal([]string{models.CanaryEventProgressing, models.CanaryEventProgressing, models.CanaryEventPromoted}, canaryEventReasons(k8s))
+	events := k8s.GetEvents("bookinfo")
+	assert.Equal("Shifted 100% of the traffic to canary version v2: error rate 0.00%, p99 120ms", events[1].Message)
+	assert.Equal(core_v1.ObjectReference{APIVersion: "v1", Kind: "Service", Namespace: "bookinfo", Name: "reviews"}, events[2].InvolvedObject)
+	assert.Equal(core_v1.EventTypeNormal, events[2].Type)
+}
+
+func TestCanaryControllerRollsBack(t *testing.T) {
+	assert := assert.New(t)
+	controller, k8s, prom := fakeCanaryController(t, `    kiali.io/canary: v2
+    kiali.io/canary-max-p99-latency: "200"`)
+
+	controller.Reconcile()
+	assert.Equal(map[string]int{"v1": 90, "v2": 10}, canaryWeights(t, k8s))
+
+	mockCanaryMetrics(prom, 0, 150)
+	controller.Reconcile()
+	assert.Equal(map[string]int{"v1": 80, "v2": 20}, canaryWeights(t, k8s))
+
+	// 10% of errors with the default 5% threshold
+	mockCanaryMetrics(prom, 1, 150)
+	controller.Reconcile()
+	assert.Equal(map[string]int{"v1": 100, "v2": 0}, canaryWeights(t, k8s))
+	vs, _ := k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.Equal("v2/"+models.CanaryRolledBack, vs.GetObjectMeta().Annotations[models.CanaryStatusAnnotation])
+
+	controller.Reconcile()
+	assert.Equal([]string{models.CanaryEventProgressing, models.CanaryEventProgressing, models.CanaryEventRolledBack}, canaryEventReasons(k8s))
+	rollback := k8s.GetEvents("bookinfo")[2]
+	assert.Equal(core_v1.EventTypeWarning, rollback.Type)
+	assert.Equal("Rolled back canary version v2 at 20%, all the traffic goes to version v1: error rate 10.00% (max 5.00%), p99 150ms (max 200ms)", rollback.Message)
+}
+
+func TestCanaryControllerIgnoresInvalidServices(t *testing.T) {
+	assert := assert.New(t)
+	controller, k8s, _ := fakeCanaryController(t, `    kiali.io/canary: v3`)
+	controller.Reconcile()
+	_, err := k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.Error(err)
+
+	controller, k8s, _ = fakeCanaryController(t, `    kiali.io/canary: v2
+    kiali.io/canary-step-weight: "0"`)
+	controller.Reconcile()
+	_, err = k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.Error(err)
+	assert.Empty(k8s.GetEvents("bookinfo"))
+}
+
+func TestCanaryControllerIgnoresNamespacesNotOptedIn(t *testing.T) {
+	assert := assert.New(t)
+	controller, k8s, _ := fakeCanaryController(t, `    kiali.io/canary: v2`)
+	conf := config.Get()
+	conf.CanaryController.Namespaces = []string{"default"}
+	config.Set(conf)
+
+	controller.Reconcile()
+	_, err := k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.Error(err)
+	assert.Empty(k8s.GetEvents("bookinfo"))
+}
@@ -0,0 +1,78 @@
+package authorization
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/business/checkers/common"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type AllowDenyConflictChecker struct {
+	AuthorizationPolicies []kubernetes.IstioObject
+}
+
+type policyRule struct {
+	policy  kubernetes.IstioObject
+	ruleIdx int
+	rule    map[string]interface{}
+}
+
+// Check flags ALLOW and DENY policies applied to the same workloads which contain the same rule.
+// As DENY policies are evaluated first, the matching ALLOW rule never takes effect.
+// A DENY policy with an empty rule conflicts with any ALLOW rule.
+func (c AllowDenyConflictChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	allowRules := map[string][]policyRule{}
+	denyRules := map[string][]policyRule{}
+
+	for _, ap := range c.AuthorizationPolicies {
+		selector := ap.GetObjectMeta().Namespace + "/" + labels.Set(common.GetSelectorLabels(ap)).String()
+		action := getAction(ap)
+		for ruleIdx, rule := range getRules(ap) {
+			if rule == nil {
+				continue
+			}
+			pr := policyRule{policy: ap, ruleIdx: ruleIdx, rule: rule}
+			switch action {
+			case "ALLOW":
+				allowRules[selector] = append(allowRules[selector], pr)
+			case "DENY":
+				denyRules[selector] = append(denyRules[selector], pr)
+			}
+		}
+	}
+
+	for selector, denies := range denyRules {
+		for _, deny := range denies {
+			for _, allow := range allowRules[selector] {
+				if !isEmptyRule(deny.rule) && !reflect.DeepEqual(deny.rule, allow.rule) {
+					continue
+				}
+				allowValidation := buildConflict(allow)
+				denyValidation := buildConflict(deny)
+				allowValidation.MergeReferences(denyValidation)
+				denyValidation.MergeReferences(allowValidation)
+				validations.MergeValidations(allowValidation)
+				validations.MergeValidations(denyValidation)
+			}
+		}
+	}
+
+	return validations
+}
+
+func buildConflict(pr policyRule) models.IstioValidations {
+	key := models.BuildKey(objectType, pr.policy.GetObjectMeta().Name, pr.policy.GetObjectMeta().Namespace)
+	check := models.Build("authorizationpolicy.allowdeny.conflict", fmt.Sprintf("spec/rules[%d]", pr.ruleIdx))
+	return models.IstioValidations{key: &models.IstioValidation{
+		Name:       pr.policy.GetObjectMeta().Name,
+		ObjectType: objectType,
+		Valid:      true,
+		Checks:     []*models.IstioCheck{&check},
+	}}
+}
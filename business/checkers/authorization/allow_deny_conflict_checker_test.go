@@ -0,0 +1,67 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data/validations"
+)
+
+// Context: ALLOW and DENY policies with the same selector
+// Context: Both policies have an identical rule
+// It returns a validation for both policies
+func TestAllowDenySameRule(t *testing.T) {
+	assert := assert.New(t)
+	vals := allowDenyConflictTestPrep("allow_deny_conflict_1.yaml", t)
+
+	ta := validations.ValidationsTestAsserter{T: t, Validations: vals}
+	ta.AssertValidationsPresent(2)
+
+	allow := vals[models.BuildKey("authorizationpolicy", "allow-get", "bookinfo")]
+	assert.NotNil(allow)
+	assert.True(allow.Valid)
+	assert.Len(allow.Checks, 1)
+	assert.Equal("spec/rules[1]", allow.Checks[0].Path)
+	assert.Equal(models.CheckMessage("authorizationpolicy.allowdeny.conflict"), allow.Checks[0].Message)
+	assert.Equal([]models.IstioValidationKey{models.BuildKey("authorizationpolicy", "deny-get", "bookinfo")}, allow.References)
+
+	deny := vals[models.BuildKey("authorizationpolicy", "deny-get", "bookinfo")]
+	assert.NotNil(deny)
+	assert.Equal("spec/rules[0]", deny.Checks[0].Path)
+	assert.Equal([]models.IstioValidationKey{models.BuildKey("authorizationpolicy", "allow-get", "bookinfo")}, deny.References)
+}
+
+// Context: ALLOW and DENY policies with different selectors or different rules
+// It doesn't return any validation
+func TestAllowDenyNoConflict(t *testing.T) {
+	vals := allowDenyConflictTestPrep("allow_deny_conflict_2.yaml", t)
+
+	ta := validations.ValidationsTestAsserter{T: t, Validations: vals}
+	ta.AssertNoValidations()
+}
+
+// Context: DENY policy with an empty rule
+// It conflicts with every ALLOW rule on the same selector
+func TestDenyAllConflict(t *testing.T) {
+	vals := allowDenyConflictTestPrep("allow_deny_conflict_3.yaml", t)
+
+	ta := validations.ValidationsTestAsserter{T: t, Validations: vals}
+	ta.AssertValidationsPresent(2)
+}
+
+func allowDenyConflictTestPrep(scenario string, t *testing.T) models.IstioValidations {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	loader := yamlFixtureLoaderFor(scenario)
+	if err := loader.Load(); err != nil {
+		t.Error("Error loading test data.")
+	}
+
+	return AllowDenyConflictChecker{
+		AuthorizationPolicies: loader.GetResources("AuthorizationPolicy"),
+	}.Check()
+}
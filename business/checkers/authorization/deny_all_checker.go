@@ -0,0 +1,52 @@
+package authorization
+
+import (
+	"fmt"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type DenyAllChecker struct {
+	AuthorizationPolicy kubernetes.IstioObject
+}
+
+// Check reports the policies that deny all the traffic to the workloads they are applied to:
+// ALLOW policies without any rule and DENY policies with an empty rule. Default-deny policies are a common
+// practice, so they are only informative.
+func (d DenyAllChecker) Check() ([]*models.IstioCheck, bool) {
+	checks := make([]*models.IstioCheck, 0)
+	rules := getRules(d.AuthorizationPolicy)
+
+	switch getAction(d.AuthorizationPolicy) {
+	case "ALLOW":
+		if len(rules) == 0 {
+			validation := models.Build("authorizationpolicy.denyall", "spec")
+			checks = append(checks, &validation)
+		}
+	case "DENY":
+		for ruleIdx, rule := range rules {
+			if isEmptyRule(rule) {
+				validation := models.Build("authorizationpolicy.denyall", fmt.Sprintf("spec/rules[%d]", ruleIdx))
+				checks = append(checks, &validation)
+			}
+		}
+	}
+
+	return checks, true
+}
+
+// isEmptyRule returns true when the rule doesn't define any from, to or when clause, so it matches every request
+func isEmptyRule(rule map[string]interface{}) bool {
+	if rule == nil {
+		return false
+	}
+	for _, field := range []string{"from", "to", "when"} {
+		if v, found := rule[field]; found && v != nil {
+			if sl, ok := v.([]interface{}); !ok || len(sl) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
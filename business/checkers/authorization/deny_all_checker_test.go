@@ -0,0 +1,48 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data/validations"
+)
+
+func TestAllowNothingPolicy(t *testing.T) {
+	vals, valid := denyAllCheckerTestPrep("allow-nothing", t)
+
+	ta := validations.IstioCheckTestAsserter{T: t, Validations: vals, Valid: valid}
+	ta.AssertValidationsPresent(1, true)
+	ta.AssertValidationAt(0, models.Unknown, "spec", "authorizationpolicy.denyall")
+}
+
+func TestDenyEmptyRulePolicy(t *testing.T) {
+	vals, valid := denyAllCheckerTestPrep("deny-all", t)
+
+	ta := validations.IstioCheckTestAsserter{T: t, Validations: vals, Valid: valid}
+	ta.AssertValidationsPresent(1, true)
+	ta.AssertValidationAt(0, models.Unknown, "spec/rules[1]", "authorizationpolicy.denyall")
+}
+
+func TestNoDenyAllPolicies(t *testing.T) {
+	for _, policy := range []string{"allow-all", "deny-nothing", "deny-foo"} {
+		vals, valid := denyAllCheckerTestPrep(policy, t)
+
+		ta := validations.IstioCheckTestAsserter{T: t, Validations: vals, Valid: valid}
+		ta.AssertNoValidations()
+	}
+}
+
+func denyAllCheckerTestPrep(policy string, t *testing.T) ([]*models.IstioCheck, bool) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	loader := yamlFixtureLoaderFor("deny_all_checker.yaml")
+	if err := loader.Load(); err != nil {
+		t.Error("Error loading test data.")
+	}
+
+	return DenyAllChecker{
+		AuthorizationPolicy: loader.GetResource("AuthorizationPolicy", policy, "bookinfo"),
+	}.Check()
+}
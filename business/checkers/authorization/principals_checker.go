@@ -0,0 +1,190 @@
+package authorization
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type PrincipalsChecker struct {
+	AuthorizationPolicy kubernetes.IstioObject
+	// ServiceAccounts holds the names of the service accounts per namespace.
+	// Principals pointing to a namespace not present in this map are not validated.
+	ServiceAccounts map[string][]string
+	// TrustDomains of the mesh and their aliases, besides the one of the configured IstioIdentityDomain
+	TrustDomains []string
+}
+
+// Check validates that principals and notPrincipals of the source rules follow the
+// <trust domain>/ns/<namespace>/sa/<service account> form, in a known trust domain, and point to existing service
+// accounts. Principals with a * prefix match any trust domain, other wildcarded principals are not validated.
+func (pc PrincipalsChecker) Check() ([]*models.IstioCheck, bool) {
+	checks := make([]*models.IstioCheck, 0)
+
+	for ruleIdx, rule := range getRules(pc.AuthorizationPolicy) {
+		froms, ok := rule["from"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for fromIdx, fromStc := range froms {
+			fromMap, ok := fromStc.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			sourceMap, ok := fromMap["source"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, field := range []string{"principals", "notPrincipals"} {
+				principals, ok := sourceMap[field].([]interface{})
+				if !ok {
+					continue
+				}
+
+				for i, p := range principals {
+					principal, ok := p.(string)
+					if !ok || principal == "*" || strings.Index(principal, "*") > 0 {
+						// Wildcarded principals can't be matched against a single service account
+						continue
+					}
+
+					path := fmt.Sprintf("spec/rules[%d]/from[%d]/source/%s[%d]", ruleIdx, fromIdx, field, i)
+					namespace, sa, valid := ParsePrincipal(principal)
+					if !valid {
+						// Suffixes may be of any form
+						if !strings.HasPrefix(principal, "*") {
+							validation := models.Build("authorizationpolicy.source.principalformat", path)
+							checks = append(checks, &validation)
+						}
+						continue
+					}
+					if !strings.HasPrefix(principal, "*") && !pc.hasTrustDomain(strings.SplitN(principal, "/", 2)[0]) {
+						validation := models.Build("authorizationpolicy.source.principaltrustdomain", path)
+						checks = append(checks, &validation)
+					}
+					if !pc.hasServiceAccount(namespace, sa) {
+						validation := models.Build("authorizationpolicy.source.principalnotfound", path)
+						checks = append(checks, &validation)
+					}
+				}
+			}
+		}
+	}
+
+	return checks, true
+}
+
+// hasTrustDomain returns true for the trust domain of the configured IstioIdentityDomain (i.e. svc.cluster.local ->
+// cluster.local) and for the trust domains of the mesh
+func (pc PrincipalsChecker) hasTrustDomain(trustDomain string) bool {
+	if trustDomain == strings.TrimPrefix(config.Get().ExternalServices.Istio.IstioIdentityDomain, "svc.") {
+		return true
+	}
+	for _, td := range pc.TrustDomains {
+		if td == trustDomain {
+			return true
+		}
+	}
+	return false
+}
+
+func (pc PrincipalsChecker) hasServiceAccount(namespace, serviceAccount string) bool {
+	sas, found := pc.ServiceAccounts[namespace]
+	if !found {
+		// Namespace is not accessible, it can't be validated
+		return true
+	}
+
+	for _, sa := range sas {
+		if sa == serviceAccount {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePrincipal splits a principal with <trust domain>/ns/<namespace>/sa/<service account> form into its namespace and
+// service account. The trust domain may be any, or a * prefix of it. It returns false when the principal doesn't follow
+// that form.
+func ParsePrincipal(principal string) (string, string, bool) {
+	parts := strings.Split(principal, "/")
+	if len(parts) != 5 || parts[0] == "" || strings.LastIndex(parts[0], "*") > 0 || parts[1] != "ns" || parts[3] != "sa" || parts[2] == "" || parts[4] == "" {
+		return "", "", false
+	}
+	if strings.Contains(parts[2], "*") || strings.Contains(parts[4], "*") {
+		return "", "", false
+	}
+
+	return parts[2], parts[4], true
+}
+
+// PrincipalNamespaces returns the namespaces referenced by the principals and notPrincipals of an AuthorizationPolicy
+func PrincipalNamespaces(ap kubernetes.IstioObject) []string {
+	namespaces := make([]string, 0)
+	found := map[string]bool{}
+
+	for _, rule := range getRules(ap) {
+		froms, ok := rule["from"].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, fromStc := range froms {
+			fromMap, ok := fromStc.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			sourceMap, ok := fromMap["source"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, field := range []string{"principals", "notPrincipals"} {
+				principals, ok := sourceMap[field].([]interface{})
+				if !ok {
+					continue
+				}
+				for _, p := range principals {
+					if principal, ok := p.(string); ok {
+						if ns, _, valid := ParsePrincipal(principal); valid && !found[ns] {
+							found[ns] = true
+							namespaces = append(namespaces, ns)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return namespaces
+}
+
+// getRules returns the rules of an AuthorizationPolicy. Rules with unexpected format are returned as nil maps
+// so the indexes of the slice keep matching the ones of the object.
+func getRules(ap kubernetes.IstioObject) []map[string]interface{} {
+	rules := make([]map[string]interface{}, 0)
+
+	rulesStct, ok := ap.GetSpec()["rules"].([]interface{})
+	if !ok {
+		return rules
+	}
+
+	for _, r := range rulesStct {
+		rule, _ := r.(map[string]interface{})
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// getAction returns the action of an AuthorizationPolicy, ALLOW by default
+func getAction(ap kubernetes.IstioObject) string {
+	if action, ok := ap.GetSpec()["action"].(string); ok && action != "" {
+		return strings.ToUpper(action)
+	}
+	return "ALLOW"
+}
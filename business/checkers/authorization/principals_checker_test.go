@@ -0,0 +1,121 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data/validations"
+)
+
+func TestPrincipalsFound(t *testing.T) {
+	vals, valid := principalsCheckerTestPrep("policy-0", t)
+
+	ta := validations.IstioCheckTestAsserter{T: t, Validations: vals, Valid: valid}
+	ta.AssertNoValidations()
+}
+
+func TestPrincipalsNotFound(t *testing.T) {
+	vals, valid := principalsCheckerTestPrep("policy-1", t)
+
+	ta := validations.IstioCheckTestAsserter{T: t, Validations: vals, Valid: valid}
+	ta.AssertValidationsPresent(2, true)
+	ta.AssertValidationAt(0, models.WarningSeverity, "spec/rules[0]/from[0]/source/principals[0]", "authorizationpolicy.source.principalnotfound")
+	ta.AssertValidationAt(1, models.WarningSeverity, "spec/rules[0]/from[1]/source/notPrincipals[1]", "authorizationpolicy.source.principalnotfound")
+}
+
+func TestPrincipalsNotValidated(t *testing.T) {
+	// Principals from non-accessible namespaces and wildcarded principals are skipped
+	vals, valid := principalsCheckerTestPrep("policy-2", t)
+
+	ta := validations.IstioCheckTestAsserter{T: t, Validations: vals, Valid: valid}
+	ta.AssertNoValidations()
+}
+
+func TestPrincipalsWrongFormat(t *testing.T) {
+	vals, valid := principalsCheckerTestPrep("policy-3", t)
+
+	ta := validations.IstioCheckTestAsserter{T: t, Validations: vals, Valid: valid}
+	ta.AssertValidationsPresent(2, true)
+	ta.AssertValidationAt(0, models.WarningSeverity, "spec/rules[0]/from[0]/source/principals[0]", "authorizationpolicy.source.principalformat")
+	ta.AssertValidationAt(1, models.Unknown, "spec/rules[0]/from[0]/source/principals[1]", "authorizationpolicy.source.principaltrustdomain")
+}
+
+func TestPrincipalsTrustDomainAliasesAndWildcards(t *testing.T) {
+	config.Set(config.NewConfig())
+
+	loader := yamlFixtureLoaderFor("principals_checker.yaml")
+	if err := loader.Load(); err != nil {
+		t.Error("Error loading test data.")
+	}
+
+	vals, valid := PrincipalsChecker{
+		AuthorizationPolicy: loader.GetResource("AuthorizationPolicy", "policy-4", "bookinfo"),
+		ServiceAccounts: map[string][]string{
+			"bookinfo": {"default", "bookinfo-reviews", "bookinfo-productpage"},
+		},
+		TrustDomains: []string{"example.com", "old.example.com"},
+	}.Check()
+
+	// Any trust domain is allowed by a * prefix, and suffixes of any form
+	ta := validations.IstioCheckTestAsserter{T: t, Validations: vals, Valid: valid}
+	ta.AssertValidationsPresent(1, true)
+	ta.AssertValidationAt(0, models.WarningSeverity, "spec/rules[0]/from[0]/source/principals[1]", "authorizationpolicy.source.principalnotfound")
+}
+
+func TestPrincipalsCustomIdentityDomain(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioIdentityDomain = "svc.example.com"
+	config.Set(conf)
+
+	checker := PrincipalsChecker{}
+	assert.True(checker.hasTrustDomain("example.com"))
+	assert.False(checker.hasTrustDomain("cluster.local"))
+
+	// Only the form of the principal is parsed
+	ns, sa, valid := ParsePrincipal("cluster.local/ns/bookinfo/sa/bookinfo-reviews")
+	assert.True(valid)
+	assert.Equal("bookinfo", ns)
+	assert.Equal("bookinfo-reviews", sa)
+
+	_, _, valid = ParsePrincipal("*/ns/bookinfo/sa/bookinfo-reviews")
+	assert.True(valid)
+	_, _, valid = ParsePrincipal("cluster.local/ns/bookinfo/sa/*")
+	assert.False(valid)
+}
+
+func TestPrincipalNamespaces(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	loader := yamlFixtureLoaderFor("principals_checker.yaml")
+	if err := loader.Load(); err != nil {
+		t.Error("Error loading test data.")
+	}
+
+	assert.Equal([]string{"bookinfo"}, PrincipalNamespaces(loader.GetResource("AuthorizationPolicy", "policy-1", "bookinfo")))
+	assert.Equal([]string{"default"}, PrincipalNamespaces(loader.GetResource("AuthorizationPolicy", "policy-2", "bookinfo")))
+	assert.Equal([]string{"bookinfo"}, PrincipalNamespaces(loader.GetResource("AuthorizationPolicy", "policy-3", "bookinfo")))
+	assert.Equal([]string{"bookinfo"}, PrincipalNamespaces(loader.GetResource("AuthorizationPolicy", "policy-4", "bookinfo")))
+}
+
+func principalsCheckerTestPrep(policy string, t *testing.T) ([]*models.IstioCheck, bool) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	loader := yamlFixtureLoaderFor("principals_checker.yaml")
+	if err := loader.Load(); err != nil {
+		t.Error("Error loading test data.")
+	}
+
+	return PrincipalsChecker{
+		AuthorizationPolicy: loader.GetResource("AuthorizationPolicy", policy, "bookinfo"),
+		ServiceAccounts: map[string][]string{
+			"bookinfo": {"default", "bookinfo-reviews", "bookinfo-productpage"},
+		},
+	}.Check()
+}
@@ -20,6 +20,7 @@ type AuthorizationPolicyChecker struct {
 	WorkloadList          models.WorkloadList
 	MtlsDetails           kubernetes.MTLSDetails
 	VirtualServices       []kubernetes.IstioObject
+	ServiceAccounts       map[string][]string
 }
 
 func (a AuthorizationPolicyChecker) Check() models.IstioValidations {
@@ -37,6 +38,10 @@ func (a AuthorizationPolicyChecker) Check() models.IstioValidations {
 		MtlsDetails:           a.MtlsDetails,
 	}.Check())
 
+	validations.MergeValidations(authorization.AllowDenyConflictChecker{
+		AuthorizationPolicies: a.AuthorizationPolicies,
+	}.Check())
+
 	return validations
 }
 
@@ -51,6 +56,8 @@ func (a AuthorizationPolicyChecker) runChecks(authPolicy kubernetes.IstioObject)
 		authorization.NamespaceMethodChecker{AuthorizationPolicy: authPolicy, Namespaces: a.Namespaces.GetNames()},
 		authorization.NoHostChecker{AuthorizationPolicy: authPolicy, Namespace: a.Namespace, Namespaces: a.Namespaces,
 			ServiceEntries: serviceHosts, Services: a.Services, VirtualServices: a.VirtualServices},
+		authorization.PrincipalsChecker{AuthorizationPolicy: authPolicy, ServiceAccounts: a.ServiceAccounts, TrustDomains: a.MtlsDetails.TrustDomains},
+		authorization.DenyAllChecker{AuthorizationPolicy: authPolicy},
 	}
 
 	for _, checker := range enabledCheckers {
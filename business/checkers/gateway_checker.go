@@ -1,6 +1,8 @@
 package checkers
 
 import (
+	core_v1 "k8s.io/api/core/v1"
+
 	"github.com/kiali/kiali/business/checkers/gateways"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
@@ -12,6 +14,7 @@ type GatewayChecker struct {
 	GatewaysPerNamespace  [][]kubernetes.IstioObject
 	Namespace             string
 	WorkloadsPerNamespace map[string]models.WorkloadList
+	SecretsPerNamespace   map[string][]core_v1.Secret
 }
 
 // Check runs checks for the all namespaces actions as well as for the single namespace validations
@@ -21,6 +24,10 @@ func (g GatewayChecker) Check() models.IstioValidations {
 		GatewaysPerNamespace: g.GatewaysPerNamespace,
 	}.Check()
 
+	validations.MergeValidations(gateways.TLSModeConflictChecker{
+		GatewaysPerNamespace: g.GatewaysPerNamespace,
+	}.Check())
+
 	// Single namespace
 	for _, nssGw := range g.GatewaysPerNamespace {
 		for _, gw := range nssGw {
@@ -41,6 +48,14 @@ func (g GatewayChecker) runSingleChecks(gw kubernetes.IstioObject) models.IstioV
 			Gateway:               gw,
 			WorkloadsPerNamespace: g.WorkloadsPerNamespace,
 		},
+		gateways.TLSChecker{
+			Gateway: gw,
+		},
+		gateways.CredentialChecker{
+			Gateway:               gw,
+			WorkloadsPerNamespace: g.WorkloadsPerNamespace,
+			SecretsPerNamespace:   g.SecretsPerNamespace,
+		},
 	}
 
 	for _, checker := range enabledCheckers {
@@ -0,0 +1,111 @@
+package gateways
+
+import (
+	"fmt"
+	"sort"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type CredentialChecker struct {
+	Gateway               kubernetes.IstioObject
+	WorkloadsPerNamespace map[string]models.WorkloadList
+	SecretsPerNamespace   map[string][]core_v1.Secret
+}
+
+// Check verifies that the secrets referenced by credentialName exist in the namespaces where the gateway workloads run.
+// Namespaces whose secrets are unknown (i.e. not accessible) are not validated.
+func (c CredentialChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	namespaces := make([]string, 0)
+	for _, ns := range GatewayWorkloadNamespaces(c.Gateway, c.WorkloadsPerNamespace) {
+		if _, found := c.SecretsPerNamespace[ns]; found {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	if len(namespaces) == 0 {
+		return validations, true
+	}
+
+	for serverIndex, serverDef := range parseServers(c.Gateway) {
+		credentialName := parseCredentialName(serverDef)
+		if credentialName == "" {
+			continue
+		}
+
+		for _, ns := range namespaces {
+			if !c.hasSecret(ns, credentialName) {
+				validation := models.Build("gateways.tls.secretnotfound", fmt.Sprintf("spec/servers[%d]/tls/credentialName", serverIndex))
+				validations = append(validations, &validation)
+				break
+			}
+		}
+	}
+
+	return validations, len(validations) == 0
+}
+
+func (c CredentialChecker) hasSecret(namespace, name string) bool {
+	for _, secret := range c.SecretsPerNamespace[namespace] {
+		if secret.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCredentialName(serverDef map[string]interface{}) string {
+	if tls, found := parseTLS(serverDef); found {
+		if credentialName, ok := tls["credentialName"].(string); ok {
+			return credentialName
+		}
+	}
+	return ""
+}
+
+// CredentialNames returns the names of the secrets referenced through credentialName by the Gateway's servers
+func CredentialNames(gw kubernetes.IstioObject) []string {
+	names := make([]string, 0)
+	for _, serverDef := range parseServers(gw) {
+		if credentialName := parseCredentialName(serverDef); credentialName != "" {
+			names = append(names, credentialName)
+		}
+	}
+	return names
+}
+
+// GatewayWorkloadNamespaces returns the sorted names of the namespaces where the workloads selected by the Gateway live
+func GatewayWorkloadNamespaces(gw kubernetes.IstioObject, workloadsPerNamespace map[string]models.WorkloadList) []string {
+	namespaces := make([]string, 0)
+
+	selectorSpec, found := gw.GetSpec()["selector"]
+	if !found {
+		return namespaces
+	}
+	selectors, ok := selectorSpec.(map[string]interface{})
+	if !ok {
+		return namespaces
+	}
+	labelSelectors := make(map[string]string, len(selectors))
+	for k, v := range selectors {
+		labelSelectors[k] = v.(string)
+	}
+	selector := labels.SelectorFromSet(labelSelectors)
+
+	for ns, wls := range workloadsPerNamespace {
+		for _, wl := range wls.Workloads {
+			if selector.Matches(labels.Set(wl.Labels)) {
+				namespaces = append(namespaces, ns)
+				break
+			}
+		}
+	}
+	sort.Strings(namespaces)
+
+	return namespaces
+}
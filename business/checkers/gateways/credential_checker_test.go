@@ -0,0 +1,115 @@
+package gateways
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestCredentialNameFound(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	validations, valid := CredentialChecker{
+		Gateway:               credentialGateway("bookinfo-cert"),
+		WorkloadsPerNamespace: ingressWorkloads(),
+		SecretsPerNamespace: map[string][]core_v1.Secret{
+			"istio-system": {fakeSecret("bookinfo-cert", "istio-system")},
+		},
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func TestCredentialNameNotFound(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	validations, valid := CredentialChecker{
+		Gateway:               credentialGateway("bookinfo-crt"),
+		WorkloadsPerNamespace: ingressWorkloads(),
+		SecretsPerNamespace: map[string][]core_v1.Secret{
+			"istio-system": {fakeSecret("bookinfo-cert", "istio-system")},
+		},
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(validations, 1)
+	assert.Equal(models.ErrorSeverity, validations[0].Severity)
+	assert.Equal(models.CheckMessage("gateways.tls.secretnotfound"), validations[0].Message)
+	assert.Equal("spec/servers[0]/tls/credentialName", validations[0].Path)
+}
+
+func TestCredentialNameInAnotherNamespace(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	// The secret must live in the namespace of the gateway workload, not in the Gateway's one
+	validations, valid := CredentialChecker{
+		Gateway:               credentialGateway("bookinfo-cert"),
+		WorkloadsPerNamespace: ingressWorkloads(),
+		SecretsPerNamespace: map[string][]core_v1.Secret{
+			"istio-system": {},
+			"bookinfo":     {fakeSecret("bookinfo-cert", "bookinfo")},
+		},
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(validations, 1)
+}
+
+func TestCredentialNameUnknownSecrets(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	// Secrets from istio-system are not accessible, so the check is skipped
+	validations, valid := CredentialChecker{
+		Gateway:               credentialGateway("bookinfo-cert"),
+		WorkloadsPerNamespace: ingressWorkloads(),
+		SecretsPerNamespace:   map[string][]core_v1.Secret{},
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func credentialGateway(credentialName string) kubernetes.IstioObject {
+	return data.AddServerToGateway(
+		data.AddTLSToServer("SIMPLE", credentialName, data.CreateServer([]string{"bookinfo.example.com"}, uint32(443), "https", "HTTPS")),
+		data.CreateEmptyGateway("bookinfo-gw", "bookinfo", map[string]string{"istio": "ingressgateway"}),
+	)
+}
+
+func ingressWorkloads() map[string]models.WorkloadList {
+	return map[string]models.WorkloadList{
+		"istio-system": data.CreateWorkloadList("istio-system",
+			data.CreateWorkloadListItem("istio-ingressgateway", map[string]string{"istio": "ingressgateway"})),
+		"bookinfo": data.CreateWorkloadList("bookinfo",
+			data.CreateWorkloadListItem("productpage-v1", map[string]string{"app": "productpage"})),
+	}
+}
+
+func fakeSecret(name, namespace string) core_v1.Secret {
+	return core_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
@@ -41,16 +41,7 @@ func (m MultiMatchChecker) Check() models.IstioValidations {
 			gatewayRuleName := g.GetObjectMeta().Name
 			gatewayNamespace := g.GetObjectMeta().Namespace
 
-			selectorString := ""
-			if selectorRaw, found := g.GetSpec()["selector"]; found {
-				if selector, ok := selectorRaw.(map[string]interface{}); ok {
-					selectorMap := map[string]string{}
-					for k, v := range selector {
-						selectorMap[k] = v.(string)
-					}
-					selectorString = labels.Set(selectorMap).String()
-				}
-			}
+			selectorString := parseSelector(g)
 
 			if specServers, found := g.GetSpec()["servers"]; found {
 				if servers, ok := specServers.([]interface{}); ok {
@@ -65,12 +56,12 @@ func (m MultiMatchChecker) Check() models.IstioValidations {
 								duplicate, dhosts := m.findMatch(host, selectorString)
 								if duplicate {
 									// The above is referenced by each one below..
-									currentHostValidation := createError(host.GatewayRuleName, host.Namespace, host.ServerIndex, host.HostIndex)
+									currentHostValidation := createError("gateways.multimatch", host.GatewayRuleName, host.Namespace, host.ServerIndex, host.HostIndex)
 
 									// CurrentHostValidation is always the first one, so we skip it
 									for i := 1; i < len(dhosts); i++ {
 										dh := dhosts[i]
-										refValidation := createError(dh.GatewayRuleName, dh.Namespace, dh.ServerIndex, dh.HostIndex)
+										refValidation := createError("gateways.multimatch", dh.GatewayRuleName, dh.Namespace, dh.ServerIndex, dh.HostIndex)
 										refValidation = refValidation.MergeReferences(currentHostValidation)
 										currentHostValidation = currentHostValidation.MergeReferences(refValidation)
 										validations = validations.MergeValidations(refValidation)
@@ -89,14 +80,14 @@ func (m MultiMatchChecker) Check() models.IstioValidations {
 	return validations
 }
 
-func createError(gatewayRuleName, namespace string, serverIndex, hostIndex int) models.IstioValidations {
+func createError(checkId, gatewayRuleName, namespace string, serverIndex, hostIndex int) models.IstioValidations {
 	key := models.IstioValidationKey{Name: gatewayRuleName, Namespace: namespace, ObjectType: GatewayCheckerType}
-	checks := models.Build("gateways.multimatch",
+	checks := models.Build(checkId,
 		"spec/servers["+strconv.Itoa(serverIndex)+"]/hosts["+strconv.Itoa(hostIndex)+"]")
 	rrValidation := &models.IstioValidation{
 		Name:       gatewayRuleName,
 		ObjectType: GatewayCheckerType,
-		Valid:      true,
+		Valid:      checks.Severity != models.ErrorSeverity,
 		Checks: []*models.IstioCheck{
 			&checks,
 		},
@@ -105,6 +96,20 @@ func createError(gatewayRuleName, namespace string, serverIndex, hostIndex int)
 	return models.IstioValidations{key: rrValidation}
 }
 
+// parseSelector returns the Gateway's selector in its string form, or an empty string when there is no selector
+func parseSelector(g kubernetes.IstioObject) string {
+	if selectorRaw, found := g.GetSpec()["selector"]; found {
+		if selector, ok := selectorRaw.(map[string]interface{}); ok {
+			selectorMap := map[string]string{}
+			for k, v := range selector {
+				selectorMap[k] = v.(string)
+			}
+			return labels.Set(selectorMap).String()
+		}
+	}
+	return ""
+}
+
 func parsePortAndHostnames(serverDef map[string]interface{}) []Host {
 	var port int
 	if portDef, found := serverDef["port"]; found {
@@ -143,38 +148,38 @@ func (m MultiMatchChecker) findMatch(host Host, selector string) (bool, []Host)
 		}
 
 		for _, h := range hostGroup {
-			if h.Port == host.Port {
-				// wildcardMatches will always match
-				if host.Hostname == wildCardMatch || h.Hostname == wildCardMatch {
-					duplicates = append(duplicates, host)
-					duplicates = append(duplicates, h)
-					continue
-				}
-
-				// Either one could include wildcards, so we need to check both ways and fix "*" -> ".*" for regexp engine
-				current := strings.ToLower(strings.Replace(host.Hostname, "*", ".*", -1))
-				previous := strings.ToLower(strings.Replace(h.Hostname, "*", ".*", -1))
-
-				// Escaping dot chars for RegExp. Dot char means all possible chars.
-				// This protects this validation to false positive for (api-dev.example.com and api.dev.example.com)
-				escapedCurrent := strings.Replace(host.Hostname, ".", "\\.", -1)
-				escapedPrevious := strings.Replace(h.Hostname, ".", "\\.", -1)
-
-				// We anchor the beginning and end of the string when it's
-				// to be used as a regex, so that we don't get spurious
-				// substring matches, e.g., "example.com" matching
-				// "foo.example.com".
-				currentRegexp := strings.Join([]string{"^", escapedCurrent, "$"}, "")
-				previousRegexp := strings.Join([]string{"^", escapedPrevious, "$"}, "")
-
-				if regexp.MustCompile(currentRegexp).MatchString(previous) ||
-					regexp.MustCompile(previousRegexp).MatchString(current) {
-					duplicates = append(duplicates, host)
-					duplicates = append(duplicates, h)
-					continue
-				}
+			if h.Port == host.Port && hostsMatch(host.Hostname, h.Hostname) {
+				duplicates = append(duplicates, host)
+				duplicates = append(duplicates, h)
 			}
 		}
 	}
 	return len(duplicates) > 0, duplicates
 }
+
+// hostsMatch checks whether two Gateway hostnames overlap. Any of them might include wildcards.
+func hostsMatch(currentHost, previousHost string) bool {
+	// wildcardMatches will always match
+	if currentHost == wildCardMatch || previousHost == wildCardMatch {
+		return true
+	}
+
+	// Either one could include wildcards, so we need to check both ways and fix "*" -> ".*" for regexp engine
+	current := strings.ToLower(strings.Replace(currentHost, "*", ".*", -1))
+	previous := strings.ToLower(strings.Replace(previousHost, "*", ".*", -1))
+
+	// Escaping dot chars for RegExp. Dot char means all possible chars.
+	// This protects this validation to false positive for (api-dev.example.com and api.dev.example.com)
+	escapedCurrent := strings.Replace(currentHost, ".", "\\.", -1)
+	escapedPrevious := strings.Replace(previousHost, ".", "\\.", -1)
+
+	// We anchor the beginning and end of the string when it's
+	// to be used as a regex, so that we don't get spurious
+	// substring matches, e.g., "example.com" matching
+	// "foo.example.com".
+	currentRegexp := strings.Join([]string{"^", escapedCurrent, "$"}, "")
+	previousRegexp := strings.Join([]string{"^", escapedPrevious, "$"}, "")
+
+	return regexp.MustCompile(currentRegexp).MatchString(previous) ||
+		regexp.MustCompile(previousRegexp).MatchString(current)
+}
@@ -0,0 +1,92 @@
+package gateways
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type TLSChecker struct {
+	Gateway kubernetes.IstioObject
+}
+
+// Check verifies that HTTPS servers define their TLS settings and that they don't enable an httpsRedirect
+func (t TLSChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	for serverIndex, serverDef := range parseServers(t.Gateway) {
+		if parseProtocol(serverDef) != "HTTPS" {
+			continue
+		}
+
+		tls, found := parseTLS(serverDef)
+		if !found {
+			validation := models.Build("gateways.tls.missing", fmt.Sprintf("spec/servers[%d]", serverIndex))
+			validations = append(validations, &validation)
+			continue
+		}
+
+		if redirect, ok := tls["httpsRedirect"].(bool); ok && redirect {
+			validation := models.Build("gateways.tls.httpsredirect", fmt.Sprintf("spec/servers[%d]/tls/httpsRedirect", serverIndex))
+			validations = append(validations, &validation)
+		}
+	}
+
+	return validations, len(validations) == 0
+}
+
+// parseServers returns the server definitions of a Gateway. The position of each server is kept in the resulting slice.
+func parseServers(gw kubernetes.IstioObject) []map[string]interface{} {
+	serverDefs := make([]map[string]interface{}, 0)
+	if serversSpec, found := gw.GetSpec()["servers"]; found {
+		if servers, ok := serversSpec.([]interface{}); ok {
+			for _, server := range servers {
+				serverDef, _ := server.(map[string]interface{})
+				serverDefs = append(serverDefs, serverDef)
+			}
+		}
+	}
+	return serverDefs
+}
+
+// parseProtocol returns the protocol of a server port in upper case
+func parseProtocol(serverDef map[string]interface{}) string {
+	if portDef, found := serverDef["port"]; found {
+		if port, ok := portDef.(map[string]interface{}); ok {
+			if protocol, ok := port["protocol"].(string); ok {
+				return strings.ToUpper(protocol)
+			}
+		}
+	}
+	return ""
+}
+
+// parseTLS returns the tls block of a server, if any
+func parseTLS(serverDef map[string]interface{}) (map[string]interface{}, bool) {
+	if tlsDef, found := serverDef["tls"]; found {
+		if tls, ok := tlsDef.(map[string]interface{}); ok {
+			return tls, true
+		}
+	}
+	return nil, false
+}
+
+// parseTLSMode returns the TLS mode of a server. Servers with TLS settings default to SIMPLE mode. Plain-text
+// servers, including HTTP servers whose tls block only redirects to HTTPS, return an empty mode.
+func parseTLSMode(serverDef map[string]interface{}) string {
+	tls, found := parseTLS(serverDef)
+	if !found || parseProtocol(serverDef) == "HTTP" {
+		return ""
+	}
+	if mode, ok := tls["mode"].(string); ok && mode != "" {
+		return strings.ToUpper(mode)
+	}
+	for setting := range tls {
+		if setting != "httpsRedirect" {
+			return "SIMPLE"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+package gateways
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestValidTLSDefinition(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	gw := data.AddServerToGateway(
+		data.AddTLSToServer("SIMPLE", "bookinfo-cert", data.CreateServer([]string{"bookinfo.example.com"}, uint32(443), "https", "HTTPS")),
+		data.AddServerToGateway(
+			data.CreateServer([]string{"bookinfo.example.com"}, uint32(80), "http", "HTTP"),
+			data.CreateEmptyGateway("valid-gw", "test", map[string]string{"istio": "ingressgateway"})),
+	)
+
+	validations, valid := TLSChecker{Gateway: gw}.Check()
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func TestHTTPSServerWithoutTLS(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	gw := data.AddServerToGateway(
+		data.CreateServer([]string{"bookinfo.example.com"}, uint32(443), "https", "HTTPS"),
+		data.CreateEmptyGateway("notvalid-gw", "test", map[string]string{"istio": "ingressgateway"}),
+	)
+
+	validations, valid := TLSChecker{Gateway: gw}.Check()
+	assert.False(valid)
+	assert.Len(validations, 1)
+	assert.Equal(models.ErrorSeverity, validations[0].Severity)
+	assert.Equal(models.CheckMessage("gateways.tls.missing"), validations[0].Message)
+	assert.Equal("spec/servers[0]", validations[0].Path)
+}
+
+func TestHTTPSRedirectOnHTTPSServer(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	server := data.AddTLSToServer("SIMPLE", "bookinfo-cert", data.CreateServer([]string{"bookinfo.example.com"}, uint32(443), "https", "HTTPS"))
+	server["tls"].(map[string]interface{})["httpsRedirect"] = true
+
+	gw := data.AddServerToGateway(server,
+		data.AddServerToGateway(
+			data.CreateServer([]string{"bookinfo.example.com"}, uint32(80), "http", "HTTP"),
+			data.CreateEmptyGateway("notvalid-gw", "test", map[string]string{"istio": "ingressgateway"})),
+	)
+
+	validations, valid := TLSChecker{Gateway: gw}.Check()
+	assert.False(valid)
+	assert.Len(validations, 1)
+	assert.Equal(models.WarningSeverity, validations[0].Severity)
+	assert.Equal(models.CheckMessage("gateways.tls.httpsredirect"), validations[0].Message)
+	assert.Equal("spec/servers[1]/tls/httpsRedirect", validations[0].Path)
+}
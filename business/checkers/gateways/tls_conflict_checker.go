@@ -0,0 +1,60 @@
+package gateways
+
+import (
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type TLSModeConflictChecker struct {
+	GatewaysPerNamespace [][]kubernetes.IstioObject
+}
+
+type tlsHost struct {
+	Host
+	Mode    string
+	Gateway string
+}
+
+// Check validates that a host+port combination is not served with different TLS modes by two Gateways
+// applied to the same gateway workloads
+func (t TLSModeConflictChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+	existingList := map[string][]tlsHost{}
+
+	for _, nsG := range t.GatewaysPerNamespace {
+		for _, g := range nsG {
+			meta := g.GetObjectMeta()
+			gatewayName := kubernetes.ParseGatewayAsHost(meta.Namespace+"/"+meta.Name, meta.Namespace, meta.ClusterName).String()
+			selectorString := parseSelector(g)
+
+			for i, serverDef := range parseServers(g) {
+				mode := parseTLSMode(serverDef)
+				for hi, host := range parsePortAndHostnames(serverDef) {
+					host.ServerIndex = i
+					host.HostIndex = hi
+					host.GatewayRuleName = meta.Name
+					host.Namespace = meta.Namespace
+					current := tlsHost{Host: host, Mode: mode, Gateway: gatewayName}
+
+					for _, previous := range existingList[selectorString] {
+						if previous.Gateway == current.Gateway || previous.Port != current.Port || previous.Mode == current.Mode {
+							continue
+						}
+						if !hostsMatch(current.Hostname, previous.Hostname) {
+							continue
+						}
+						currentValidation := createError("gateways.tls.modeconflict", current.GatewayRuleName, current.Namespace, current.ServerIndex, current.HostIndex)
+						previousValidation := createError("gateways.tls.modeconflict", previous.GatewayRuleName, previous.Namespace, previous.ServerIndex, previous.HostIndex)
+						currentValidation.MergeReferences(previousValidation)
+						previousValidation.MergeReferences(currentValidation)
+						validations.MergeValidations(currentValidation)
+						validations.MergeValidations(previousValidation)
+					}
+					existingList[selectorString] = append(existingList[selectorString], current)
+				}
+			}
+		}
+	}
+
+	return validations
+}
@@ -0,0 +1,125 @@
+package gateways
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestSameTLSModeNoConflict(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	gwObject := data.AddServerToGateway(
+		data.AddTLSToServer("SIMPLE", "cert", data.CreateServer([]string{"bookinfo.example.com"}, 443, "https", "HTTPS")),
+		data.CreateEmptyGateway("first", "test", map[string]string{"istio": "ingressgateway"}))
+	gwObject2 := data.AddServerToGateway(
+		data.AddTLSToServer("", "cert", data.CreateServer([]string{"*.example.com"}, 443, "https", "HTTPS")),
+		data.CreateEmptyGateway("second", "test2", map[string]string{"istio": "ingressgateway"}))
+
+	validations := TLSModeConflictChecker{
+		GatewaysPerNamespace: [][]kubernetes.IstioObject{{gwObject}, {gwObject2}},
+	}.Check()
+
+	assert.Empty(validations)
+}
+
+func TestDifferentTLSModeConflict(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	gwObject := data.AddServerToGateway(
+		data.AddTLSToServer("SIMPLE", "cert", data.CreateServer([]string{"bookinfo.example.com"}, 443, "https", "HTTPS")),
+		data.CreateEmptyGateway("first", "test", map[string]string{"istio": "ingressgateway"}))
+	gwObject2 := data.AddServerToGateway(
+		data.AddTLSToServer("PASSTHROUGH", "", data.CreateServer([]string{"*.example.com"}, 443, "tls", "TLS")),
+		data.CreateEmptyGateway("second", "test2", map[string]string{"istio": "ingressgateway"}))
+
+	validations := TLSModeConflictChecker{
+		GatewaysPerNamespace: [][]kubernetes.IstioObject{{gwObject}, {gwObject2}},
+	}.Check()
+
+	assert.Len(validations, 2)
+	first, ok := validations[models.IstioValidationKey{ObjectType: "gateway", Namespace: "test", Name: "first"}]
+	assert.True(ok)
+	assert.False(first.Valid)
+	assert.Equal(models.CheckMessage("gateways.tls.modeconflict"), first.Checks[0].Message)
+	assert.Equal("spec/servers[0]/hosts[0]", first.Checks[0].Path)
+	assert.Equal([]models.IstioValidationKey{{ObjectType: "gateway", Namespace: "test2", Name: "second"}}, first.References)
+
+	second, ok := validations[models.IstioValidationKey{ObjectType: "gateway", Namespace: "test2", Name: "second"}]
+	assert.True(ok)
+	assert.False(second.Valid)
+	assert.Equal([]models.IstioValidationKey{{ObjectType: "gateway", Namespace: "test", Name: "first"}}, second.References)
+}
+
+func TestDifferentTLSModeDifferentIngress(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	gwObject := data.AddServerToGateway(
+		data.AddTLSToServer("SIMPLE", "cert", data.CreateServer([]string{"bookinfo.example.com"}, 443, "https", "HTTPS")),
+		data.CreateEmptyGateway("first", "test", map[string]string{"istio": "ingressgateway"}))
+	gwObject2 := data.AddServerToGateway(
+		data.AddTLSToServer("PASSTHROUGH", "", data.CreateServer([]string{"bookinfo.example.com"}, 443, "tls", "TLS")),
+		data.CreateEmptyGateway("second", "test", map[string]string{"istio": "private-ingressgateway"}))
+
+	validations := TLSModeConflictChecker{
+		GatewaysPerNamespace: [][]kubernetes.IstioObject{{gwObject, gwObject2}},
+	}.Check()
+
+	assert.Empty(validations)
+}
+
+func TestDifferentTLSModeSameGateway(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	// A single Gateway is already rejected by Istio when it declares the same port twice
+	gwObject := data.AddServerToGateway(
+		data.AddTLSToServer("SIMPLE", "cert", data.CreateServer([]string{"bookinfo.example.com"}, 443, "https", "HTTPS")),
+		data.AddServerToGateway(
+			data.AddTLSToServer("MUTUAL", "cert", data.CreateServer([]string{"bookinfo.example.com"}, 443, "https", "HTTPS")),
+			data.CreateEmptyGateway("first", "test", map[string]string{"istio": "ingressgateway"})))
+
+	validations := TLSModeConflictChecker{
+		GatewaysPerNamespace: [][]kubernetes.IstioObject{{gwObject}},
+	}.Check()
+
+	assert.Empty(validations)
+}
+
+func TestHTTPSRedirectNoConflict(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	// HTTP servers whose tls block only redirects to HTTPS are still plain-text servers
+	redirectServer := data.CreateServer([]string{"bookinfo.example.com"}, 80, "http", "HTTP")
+	redirectServer["tls"] = map[string]interface{}{"httpsRedirect": true}
+	gwObject := data.AddServerToGateway(redirectServer,
+		data.CreateEmptyGateway("first", "test", map[string]string{"istio": "ingressgateway"}))
+	gwObject2 := data.AddServerToGateway(
+		data.CreateServer([]string{"*.example.com"}, 80, "http", "HTTP"),
+		data.CreateEmptyGateway("second", "test2", map[string]string{"istio": "ingressgateway"}))
+
+	validations := TLSModeConflictChecker{
+		GatewaysPerNamespace: [][]kubernetes.IstioObject{{gwObject}, {gwObject2}},
+	}.Check()
+
+	assert.Empty(validations)
+}
@@ -0,0 +1,48 @@
+package checkers
+
+import (
+	"github.com/kiali/kiali/business/checkers/k8sgateways"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const K8sGatewayCheckerType = "k8sgateway"
+
+// K8sGatewayChecker validates the Gateways of the Kubernetes Gateway API
+type K8sGatewayChecker struct {
+	Namespace   string
+	K8sGateways []kubernetes.IstioObject
+	// GatewayClasses of the cluster, nil when they couldn't be read
+	GatewayClasses []kubernetes.IstioObject
+}
+
+func (g K8sGatewayChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, gw := range g.K8sGateways {
+		if gw.GetObjectMeta().Namespace == g.Namespace {
+			validations.MergeValidations(g.runChecks(gw))
+		}
+	}
+
+	return validations
+}
+
+func (g K8sGatewayChecker) runChecks(gw kubernetes.IstioObject) models.IstioValidations {
+	key, validations := EmptyValidValidation(gw.GetObjectMeta().Name, gw.GetObjectMeta().Namespace, K8sGatewayCheckerType)
+
+	enabledCheckers := []Checker{
+		k8sgateways.ListenerConflictChecker{K8sGateway: gw},
+	}
+	if g.GatewayClasses != nil {
+		enabledCheckers = append(enabledCheckers, k8sgateways.GatewayClassChecker{K8sGateway: gw, GatewayClasses: g.GatewayClasses})
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		validations.Checks = append(validations.Checks, checks...)
+		validations.Valid = validations.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: validations}
+}
@@ -0,0 +1,55 @@
+package checkers
+
+import (
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/business/checkers/k8sroutes"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	K8sHTTPRouteCheckerType = "k8shttproute"
+	K8sTCPRouteCheckerType  = "k8stcproute"
+)
+
+// K8sRouteChecker validates the HTTPRoutes and TCPRoutes of the Kubernetes Gateway API
+type K8sRouteChecker struct {
+	Namespace     string
+	Namespaces    models.Namespaces
+	K8sHTTPRoutes []kubernetes.IstioObject
+	K8sTCPRoutes  []kubernetes.IstioObject
+	// Gateways of all the namespaces, as routes can be attached to Gateways of other namespaces
+	K8sGateways []kubernetes.IstioObject
+	Services    []core_v1.Service
+}
+
+func (r K8sRouteChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, route := range r.K8sHTTPRoutes {
+		validations.MergeValidations(r.runChecks(route, K8sHTTPRouteCheckerType))
+	}
+	for _, route := range r.K8sTCPRoutes {
+		validations.MergeValidations(r.runChecks(route, K8sTCPRouteCheckerType))
+	}
+
+	return validations
+}
+
+func (r K8sRouteChecker) runChecks(route kubernetes.IstioObject, objectType string) models.IstioValidations {
+	key, validations := EmptyValidValidation(route.GetObjectMeta().Name, route.GetObjectMeta().Namespace, objectType)
+
+	enabledCheckers := []Checker{
+		k8sroutes.ParentRefChecker{Route: route, Namespaces: r.Namespaces, K8sGateways: r.K8sGateways},
+		k8sroutes.BackendRefChecker{Route: route, Services: r.Services},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		validations.Checks = append(validations.Checks, checks...)
+		validations.Valid = validations.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: validations}
+}
@@ -0,0 +1,27 @@
+package k8sgateways
+
+import (
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// GatewayClassChecker checks that the GatewayClass of the Gateway exists
+type GatewayClassChecker struct {
+	K8sGateway     kubernetes.IstioObject
+	GatewayClasses []kubernetes.IstioObject
+}
+
+func (gc GatewayClassChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	className, _ := gc.K8sGateway.GetSpec()["gatewayClassName"].(string)
+	for _, class := range gc.GatewayClasses {
+		if class.GetObjectMeta().Name == className {
+			return validations, true
+		}
+	}
+
+	validation := models.Build("k8sgateways.gatewayclass.notfound", "spec/gatewayClassName")
+	validations = append(validations, &validation)
+	return validations, false
+}
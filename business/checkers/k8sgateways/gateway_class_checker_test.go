@@ -0,0 +1,38 @@
+package k8sgateways
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestGatewayClassFound(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	validations, valid := GatewayClassChecker{
+		K8sGateway:     data.CreateK8sGateway("bookinfo-gateway", "bookinfo", "istio"),
+		GatewayClasses: []kubernetes.IstioObject{data.CreateK8sGatewayClass("istio", "istio.io/gateway-controller")},
+	}.Check()
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func TestGatewayClassNotFound(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	validations, valid := GatewayClassChecker{
+		K8sGateway:     data.CreateK8sGateway("bookinfo-gateway", "bookinfo", "contour"),
+		GatewayClasses: []kubernetes.IstioObject{data.CreateK8sGatewayClass("istio", "istio.io/gateway-controller")},
+	}.Check()
+	assert.False(valid)
+	assert.Len(validations, 1)
+	assert.Equal(models.CheckMessage("k8sgateways.gatewayclass.notfound"), validations[0].Message)
+	assert.Equal("spec/gatewayClassName", validations[0].Path)
+}
@@ -0,0 +1,49 @@
+package k8sgateways
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// ListenerConflictChecker looks for listeners of the Gateway with the same hostname and port. The Gateway API marks
+// them as conflicted and they don't accept routes.
+type ListenerConflictChecker struct {
+	K8sGateway kubernetes.IstioObject
+}
+
+func (lc ListenerConflictChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	listeners, _ := lc.K8sGateway.GetSpec()["listeners"].([]interface{})
+	listenersPerKey := map[string][]int{}
+	keys := []string{}
+	for i, l := range listeners {
+		listener, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// A listener without hostname matches all the hosts
+		hostname := "*"
+		if h, ok := listener["hostname"].(string); ok && h != "" {
+			hostname = strings.ToLower(h)
+		}
+		key := fmt.Sprintf("%v/%s", listener["port"], hostname)
+		if _, found := listenersPerKey[key]; !found {
+			keys = append(keys, key)
+		}
+		listenersPerKey[key] = append(listenersPerKey[key], i)
+	}
+
+	for _, key := range keys {
+		if indexes := listenersPerKey[key]; len(indexes) > 1 {
+			for _, i := range indexes {
+				validation := models.Build("k8sgateways.listener.conflict", fmt.Sprintf("spec/listeners[%d]/hostname", i))
+				validations = append(validations, &validation)
+			}
+		}
+	}
+	return validations, len(validations) == 0
+}
@@ -0,0 +1,43 @@
+package k8sgateways
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestListenersWithoutConflicts(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	gw := data.CreateK8sGateway("bookinfo-gateway", "bookinfo", "istio")
+	gw = data.AddListenerToK8sGateway("http", "bookinfo.example.com", 80, "HTTP", gw)
+	gw = data.AddListenerToK8sGateway("reviews", "reviews.example.com", 80, "HTTP", gw)
+	gw = data.AddListenerToK8sGateway("https", "bookinfo.example.com", 443, "HTTPS", gw)
+
+	validations, valid := ListenerConflictChecker{K8sGateway: gw}.Check()
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func TestListenersWithSameHostnameAndPort(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	gw := data.CreateK8sGateway("bookinfo-gateway", "bookinfo", "istio")
+	gw = data.AddListenerToK8sGateway("http", "Bookinfo.example.com", 80, "HTTP", gw)
+	gw = data.AddListenerToK8sGateway("any", "", 80, "HTTP", gw)
+	gw = data.AddListenerToK8sGateway("http-2", "bookinfo.example.com", 80, "HTTP", gw)
+
+	validations, valid := ListenerConflictChecker{K8sGateway: gw}.Check()
+	assert.False(valid)
+	assert.Len(validations, 2)
+	assert.Equal(models.CheckMessage("k8sgateways.listener.conflict"), validations[0].Message)
+	assert.Equal(models.ErrorSeverity, validations[0].Severity)
+	assert.Equal("spec/listeners[0]/hostname", validations[0].Path)
+	assert.Equal("spec/listeners[2]/hostname", validations[1].Path)
+}
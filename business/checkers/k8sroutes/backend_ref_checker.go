@@ -0,0 +1,53 @@
+package k8sroutes
+
+import (
+	"fmt"
+
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// BackendRefChecker checks that the Services referenced by the backendRefs of a HTTPRoute or TCPRoute exist
+type BackendRefChecker struct {
+	Route kubernetes.IstioObject
+	// Services of the namespace of the route
+	Services []core_v1.Service
+}
+
+func (bc BackendRefChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+	valid := true
+
+	namespace := bc.Route.GetObjectMeta().Namespace
+	for i, rule := range kubernetes.GetK8sRouteBackendRefs(bc.Route) {
+		for j, ref := range rule {
+			// Only Services are validated, other backends are implementation specific
+			if ref.Group != "" || ref.Kind != kubernetes.ServiceType {
+				continue
+			}
+			path := fmt.Sprintf("spec/rules[%d]/backendRefs[%d]/name", i, j)
+			if ref.Namespace != namespace {
+				validation := models.Build("validation.unable.cross-namespace", path)
+				validations = append(validations, &validation)
+				continue
+			}
+			if !bc.serviceExists(ref.Name) {
+				validation := models.Build("k8sroutes.nobackend", path)
+				validations = append(validations, &validation)
+				valid = false
+			}
+		}
+	}
+	return validations, valid
+}
+
+func (bc BackendRefChecker) serviceExists(name string) bool {
+	for _, s := range bc.Services {
+		if s.Name == name {
+			return true
+		}
+	}
+	return false
+}
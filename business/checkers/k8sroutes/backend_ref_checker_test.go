@@ -0,0 +1,56 @@
+package k8sroutes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func fakeServices(names ...string) []core_v1.Service {
+	services := []core_v1.Service{}
+	for _, name := range names {
+		services = append(services, core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo"}})
+	}
+	return services
+}
+
+func TestBackendRefsFound(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	route := data.CreateK8sRoute("reviews", "bookinfo")
+	route = data.AddRuleToK8sRoute([]map[string]interface{}{
+		data.CreateK8sBackendRef("reviews-v1", "", 9080),
+		data.CreateK8sBackendRef("reviews-v2", "bookinfo", 9080),
+	}, route)
+
+	validations, valid := BackendRefChecker{Route: route, Services: fakeServices("reviews-v1", "reviews-v2")}.Check()
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func TestBackendRefsNotFound(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	route := data.CreateK8sRoute("reviews", "bookinfo")
+	route = data.AddRuleToK8sRoute([]map[string]interface{}{data.CreateK8sBackendRef("reviews-v1", "", 9080)}, route)
+	route = data.AddRuleToK8sRoute([]map[string]interface{}{
+		data.CreateK8sBackendRef("reviews-v3", "", 9080),
+		data.CreateK8sBackendRef("ratings", "other", 9080),
+	}, route)
+
+	validations, valid := BackendRefChecker{Route: route, Services: fakeServices("reviews-v1")}.Check()
+	assert.False(valid)
+	assert.Len(validations, 2)
+	assert.Equal(models.CheckMessage("k8sroutes.nobackend"), validations[0].Message)
+	assert.Equal("spec/rules[1]/backendRefs[0]/name", validations[0].Path)
+	assert.Equal(models.CheckMessage("validation.unable.cross-namespace"), validations[1].Message)
+	assert.Equal("spec/rules[1]/backendRefs[1]/name", validations[1].Path)
+}
@@ -0,0 +1,49 @@
+package k8sroutes
+
+import (
+	"fmt"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// ParentRefChecker checks that the Gateways referenced by the parentRefs of a HTTPRoute or TCPRoute exist
+type ParentRefChecker struct {
+	Route      kubernetes.IstioObject
+	Namespaces models.Namespaces
+	// Gateways of all the namespaces
+	K8sGateways []kubernetes.IstioObject
+}
+
+func (pc ParentRefChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+	valid := true
+
+	for i, ref := range kubernetes.GetK8sRouteParentRefs(pc.Route) {
+		// Only Gateways are validated, other parents are implementation specific
+		if ref.Group != kubernetes.GatewayAPIGroupVersion.Group || ref.Kind != kubernetes.K8sGatewayType {
+			continue
+		}
+		path := fmt.Sprintf("spec/parentRefs[%d]/name", i)
+		if !pc.Namespaces.Includes(ref.Namespace) {
+			validation := models.Build("validation.unable.cross-namespace", path)
+			validations = append(validations, &validation)
+			continue
+		}
+		if !pc.gatewayExists(ref.Name, ref.Namespace) {
+			validation := models.Build("k8sroutes.nogateway", path)
+			validations = append(validations, &validation)
+			valid = false
+		}
+	}
+	return validations, valid
+}
+
+func (pc ParentRefChecker) gatewayExists(name, namespace string) bool {
+	for _, gw := range pc.K8sGateways {
+		if gw.GetObjectMeta().Name == name && gw.GetObjectMeta().Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
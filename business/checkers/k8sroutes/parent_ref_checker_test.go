@@ -0,0 +1,54 @@
+package k8sroutes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestParentRefsFound(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	route := data.CreateK8sRoute("reviews", "bookinfo")
+	route = data.AddParentRefToK8sRoute("bookinfo-gateway", "", route)
+	route = data.AddParentRefToK8sRoute("shared-gateway", "infra", route)
+
+	validations, valid := ParentRefChecker{
+		Route:      route,
+		Namespaces: models.Namespaces{{Name: "bookinfo"}, {Name: "infra"}},
+		K8sGateways: []kubernetes.IstioObject{
+			data.CreateK8sGateway("bookinfo-gateway", "bookinfo", "istio"),
+			data.CreateK8sGateway("shared-gateway", "infra", "istio"),
+		},
+	}.Check()
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func TestParentRefsNotFound(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	route := data.CreateK8sRoute("reviews", "bookinfo")
+	route = data.AddParentRefToK8sRoute("bookinfo-gateway", "infra", route)
+	route = data.AddParentRefToK8sRoute("shared-gateway", "other", route)
+
+	validations, valid := ParentRefChecker{
+		Route:       route,
+		Namespaces:  models.Namespaces{{Name: "bookinfo"}, {Name: "infra"}},
+		K8sGateways: []kubernetes.IstioObject{data.CreateK8sGateway("bookinfo-gateway", "bookinfo", "istio")},
+	}.Check()
+	assert.False(valid)
+	assert.Len(validations, 2)
+	assert.Equal(models.CheckMessage("k8sroutes.nogateway"), validations[0].Message)
+	assert.Equal("spec/parentRefs[0]/name", validations[0].Path)
+	// Gateways of namespaces not accessible can't be validated
+	assert.Equal(models.CheckMessage("validation.unable.cross-namespace"), validations[1].Message)
+	assert.Equal("spec/parentRefs[1]/name", validations[1].Path)
+}
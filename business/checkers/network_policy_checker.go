@@ -0,0 +1,148 @@
+package checkers
+
+import (
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
+
+	"github.com/kiali/kiali/business/checkers/networkpolicies"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const NetworkPolicyCheckerType = "networkpolicy"
+
+// NetworkPolicyChecker looks for Kubernetes NetworkPolicies blocking traffic allowed by Istio: traffic to Services
+// and to VirtualService destinations, and the traffic Istio itself needs
+type NetworkPolicyChecker struct {
+	Namespace       string
+	Namespaces      models.Namespaces
+	NetworkPolicies []networking_v1.NetworkPolicy
+	Services        []core_v1.Service
+	Pods            []core_v1.Pod
+	VirtualServices []kubernetes.IstioObject
+	// Gateways of all the namespaces, selecting the gateway pods that call VirtualService destinations
+	GatewaysPerNamespace [][]kubernetes.IstioObject
+	// Workloads observed sending traffic to the services of the namespace, by service name
+	ObservedCallers map[string][]networkpolicies.Peer
+}
+
+func (nc NetworkPolicyChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	istioNamespace := nc.namespace(config.Get().IstioNamespace)
+	for _, np := range nc.NetworkPolicies {
+		key, validation := EmptyValidValidation(np.Name, nc.Namespace, NetworkPolicyCheckerType)
+		checker := networkpolicies.IstioPortsChecker{NetworkPolicy: np, NetworkPolicies: nc.NetworkPolicies, Pods: nc.Pods, IstioNamespace: istioNamespace}
+		validation.Checks, validation.Valid = checker.Check()
+		validations.MergeValidations(models.IstioValidations{key: validation})
+	}
+
+	// Services and VirtualServices are validated by other checkers, they are only added when NetworkPolicies block them
+	if len(nc.NetworkPolicies) == 0 {
+		return validations
+	}
+	for _, s := range nc.Services {
+		checker := networkpolicies.ServiceReachabilityChecker{Service: s, Callers: nc.ObservedCallers[s.Name], Pods: nc.Pods, NetworkPolicies: nc.NetworkPolicies}
+		validations.MergeValidations(blockedValidations(s.Name, s.Namespace, ServiceCheckerType, checker))
+	}
+	for _, vs := range nc.VirtualServices {
+		if vs.GetObjectMeta().Namespace != nc.Namespace {
+			continue
+		}
+		checker := networkpolicies.RouteReachabilityChecker{VirtualService: vs, Callers: nc.callers(vs), Services: nc.Services, Pods: nc.Pods, NetworkPolicies: nc.NetworkPolicies}
+		validations.MergeValidations(blockedValidations(vs.GetObjectMeta().Name, nc.Namespace, VirtualCheckerType, checker))
+	}
+	return validations
+}
+
+func blockedValidations(name, namespace, objectType string, checker Checker) models.IstioValidations {
+	checks, valid := checker.Check()
+	if len(checks) == 0 {
+		return models.IstioValidations{}
+	}
+	key, validation := EmptyValidValidation(name, namespace, objectType)
+	validation.Checks, validation.Valid = checks, valid
+	return models.IstioValidations{key: validation}
+}
+
+// callers returns the peers sending traffic through the VirtualService: the sidecars of its namespace when it
+// applies to the mesh, the pods selected by its gateways and the workloads observed calling its hosts
+func (nc NetworkPolicyChecker) callers(vs kubernetes.IstioObject) []networkpolicies.Peer {
+	callers := nc.observedCallers(vs)
+	gateways, _ := vs.GetSpec()["gateways"].([]interface{})
+	if len(gateways) == 0 {
+		return append(callers, networkpolicies.Peer{Namespace: nc.namespace(nc.Namespace)})
+	}
+
+	for _, g := range gateways {
+		gateway, _ := g.(string)
+		if gateway == "mesh" {
+			callers = append(callers, networkpolicies.Peer{Namespace: nc.namespace(nc.Namespace)})
+			continue
+		}
+		name, namespace := gateway, nc.Namespace
+		if parts := strings.SplitN(gateway, "/", 2); len(parts) == 2 {
+			namespace, name = parts[0], parts[1]
+		} else if parts := strings.SplitN(gateway, ".", 2); len(parts) == 2 {
+			name, namespace = parts[0], parts[1]
+		}
+		gw := nc.gateway(name, namespace)
+		if gw == nil {
+			continue
+		}
+		selector := map[string]string{}
+		if s, ok := gw.GetSpec()["selector"].(map[string]interface{}); ok {
+			for k, v := range s {
+				if value, ok := v.(string); ok {
+					selector[k] = value
+				}
+			}
+		}
+		// Gateway pods usually run in the Istio namespace
+		istioNamespace := nc.namespace(config.Get().IstioNamespace)
+		callers = append(callers, networkpolicies.Peer{Namespace: istioNamespace, Labels: selector, AnyNamespace: istioNamespace.Labels == nil})
+	}
+	return callers
+}
+
+// observedCallers returns the workloads observed calling the services of the namespace that are hosts of the
+// VirtualService
+func (nc NetworkPolicyChecker) observedCallers(vs kubernetes.IstioObject) []networkpolicies.Peer {
+	callers := []networkpolicies.Peer{}
+	if len(nc.ObservedCallers) == 0 {
+		return callers
+	}
+	hosts, _ := vs.GetSpec()["hosts"].([]interface{})
+	for _, s := range nc.Services {
+		for _, h := range hosts {
+			if host, ok := h.(string); ok && kubernetes.FilterByHost(host, s.Name, s.Namespace) {
+				callers = append(callers, nc.ObservedCallers[s.Name]...)
+				break
+			}
+		}
+	}
+	return callers
+}
+
+func (nc NetworkPolicyChecker) gateway(name, namespace string) kubernetes.IstioObject {
+	for _, gateways := range nc.GatewaysPerNamespace {
+		for _, gw := range gateways {
+			if gw.GetObjectMeta().Name == name && gw.GetObjectMeta().Namespace == namespace {
+				return gw
+			}
+		}
+	}
+	return nil
+}
+
+func (nc NetworkPolicyChecker) namespace(name string) models.Namespace {
+	for _, ns := range nc.Namespaces {
+		if ns.Name == name {
+			return ns
+		}
+	}
+	return models.Namespace{Name: name}
+}
@@ -0,0 +1,64 @@
+package networkpolicies
+
+import (
+	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	// Port used by the sidecars to get their configuration (xDS) and certificates from istiod
+	istiodXdsPort = 15012
+	// Port of the sidecar injection and validation webhooks of istiod, called by the Kubernetes API server
+	istiodWebhookPort = 15017
+)
+
+// IstioPortsChecker checks that the NetworkPolicy, along with the other policies of the namespace, doesn't block
+// the traffic Istio needs: sidecars to istiod for xDS and the API server to istiod for sidecar injection
+type IstioPortsChecker struct {
+	NetworkPolicy   networking_v1.NetworkPolicy
+	NetworkPolicies []networking_v1.NetworkPolicy
+	Pods            []core_v1.Pod
+	// Namespace where istiod runs. When it isn't accessible, only its name is known.
+	IstioNamespace models.Namespace
+}
+
+func (ip IstioPortsChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+	conf := config.Get()
+
+	istiodLabels := map[string]string{conf.IstioLabels.AppLabelName: "istiod"}
+	istiod := Peer{Namespace: ip.IstioNamespace, Labels: istiodLabels, AnyNamespace: ip.IstioNamespace.Labels == nil}
+	sidecars := Peer{AnyNamespace: true}
+	apiServer := Peer{External: true}
+
+	xdsBlocked, webhookBlocked, sidecarXdsBlocked := false, false, false
+	for _, pod := range ip.Pods {
+		if ip.NetworkPolicy.Namespace == ip.IstioNamespace.Name && selectorMatches(&ip.NetworkPolicy.Spec.PodSelector, pod.Labels) &&
+			pod.Labels[conf.IstioLabels.AppLabelName] == "istiod" && hasPolicyType(ip.NetworkPolicy, networking_v1.PolicyTypeIngress) {
+			xdsBlocked = xdsBlocked || !ingressAllowed(ip.NetworkPolicies, pod, sidecars, Port{Number: istiodXdsPort})
+			webhookBlocked = webhookBlocked || !ingressAllowed(ip.NetworkPolicies, pod, apiServer, Port{Number: istiodWebhookPort})
+		}
+
+		if _, sidecar := pod.Annotations[conf.ExternalServices.Istio.IstioSidecarAnnotation]; sidecar &&
+			selectorMatches(&ip.NetworkPolicy.Spec.PodSelector, pod.Labels) && hasPolicyType(ip.NetworkPolicy, networking_v1.PolicyTypeEgress) {
+			sidecarXdsBlocked = sidecarXdsBlocked || !egressAllowed(ip.NetworkPolicies, pod, istiod, Port{Number: istiodXdsPort})
+		}
+	}
+
+	if xdsBlocked {
+		validation := models.Build("networkpolicy.istiod.xds.blocked", "spec/ingress")
+		validations = append(validations, &validation)
+	}
+	if webhookBlocked {
+		validation := models.Build("networkpolicy.istiod.webhook.blocked", "spec/ingress")
+		validations = append(validations, &validation)
+	}
+	if sidecarXdsBlocked {
+		validation := models.Build("networkpolicy.sidecar.xds.blocked", "spec/egress")
+		validations = append(validations, &validation)
+	}
+	return validations, len(validations) == 0
+}
@@ -0,0 +1,102 @@
+package networkpolicies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+func TestIstiodPortsBlocked(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	policy := fakePolicy(t, `
+metadata:
+  name: istio-system-only
+  namespace: istio-system
+spec:
+  podSelector: {}
+  ingress:
+  - from:
+    - podSelector: {}
+`)
+	istiod := fakePod("istiod-1234", map[string]string{"app": "istiod"})
+
+	validations, valid := IstioPortsChecker{
+		NetworkPolicy:   policy,
+		NetworkPolicies: []networking_v1.NetworkPolicy{policy},
+		Pods:            []core_v1.Pod{istiod},
+		IstioNamespace:  models.Namespace{Name: "istio-system"},
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(validations, 2)
+	assert.Equal(models.CheckMessage("networkpolicy.istiod.xds.blocked"), validations[0].Message)
+	assert.Equal(models.CheckMessage("networkpolicy.istiod.webhook.blocked"), validations[1].Message)
+	assert.Equal("spec/ingress", validations[0].Path)
+
+	// Another policy opening the ports
+	open := fakePolicy(t, `
+metadata:
+  name: istiod
+  namespace: istio-system
+spec:
+  podSelector:
+    matchLabels: {app: istiod}
+  ingress:
+  - ports:
+    - port: 15012
+    - port: 15017
+`)
+	validations, valid = IstioPortsChecker{
+		NetworkPolicy:   policy,
+		NetworkPolicies: []networking_v1.NetworkPolicy{policy, open},
+		Pods:            []core_v1.Pod{istiod},
+		IstioNamespace:  models.Namespace{Name: "istio-system"},
+	}.Check()
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func TestSidecarXdsBlocked(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	policy := fakePolicy(t, `
+metadata:
+  name: egress
+  namespace: bookinfo
+spec:
+  podSelector: {}
+  policyTypes: [Egress]
+  egress:
+  - ports:
+    - port: 53
+      protocol: UDP
+`)
+	withSidecar := fakePod("reviews-v1", map[string]string{"app": "reviews"})
+	withSidecar.Annotations = map[string]string{"sidecar.istio.io/status": "{}"}
+	withoutSidecar := fakePod("legacy", map[string]string{"app": "legacy"})
+
+	checker := IstioPortsChecker{
+		NetworkPolicy:   policy,
+		NetworkPolicies: []networking_v1.NetworkPolicy{policy},
+		Pods:            []core_v1.Pod{withoutSidecar},
+		IstioNamespace:  models.Namespace{Name: "istio-system"},
+	}
+	validations, valid := checker.Check()
+	assert.True(valid)
+	assert.Empty(validations)
+
+	checker.Pods = append(checker.Pods, withSidecar)
+	validations, valid = checker.Check()
+	assert.False(valid)
+	assert.Len(validations, 1)
+	assert.Equal(models.CheckMessage("networkpolicy.sidecar.xds.blocked"), validations[0].Message)
+	assert.Equal("spec/egress", validations[0].Path)
+}
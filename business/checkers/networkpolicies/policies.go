@@ -0,0 +1,200 @@
+package networkpolicies
+
+import (
+	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/models"
+)
+
+// Peer is a source or destination of traffic: the pods with the given labels in a namespace, or any pod of the
+// namespace when Labels is nil (i.e. the sidecars of a namespace)
+type Peer struct {
+	Namespace models.Namespace
+	Labels    map[string]string
+	// Pods of namespaces selected by any namespaceSelector, when the namespace is unknown or there are many
+	AnyNamespace bool
+	// Not a pod, only matched by ipBlock peers (i.e. the Kubernetes API server)
+	External bool
+}
+
+// Port is a port of a pod, by number and by name
+type Port struct {
+	Number int32
+	Name   string
+}
+
+// isolatingIngress returns the policies that select the pod for ingress traffic. Pods not selected by any
+// of them accept all the ingress traffic.
+func isolatingIngress(policies []networking_v1.NetworkPolicy, pod core_v1.Pod) []networking_v1.NetworkPolicy {
+	return isolating(policies, pod, networking_v1.PolicyTypeIngress)
+}
+
+// isolatingEgress returns the policies that select the pod for egress traffic
+func isolatingEgress(policies []networking_v1.NetworkPolicy, pod core_v1.Pod) []networking_v1.NetworkPolicy {
+	return isolating(policies, pod, networking_v1.PolicyTypeEgress)
+}
+
+func isolating(policies []networking_v1.NetworkPolicy, pod core_v1.Pod, policyType networking_v1.PolicyType) []networking_v1.NetworkPolicy {
+	result := []networking_v1.NetworkPolicy{}
+	for _, np := range policies {
+		if hasPolicyType(np, policyType) && selectorMatches(&np.Spec.PodSelector, pod.Labels) {
+			result = append(result, np)
+		}
+	}
+	return result
+}
+
+// hasPolicyType applies the defaults of the policyTypes field: Ingress always, Egress when there are egress rules
+func hasPolicyType(np networking_v1.NetworkPolicy, policyType networking_v1.PolicyType) bool {
+	if len(np.Spec.PolicyTypes) == 0 {
+		return policyType == networking_v1.PolicyTypeIngress || len(np.Spec.Egress) > 0
+	}
+	for _, pt := range np.Spec.PolicyTypes {
+		if pt == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+// ingressAllowed returns true when the pod accepts traffic from the peer on the port
+func ingressAllowed(policies []networking_v1.NetworkPolicy, pod core_v1.Pod, from Peer, port Port) bool {
+	isolatingPolicies := isolatingIngress(policies, pod)
+	if len(isolatingPolicies) == 0 {
+		return true
+	}
+	port = resolvePort(pod, port)
+	for _, np := range isolatingPolicies {
+		for _, rule := range np.Spec.Ingress {
+			if portsMatch(rule.Ports, port) && peersMatch(rule.From, np.Namespace, from) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ingressOpen returns true when the pod accepts traffic on the port from some peer
+func ingressOpen(policies []networking_v1.NetworkPolicy, pod core_v1.Pod, port Port) bool {
+	isolatingPolicies := isolatingIngress(policies, pod)
+	if len(isolatingPolicies) == 0 {
+		return true
+	}
+	port = resolvePort(pod, port)
+	for _, np := range isolatingPolicies {
+		for _, rule := range np.Spec.Ingress {
+			if portsMatch(rule.Ports, port) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// egressAllowed returns true when the pod can send traffic to the peer on the port
+func egressAllowed(policies []networking_v1.NetworkPolicy, pod core_v1.Pod, to Peer, port Port) bool {
+	isolatingPolicies := isolatingEgress(policies, pod)
+	if len(isolatingPolicies) == 0 {
+		return true
+	}
+	for _, np := range isolatingPolicies {
+		for _, rule := range np.Spec.Egress {
+			if portsMatch(rule.Ports, port) && peersMatch(rule.To, np.Namespace, to) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// peersMatch returns true when the peer may be one of the peers of a rule. As the IPs of the pods are not resolved,
+// ipBlock peers are assumed to match.
+func peersMatch(peers []networking_v1.NetworkPolicyPeer, policyNamespace string, peer Peer) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	for _, p := range peers {
+		if p.IPBlock != nil {
+			return true
+		}
+		if peer.External {
+			continue
+		}
+		if p.NamespaceSelector == nil {
+			if peer.AnyNamespace || peer.Namespace.Name != policyNamespace {
+				continue
+			}
+		} else if !peer.AnyNamespace && !selectorMatches(p.NamespaceSelector, peer.Namespace.Labels) {
+			continue
+		}
+		// Unknown pods may be any of the selected ones
+		if p.PodSelector == nil || peer.Labels == nil || selectorMatches(p.PodSelector, peer.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func portsMatch(ports []networking_v1.NetworkPolicyPort, port Port) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, p := range ports {
+		if p.Protocol != nil && *p.Protocol != core_v1.ProtocolTCP {
+			continue
+		}
+		if p.Port == nil || (p.Port.IntValue() > 0 && int32(p.Port.IntValue()) == port.Number) || (p.Port.IntValue() == 0 && port.Name != "" && p.Port.String() == port.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func selectorMatches(selector *meta_v1.LabelSelector, podLabels map[string]string) bool {
+	s, err := meta_v1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(podLabels))
+}
+
+// resolvePort sets the number of a named port, and the name of a numbered port, from the containers of the pod
+func resolvePort(pod core_v1.Pod, port Port) Port {
+	for _, c := range pod.Spec.Containers {
+		for _, cp := range c.Ports {
+			if (port.Name != "" && cp.Name == port.Name) || (port.Number > 0 && cp.ContainerPort == port.Number) {
+				return Port{Number: cp.ContainerPort, Name: cp.Name}
+			}
+		}
+	}
+	return port
+}
+
+// servicePods returns the pods selected by the service
+func servicePods(service core_v1.Service, pods []core_v1.Pod) []core_v1.Pod {
+	result := []core_v1.Pod{}
+	if len(service.Spec.Selector) == 0 {
+		return result
+	}
+	selector := labels.SelectorFromSet(service.Spec.Selector)
+	for _, pod := range pods {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
+
+// targetPort returns the port of the pods where the service port is forwarded
+func targetPort(port core_v1.ServicePort) Port {
+	if port.TargetPort.IntValue() > 0 {
+		return Port{Number: int32(port.TargetPort.IntValue())}
+	}
+	if name := port.TargetPort.String(); name != "" && name != "0" {
+		return Port{Name: name}
+	}
+	return Port{Number: port.Port}
+}
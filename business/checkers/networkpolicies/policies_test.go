@@ -0,0 +1,98 @@
+package networkpolicies
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/kiali/kiali/models"
+)
+
+func TestIngressAllowed(t *testing.T) {
+	assert := assert.New(t)
+
+	policies := []networking_v1.NetworkPolicy{
+		fakePolicy(t, `
+metadata:
+  name: reviews
+  namespace: bookinfo
+spec:
+  podSelector:
+    matchLabels: {app: reviews}
+  ingress:
+  - from:
+    - podSelector:
+        matchLabels: {app: productpage}
+    ports:
+    - port: http
+  - from:
+    - namespaceSelector:
+        matchLabels: {team: qa}
+`),
+	}
+	reviews := fakePod("reviews", map[string]string{"app": "reviews"}, core_v1.ContainerPort{Name: "http", ContainerPort: 9080})
+	ratings := fakePod("ratings", map[string]string{"app": "ratings"})
+	bookinfo := models.Namespace{Name: "bookinfo"}
+	qa := models.Namespace{Name: "qa", Labels: map[string]string{"team": "qa"}}
+
+	assert.True(ingressAllowed(policies, reviews, Peer{Namespace: bookinfo, Labels: map[string]string{"app": "productpage"}}, Port{Number: 9080}))
+	assert.False(ingressAllowed(policies, reviews, Peer{Namespace: bookinfo, Labels: map[string]string{"app": "details"}}, Port{Number: 9080}))
+	assert.False(ingressAllowed(policies, reviews, Peer{Namespace: bookinfo, Labels: map[string]string{"app": "productpage"}}, Port{Number: 9090}))
+	// Any pod of the namespace may be productpage
+	assert.True(ingressAllowed(policies, reviews, Peer{Namespace: bookinfo}, Port{Number: 9080}))
+	assert.True(ingressAllowed(policies, reviews, Peer{Namespace: qa, Labels: map[string]string{"app": "tests"}}, Port{Number: 9090}))
+	assert.False(ingressAllowed(policies, reviews, Peer{Namespace: models.Namespace{Name: "travels"}}, Port{Number: 9080}))
+	assert.False(ingressAllowed(policies, reviews, Peer{External: true}, Port{Number: 9080}))
+	// Pods not selected by any policy aren't isolated
+	assert.True(ingressAllowed(policies, ratings, Peer{Namespace: models.Namespace{Name: "travels"}}, Port{Number: 9080}))
+}
+
+func TestEgressAllowed(t *testing.T) {
+	assert := assert.New(t)
+
+	policies := []networking_v1.NetworkPolicy{
+		fakePolicy(t, `
+metadata:
+  name: egress
+  namespace: bookinfo
+spec:
+  podSelector: {}
+  policyTypes: [Egress]
+  egress:
+  - to:
+    - namespaceSelector:
+        matchLabels: {name: istio-system}
+    ports:
+    - port: 15012
+`),
+	}
+	pod := fakePod("reviews", map[string]string{"app": "reviews"})
+	istio := models.Namespace{Name: "istio-system", Labels: map[string]string{"name": "istio-system"}}
+
+	assert.True(egressAllowed(policies, pod, Peer{Namespace: istio}, Port{Number: 15012}))
+	assert.False(egressAllowed(policies, pod, Peer{Namespace: istio}, Port{Number: 15010}))
+	assert.False(egressAllowed(policies, pod, Peer{Namespace: models.Namespace{Name: "istio-system"}}, Port{Number: 15012}))
+	assert.True(egressAllowed(policies, pod, Peer{AnyNamespace: true}, Port{Number: 15012}))
+	// Ingress is not isolated by Egress policies
+	assert.True(ingressAllowed(policies, pod, Peer{External: true}, Port{Number: 9080}))
+}
+
+func fakePolicy(t *testing.T, manifest string) networking_v1.NetworkPolicy {
+	np := networking_v1.NetworkPolicy{}
+	if err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096).Decode(&np); err != nil {
+		t.Fatal(err)
+	}
+	return np
+}
+
+func fakePod(name string, labels map[string]string, ports ...core_v1.ContainerPort) core_v1.Pod {
+	return core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo", Labels: labels},
+		Spec:       core_v1.PodSpec{Containers: []core_v1.Container{{Name: name, Ports: ports}}},
+	}
+}
@@ -0,0 +1,115 @@
+package networkpolicies
+
+import (
+	"fmt"
+
+	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// RouteReachabilityChecker checks that the pods of the route destinations accept traffic from the callers of the
+// VirtualService: the sidecars of its namespace or the pods of its gateways, and the workloads observed calling its hosts
+type RouteReachabilityChecker struct {
+	VirtualService  kubernetes.IstioObject
+	Callers         []Peer
+	Services        []core_v1.Service
+	Pods            []core_v1.Pod
+	NetworkPolicies []networking_v1.NetworkPolicy
+}
+
+func (r RouteReachabilityChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	for _, protocol := range []string{"http", "tcp", "tls"} {
+		routes, ok := r.VirtualService.GetSpec()[protocol].([]interface{})
+		if !ok {
+			continue
+		}
+		for k, route := range routes {
+			routeMap, ok := route.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			destinations, ok := routeMap["route"].([]interface{})
+			if !ok {
+				continue
+			}
+			for i, d := range destinations {
+				destinationMap, ok := d.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				destination, _ := destinationMap["destination"].(map[string]interface{})
+				if !r.reachable(destination) {
+					validation := models.Build("virtualservices.route.networkpolicy.blocked",
+						fmt.Sprintf("spec/%s[%d]/route[%d]/destination/host", protocol, k, i))
+					validations = append(validations, &validation)
+				}
+			}
+		}
+	}
+	return validations, true
+}
+
+// reachable returns true when every caller reaches a pod of the destination on some of its ports
+func (r RouteReachabilityChecker) reachable(destination map[string]interface{}) bool {
+	host, _ := destination["host"].(string)
+	var service *core_v1.Service
+	for i, s := range r.Services {
+		if kubernetes.FilterByHost(host, s.Name, s.Namespace) {
+			service = &r.Services[i]
+			break
+		}
+	}
+	if service == nil {
+		return true
+	}
+
+	var destinationPort int32
+	if port, ok := destination["port"].(map[string]interface{}); ok {
+		destinationPort = number(port["number"])
+	}
+	ports := []Port{}
+	for _, port := range service.Spec.Ports {
+		if destinationPort == 0 || port.Port == destinationPort {
+			ports = append(ports, targetPort(port))
+		}
+	}
+
+	pods := servicePods(*service, r.Pods)
+	for _, caller := range r.Callers {
+		if !r.reachableFrom(caller, pods, ports) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r RouteReachabilityChecker) reachableFrom(caller Peer, pods []core_v1.Pod, ports []Port) bool {
+	if len(pods) == 0 || len(ports) == 0 {
+		return true
+	}
+	for _, pod := range pods {
+		for _, port := range ports {
+			if ingressAllowed(r.NetworkPolicies, pod, caller, port) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func number(n interface{}) int32 {
+	switch v := n.(type) {
+	case float64:
+		return int32(v)
+	case int64:
+		return int32(v)
+	case int:
+		return int32(v)
+	}
+	return 0
+}
@@ -0,0 +1,50 @@
+package networkpolicies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestRouteFromGatewayBlocked(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	checker := RouteReachabilityChecker{
+		VirtualService: data.AddRoutesToVirtualService("http", data.CreateRoute("reviews", "v1", -1),
+			data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})),
+		Services: []core_v1.Service{fakeService("reviews", 9080)},
+		Pods:     []core_v1.Pod{fakePod("reviews-v1", map[string]string{"app": "reviews"})},
+		NetworkPolicies: []networking_v1.NetworkPolicy{fakePolicy(t, `
+metadata:
+  name: reviews
+  namespace: bookinfo
+spec:
+  podSelector:
+    matchLabels: {app: reviews}
+  ingress:
+  - from:
+    - podSelector: {}
+`)},
+	}
+
+	// Sidecars of the namespace are allowed
+	checker.Callers = []Peer{{Namespace: models.Namespace{Name: "bookinfo"}}}
+	validations, valid := checker.Check()
+	assert.Empty(validations)
+	assert.True(valid)
+
+	// Ingress gateway pods are not
+	checker.Callers = append(checker.Callers, Peer{Namespace: models.Namespace{Name: "istio-system", Labels: map[string]string{}}, Labels: map[string]string{"istio": "ingressgateway"}})
+	validations, valid = checker.Check()
+	assert.Len(validations, 1)
+	assert.True(valid)
+	assert.Equal(models.CheckMessage("virtualservices.route.networkpolicy.blocked"), validations[0].Message)
+	assert.Equal("spec/http[0]/route[0]/destination/host", validations[0].Path)
+}
@@ -0,0 +1,61 @@
+package networkpolicies
+
+import (
+	"fmt"
+
+	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
+
+	"github.com/kiali/kiali/models"
+)
+
+// ServiceReachabilityChecker checks that the pods of the service accept traffic on the ports of the service,
+// from some caller and from every caller observed in the telemetry, when they are isolated by NetworkPolicies
+type ServiceReachabilityChecker struct {
+	Service core_v1.Service
+	// Workloads observed sending traffic to the service
+	Callers         []Peer
+	Pods            []core_v1.Pod
+	NetworkPolicies []networking_v1.NetworkPolicy
+}
+
+func (s ServiceReachabilityChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	pods := servicePods(s.Service, s.Pods)
+	if len(pods) == 0 {
+		return validations, true
+	}
+
+	for i, port := range s.Service.Spec.Ports {
+		reachable := false
+		for _, pod := range pods {
+			if ingressOpen(s.NetworkPolicies, pod, targetPort(port)) {
+				reachable = true
+				break
+			}
+		}
+		if !reachable {
+			validation := models.Build("service.networkpolicy.unreachable", fmt.Sprintf("spec/ports[%d]", i))
+			validations = append(validations, &validation)
+			continue
+		}
+		for _, caller := range s.Callers {
+			if !s.reachableFrom(caller, pods, targetPort(port)) {
+				validation := models.Build("service.networkpolicy.caller.blocked", fmt.Sprintf("spec/ports[%d]", i))
+				validations = append(validations, &validation)
+				break
+			}
+		}
+	}
+	return validations, true
+}
+
+func (s ServiceReachabilityChecker) reachableFrom(caller Peer, pods []core_v1.Pod, port Port) bool {
+	for _, pod := range pods {
+		if ingressAllowed(s.NetworkPolicies, pod, caller, port) {
+			return true
+		}
+	}
+	return false
+}
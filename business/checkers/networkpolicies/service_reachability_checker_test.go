@@ -0,0 +1,99 @@
+package networkpolicies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kiali/kiali/models"
+)
+
+func TestServiceReachable(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := ServiceReachabilityChecker{
+		Service: fakeService("reviews", 9080),
+		Pods:    []core_v1.Pod{fakePod("reviews-v1", map[string]string{"app": "reviews"})},
+		NetworkPolicies: []networking_v1.NetworkPolicy{fakePolicy(t, `
+metadata:
+  name: reviews
+spec:
+  podSelector:
+    matchLabels: {app: reviews}
+  ingress:
+  - ports:
+    - port: 9080
+`)},
+	}.Check()
+
+	assert.Empty(validations)
+	assert.True(valid)
+}
+
+func TestServiceUnreachable(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := ServiceReachabilityChecker{
+		Service: fakeService("reviews", 9080),
+		Pods:    []core_v1.Pod{fakePod("reviews-v1", map[string]string{"app": "reviews"})},
+		NetworkPolicies: []networking_v1.NetworkPolicy{fakePolicy(t, `
+metadata:
+  name: deny-all
+spec:
+  podSelector: {}
+`)},
+	}.Check()
+
+	assert.Len(validations, 1)
+	assert.True(valid)
+	assert.Equal(models.CheckMessage("service.networkpolicy.unreachable"), validations[0].Message)
+	assert.Equal("spec/ports[0]", validations[0].Path)
+}
+
+func TestServiceBlockedForObservedCaller(t *testing.T) {
+	assert := assert.New(t)
+
+	checker := ServiceReachabilityChecker{
+		Service: fakeService("reviews", 9080),
+		Pods:    []core_v1.Pod{fakePod("reviews-v1", map[string]string{"app": "reviews"})},
+		NetworkPolicies: []networking_v1.NetworkPolicy{fakePolicy(t, `
+metadata:
+  name: reviews
+  namespace: bookinfo
+spec:
+  podSelector:
+    matchLabels: {app: reviews}
+  ingress:
+  - from:
+    - podSelector:
+        matchLabels: {app: productpage}
+`)},
+	}
+
+	// Callers of the namespace allowed by the policy
+	checker.Callers = []Peer{{Namespace: models.Namespace{Name: "bookinfo"}, Labels: map[string]string{"app": "productpage"}}}
+	validations, valid := checker.Check()
+	assert.Empty(validations)
+	assert.True(valid)
+
+	checker.Callers = append(checker.Callers, Peer{Namespace: models.Namespace{Name: "bookinfo"}, Labels: map[string]string{"app": "ratings"}})
+	validations, valid = checker.Check()
+	assert.Len(validations, 1)
+	assert.True(valid)
+	assert.Equal(models.CheckMessage("service.networkpolicy.caller.blocked"), validations[0].Message)
+	assert.Equal("spec/ports[0]", validations[0].Path)
+}
+
+func fakeService(name string, port int32) core_v1.Service {
+	return core_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo"},
+		Spec: core_v1.ServiceSpec{
+			Selector: map[string]string{"app": name},
+			Ports:    []core_v1.ServicePort{{Name: "http", Port: port, TargetPort: intstr.FromInt(int(port))}},
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package telemetries
+
+import (
+	"fmt"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// Metrics read from Prometheus to build the graph, as named in the Telemetry API
+var graphMetrics = map[string]bool{
+	"ALL_METRICS":        true,
+	"REQUEST_COUNT":      true,
+	"REQUEST_DURATION":   true,
+	"TCP_SENT_BYTES":     true,
+	"TCP_RECEIVED_BYTES": true,
+}
+
+// MetricsDisabledChecker checks that the Telemetry doesn't disable, for the Prometheus provider,
+// any of the metrics that the graph depends on
+type MetricsDisabledChecker struct {
+	Telemetry kubernetes.IstioObject
+}
+
+func (mc MetricsDisabledChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	metrics, _ := mc.Telemetry.GetSpec()["metrics"].([]interface{})
+	for i, m := range metrics {
+		metric, ok := m.(map[string]interface{})
+		if !ok || !hasPrometheusProvider(metric) {
+			continue
+		}
+		overrides, _ := metric["overrides"].([]interface{})
+		for j, o := range overrides {
+			override, ok := o.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if disabled, _ := override["disabled"].(bool); disabled && isGraphMetric(override) {
+				validation := models.Build("telemetry.metrics.disabled", fmt.Sprintf("spec/metrics[%d]/overrides[%d]/disabled", i, j))
+				validations = append(validations, &validation)
+			}
+		}
+	}
+
+	return validations, true
+}
+
+// hasPrometheusProvider returns true when the metrics are sent to Prometheus, which is also the default provider
+func hasPrometheusProvider(metric map[string]interface{}) bool {
+	providers, _ := metric["providers"].([]interface{})
+	if len(providers) == 0 {
+		return true
+	}
+	for _, p := range providers {
+		if provider, ok := p.(map[string]interface{}); ok && provider["name"] == "prometheus" {
+			return true
+		}
+	}
+	return false
+}
+
+// isGraphMetric returns true when the override matches a metric used by the graph. An override without
+// a match applies to all the metrics, and one matching a custom metric never affects the standard ones.
+func isGraphMetric(override map[string]interface{}) bool {
+	match, ok := override["match"].(map[string]interface{})
+	if !ok {
+		return true
+	}
+	if _, found := match["customMetric"]; found {
+		return false
+	}
+	metric, ok := match["metric"].(string)
+	if !ok {
+		return true
+	}
+	return graphMetrics[metric]
+}
@@ -0,0 +1,41 @@
+package telemetries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestMetricsNotDisabled(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	telemetry := data.CreateTelemetry("mesh-default", "istio-system", nil)
+	telemetry = data.AddMetricsToTelemetry([]string{"prometheus"}, []string{"REQUEST_SIZE", "GRPC_REQUEST_MESSAGES"}, telemetry)
+	telemetry = data.AddMetricsToTelemetry([]string{"stackdriver"}, []string{""}, telemetry)
+
+	validations, valid := MetricsDisabledChecker{Telemetry: telemetry}.Check()
+	assert.True(valid)
+	assert.Empty(validations)
+}
+
+func TestGraphMetricsDisabled(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	telemetry := data.CreateTelemetry("reviews", "bookinfo", map[string]interface{}{"app": "reviews"})
+	telemetry = data.AddMetricsToTelemetry([]string{"prometheus"}, []string{"REQUEST_SIZE", "REQUEST_COUNT"}, telemetry)
+	telemetry = data.AddMetricsToTelemetry(nil, []string{""}, telemetry)
+
+	validations, valid := MetricsDisabledChecker{Telemetry: telemetry}.Check()
+	assert.True(valid)
+	assert.Len(validations, 2)
+	assert.Equal(models.CheckMessage("telemetry.metrics.disabled"), validations[0].Message)
+	assert.Equal(models.WarningSeverity, validations[0].Severity)
+	assert.Equal("spec/metrics[0]/overrides[1]/disabled", validations[0].Path)
+	assert.Equal("spec/metrics[1]/overrides[0]/disabled", validations[1].Path)
+}
@@ -0,0 +1,47 @@
+package checkers
+
+import (
+	"github.com/kiali/kiali/business/checkers/common"
+	"github.com/kiali/kiali/business/checkers/telemetries"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const TelemetryCheckerType = "telemetry"
+
+type TelemetryChecker struct {
+	Telemetries  []kubernetes.IstioObject
+	WorkloadList models.WorkloadList
+}
+
+func (t TelemetryChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	// Only one Telemetry should apply to a workload, Istio doesn't define how overlapping ones are merged
+	validations.MergeValidations(common.SelectorMultiMatchChecker(TelemetryCheckerType, t.Telemetries, t.WorkloadList).Check())
+
+	for _, telemetry := range t.Telemetries {
+		validations.MergeValidations(t.runChecks(telemetry))
+	}
+
+	return validations
+}
+
+// runChecks runs all the individual checks for a single telemetry and appends the result into validations.
+func (t TelemetryChecker) runChecks(telemetry kubernetes.IstioObject) models.IstioValidations {
+	telemetryName := telemetry.GetObjectMeta().Name
+	key, rrValidation := EmptyValidValidation(telemetryName, telemetry.GetObjectMeta().Namespace, TelemetryCheckerType)
+
+	enabledCheckers := []Checker{
+		common.SelectorNoWorkloadFoundChecker(TelemetryCheckerType, telemetry, t.WorkloadList),
+		telemetries.MetricsDisabledChecker{Telemetry: telemetry},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		rrValidation.Checks = append(rrValidation.Checks, checks...)
+		rrValidation.Valid = rrValidation.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: rrValidation}
+}
@@ -0,0 +1,44 @@
+package checkers
+
+import (
+	"github.com/kiali/kiali/business/checkers/common"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const WasmPluginCheckerType = "wasmplugin"
+
+type WasmPluginChecker struct {
+	WasmPlugins  []kubernetes.IstioObject
+	WorkloadList models.WorkloadList
+}
+
+// Check runs the checks of every WasmPlugin. Several plugins can apply to the same workload, their order is
+// set by their phase and priority, so there are no group checks.
+func (w WasmPluginChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, wasmPlugin := range w.WasmPlugins {
+		validations.MergeValidations(w.runChecks(wasmPlugin))
+	}
+
+	return validations
+}
+
+// runChecks runs all the individual checks for a single wasm plugin and appends the result into validations.
+func (w WasmPluginChecker) runChecks(wasmPlugin kubernetes.IstioObject) models.IstioValidations {
+	wasmPluginName := wasmPlugin.GetObjectMeta().Name
+	key, rrValidation := EmptyValidValidation(wasmPluginName, wasmPlugin.GetObjectMeta().Namespace, WasmPluginCheckerType)
+
+	enabledCheckers := []Checker{
+		common.SelectorNoWorkloadFoundChecker(WasmPluginCheckerType, wasmPlugin, w.WorkloadList),
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		rrValidation.Checks = append(rrValidation.Checks, checks...)
+		rrValidation.Valid = rrValidation.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: rrValidation}
+}
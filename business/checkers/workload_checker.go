@@ -0,0 +1,74 @@
+package checkers
+
+import (
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/business/checkers/workloads"
+	"github.com/kiali/kiali/models"
+)
+
+const WorkloadCheckerType = "workload"
+
+type WorkloadChecker struct {
+	Namespace    string
+	Namespaces   models.Namespaces
+	WorkloadList models.WorkloadList
+	Pods         []core_v1.Pod
+	// Mesh-wide holdApplicationUntilProxyStarts setting
+	HoldApplicationUntilProxyStarts bool
+}
+
+func (wc WorkloadChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, w := range wc.WorkloadList.Workloads {
+		validations.MergeValidations(wc.runSingleChecks(w))
+	}
+
+	return validations
+}
+
+func (wc WorkloadChecker) runSingleChecks(workload models.WorkloadListItem) models.IstioValidations {
+	key, validations := EmptyValidValidation(workload.Name, wc.Namespace, WorkloadCheckerType)
+
+	enabledCheckers := []Checker{
+		workloads.LabelsChecker{Workload: workload},
+	}
+
+	// Pods of a workload share the same template, so the first one is checked
+	if pod := wc.findPod(workload); pod != nil {
+		namespace := models.Namespace{Name: wc.Namespace}
+		for _, ns := range wc.Namespaces {
+			if ns.Name == wc.Namespace {
+				namespace = ns
+			}
+		}
+		enabledCheckers = append(enabledCheckers,
+			workloads.ContainersChecker{Pod: *pod},
+			workloads.SidecarInjectionChecker{Pod: *pod, Namespace: namespace},
+			workloads.HoldApplicationChecker{Pod: *pod, HoldApplicationUntilProxyStarts: wc.HoldApplicationUntilProxyStarts},
+		)
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		validations.Checks = append(validations.Checks, checks...)
+		validations.Valid = validations.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: validations}
+}
+
+func (wc WorkloadChecker) findPod(workload models.WorkloadListItem) *core_v1.Pod {
+	if len(workload.Labels) == 0 {
+		return nil
+	}
+	selector := labels.SelectorFromSet(workload.Labels)
+	for i, pod := range wc.Pods {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return &wc.Pods[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestWorkloadChecker(t *testing.T) {
+	config.Set(config.NewConfig())
+	assert := assert.New(t)
+
+	validations := WorkloadChecker{
+		Namespace:  "bookinfo",
+		Namespaces: models.Namespaces{{Name: "bookinfo", Labels: map[string]string{"istio-injection": "enabled"}}},
+		WorkloadList: data.CreateWorkloadList("bookinfo",
+			data.CreateWorkloadListItem("reviews-v1", appVersionLabel("reviews", "v1")),
+			data.CreateWorkloadListItem("details", map[string]string{"app": "details"}),
+		),
+		Pods: []core_v1.Pod{
+			{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:        "reviews-v1-1234",
+					Labels:      appVersionLabel("reviews", "v1"),
+					Annotations: map[string]string{"sidecar.istio.io/status": "{}"},
+				},
+				Spec: core_v1.PodSpec{Containers: []core_v1.Container{
+					{Name: "reviews", ReadinessProbe: &core_v1.Probe{}, Ports: []core_v1.ContainerPort{{ContainerPort: 15020}}},
+					{Name: "istio-proxy"},
+				}},
+			},
+		},
+	}.Check()
+
+	assert.Len(validations, 2)
+
+	reviews, ok := validations[models.IstioValidationKey{ObjectType: "workload", Name: "reviews-v1", Namespace: "bookinfo"}]
+	assert.True(ok)
+	assert.False(reviews.Valid)
+	assert.Len(reviews.Checks, 1)
+	assert.Equal(models.CheckMessage("workload.container.port.reserved"), reviews.Checks[0].Message)
+
+	// Workloads without pods only get the label checks
+	details, ok := validations[models.IstioValidationKey{ObjectType: "workload", Name: "details", Namespace: "bookinfo"}]
+	assert.True(ok)
+	assert.True(details.Valid)
+	assert.Len(details.Checks, 1)
+	assert.Equal(models.CheckMessage("workload.label.version.missing"), details.Checks[0].Message)
+}
@@ -0,0 +1,51 @@
+package workloads
+
+import (
+	"fmt"
+
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	proxyContainerName = "istio-proxy"
+	// Ports from 15000 to 15090 are reserved by Envoy and the Istio agent
+	firstReservedPort = 15000
+	lastReservedPort  = 15090
+)
+
+// ContainersChecker checks the application containers of a pod of the workload: they should define a readiness
+// probe, and must not use the ports reserved by the Istio proxy
+type ContainersChecker struct {
+	Pod core_v1.Pod
+}
+
+func (c ContainersChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	for i, container := range c.Pod.Spec.Containers {
+		if container.Name == proxyContainerName {
+			continue
+		}
+		path := fmt.Sprintf("spec/template/spec/containers[%d]", i)
+		if container.ReadinessProbe == nil {
+			validation := models.Build("workload.container.readinessprobe.missing", path)
+			validations = append(validations, &validation)
+		}
+		for j, port := range container.Ports {
+			if port.ContainerPort >= firstReservedPort && port.ContainerPort <= lastReservedPort {
+				validation := models.Build("workload.container.port.reserved", fmt.Sprintf("%s/ports[%d]", path, j))
+				validations = append(validations, &validation)
+			}
+		}
+	}
+
+	valid := true
+	for _, v := range validations {
+		if v.Severity == models.ErrorSeverity {
+			valid = false
+		}
+	}
+	return validations, valid
+}
@@ -0,0 +1,63 @@
+package workloads
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/models"
+)
+
+func TestContainersWithProbeAndPorts(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := ContainersChecker{
+		Pod: fakePod(
+			core_v1.Container{Name: "reviews", ReadinessProbe: &core_v1.Probe{}, Ports: []core_v1.ContainerPort{{ContainerPort: 9080}}},
+			core_v1.Container{Name: "istio-proxy", Ports: []core_v1.ContainerPort{{ContainerPort: 15090}}},
+		),
+	}.Check()
+
+	assert.Empty(validations)
+	assert.True(valid)
+}
+
+func TestContainerWithoutReadinessProbe(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := ContainersChecker{
+		Pod: fakePod(core_v1.Container{Name: "reviews"}),
+	}.Check()
+
+	assert.Len(validations, 1)
+	assert.True(valid)
+	assert.Equal(models.WarningSeverity, validations[0].Severity)
+	assert.Equal(models.CheckMessage("workload.container.readinessprobe.missing"), validations[0].Message)
+	assert.Equal("spec/template/spec/containers[0]", validations[0].Path)
+}
+
+func TestContainerWithReservedPort(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := ContainersChecker{
+		Pod: fakePod(core_v1.Container{
+			Name:           "reviews",
+			ReadinessProbe: &core_v1.Probe{},
+			Ports:          []core_v1.ContainerPort{{ContainerPort: 9080}, {ContainerPort: 15001}},
+		}),
+	}.Check()
+
+	assert.Len(validations, 1)
+	assert.False(valid)
+	assert.Equal(models.ErrorSeverity, validations[0].Severity)
+	assert.Equal(models.CheckMessage("workload.container.port.reserved"), validations[0].Message)
+	assert.Equal("spec/template/spec/containers[0]/ports[1]", validations[0].Path)
+}
+
+func fakePod(containers ...core_v1.Container) core_v1.Pod {
+	pod := core_v1.Pod{}
+	pod.Name = "reviews-v1-1234"
+	pod.Spec.Containers = containers
+	return pod
+}
@@ -0,0 +1,67 @@
+package workloads
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/models"
+)
+
+const proxyConfigAnnotation = "proxy.istio.io/config"
+
+// HoldApplicationChecker checks that workloads whose containers call out when they start hold the application
+// until the proxy is ready, as those calls fail while the proxy is starting.
+// Containers are considered to call out at startup when they have a postStart hook, or when their command
+// invokes curl or wget or references an http(s) URL.
+type HoldApplicationChecker struct {
+	Pod core_v1.Pod
+	// Mesh-wide default, from the defaultConfig of the mesh config
+	HoldApplicationUntilProxyStarts bool
+}
+
+func (h HoldApplicationChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	if h.holdsApplication() {
+		return validations, true
+	}
+
+	for i, container := range h.Pod.Spec.Containers {
+		if container.Name != proxyContainerName && callsOutAtStartup(container) {
+			validation := models.Build("workload.proxy.holdapplication.missing", fmt.Sprintf("spec/template/spec/containers[%d]", i))
+			validations = append(validations, &validation)
+		}
+	}
+	return validations, true
+}
+
+func (h HoldApplicationChecker) holdsApplication() bool {
+	annotation, found := h.Pod.Annotations[proxyConfigAnnotation]
+	if !found {
+		return h.HoldApplicationUntilProxyStarts
+	}
+	// Unset fields keep the mesh-wide default
+	proxyConfig := struct {
+		HoldApplicationUntilProxyStarts *bool `yaml:"holdApplicationUntilProxyStarts,omitempty"`
+	}{}
+	if err := yaml.Unmarshal([]byte(annotation), &proxyConfig); err != nil || proxyConfig.HoldApplicationUntilProxyStarts == nil {
+		return h.HoldApplicationUntilProxyStarts
+	}
+	return *proxyConfig.HoldApplicationUntilProxyStarts
+}
+
+func callsOutAtStartup(container core_v1.Container) bool {
+	if container.Lifecycle != nil && container.Lifecycle.PostStart != nil {
+		return true
+	}
+	for _, arg := range append(append([]string{}, container.Command...), container.Args...) {
+		arg = strings.ToLower(arg)
+		if strings.Contains(arg, "curl") || strings.Contains(arg, "wget") || strings.Contains(arg, "http://") || strings.Contains(arg, "https://") {
+			return true
+		}
+	}
+	return false
+}
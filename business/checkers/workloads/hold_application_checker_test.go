@@ -0,0 +1,73 @@
+package workloads
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/models"
+)
+
+func TestHoldApplicationNoCallsAtStartup(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := HoldApplicationChecker{
+		Pod: fakePod(core_v1.Container{Name: "reviews", Command: []string{"/opt/reviews"}}),
+	}.Check()
+
+	assert.Empty(validations)
+	assert.True(valid)
+}
+
+func TestHoldApplicationMissing(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, valid := HoldApplicationChecker{
+		Pod: fakePod(
+			core_v1.Container{Name: "reviews"},
+			core_v1.Container{Name: "init", Command: []string{"sh", "-c"}, Args: []string{"curl http://details:9080 && /opt/reviews"}},
+		),
+	}.Check()
+
+	assert.Len(validations, 1)
+	assert.True(valid)
+	assert.Equal(models.WarningSeverity, validations[0].Severity)
+	assert.Equal(models.CheckMessage("workload.proxy.holdapplication.missing"), validations[0].Message)
+	assert.Equal("spec/template/spec/containers[1]", validations[0].Path)
+}
+
+func TestHoldApplicationPostStart(t *testing.T) {
+	assert := assert.New(t)
+
+	validations, _ := HoldApplicationChecker{
+		Pod: fakePod(core_v1.Container{Name: "reviews", Lifecycle: &core_v1.Lifecycle{PostStart: &core_v1.Handler{}}}),
+	}.Check()
+
+	assert.Len(validations, 1)
+}
+
+func TestHoldApplicationEnabled(t *testing.T) {
+	assert := assert.New(t)
+	container := core_v1.Container{Name: "reviews", Command: []string{"wget", "https://example.com"}}
+
+	// Mesh-wide setting
+	validations, _ := HoldApplicationChecker{Pod: fakePod(container), HoldApplicationUntilProxyStarts: true}.Check()
+	assert.Empty(validations)
+
+	// Pod annotation
+	pod := fakePod(container)
+	pod.Annotations = map[string]string{proxyConfigAnnotation: "holdApplicationUntilProxyStarts: true"}
+	validations, _ = HoldApplicationChecker{Pod: pod}.Check()
+	assert.Empty(validations)
+
+	// Pod annotation overrides the mesh-wide setting
+	pod.Annotations = map[string]string{proxyConfigAnnotation: "holdApplicationUntilProxyStarts: false"}
+	validations, _ = HoldApplicationChecker{Pod: pod, HoldApplicationUntilProxyStarts: true}.Check()
+	assert.Len(validations, 1)
+
+	// Annotations that don't set it keep the mesh-wide setting
+	pod.Annotations = map[string]string{proxyConfigAnnotation: "concurrency: 2"}
+	validations, _ = HoldApplicationChecker{Pod: pod, HoldApplicationUntilProxyStarts: true}.Check()
+	assert.Empty(validations)
+}
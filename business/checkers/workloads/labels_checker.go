@@ -0,0 +1,25 @@
+package workloads
+
+import (
+	"github.com/kiali/kiali/models"
+)
+
+// LabelsChecker checks that the workload has the app and version labels used by Istio, as configured in IstioLabels
+type LabelsChecker struct {
+	Workload models.WorkloadListItem
+}
+
+func (l LabelsChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	if !l.Workload.AppLabel {
+		validation := models.Build("workload.label.app.missing", "spec/template/metadata/labels")
+		validations = append(validations, &validation)
+	}
+	if !l.Workload.VersionLabel {
+		validation := models.Build("workload.label.version.missing", "spec/template/metadata/labels")
+		validations = append(validations, &validation)
+	}
+
+	return validations, true
+}
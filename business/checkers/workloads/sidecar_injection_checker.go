@@ -0,0 +1,39 @@
+package workloads
+
+import (
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	injectAnnotation  = "sidecar.istio.io/inject"
+	revisionLabel     = "istio.io/rev"
+	injectionEnabled  = "enabled"
+	injectionDisabled = "false"
+)
+
+// SidecarInjectionChecker checks that the pods of namespaces with sidecar injection enabled have a sidecar,
+// unless injection is explicitly disabled for the pod
+type SidecarInjectionChecker struct {
+	Pod       core_v1.Pod
+	Namespace models.Namespace
+}
+
+func (s SidecarInjectionChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+	conf := config.Get()
+
+	_, revision := s.Namespace.Labels[revisionLabel]
+	injected := s.Namespace.Labels[conf.IstioLabels.InjectionLabelName] == injectionEnabled || revision
+	if !injected || s.Pod.Annotations[injectAnnotation] == injectionDisabled {
+		return validations, true
+	}
+
+	if _, found := s.Pod.Annotations[conf.ExternalServices.Istio.IstioSidecarAnnotation]; !found {
+		validation := models.Build("workload.sidecar.missing", "spec/template/metadata")
+		validations = append(validations, &validation)
+	}
+	return validations, true
+}
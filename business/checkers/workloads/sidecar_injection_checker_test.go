@@ -0,0 +1,52 @@
+package workloads
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+func TestSidecarMissingInInjectedNamespace(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	validations, valid := SidecarInjectionChecker{
+		Pod:       fakePod(core_v1.Container{Name: "reviews"}),
+		Namespace: models.Namespace{Name: "bookinfo", Labels: map[string]string{"istio-injection": "enabled"}},
+	}.Check()
+
+	assert.Len(validations, 1)
+	assert.True(valid)
+	assert.Equal(models.CheckMessage("workload.sidecar.missing"), validations[0].Message)
+	assert.Equal("spec/template/metadata", validations[0].Path)
+
+	// Revision labels enable injection too
+	validations, _ = SidecarInjectionChecker{
+		Pod:       fakePod(core_v1.Container{Name: "reviews"}),
+		Namespace: models.Namespace{Name: "bookinfo", Labels: map[string]string{"istio.io/rev": "canary"}},
+	}.Check()
+	assert.Len(validations, 1)
+}
+
+func TestSidecarPresentOrNotExpected(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+	injected := models.Namespace{Name: "bookinfo", Labels: map[string]string{"istio-injection": "enabled"}}
+
+	pod := fakePod(core_v1.Container{Name: "reviews"}, core_v1.Container{Name: "istio-proxy"})
+	pod.Annotations = map[string]string{"sidecar.istio.io/status": "{}"}
+	validations, _ := SidecarInjectionChecker{Pod: pod, Namespace: injected}.Check()
+	assert.Empty(validations)
+
+	pod = fakePod(core_v1.Container{Name: "reviews"})
+	pod.Annotations = map[string]string{"sidecar.istio.io/inject": "false"}
+	validations, _ = SidecarInjectionChecker{Pod: pod, Namespace: injected}.Check()
+	assert.Empty(validations)
+
+	validations, _ = SidecarInjectionChecker{Pod: fakePod(core_v1.Container{Name: "reviews"}), Namespace: models.Namespace{Name: "bookinfo"}}.Check()
+	assert.Empty(validations)
+}
@@ -0,0 +1,673 @@
+package business
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+	"github.com/kiali/kiali/util"
+)
+
+// User recorded in the history of the Istio config changed when the fault experiments are checked
+const faultExperimentsUser = "kiali-fault-experiments"
+
+// Experiments kept per service, older ones are dropped once finished
+const maxFaultExperiments = 20
+
+// ConfigMaps of the Kiali namespace holding the records of the fault experiments and the lease of their watcher
+const (
+	faultExperimentsConfigMap = "kiali-fault-experiments"
+	faultExperimentsLease     = "kiali-fault-experiments-lease"
+)
+
+var faultExperiments = newFaultExperimentStore(getKialiSAClient)
+
+// Held while an experiment ends, so its faults are only removed once
+var faultExperimentsEndLock sync.Mutex
+
+var faultExperimentWatcher *FaultExperimentWatcher
+var faultExperimentWatcherLock sync.Mutex
+
+// faultExperimentStore keeps the records of the fault experiments in a ConfigMap of the Kiali namespace, one record
+// per key, so they are shared by the Kiali replicas and kept when Kiali restarts. Users may not be allowed to write
+// in the Kiali namespace, records are stored by the Kiali ServiceAccount.
+type faultExperimentStore struct {
+	client func() (kubernetes.ClientInterface, error)
+}
+
+func newFaultExperimentStore(client func() (kubernetes.ClientInterface, error)) *faultExperimentStore {
+	return &faultExperimentStore{client: client}
+}
+
+// faultExperimentKey is unique, as the ID starts with the service name and names can't have dots
+func faultExperimentKey(experiment models.FaultExperiment) string {
+	return experiment.Namespace + "." + experiment.ID
+}
+
+// read returns the records of the ConfigMap, oldest first, and the ConfigMap, empty when it doesn't exist yet
+func (in *faultExperimentStore) read(k8s kubernetes.ClientInterface) ([]models.FaultExperiment, *core_v1.ConfigMap, error) {
+	namespace := config.Get().Deployment.Namespace
+	configMap, err := k8s.GetConfigMap(namespace, faultExperimentsConfigMap)
+	if err != nil {
+		if !errors2.IsNotFound(err) {
+			return nil, nil, err
+		}
+		configMap = &core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: faultExperimentsConfigMap, Namespace: namespace}}
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	experiments := make([]models.FaultExperiment, 0, len(configMap.Data))
+	for key, value := range configMap.Data {
+		experiment := models.FaultExperiment{}
+		if err := json.Unmarshal([]byte(value), &experiment); err != nil {
+			log.Warningf("Skipping fault experiment %s: %s", key, err)
+			continue
+		}
+		experiments = append(experiments, experiment)
+	}
+	sort.Slice(experiments, func(i, j int) bool {
+		if experiments[i].StartTime.Equal(experiments[j].StartTime) {
+			return experiments[i].ID < experiments[j].ID
+		}
+		return experiments[i].StartTime.Before(experiments[j].StartTime)
+	})
+	return experiments, configMap, nil
+}
+
+// write applies the change to the records, retrying when the ConfigMap was updated concurrently by
+// other Kiali requests or replicas
+func (in *faultExperimentStore) write(change func(experiments []models.FaultExperiment, configMap *core_v1.ConfigMap) error) error {
+	k8s, err := in.client()
+	if err != nil {
+		return err
+	}
+	namespace := config.Get().Deployment.Namespace
+	for attempt := 1; ; attempt++ {
+		experiments, configMap, err := in.read(k8s)
+		if err != nil {
+			return err
+		}
+		if err = change(experiments, configMap); err != nil {
+			return err
+		}
+		if configMap.ResourceVersion == "" {
+			_, err = k8s.CreateConfigMap(namespace, configMap)
+		} else {
+			_, err = k8s.UpdateConfigMap(namespace, configMap)
+		}
+		if err == nil || attempt == configHistoryUpdateMaxRetries || !(errors2.IsConflict(err) || errors2.IsAlreadyExists(err)) {
+			return err
+		}
+	}
+}
+
+func (in *faultExperimentStore) add(experiment models.FaultExperiment) error {
+	return in.write(func(experiments []models.FaultExperiment, configMap *core_v1.ConfigMap) error {
+		raw, err := json.Marshal(experiment)
+		if err != nil {
+			return err
+		}
+		configMap.Data[faultExperimentKey(experiment)] = string(raw)
+		kept := 1
+		for i := len(experiments) - 1; i >= 0; i-- {
+			e := experiments[i]
+			if e.Namespace != experiment.Namespace || e.Service != experiment.Service {
+				continue
+			}
+			if kept++; kept > maxFaultExperiments && e.Status != models.FaultExperimentRunning {
+				delete(configMap.Data, faultExperimentKey(e))
+			}
+		}
+		return nil
+	})
+}
+
+func (in *faultExperimentStore) update(experiment models.FaultExperiment) error {
+	return in.write(func(experiments []models.FaultExperiment, configMap *core_v1.ConfigMap) error {
+		key := faultExperimentKey(experiment)
+		if _, found := configMap.Data[key]; !found {
+			return errors2.NewNotFound(schema.GroupResource{Resource: "faultexperiments"}, experiment.ID)
+		}
+		raw, err := json.Marshal(experiment)
+		if err != nil {
+			return err
+		}
+		configMap.Data[key] = string(raw)
+		return nil
+	})
+}
+
+func (in *faultExperimentStore) get(namespace, service, id string) (models.FaultExperiment, bool, error) {
+	experiments, err := in.list(namespace, service)
+	if err != nil {
+		return models.FaultExperiment{}, false, err
+	}
+	for _, e := range experiments {
+		if e.ID == id {
+			return e, true, nil
+		}
+	}
+	return models.FaultExperiment{}, false, nil
+}
+
+// list returns the experiments of a service, oldest first, or of every service when it's empty
+func (in *faultExperimentStore) list(namespace, service string) ([]models.FaultExperiment, error) {
+	k8s, err := in.client()
+	if err != nil {
+		return nil, err
+	}
+	experiments, _, err := in.read(k8s)
+	if err != nil {
+		return nil, err
+	}
+	if service == "" {
+		return experiments, nil
+	}
+	filtered := []models.FaultExperiment{}
+	for _, e := range experiments {
+		if e.Namespace == namespace && e.Service == service {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered, nil
+}
+
+func (in *faultExperimentStore) running(namespace, service string) (bool, error) {
+	experiments, err := in.list(namespace, service)
+	if err != nil {
+		return false, err
+	}
+	for _, e := range experiments {
+		if e.Status == models.FaultExperimentRunning {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// StartFaultExperiment injects the faults of the request in the requests to every version of the service. It creates
+// the VirtualService of the service with the fault injection wizard when there is none, or adds the faults to the
+// HTTP routes of the existing one. The faults are removed when the duration expires or when the error rate seen by
+// the callers crosses its threshold.
+func (in *IstioConfigService) StartFaultExperiment(namespace, service string, request models.FaultExperimentRequest, user string) (models.FaultExperiment, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "StartFaultExperiment")
+	defer promtimer.ObserveNow(&err)
+
+	experiment := models.FaultExperiment{}
+	// Without the watcher, the faults would never be removed
+	if !config.Get().FaultExperiments.Enabled {
+		err = errors2.NewServiceUnavailable("Fault experiments are disabled in the Kiali configuration")
+		return experiment, err
+	}
+	duration, err := validateFaultExperiment(request)
+	if err != nil {
+		return experiment, err
+	}
+	versions, err := in.getServiceVersions(namespace, service)
+	if err != nil {
+		return experiment, err
+	}
+	if err = validateTrafficWizard(models.TrafficWizard{Type: models.WizardFaultInjection, Fault: &request.Fault}, versions); err != nil {
+		return experiment, err
+	}
+	running, err := faultExperiments.running(namespace, service)
+	if err != nil {
+		return experiment, err
+	}
+	if running {
+		err = errors2.NewConflict(schema.GroupResource{Resource: "services"}, service, fmt.Errorf("a fault experiment is already running"))
+		return experiment, err
+	}
+	if in.prom == nil {
+		err = errors2.NewServiceUnavailable("Prometheus is required to check the fault experiments")
+		return experiment, err
+	}
+
+	start := util.Clock.Now()
+	experiment = models.FaultExperiment{
+		FaultExperimentRequest: request,
+		ID:                     fmt.Sprintf("%s-%d", service, start.Unix()),
+		Namespace:              namespace,
+		Service:                service,
+		User:                   user,
+		Status:                 models.FaultExperimentRunning,
+		StartTime:              start,
+		ExpiresAt:              start.Add(duration),
+	}
+	if experiment.Before, err = getCallerMetrics(in.prom, namespace, service, start.Add(-duration), start); err != nil {
+		return experiment, err
+	}
+	if err = in.injectFaults(experiment); err != nil {
+		return experiment, err
+	}
+	// The watcher only removes the faults of the recorded experiments
+	if err = faultExperiments.add(experiment); err != nil {
+		if removeErr := in.removeFaults(namespace, service, experiment.ID, user); removeErr != nil {
+			log.Errorf("Faults of unrecorded experiment %s could not be removed: %s", experiment.ID, removeErr)
+		}
+		return experiment, err
+	}
+	return experiment, nil
+}
+
+// StopFaultExperiment removes the faults of a running experiment before its duration expires
+func (in *IstioConfigService) StopFaultExperiment(namespace, service, id, user string) (models.FaultExperiment, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "StopFaultExperiment")
+	defer promtimer.ObserveNow(&err)
+
+	experiment, err := in.GetFaultExperiment(namespace, service, id)
+	if err != nil {
+		return experiment, err
+	}
+	if experiment.Status != models.FaultExperimentRunning {
+		err = errors2.NewBadRequest(fmt.Sprintf("Fault experiment %s is not running, it is %s", id, experiment.Status))
+		return experiment, err
+	}
+	return in.endFaultExperiment(experiment, models.FaultExperimentStopped, "Stopped by "+user, user), nil
+}
+
+// GetFaultExperiments returns the fault experiments of a service, oldest first
+func (in *IstioConfigService) GetFaultExperiments(namespace, service string) ([]models.FaultExperiment, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "GetFaultExperiments")
+	defer promtimer.ObserveNow(&err)
+
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err = in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return nil, err
+	}
+	return faultExperiments.list(namespace, service)
+}
+
+// GetFaultExperiment returns a fault experiment of a service
+func (in *IstioConfigService) GetFaultExperiment(namespace, service, id string) (models.FaultExperiment, error) {
+	experiments, err := in.GetFaultExperiments(namespace, service)
+	if err != nil {
+		return models.FaultExperiment{}, err
+	}
+	for _, e := range experiments {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return models.FaultExperiment{}, errors2.NewNotFound(schema.GroupResource{Resource: "faultexperiments"}, id)
+}
+
+// injectFaults adds the faults of the experiment to the VirtualService of the service, annotated with the experiment
+func (in *IstioConfigService) injectFaults(experiment models.FaultExperiment) error {
+	namespace, service := experiment.Namespace, experiment.Service
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				models.FaultExperimentAnnotation:        experiment.ID,
+				models.FaultExperimentExpiresAnnotation: experiment.ExpiresAt.UTC().Format(time.RFC3339),
+			},
+		},
+	}
+
+	created := false
+	vs, err := in.k8s.GetIstioObject(namespace, kubernetes.VirtualServices, service)
+	switch {
+	case errors2.IsNotFound(err):
+		wizard := models.TrafficWizard{Type: models.WizardFaultInjection, Fault: &experiment.Fault}
+		result, err := in.ApplyTrafficWizard(namespace, service, wizard, false, experiment.User)
+		if err != nil {
+			return err
+		}
+		if !result.Import.Applied {
+			return errors2.NewBadRequest("Kiali validations found errors in the generated VirtualService and DestinationRule")
+		}
+		created = true
+		if vs, err = in.k8s.GetIstioObject(namespace, kubernetes.VirtualServices, service); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		if _, found := vs.GetObjectMeta().Annotations[models.FaultExperimentAnnotation]; found {
+			return errors2.NewConflict(schema.GroupResource{Group: kubernetes.ResourceTypesToAPI[kubernetes.VirtualServices], Resource: kubernetes.VirtualServices},
+				service, fmt.Errorf("it belongs to another fault experiment"))
+		}
+		http, _ := toUnstructured(vs)["spec"].(map[string]interface{})["http"].([]interface{})
+		if len(http) == 0 {
+			return errors2.NewBadRequest(fmt.Sprintf("VirtualService %s has no HTTP routes", service))
+		}
+		for _, r := range http {
+			route, _ := r.(map[string]interface{})
+			if _, found := route["fault"]; found {
+				return errors2.NewConflict(schema.GroupResource{Group: kubernetes.ResourceTypesToAPI[kubernetes.VirtualServices], Resource: kubernetes.VirtualServices},
+					service, fmt.Errorf("it already injects faults"))
+			}
+			route["fault"] = wizardFault(experiment.Fault)
+		}
+		patch["spec"] = map[string]interface{}{"http": http}
+	}
+
+	jsonPatch, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	api := kubernetes.ResourceTypesToAPI[kubernetes.VirtualServices]
+	if _, err = in.UpdateIstioConfigDetail(api, namespace, kubernetes.VirtualServices, service, string(jsonPatch), vs.GetObjectMeta().ResourceVersion, experiment.User); err != nil && created {
+		if deleteErr := in.DeleteTrafficWizard(namespace, service, experiment.User); deleteErr != nil {
+			log.Errorf("VirtualService and DestinationRule created for fault experiment %s could not be deleted: %s", experiment.ID, deleteErr)
+		}
+	}
+	return err
+}
+
+// removeFaults removes the faults injected by an experiment. The VirtualService created for it is deleted, otherwise
+// the faults are removed from its routes. Nothing is done when the VirtualService no longer belongs to the experiment.
+func (in *IstioConfigService) removeFaults(namespace, service, id, user string) error {
+	vs, err := in.k8s.GetIstioObject(namespace, kubernetes.VirtualServices, service)
+	if errors2.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	meta := vs.GetObjectMeta()
+	if meta.Annotations[models.FaultExperimentAnnotation] != id {
+		return nil
+	}
+	if meta.Labels[models.WizardLabel] == models.WizardFaultInjection {
+		return in.DeleteTrafficWizard(namespace, service, user)
+	}
+
+	http, _ := toUnstructured(vs)["spec"].(map[string]interface{})["http"].([]interface{})
+	for _, r := range http {
+		if route, ok := r.(map[string]interface{}); ok {
+			delete(route, "fault")
+		}
+	}
+	jsonPatch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				models.FaultExperimentAnnotation:        nil,
+				models.FaultExperimentExpiresAnnotation: nil,
+			},
+		},
+		"spec": map[string]interface{}{"http": http},
+	})
+	if err != nil {
+		return err
+	}
+	api := kubernetes.ResourceTypesToAPI[kubernetes.VirtualServices]
+	_, err = in.UpdateIstioConfigDetail(api, namespace, kubernetes.VirtualServices, service, string(jsonPatch), meta.ResourceVersion, user)
+	return err
+}
+
+// endFaultExperiment removes the faults of a running experiment and records its end with the traffic while it ran
+func (in *IstioConfigService) endFaultExperiment(experiment models.FaultExperiment, status, reason, user string) models.FaultExperiment {
+	faultExperimentsEndLock.Lock()
+	defer faultExperimentsEndLock.Unlock()
+	if current, found, err := faultExperiments.get(experiment.Namespace, experiment.Service, experiment.ID); err != nil {
+		log.Errorf("Fault experiment %s could not be read: %s", experiment.ID, err)
+	} else if found && current.Status != models.FaultExperimentRunning {
+		return current
+	}
+
+	end := util.Clock.Now()
+	if err := in.removeFaults(experiment.Namespace, experiment.Service, experiment.ID, user); err != nil {
+		status = models.FaultExperimentFailed
+		reason = fmt.Sprintf("%s, but the faults could not be removed: %s", reason, err)
+	}
+	experiment.Status = status
+	experiment.Reason = reason
+	experiment.EndTime = &end
+	if in.prom != nil {
+		during, err := getCallerMetrics(in.prom, experiment.Namespace, experiment.Service, experiment.StartTime, end)
+		if err != nil {
+			log.Errorf("Metrics of fault experiment %s could not be fetched: %s", experiment.ID, err)
+		}
+		experiment.During = during
+	}
+	if err := faultExperiments.update(experiment); err != nil {
+		log.Errorf("Fault experiment %s could not be recorded: %s", experiment.ID, err)
+	}
+	log.Infof("Fault experiment %s of service %s/%s is %s: %s", experiment.ID, experiment.Namespace, experiment.Service, status, reason)
+	return experiment
+}
+
+func validateFaultExperiment(request models.FaultExperimentRequest) (time.Duration, error) {
+	duration, err := time.ParseDuration(request.Duration)
+	if err != nil || duration <= 0 {
+		return 0, errors2.NewBadRequest(fmt.Sprintf("Duration %s is not a valid duration", request.Duration))
+	}
+	// Max duration is validated when Kiali starts
+	maxDuration, _ := time.ParseDuration(config.Get().FaultExperiments.MaxDuration)
+	if duration > maxDuration {
+		return 0, errors2.NewBadRequest(fmt.Sprintf("Duration %s is longer than the %s allowed", request.Duration, config.Get().FaultExperiments.MaxDuration))
+	}
+	if request.MaxCallerErrorRate <= 0 || request.MaxCallerErrorRate > 100 {
+		return 0, errors2.NewBadRequest("Max caller error rate must be greater than 0 and up to 100")
+	}
+	return duration, nil
+}
+
+// getCallerMetrics returns the traffic of the service reported by its callers between from and to. Faults are
+// injected by the proxies of the callers, so only them see the aborts and delays.
+func getCallerMetrics(prom prometheus.ClientInterface, namespace, service string, from, to time.Time) (*models.FaultExperimentMetrics, error) {
+	window := to.Sub(from)
+	if window < time.Second {
+		window = time.Second
+	}
+	// Go durations like 1m30s are not valid in PromQL
+	rateInterval := fmt.Sprintf("%ds", int(window.Seconds()))
+
+	rates, err := prom.GetServiceRequestRates(namespace, service, rateInterval, to)
+	if err != nil {
+		return nil, err
+	}
+	metrics := &models.FaultExperimentMetrics{From: from, To: to}
+	errors := 0.0
+	for _, sample := range rates {
+		if sample.Metric["reporter"] != "source" {
+			continue
+		}
+		metrics.RequestRate += float64(sample.Value)
+		// Requests without response, i.e. reset connections, are reported with code 0
+		if code, err := strconv.Atoi(string(sample.Metric["response_code"])); err == nil && (code >= 400 || code == 0) {
+			errors += float64(sample.Value)
+		}
+	}
+	if metrics.RequestRate > 0 {
+		metrics.ErrorRate = 100 * errors / metrics.RequestRate
+	}
+
+	labels := NewMetricsLabelsBuilder("inbound").Reporter("source").Service(service, namespace).Build()
+	stats, err := prom.FetchHistogramValues("istio_request_duration_milliseconds", labels, "", rateInterval, false, []string{p99Quantile}, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, sample := range stats[p99Quantile] {
+		if value := float64(sample.Value); !math.IsNaN(value) {
+			metrics.P99 = &value
+		}
+	}
+	return metrics, nil
+}
+
+// FaultExperimentWatcher ends the fault experiments whose duration expired or whose caller error rate crossed its
+// threshold, and completes their records with the traffic after them. The experiments are checked by a single Kiali
+// replica: the one holding the watcher lease.
+type FaultExperimentWatcher struct {
+	// Returns the business layer used for a check, with the Kiali ServiceAccount permissions
+	getLayer func() (*Layer, error)
+	interval time.Duration
+	lease    *ConfigMapLease
+	stop     chan struct{}
+}
+
+// StartFaultExperimentWatcher starts checking the fault experiments in background when they are enabled in the
+// configuration
+func StartFaultExperimentWatcher() {
+	conf := config.Get().FaultExperiments
+	if !conf.Enabled {
+		return
+	}
+	faultExperimentWatcherLock.Lock()
+	defer faultExperimentWatcherLock.Unlock()
+	if faultExperimentWatcher != nil {
+		return
+	}
+
+	// Check interval is validated when Kiali starts
+	interval, _ := time.ParseDuration(conf.CheckInterval)
+	faultExperimentWatcher = newFaultExperimentWatcher(kialiSALayerLoader(), interval)
+	// The lease expires when its holder missed a few checks
+	faultExperimentWatcher.lease = NewConfigMapLease(getKialiSAClient, config.Get().Deployment.Namespace, faultExperimentsLease, 3*interval)
+	go faultExperimentWatcher.run()
+	log.Infof("Fault experiment watcher started, experiments are checked every %s", conf.CheckInterval)
+}
+
+// StopFaultExperimentWatcher stops checking the fault experiments, if it was started
+func StopFaultExperimentWatcher() {
+	faultExperimentWatcherLock.Lock()
+	defer faultExperimentWatcherLock.Unlock()
+	if faultExperimentWatcher != nil {
+		close(faultExperimentWatcher.stop)
+		faultExperimentWatcher = nil
+	}
+}
+
+func newFaultExperimentWatcher(getLayer func() (*Layer, error), interval time.Duration) *FaultExperimentWatcher {
+	return &FaultExperimentWatcher{getLayer: getLayer, interval: interval, stop: make(chan struct{})}
+}
+
+func (in *FaultExperimentWatcher) run() {
+	ticker := time.NewTicker(in.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-in.stop:
+			return
+		case <-ticker.C:
+			if in.lease.Acquire() {
+				in.Check()
+			}
+		}
+	}
+}
+
+// Check ends the running experiments that expired or crossed their threshold, fetches the traffic after the ended
+// ones, and retries removing the faults left by the ended experiments
+func (in *FaultExperimentWatcher) Check() {
+	layer, err := in.getLayer()
+	if err != nil {
+		log.Errorf("Fault experiments could not be checked: %s", err)
+		return
+	}
+	service := &layer.IstioConfig
+	now := util.Clock.Now()
+
+	experiments, err := faultExperiments.list("", "")
+	if err != nil {
+		log.Errorf("Fault experiments could not be read: %s", err)
+		return
+	}
+	// Records are kept up to date, they are updated again when the faults left by the ended experiments are removed
+	for i, e := range experiments {
+		switch {
+		case e.Status == models.FaultExperimentRunning && !now.Before(e.ExpiresAt):
+			experiments[i] = service.endFaultExperiment(e, models.FaultExperimentCompleted, fmt.Sprintf("Duration of %s expired", e.Duration), faultExperimentsUser)
+		case e.Status == models.FaultExperimentRunning:
+			from := now.Add(-in.interval)
+			if from.Before(e.StartTime) {
+				from = e.StartTime
+			}
+			metrics, err := getCallerMetrics(service.prom, e.Namespace, e.Service, from, now)
+			if err != nil {
+				// The faults are still removed when the experiment expires
+				log.Errorf("Caller error rate of fault experiment %s could not be checked: %s", e.ID, err)
+				continue
+			}
+			if metrics.ErrorRate > e.MaxCallerErrorRate {
+				experiments[i] = service.endFaultExperiment(e, models.FaultExperimentAborted,
+					fmt.Sprintf("Caller error rate %.2f%% crossed the %.2f%% threshold", metrics.ErrorRate, e.MaxCallerErrorRate), faultExperimentsUser)
+			}
+		case e.EndTime != nil && e.After == nil:
+			// Traffic after the experiment is observed for as long as it ran
+			afterEnd := e.EndTime.Add(e.EndTime.Sub(e.StartTime))
+			if now.Before(afterEnd) {
+				continue
+			}
+			if e.After, err = getCallerMetrics(service.prom, e.Namespace, e.Service, *e.EndTime, afterEnd); err != nil {
+				log.Errorf("Metrics after fault experiment %s could not be fetched: %s", e.ID, err)
+				continue
+			}
+			if err = faultExperiments.update(e); err != nil {
+				log.Errorf("Fault experiment %s could not be recorded: %s", e.ID, err)
+			}
+			experiments[i] = e
+		}
+	}
+
+	in.removeLostFaults(layer, experiments, now)
+}
+
+// removeLostFaults removes the faults left by the ended experiments, whose removal failed. Only the VirtualServices
+// annotated with the ID of an experiment recorded by Kiali are changed.
+func (in *FaultExperimentWatcher) removeLostFaults(layer *Layer, experiments []models.FaultExperiment, now time.Time) {
+	namespaces := map[string]bool{}
+	for _, e := range experiments {
+		namespaces[e.Namespace] = true
+	}
+	var err error
+	for ns := range namespaces {
+		var vss []kubernetes.IstioObject
+		if IsResourceCached(ns, kubernetes.VirtualServices) {
+			vss, err = kialiCache.GetIstioObjects(ns, kubernetes.VirtualServices, "")
+		} else {
+			vss, err = layer.k8s.GetIstioObjects(ns, kubernetes.VirtualServices, "")
+		}
+		if err != nil {
+			log.Errorf("Fault experiments could not list the VirtualServices of namespace %s: %s", ns, err)
+			continue
+		}
+		for _, vs := range vss {
+			meta := vs.GetObjectMeta()
+			id, found := meta.Annotations[models.FaultExperimentAnnotation]
+			if !found {
+				continue
+			}
+			var experiment *models.FaultExperiment
+			for i := range experiments {
+				if e := experiments[i]; e.Namespace == ns && e.Service == meta.Name && e.ID == id {
+					experiment = &experiments[i]
+				}
+			}
+			if experiment == nil || experiment.Status == models.FaultExperimentRunning {
+				// Not started by Kiali, or removed when the experiment ends
+				continue
+			}
+			if err := layer.IstioConfig.removeFaults(ns, meta.Name, id, faultExperimentsUser); err != nil {
+				log.Errorf("Faults of experiment %s could not be removed: %s", id, err)
+				continue
+			}
+			log.Infof("Faults of experiment %s of service %s/%s were removed", id, ns, meta.Name)
+			experiment.Reason = fmt.Sprintf("%s. They were removed at %s", experiment.Reason, now.UTC().Format(time.RFC3339))
+			if err := faultExperiments.update(*experiment); err != nil {
+				log.Errorf("Fault experiment %s could not be recorded: %s", id, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package business
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/util"
+)
+
+var faultExperimentTime = time.Date(2021, 06, 01, 0, 0, 0, 0, time.UTC)
+
+func fakeFaultExperimentLayer(t *testing.T, objects string) (*Layer, *kubernetes.MemoryClient, *prometheustest.PromClientMock) {
+	util.Clock = util.ClockMock{Time: faultExperimentTime}
+	layer, k8s := fakeImportLayer(t, objects)
+	faultExperiments = newFaultExperimentStore(func() (kubernetes.ClientInterface, error) { return k8s, nil })
+	conf := config.Get()
+	conf.FaultExperiments.Enabled = true
+	config.Set(conf)
+	prom := new(prometheustest.PromClientMock)
+	layer.IstioConfig.prom = prom
+	mockCallerMetrics(prom, 0)
+	return layer, k8s, prom
+}
+
+// mockCallerMetrics returns 10 requests per second seen by the callers of reviews, with a percentage of aborts
+func mockCallerMetrics(prom *prometheustest.PromClientMock, errorRate float64) {
+	sample := func(reporter, code string, value float64) *model.Sample {
+		return &model.Sample{
+			Metric: model.Metric{"reporter": model.LabelValue(reporter), "response_code": model.LabelValue(code)},
+			Value:  model.SampleValue(value),
+		}
+	}
+	prom.ExpectedCalls = nil
+	prom.On("GetServiceRequestRates", "bookinfo", "reviews", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(model.Vector{
+		sample("source", "200", 10-errorRate/10),
+		sample("source", "503", errorRate/10),
+		// Aborted requests never reach the destination
+		sample("destination", "200", 10-errorRate/10),
+	}, nil)
+	prom.On("FetchHistogramValues", "istio_request_duration_milliseconds", mock.AnythingOfType("string"), "", mock.AnythingOfType("string"), false, []string{"0.99"}, mock.AnythingOfType("time.Time")).
+		Return(map[string]model.Vector{"0.99": {&model.Sample{Value: model.SampleValue(120)}}}, nil)
+}
+
+func faultExperimentRequest() models.FaultExperimentRequest {
+	return models.FaultExperimentRequest{
+		Fault:              models.TrafficWizardFault{Abort: &models.TrafficWizardAbort{Percentage: 10, HttpStatus: 503}},
+		Duration:           "5m",
+		MaxCallerErrorRate: 20,
+	}
+}
+
+func TestFaultExperimentAbortsOnCallerErrors(t *testing.T) {
+	assert := assert.New(t)
+	layer, k8s, prom := fakeFaultExperimentLayer(t, reviewsPods)
+
+	experiment, err := layer.IstioConfig.StartFaultExperiment("bookinfo", "reviews", faultExperimentRequest(), "alice")
+	assert.NoError(err)
+	assert.Equal("reviews-1622505600", experiment.ID)
+	assert.Equal(models.FaultExperimentRunning, experiment.Status)
+	assert.Equal(faultExperimentTime.Add(5*time.Minute), experiment.ExpiresAt)
+	assert.Equal(10.0, experiment.Before.RequestRate)
+	assert.Equal(0.0, experiment.Before.ErrorRate)
+
+	vs, err := k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.NoError(err)
+	assert.Equal(models.WizardFaultInjection, vs.GetObjectMeta().Labels[models.WizardLabel])
+	assert.Equal(experiment.ID, vs.GetObjectMeta().Annotations[models.FaultExperimentAnnotation])
+	assert.Contains(vs.GetSpec()["http"].([]interface{})[0], "fault")
+
+	_, err = layer.IstioConfig.StartFaultExperiment("bookinfo", "reviews", faultExperimentRequest(), "alice")
+	assert.True(errors2.IsConflict(err))
+
+	// Records are shared by the Kiali replicas
+	experiments, err := newFaultExperimentStore(func() (kubernetes.ClientInterface, error) { return k8s, nil }).list("bookinfo", "reviews")
+	assert.NoError(err)
+	assert.Equal([]models.FaultExperiment{experiment}, experiments)
+
+	// Errors below the threshold don't end the experiment
+	watcher := newFaultExperimentWatcher(func() (*Layer, error) { return layer, nil }, 15*time.Second)
+	util.Clock = util.ClockMock{Time: faultExperimentTime.Add(time.Minute)}
+	mockCallerMetrics(prom, 10)
+	watcher.Check()
+	experiment, _ = layer.IstioConfig.GetFaultExperiment("bookinfo", "reviews", experiment.ID)
+	assert.Equal(models.FaultExperimentRunning, experiment.Status)
+
+	util.Clock = util.ClockMock{Time: faultExperimentTime.Add(2 * time.Minute)}
+	mockCallerMetrics(prom, 30)
+	watcher.Check()
+	experiment, _ = layer.IstioConfig.GetFaultExperiment("bookinfo", "reviews", experiment.ID)
+	assert.Equal(models.FaultExperimentAborted, experiment.Status)
+	assert.Equal("Caller error rate 30.00% crossed the 20.00% threshold", experiment.Reason)
+	assert.Equal(30.0, experiment.During.ErrorRate)
+	assert.Equal(120.0, *experiment.During.P99)
+
+	_, err = k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.True(errors2.IsNotFound(err))
+}
+
+func TestFaultExperimentRevertsExistingVirtualService(t *testing.T) {
+	assert := assert.New(t)
+	layer, k8s, _ := fakeFaultExperimentLayer(t, `apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: reviews
+spec:
+  hosts:
+  - reviews
+  http:
+  - route:
+    - destination:
+        host: reviews
+---
+`+reviewsPods)
+
+	experiment, err := layer.IstioConfig.StartFaultExperiment("bookinfo", "reviews", faultExperimentRequest(), "alice")
+	assert.NoError(err)
+	vs, _ := k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	route := vs.GetSpec()["http"].([]interface{})[0].(map[string]interface{})
+	assert.Contains(route, "fault")
+	assert.Contains(route, "route")
+
+	watcher := newFaultExperimentWatcher(func() (*Layer, error) { return layer, nil }, 15*time.Second)
+	util.Clock = util.ClockMock{Time: experiment.ExpiresAt}
+	watcher.Check()
+	experiment, _ = layer.IstioConfig.GetFaultExperiment("bookinfo", "reviews", experiment.ID)
+	assert.Equal(models.FaultExperimentCompleted, experiment.Status)
+	assert.Nil(experiment.After)
+
+	vs, err = k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.NoError(err)
+	assert.NotContains(vs.GetSpec()["http"].([]interface{})[0], "fault")
+	assert.NotContains(vs.GetObjectMeta().Annotations, models.FaultExperimentAnnotation)
+
+	// Traffic after the experiment is fetched once it was observed for as long as the experiment ran
+	util.Clock = util.ClockMock{Time: experiment.ExpiresAt.Add(5 * time.Minute)}
+	watcher.Check()
+	experiment, _ = layer.IstioConfig.GetFaultExperiment("bookinfo", "reviews", experiment.ID)
+	assert.Equal(experiment.ExpiresAt, experiment.After.From)
+	assert.Equal(10.0, experiment.After.RequestRate)
+
+	_, err = layer.IstioConfig.StopFaultExperiment("bookinfo", "reviews", experiment.ID, "alice")
+	assert.True(errors2.IsBadRequest(err))
+}
+
+func TestFaultExperimentRetriesFailedRemoval(t *testing.T) {
+	assert := assert.New(t)
+	layer, k8s, _ := fakeFaultExperimentLayer(t, reviewsPods)
+
+	experiment, err := layer.IstioConfig.StartFaultExperiment("bookinfo", "reviews", faultExperimentRequest(), "alice")
+	assert.NoError(err)
+
+	// The faults could not be removed when the experiment ended
+	end := faultExperimentTime.Add(time.Minute)
+	experiment.Status = models.FaultExperimentFailed
+	experiment.Reason = "Stopped by alice, but the faults could not be removed: timeout"
+	experiment.EndTime = &end
+	assert.NoError(faultExperiments.update(experiment))
+
+	watcher := newFaultExperimentWatcher(func() (*Layer, error) { return layer, nil }, 15*time.Second)
+	util.Clock = util.ClockMock{Time: end.Add(15 * time.Second)}
+	watcher.Check()
+
+	_, err = k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.True(errors2.IsNotFound(err))
+	experiment, _ = layer.IstioConfig.GetFaultExperiment("bookinfo", "reviews", experiment.ID)
+	assert.Equal(models.FaultExperimentFailed, experiment.Status)
+	assert.Equal("Stopped by alice, but the faults could not be removed: timeout. They were removed at 2021-06-01T00:01:15Z", experiment.Reason)
+}
+
+func TestFaultExperimentIgnoresUnknownExperiments(t *testing.T) {
+	assert := assert.New(t)
+	layer, k8s, _ := fakeFaultExperimentLayer(t, `apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: reviews
+  annotations:
+    kiali.io/fault-experiment: reviews-1622505000
+    kiali.io/fault-experiment-expires: "2021-06-01T00:00:00Z"
+spec:
+  hosts:
+  - reviews
+  http:
+  - fault:
+      abort:
+        httpStatus: 503
+        percentage:
+          value: 10
+    route:
+    - destination:
+        host: reviews
+---
+`+reviewsPods)
+
+	// An ended experiment of the service, with another ID
+	end := faultExperimentTime.Add(-time.Minute)
+	assert.NoError(faultExperiments.add(models.FaultExperiment{
+		ID:        "reviews-1622505540",
+		Namespace: "bookinfo",
+		Service:   "reviews",
+		Status:    models.FaultExperimentCompleted,
+		StartTime: end.Add(-time.Minute),
+		EndTime:   &end,
+	}))
+
+	watcher := newFaultExperimentWatcher(func() (*Layer, error) { return layer, nil }, 15*time.Second)
+	util.Clock = util.ClockMock{Time: faultExperimentTime.Add(time.Hour)}
+	watcher.Check()
+
+	vs, err := k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.NoError(err)
+	assert.Contains(vs.GetSpec()["http"].([]interface{})[0], "fault")
+	assert.Equal("reviews-1622505000", vs.GetObjectMeta().Annotations[models.FaultExperimentAnnotation])
+}
+
+func TestFaultExperimentsDisabled(t *testing.T) {
+	assert := assert.New(t)
+	layer, k8s, _ := fakeFaultExperimentLayer(t, reviewsPods)
+	config.Set(config.NewConfig())
+
+	_, err := layer.IstioConfig.StartFaultExperiment("bookinfo", "reviews", faultExperimentRequest(), "alice")
+	assert.True(errors2.IsServiceUnavailable(err))
+	_, err = k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.True(errors2.IsNotFound(err))
+}
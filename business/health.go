@@ -29,7 +29,9 @@ func (in *HealthService) GetServiceHealth(namespace, service, rateInterval strin
 	defer promtimer.ObserveNow(&err)
 
 	rqHealth, err := in.getServiceRequestsHealth(namespace, service, rateInterval, queryTime)
-	return models.ServiceHealth{Requests: rqHealth}, err
+	health := models.ServiceHealth{Requests: rqHealth}
+	evaluateServiceHealth(namespace, service, &health)
+	return health, err
 }
 
 // GetAppHealth returns an app health from just Namespace and app name (thus, it fetches data from K8S and Prometheus)
@@ -75,6 +77,7 @@ func (in *HealthService) getAppHealth(namespace, app, rateInterval string, query
 
 	// Deployment status
 	health.WorkloadStatuses = ws.CastWorkloadStatuses()
+	evaluateAppHealth(namespace, app, &health)
 
 	return health, errRate
 }
@@ -94,10 +97,12 @@ func (in *HealthService) GetWorkloadHealth(namespace, workload, workloadType, ra
 
 	// Perf: do not bother fetching request rate if workload has no sidecar
 	if !w.IstioSidecar {
-		return models.WorkloadHealth{
+		health := models.WorkloadHealth{
 			WorkloadStatus: status,
 			Requests:       models.NewEmptyRequestHealth(),
-		}, nil
+		}
+		evaluateWorkloadHealth(namespace, workload, &health)
+		return health, nil
 	}
 
 	// Add Proxy Status info
@@ -113,10 +118,12 @@ func (in *HealthService) GetWorkloadHealth(namespace, workload, workloadType, ra
 
 	// Add Telemetry info
 	rate, err := in.getWorkloadRequestsHealth(namespace, workload, rateInterval, queryTime)
-	return models.WorkloadHealth{
+	health := models.WorkloadHealth{
 		WorkloadStatus: status,
 		Requests:       rate,
-	}, err
+	}
+	evaluateWorkloadHealth(namespace, workload, &health)
+	return health, err
 }
 
 // GetNamespaceAppHealth returns a health for all apps in given Namespace (thus, it fetches data from K8S and Prometheus)
@@ -164,6 +171,9 @@ func (in *HealthService) getNamespaceAppHealth(namespace string, appEntities nam
 		// Fill with collected request rates
 		fillAppRequestRates(allHealth, rates)
 	}
+	for app, health := range allHealth {
+		evaluateAppHealth(namespace, app, health)
+	}
 
 	return allHealth, errRate
 }
@@ -205,12 +215,9 @@ func (in *HealthService) getNamespaceServiceHealth(namespace string, services []
 	// Fetch services requests rates
 	rates, _ := in.prom.GetNamespaceServicesRequestRates(namespace, rateInterval, queryTime)
 	// Fill with collected request rates
-	lblDestSvc := model.LabelName("destination_service_name")
-	for _, sample := range rates {
-		service := string(sample.Metric[lblDestSvc])
-		if health, ok := allHealth[service]; ok {
-			health.Requests.AggregateInbound(sample)
-		}
+	fillServiceRequestRates(allHealth, rates)
+	for service, health := range allHealth {
+		evaluateServiceHealth(namespace, service, health)
 	}
 
 	return allHealth
@@ -251,10 +258,24 @@ func (in *HealthService) getNamespaceWorkloadHealth(namespace string, ws models.
 		// Fill with collected request rates
 		fillWorkloadRequestRates(allHealth, rates)
 	}
+	for workload, health := range allHealth {
+		evaluateWorkloadHealth(namespace, workload, health)
+	}
 
 	return allHealth, err
 }
 
+// fillServiceRequestRates aggregates requests rates from metrics fetched from Prometheus, and stores the result in the health map.
+func fillServiceRequestRates(allHealth models.NamespaceServiceHealth, rates model.Vector) {
+	lblDestSvc := model.LabelName("destination_service_name")
+	for _, sample := range rates {
+		service := string(sample.Metric[lblDestSvc])
+		if health, ok := allHealth[service]; ok {
+			health.Requests.AggregateInbound(sample)
+		}
+	}
+}
+
 // fillAppRequestRates aggregates requests rates from metrics fetched from Prometheus, and stores the result in the health map.
 func fillAppRequestRates(allHealth models.NamespaceAppHealth, rates model.Vector) {
 	lblDest := model.LabelName("destination_canonical_service")
@@ -0,0 +1,267 @@
+package business
+
+import (
+	"fmt"
+	"time"
+
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	core_v1 "k8s.io/api/core/v1"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// Limit of the buckets of a health history, far below the points allowed by Prometheus for a range query
+const maxHealthHistoryBuckets = 1000
+
+// GetHealthHistory returns the health status of an app, service or workload, or of the namespace when the name is
+// empty, for every step of the range. The status of a step is evaluated from the error rates since the previous step.
+func (in *HealthService) GetHealthHistory(namespace, healthType, name string, bounds prom_v1.Range) (models.HealthHistory, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "HealthService", "GetHealthHistory")
+	defer promtimer.ObserveNow(&err)
+
+	// Buckets are aligned on seconds, like the timestamps returned by Prometheus
+	start, end, step := bounds.Start.Truncate(time.Second), bounds.End.Truncate(time.Second), bounds.Step.Truncate(time.Second)
+	history := models.HealthHistory{
+		Namespace: namespace,
+		Type:      healthType,
+		Name:      name,
+		Start:     start,
+		End:       end,
+		Step:      int(step.Seconds()),
+		Buckets:   []models.HealthBucket{},
+		Periods:   []models.HealthPeriod{},
+	}
+	if healthType != "app" && healthType != "service" && healthType != "workload" {
+		err = errors2.NewBadRequest(fmt.Sprintf("Health type %s is not one of app, service or workload", healthType))
+		return history, err
+	}
+	if step <= 0 || !start.Before(end) {
+		err = errors2.NewBadRequest("Health history requires a positive step and a start before the end")
+		return history, err
+	}
+	if end.Sub(start)/step > maxHealthHistoryBuckets {
+		err = errors2.NewBadRequest(fmt.Sprintf("Health history is limited to %d buckets, use a larger step", maxHealthHistoryBuckets))
+		return history, err
+	}
+
+	// The first bucket ends one step after the start
+	rangeBounds := prom_v1.Range{Start: start.Add(step), End: end, Step: step}
+	rateInterval := fmt.Sprintf("%ds", history.Step)
+	var statuses func(t model.Time) models.HealthStatus
+	if name == "" {
+		statuses, err = in.getNamespaceHealthHistory(namespace, healthType, rateInterval, rangeBounds)
+	} else {
+		statuses, err = in.getEntityHealthHistory(namespace, healthType, name, rateInterval, rangeBounds)
+	}
+	if err != nil {
+		return history, err
+	}
+
+	for t := rangeBounds.Start; !t.After(end); t = t.Add(step) {
+		history.Buckets = append(history.Buckets, models.HealthBucket{
+			HealthStatus: statuses(model.TimeFromUnixNano(t.UnixNano())),
+			Time:         t,
+		})
+	}
+	history.Periods = healthPeriods(history.Buckets, step)
+	return history, nil
+}
+
+// bucketVectors are the samples of range queries at every step, i.e. [query][time]
+type bucketVectors []map[model.Time]model.Vector
+
+func newBucketVectors(matrices ...model.Matrix) bucketVectors {
+	vectors := make(bucketVectors, 0, len(matrices))
+	for _, matrix := range matrices {
+		byTime := map[model.Time]model.Vector{}
+		for _, stream := range matrix {
+			for _, value := range stream.Values {
+				byTime[value.Timestamp] = append(byTime[value.Timestamp], &model.Sample{Metric: stream.Metric, Value: value.Value, Timestamp: value.Timestamp})
+			}
+		}
+		vectors = append(vectors, byTime)
+	}
+	return vectors
+}
+
+// getEntityHealthHistory fetches the request rates of an entity for the range, and returns how to evaluate its status
+// at a step
+func (in *HealthService) getEntityHealthHistory(namespace, healthType, name, rateInterval string, bounds prom_v1.Range) (func(model.Time) models.HealthStatus, error) {
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return nil, err
+	}
+
+	var vectors bucketVectors
+	switch healthType {
+	case "service":
+		inbound, err := in.prom.GetServiceRequestRatesRange(namespace, name, rateInterval, bounds)
+		if err != nil {
+			return nil, err
+		}
+		vectors = newBucketVectors(inbound)
+	case "app":
+		inbound, outbound, err := in.prom.GetAppRequestRatesRange(namespace, name, rateInterval, bounds)
+		if err != nil {
+			return nil, err
+		}
+		vectors = newBucketVectors(inbound, outbound)
+	default:
+		inbound, outbound, err := in.prom.GetWorkloadRequestRatesRange(namespace, name, rateInterval, bounds)
+		if err != nil {
+			return nil, err
+		}
+		vectors = newBucketVectors(inbound, outbound)
+	}
+
+	return func(t model.Time) models.HealthStatus {
+		eval := newHealthEvaluation()
+		requests := models.NewEmptyRequestHealth()
+		for _, sample := range vectors[0][t] {
+			requests.AggregateInbound(sample)
+		}
+		if len(vectors) > 1 {
+			for _, sample := range vectors[1][t] {
+				requests.AggregateOutbound(sample)
+			}
+		}
+		evaluateRequestHealth(eval, namespace, healthType, name, requests)
+		return eval.result()
+	}, nil
+}
+
+// getNamespaceHealthHistory fetches the request rates of the apps, services or workloads of a namespace for the range,
+// and returns how to evaluate the status of the namespace at a step
+func (in *HealthService) getNamespaceHealthHistory(namespace, healthType, rateInterval string, bounds prom_v1.Range) (func(model.Time) models.HealthStatus, error) {
+	names := []string{}
+	var rates model.Matrix
+	var err error
+	switch healthType {
+	case "app":
+		var apps namespaceApps
+		if apps, err = fetchNamespaceApps(in.businessLayer, namespace, ""); err != nil {
+			return nil, err
+		}
+		for app := range apps {
+			if app != "" {
+				names = append(names, app)
+			}
+		}
+		rates, err = in.prom.GetAllRequestRatesRange(namespace, rateInterval, bounds)
+	case "service":
+		// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+		// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+		if _, err = in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+			return nil, err
+		}
+		var services []core_v1.Service
+		if IsNamespaceCached(namespace) {
+			services, err = kialiCache.GetServices(namespace, nil)
+		} else {
+			services, err = in.k8s.GetServices(namespace, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range services {
+			names = append(names, service.Name)
+		}
+		rates, err = in.prom.GetNamespaceServicesRequestRatesRange(namespace, rateInterval, bounds)
+	default:
+		var ws models.Workloads
+		if ws, err = fetchWorkloads(in.businessLayer, namespace, ""); err != nil {
+			return nil, err
+		}
+		for _, w := range ws {
+			names = append(names, w.Name)
+		}
+		rates, err = in.prom.GetAllRequestRatesRange(namespace, rateInterval, bounds)
+	}
+	if err != nil {
+		return nil, err
+	}
+	vectors := newBucketVectors(rates)
+
+	return func(t model.Time) models.HealthStatus {
+		statuses := make(map[string]models.HealthStatus, len(names))
+		switch healthType {
+		case "app":
+			allHealth := make(models.NamespaceAppHealth, len(names))
+			for _, name := range names {
+				h := models.EmptyAppHealth()
+				allHealth[name] = &h
+			}
+			fillAppRequestRates(allHealth, vectors[0][t])
+			for name, h := range allHealth {
+				evaluateAppHealth(namespace, name, h)
+				statuses[name] = h.Status
+			}
+		case "service":
+			allHealth := make(models.NamespaceServiceHealth, len(names))
+			for _, name := range names {
+				h := models.EmptyServiceHealth()
+				allHealth[name] = &h
+			}
+			fillServiceRequestRates(allHealth, vectors[0][t])
+			for name, h := range allHealth {
+				evaluateServiceHealth(namespace, name, h)
+				statuses[name] = h.Status
+			}
+		default:
+			allHealth := make(models.NamespaceWorkloadHealth, len(names))
+			for _, name := range names {
+				allHealth[name] = models.EmptyWorkloadHealth()
+			}
+			fillWorkloadRequestRates(allHealth, vectors[0][t])
+			for name, h := range allHealth {
+				evaluateWorkloadHealth(namespace, name, h)
+				statuses[name] = h.Status
+			}
+		}
+		return namespaceHealthStatus(healthType, statuses).HealthStatus
+	}, nil
+}
+
+// healthPeriods groups the consecutive buckets with the same status
+func healthPeriods(buckets []models.HealthBucket, step time.Duration) []models.HealthPeriod {
+	periods := []models.HealthPeriod{}
+	seen := map[string]bool{}
+	for _, bucket := range buckets {
+		last := len(periods) - 1
+		if last < 0 || periods[last].Status != bucket.Status {
+			periods = append(periods, models.HealthPeriod{Status: bucket.Status, From: bucket.Time.Add(-step), Reasons: []string{}})
+			last++
+			seen = map[string]bool{}
+		}
+		periods[last].To = bucket.Time
+		for _, reason := range bucket.Reasons {
+			if !seen[reason] {
+				seen[reason] = true
+				periods[last].Reasons = append(periods[last].Reasons, reason)
+			}
+		}
+	}
+	for i := range periods {
+		periods[i].Description = fmt.Sprintf("%s from %s to %s", periods[i].Status, formatHealthTime(periods[i].From, periods[i].To), formatHealthTime(periods[i].To, periods[i].From))
+	}
+	return periods
+}
+
+// formatHealthTime formats a time of a period in UTC, with the date only when the period spans several days and the
+// seconds only when the buckets are not aligned on minutes
+func formatHealthTime(t, other time.Time) string {
+	t, other = t.UTC(), other.UTC()
+	layout := "15:04"
+	if t.Second() != 0 {
+		layout = "15:04:05"
+	}
+	if t.YearDay() != other.YearDay() || t.Year() != other.Year() {
+		layout = "2006-01-02 " + layout
+	}
+	return t.Format(layout)
+}
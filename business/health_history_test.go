@@ -0,0 +1,90 @@
+package business
+
+import (
+	"testing"
+	"time"
+
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+var healthHistoryStart = time.Date(2021, 06, 01, 10, 0, 0, 0, time.UTC)
+
+// fakeRatesStream returns the rates of a response code of reviews for every minute after the start
+func fakeRatesStream(code string, rates ...float64) *model.SampleStream {
+	stream := &model.SampleStream{
+		Metric: model.Metric{"destination_service_name": "reviews", "request_protocol": "http", "response_code": model.LabelValue(code)},
+	}
+	for i, rate := range rates {
+		t := healthHistoryStart.Add(time.Duration(i+1) * time.Minute)
+		stream.Values = append(stream.Values, model.SamplePair{Timestamp: model.TimeFromUnixNano(t.UnixNano()), Value: model.SampleValue(rate)})
+	}
+	return stream
+}
+
+func TestGetServiceHealthHistory(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, "")
+	prom := new(prometheustest.PromClientMock)
+	layer.Health.prom = prom
+
+	bounds := prom_v1.Range{Start: healthHistoryStart.Add(time.Minute), End: healthHistoryStart.Add(6 * time.Minute), Step: time.Minute}
+	prom.On("GetServiceRequestRatesRange", "bookinfo", "reviews", "60s", bounds).Return(model.Matrix{
+		fakeRatesStream("200", 10, 10, 19, 5, 10),
+		fakeRatesStream("503", 0, 0, 1, 5, 0),
+	}, nil)
+
+	history, err := layer.Health.GetHealthHistory("bookinfo", "service", "reviews", prom_v1.Range{Start: healthHistoryStart, End: healthHistoryStart.Add(6 * time.Minute), Step: time.Minute})
+	assert.NoError(err)
+	assert.Equal(60, history.Step)
+	assert.Len(history.Buckets, 6)
+	assert.Equal(healthHistoryStart.Add(time.Minute), history.Buckets[0].Time)
+	assert.Equal(models.HealthHealthy, history.Buckets[0].Status)
+	assert.Equal(models.HealthDegraded, history.Buckets[2].Status)
+	assert.Equal(models.HealthFailure, history.Buckets[3].Status)
+	// No traffic in the last bucket
+	assert.Equal(models.HealthNA, history.Buckets[5].Status)
+
+	assert.Equal([]string{
+		"Healthy from 10:00 to 10:02",
+		"Degraded from 10:02 to 10:03",
+		"Failure from 10:03 to 10:04",
+		"Healthy from 10:04 to 10:05",
+		"NA from 10:05 to 10:06",
+	}, healthPeriodDescriptions(history.Periods))
+	assert.Equal([]string{"inbound http error rate of 50.00% for codes ^5\\d\\d$ reaches the Failure tolerance of 10%"}, history.Periods[2].Reasons)
+}
+
+func TestGetNamespaceHealthHistory(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, "")
+	prom := new(prometheustest.PromClientMock)
+	layer.Health.prom = prom
+
+	bounds := prom_v1.Range{Start: healthHistoryStart.Add(time.Minute), End: healthHistoryStart.Add(3 * time.Minute), Step: time.Minute}
+	prom.On("GetNamespaceServicesRequestRatesRange", "bookinfo", "60s", bounds).Return(model.Matrix{
+		fakeRatesStream("200", 10, 19, 10),
+		fakeRatesStream("503", 0, 1, 0),
+	}, nil)
+
+	history, err := layer.Health.GetHealthHistory("bookinfo", "service", "", prom_v1.Range{Start: healthHistoryStart, End: healthHistoryStart.Add(3 * time.Minute), Step: time.Minute})
+	assert.NoError(err)
+	assert.Equal([]string{"Healthy from 10:00 to 10:01", "Degraded from 10:01 to 10:02", "Healthy from 10:02 to 10:03"}, healthPeriodDescriptions(history.Periods))
+	assert.Equal([]string{"service reviews is Degraded"}, history.Periods[1].Reasons)
+
+	_, err = layer.Health.GetHealthHistory("bookinfo", "service", "", prom_v1.Range{Start: healthHistoryStart, End: healthHistoryStart.Add(24 * time.Hour), Step: time.Minute})
+	assert.True(errors2.IsBadRequest(err))
+}
+
+func healthPeriodDescriptions(periods []models.HealthPeriod) []string {
+	descriptions := []string{}
+	for _, p := range periods {
+		descriptions = append(descriptions, p.Description)
+	}
+	return descriptions
+}
@@ -0,0 +1,232 @@
+package business
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+var healthStatusPriorities = map[string]int{
+	models.HealthNA:       0,
+	models.HealthHealthy:  1,
+	models.HealthNotReady: 2,
+	models.HealthDegraded: 3,
+	models.HealthFailure:  4,
+}
+
+// worseHealthStatus returns the worst of two health statuses
+func worseHealthStatus(status1, status2 string) string {
+	if healthStatusPriorities[status2] > healthStatusPriorities[status1] {
+		return status2
+	}
+	return status1
+}
+
+type healthReason struct {
+	status string
+	reason string
+}
+
+// healthEvaluation collects the statuses of the parts of an entity, its status is the worst of them
+type healthEvaluation struct {
+	status  string
+	reasons []healthReason
+}
+
+func newHealthEvaluation() *healthEvaluation {
+	return &healthEvaluation{status: models.HealthNA}
+}
+
+// add records the status of a part of the entity. Reasons are only kept when it is not healthy.
+func (in *healthEvaluation) add(status, reason string) {
+	in.status = worseHealthStatus(in.status, status)
+	if status != models.HealthHealthy && reason != "" {
+		in.reasons = append(in.reasons, healthReason{status: status, reason: reason})
+	}
+}
+
+func (in *healthEvaluation) result() models.HealthStatus {
+	sort.SliceStable(in.reasons, func(i, j int) bool {
+		return healthStatusPriorities[in.reasons[i].status] > healthStatusPriorities[in.reasons[j].status]
+	})
+	reasons := make([]string, 0, len(in.reasons))
+	for _, r := range in.reasons {
+		reasons = append(reasons, r.reason)
+	}
+	return models.HealthStatus{Status: in.status, Reasons: reasons}
+}
+
+// matchHealthExpr returns true when the value matches the regular expression of the health config. Like in the
+// UI, expressions are not anchored.
+func matchHealthExpr(expr, value string) bool {
+	matched, err := regexp.MatchString(expr, value)
+	return err == nil && matched
+}
+
+// getHealthTolerances returns the error rate tolerances of the first rate of the health config matching the entity
+func getHealthTolerances(namespace, kind, name string) []config.Tolerance {
+	for _, rate := range config.Get().HealthConfig.Rate {
+		if matchHealthExpr(rate.Namespace, namespace) && matchHealthExpr(rate.Kind, kind) && matchHealthExpr(rate.Name, name) {
+			return rate.Tolerance
+		}
+	}
+	return nil
+}
+
+// evaluateRequestHealth evaluates the error rates of every protocol and direction against the tolerances of the entity
+func evaluateRequestHealth(eval *healthEvaluation, namespace, kind, name string, requests models.RequestHealth) {
+	tolerances := getHealthTolerances(namespace, kind, name)
+	for _, direction := range []string{"inbound", "outbound"} {
+		rates := requests.Inbound
+		if direction == "outbound" {
+			rates = requests.Outbound
+		}
+		protocols := make([]string, 0, len(rates))
+		for protocol := range rates {
+			protocols = append(protocols, protocol)
+		}
+		sort.Strings(protocols)
+		for _, protocol := range protocols {
+			codes := rates[protocol]
+			total := 0.0
+			for _, rate := range codes {
+				total += rate
+			}
+			if total == 0 {
+				continue
+			}
+			eval.add(models.HealthHealthy, "")
+			for _, tolerance := range tolerances {
+				if !matchHealthExpr(tolerance.Protocol, protocol) || !matchHealthExpr(tolerance.Direction, direction) {
+					continue
+				}
+				errors := 0.0
+				for code, rate := range codes {
+					if matchHealthExpr(tolerance.Code, code) {
+						errors += rate
+					}
+				}
+				if errors == 0 {
+					continue
+				}
+				ratio := 100 * errors / total
+				status, threshold := models.HealthHealthy, float32(0)
+				if ratio >= float64(tolerance.Failure) {
+					status, threshold = models.HealthFailure, tolerance.Failure
+				} else if ratio >= float64(tolerance.Degraded) {
+					status, threshold = models.HealthDegraded, tolerance.Degraded
+				}
+				eval.add(status, fmt.Sprintf("%s %s error rate of %.2f%% for codes %s reaches the %s tolerance of %g%%",
+					direction, protocol, ratio, tolerance.Code, status, threshold))
+			}
+		}
+	}
+}
+
+// evaluateWorkloadStatus evaluates the available replicas and synced proxies of a workload
+func evaluateWorkloadStatus(eval *healthEvaluation, status *models.WorkloadStatus) {
+	if status == nil {
+		return
+	}
+	switch {
+	case status.DesiredReplicas == 0:
+		eval.add(models.HealthNotReady, fmt.Sprintf("%s: scaled to 0 replicas", status.Name))
+		return
+	case status.AvailableReplicas == 0:
+		eval.add(models.HealthFailure, fmt.Sprintf("%s: no replica available out of %d", status.Name, status.DesiredReplicas))
+		return
+	case status.AvailableReplicas < status.DesiredReplicas:
+		eval.add(models.HealthDegraded, fmt.Sprintf("%s: %d of %d replicas available", status.Name, status.AvailableReplicas, status.DesiredReplicas))
+	default:
+		eval.add(models.HealthHealthy, "")
+	}
+	// Proxies are not counted when the workload has no sidecar
+	if status.SyncedProxies >= 0 && status.SyncedProxies < status.AvailableReplicas {
+		eval.add(models.HealthDegraded, fmt.Sprintf("%s: %d of %d proxies synced", status.Name, status.SyncedProxies, status.AvailableReplicas))
+	}
+}
+
+func evaluateServiceHealth(namespace, service string, health *models.ServiceHealth) {
+	eval := newHealthEvaluation()
+	evaluateRequestHealth(eval, namespace, "service", service, health.Requests)
+	health.Status = eval.result()
+}
+
+func evaluateAppHealth(namespace, app string, health *models.AppHealth) {
+	eval := newHealthEvaluation()
+	for _, status := range health.WorkloadStatuses {
+		evaluateWorkloadStatus(eval, status)
+	}
+	evaluateRequestHealth(eval, namespace, "app", app, health.Requests)
+	health.Status = eval.result()
+}
+
+func evaluateWorkloadHealth(namespace, workload string, health *models.WorkloadHealth) {
+	eval := newHealthEvaluation()
+	evaluateWorkloadStatus(eval, health.WorkloadStatus)
+	evaluateRequestHealth(eval, namespace, "workload", workload, health.Requests)
+	health.Status = eval.result()
+}
+
+// GetNamespaceHealthStatus returns the status of a namespace, the worst of the statuses of its apps, services or
+// workloads depending on the health type, with the entities which are not healthy as reasons
+func (in *HealthService) GetNamespaceHealthStatus(namespace, healthType, rateInterval string, queryTime time.Time) (models.NamespaceHealthStatus, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "HealthService", "GetNamespaceHealthStatus")
+	defer promtimer.ObserveNow(&err)
+
+	statuses := map[string]models.HealthStatus{}
+	switch healthType {
+	case "app":
+		var health models.NamespaceAppHealth
+		health, err = in.GetNamespaceAppHealth(namespace, rateInterval, queryTime)
+		for name, h := range health {
+			statuses[name] = h.Status
+		}
+	case "service":
+		var health models.NamespaceServiceHealth
+		health, err = in.GetNamespaceServiceHealth(namespace, rateInterval, queryTime)
+		for name, h := range health {
+			statuses[name] = h.Status
+		}
+	case "workload":
+		var health models.NamespaceWorkloadHealth
+		health, err = in.GetNamespaceWorkloadHealth(namespace, rateInterval, queryTime)
+		for name, h := range health {
+			statuses[name] = h.Status
+		}
+	default:
+		err = fmt.Errorf("health type %s is not one of app, service or workload", healthType)
+	}
+	if err != nil {
+		return models.NamespaceHealthStatus{}, err
+	}
+	return namespaceHealthStatus(healthType, statuses), nil
+}
+
+func namespaceHealthStatus(healthType string, statuses map[string]models.HealthStatus) models.NamespaceHealthStatus {
+	eval := newHealthEvaluation()
+	entities := map[string]int{}
+	// Sorted for stable reasons
+	names := make([]string, 0, len(statuses))
+	for name := range statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		status := statuses[name].Status
+		entities[status]++
+		// Entities without anything to evaluate don't make the namespace unhealthy
+		reason := ""
+		if status != models.HealthNA {
+			reason = fmt.Sprintf("%s %s is %s", healthType, name, status)
+		}
+		eval.add(status, reason)
+	}
+	return models.NamespaceHealthStatus{HealthStatus: eval.result(), Entities: entities}
+}
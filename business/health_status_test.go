@@ -0,0 +1,90 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+func TestEvaluateRequestHealth(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	health := models.EmptyServiceHealth()
+	evaluateServiceHealth("bookinfo", "reviews", &health)
+	assert.Equal(models.HealthStatus{Status: models.HealthNA, Reasons: []string{}}, health.Status)
+
+	// Any 5xx degrades, 4xx are tolerated up to 10%, grpc code 0 is OK
+	health.Requests.Inbound = map[string]map[string]float64{
+		"http": {"200": 9.5, "404": 0.5},
+		"grpc": {"0": 10},
+	}
+	evaluateServiceHealth("bookinfo", "reviews", &health)
+	assert.Equal(models.HealthStatus{Status: models.HealthHealthy, Reasons: []string{}}, health.Status)
+
+	health.Requests.Inbound["http"]["503"] = 0.5
+	health.Requests.Outbound = map[string]map[string]float64{"grpc": {"0": 8, "14": 2}}
+	evaluateServiceHealth("bookinfo", "reviews", &health)
+	assert.Equal(models.HealthFailure, health.Status.Status)
+	assert.Equal([]string{
+		"outbound grpc error rate of 20.00% for codes ^[1-9]$|^1[0-6]$ reaches the Failure tolerance of 10%",
+		"inbound http error rate of 4.76% for codes ^5\\d\\d$ reaches the Degraded tolerance of 0%",
+	}, health.Status.Reasons)
+
+	// The first matching rate of the config is used
+	conf := config.NewConfig()
+	conf.HealthConfig.Rate = append([]config.Rate{{
+		Namespace: "bookinfo",
+		Kind:      "service",
+		Name:      "reviews",
+		Tolerance: []config.Tolerance{{Code: "^5\\d\\d$", Protocol: "http", Direction: "inbound", Degraded: 5, Failure: 20}},
+	}}, conf.HealthConfig.Rate...)
+	config.Set(conf)
+	evaluateServiceHealth("bookinfo", "reviews", &health)
+	assert.Equal(models.HealthStatus{Status: models.HealthHealthy, Reasons: []string{}}, health.Status)
+}
+
+func TestEvaluateWorkloadStatus(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	health := models.EmptyAppHealth()
+	health.WorkloadStatuses = []*models.WorkloadStatus{
+		{Name: "reviews-v1", DesiredReplicas: 1, CurrentReplicas: 1, AvailableReplicas: 1, SyncedProxies: 1},
+		{Name: "reviews-v2", DesiredReplicas: 2, CurrentReplicas: 2, AvailableReplicas: 1, SyncedProxies: 0},
+		{Name: "reviews-v3", DesiredReplicas: 0, CurrentReplicas: 0, AvailableReplicas: 0, SyncedProxies: -1},
+	}
+	evaluateAppHealth("bookinfo", "reviews", &health)
+	assert.Equal(models.HealthStatus{
+		Status:  models.HealthDegraded,
+		Reasons: []string{"reviews-v2: 1 of 2 replicas available", "reviews-v2: 0 of 1 proxies synced", "reviews-v3: scaled to 0 replicas"},
+	}, health.Status)
+
+	// Proxies are not counted without sidecar
+	workload := models.WorkloadHealth{
+		WorkloadStatus: &models.WorkloadStatus{Name: "details-v1", DesiredReplicas: 1, CurrentReplicas: 1, AvailableReplicas: 0, SyncedProxies: -1},
+		Requests:       models.NewEmptyRequestHealth(),
+	}
+	evaluateWorkloadHealth("bookinfo", "details-v1", &workload)
+	assert.Equal(models.HealthStatus{Status: models.HealthFailure, Reasons: []string{"details-v1: no replica available out of 1"}}, workload.Status)
+}
+
+func TestNamespaceHealthStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	status := namespaceHealthStatus("app", map[string]models.HealthStatus{
+		"details":     {Status: models.HealthHealthy},
+		"productpage": {Status: models.HealthNA},
+		"ratings":     {Status: models.HealthDegraded},
+		"reviews":     {Status: models.HealthFailure},
+	})
+	assert.Equal(models.HealthFailure, status.Status)
+	assert.Equal([]string{"app reviews is Failure", "app ratings is Degraded"}, status.Reasons)
+	assert.Equal(map[string]int{models.HealthHealthy: 1, models.HealthNA: 1, models.HealthDegraded: 1, models.HealthFailure: 1}, status.Entities)
+
+	status = namespaceHealthStatus("app", map[string]models.HealthStatus{})
+	assert.Equal(models.HealthNA, status.Status)
+}
@@ -12,13 +12,16 @@ import (
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/prometheus/internalmetrics"
 	"github.com/kiali/kiali/util"
 )
 
 type IstioConfigService struct {
 	k8s           kubernetes.ClientInterface
+	prom          prometheus.ClientInterface
 	businessLayer *Layer
+	history       IstioConfigHistoryStore
 }
 
 type IstioConfigCriteria struct {
@@ -33,6 +36,12 @@ type IstioConfigCriteria struct {
 	IncludeWorkloadEntries        bool
 	IncludeRequestAuthentications bool
 	IncludeEnvoyFilters           bool
+	IncludeTelemetries            bool
+	IncludeWasmPlugins            bool
+	IncludeK8sGatewayClasses      bool
+	IncludeK8sGateways            bool
+	IncludeK8sHTTPRoutes          bool
+	IncludeK8sTCPRoutes           bool
 	LabelSelector                 string
 	WorkloadSelector              string
 }
@@ -61,6 +70,18 @@ func (icc IstioConfigCriteria) Include(resource string) bool {
 		return icc.IncludeRequestAuthentications
 	case kubernetes.EnvoyFilters:
 		return icc.IncludeEnvoyFilters
+	case kubernetes.Telemetries:
+		return icc.IncludeTelemetries
+	case kubernetes.WasmPlugins:
+		return icc.IncludeWasmPlugins
+	case kubernetes.K8sGatewayClasses:
+		return icc.IncludeK8sGatewayClasses && !isWorkloadSelector
+	case kubernetes.K8sGateways:
+		return icc.IncludeK8sGateways && !isWorkloadSelector
+	case kubernetes.K8sHTTPRoutes:
+		return icc.IncludeK8sHTTPRoutes && !isWorkloadSelector
+	case kubernetes.K8sTCPRoutes:
+		return icc.IncludeK8sTCPRoutes && !isWorkloadSelector
 	}
 	return false
 }
@@ -96,6 +117,12 @@ func (in *IstioConfigService) GetIstioConfigList(criteria IstioConfigCriteria) (
 		WorkloadEntries:        models.WorkloadEntries{},
 		RequestAuthentications: models.RequestAuthentications{},
 		EnvoyFilters:           models.EnvoyFilters{},
+		Telemetries:            models.Telemetries{},
+		WasmPlugins:            models.WasmPlugins{},
+		K8sGatewayClasses:      models.K8sGatewayClasses{},
+		K8sGateways:            models.K8sGateways{},
+		K8sHTTPRoutes:          models.K8sHTTPRoutes{},
+		K8sTCPRoutes:           models.K8sTCPRoutes{},
 	}
 
 	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
@@ -110,10 +137,14 @@ func (in *IstioConfigService) GetIstioConfigList(criteria IstioConfigCriteria) (
 		workloadSelector = criteria.WorkloadSelector
 	}
 
-	errChan := make(chan error, 10)
+	// Gateway API objects are only fetched when its CRDs are installed
+	isGatewayAPI := (criteria.Include(kubernetes.K8sGatewayClasses) || criteria.Include(kubernetes.K8sGateways) ||
+		criteria.Include(kubernetes.K8sHTTPRoutes) || criteria.Include(kubernetes.K8sTCPRoutes)) && in.k8s.IsGatewayAPI()
+
+	errChan := make(chan error, 16)
 
 	var wg sync.WaitGroup
-	wg.Add(10)
+	wg.Add(16)
 
 	go func(errChan chan error) {
 		defer wg.Done()
@@ -303,6 +334,99 @@ func (in *IstioConfigService) GetIstioConfigList(criteria IstioConfigCriteria) (
 		}
 	}(errChan)
 
+	go func(errChan chan error) {
+		defer wg.Done()
+		if criteria.Include(kubernetes.Telemetries) {
+			if tm, tmErr := in.k8s.GetIstioObjects(criteria.Namespace, kubernetes.Telemetries, criteria.LabelSelector); tmErr == nil {
+				if isWorkloadSelector {
+					tm = kubernetes.FilterIstioObjectsForWorkloadSelector(workloadSelector, tm)
+				}
+				(&istioConfigList.Telemetries).Parse(tm)
+			} else {
+				errChan <- tmErr
+			}
+		}
+	}(errChan)
+
+	go func(errChan chan error) {
+		defer wg.Done()
+		if criteria.Include(kubernetes.WasmPlugins) {
+			if wp, wpErr := in.k8s.GetIstioObjects(criteria.Namespace, kubernetes.WasmPlugins, criteria.LabelSelector); wpErr == nil {
+				if isWorkloadSelector {
+					wp = kubernetes.FilterIstioObjectsForWorkloadSelector(workloadSelector, wp)
+				}
+				(&istioConfigList.WasmPlugins).Parse(wp)
+			} else {
+				errChan <- wpErr
+			}
+		}
+	}(errChan)
+
+	go func(errChan chan error) {
+		defer wg.Done()
+		if isGatewayAPI && criteria.Include(kubernetes.K8sGatewayClasses) {
+			if gc, gcErr := in.k8s.GetIstioObjects("", kubernetes.K8sGatewayClasses, criteria.LabelSelector); gcErr == nil {
+				(&istioConfigList.K8sGatewayClasses).Parse(gc)
+			} else {
+				errChan <- gcErr
+			}
+		}
+	}(errChan)
+
+	go func(errChan chan error) {
+		defer wg.Done()
+		if isGatewayAPI && criteria.Include(kubernetes.K8sGateways) {
+			var gw []kubernetes.IstioObject
+			var gwErr error
+			if IsResourceCached(criteria.Namespace, kubernetes.K8sGateways) {
+				gw, gwErr = kialiCache.GetIstioObjects(criteria.Namespace, kubernetes.K8sGateways, criteria.LabelSelector)
+			} else {
+				gw, gwErr = in.k8s.GetIstioObjects(criteria.Namespace, kubernetes.K8sGateways, criteria.LabelSelector)
+			}
+			if gwErr == nil {
+				(&istioConfigList.K8sGateways).Parse(gw)
+			} else {
+				errChan <- gwErr
+			}
+		}
+	}(errChan)
+
+	go func(errChan chan error) {
+		defer wg.Done()
+		if isGatewayAPI && criteria.Include(kubernetes.K8sHTTPRoutes) {
+			var hr []kubernetes.IstioObject
+			var hrErr error
+			if IsResourceCached(criteria.Namespace, kubernetes.K8sHTTPRoutes) {
+				hr, hrErr = kialiCache.GetIstioObjects(criteria.Namespace, kubernetes.K8sHTTPRoutes, criteria.LabelSelector)
+			} else {
+				hr, hrErr = in.k8s.GetIstioObjects(criteria.Namespace, kubernetes.K8sHTTPRoutes, criteria.LabelSelector)
+			}
+			if hrErr == nil {
+				(&istioConfigList.K8sHTTPRoutes).Parse(hr)
+			} else {
+				errChan <- hrErr
+			}
+		}
+	}(errChan)
+
+	go func(errChan chan error) {
+		defer wg.Done()
+		if isGatewayAPI && criteria.Include(kubernetes.K8sTCPRoutes) {
+			var tr []kubernetes.IstioObject
+			var trErr error
+			if IsResourceCached(criteria.Namespace, kubernetes.K8sTCPRoutes) {
+				tr, trErr = kialiCache.GetIstioObjects(criteria.Namespace, kubernetes.K8sTCPRoutes, criteria.LabelSelector)
+			} else {
+				tr, trErr = in.k8s.GetIstioObjects(criteria.Namespace, kubernetes.K8sTCPRoutes, criteria.LabelSelector)
+			}
+			if trErr == nil {
+				(&istioConfigList.K8sTCPRoutes).Parse(tr)
+			} else {
+				errChan <- trErr
+			}
+		}
+	}(errChan)
+
 	wg.Wait()
 
 	close(errChan)
@@ -420,6 +544,26 @@ func (in *IstioConfigService) GetIstioConfigDetails(namespace, objectType, objec
 		} else {
 			err = iErr
 		}
+	case kubernetes.Telemetries:
+		if tm, iErr := in.k8s.GetIstioObject(namespace, kubernetes.Telemetries, object); iErr == nil {
+			istioConfigDetail.Telemetry = &models.Telemetry{}
+			istioConfigDetail.Telemetry.Parse(tm)
+		} else {
+			err = iErr
+		}
+	case kubernetes.WasmPlugins:
+		if wp, iErr := in.k8s.GetIstioObject(namespace, kubernetes.WasmPlugins, object); iErr == nil {
+			istioConfigDetail.WasmPlugin = &models.WasmPlugin{}
+			istioConfigDetail.WasmPlugin.Parse(wp)
+		} else {
+			err = iErr
+		}
+	case kubernetes.K8sGatewayClasses, kubernetes.K8sGateways, kubernetes.K8sHTTPRoutes, kubernetes.K8sTCPRoutes:
+		if o, iErr := in.k8s.GetIstioObject(namespace, objectType, object); iErr == nil {
+			err = parseIstioConfigDetail(&istioConfigDetail, objectType, o)
+		} else {
+			err = iErr
+		}
 	default:
 		err = fmt.Errorf("object type not found: %v", objectType)
 	}
@@ -438,38 +582,12 @@ func GetIstioAPI(resourceType string) string {
 // ParseJsonForCreate checks if a json is well formed according resourceType
 // It returns a json validated to be used in the Create operation, or an error to report in the handler layer.
 func (in *IstioConfigService) ParseJsonForCreate(resourceType string, body []byte) (string, error) {
-	var err error
-	istioConfigDetail := models.IstioConfigDetails{}
 	apiVersion := kubernetes.ApiToVersion[kubernetes.ResourceTypesToAPI[resourceType]]
 	var kind string
 	var marshalled string
 	kind = kubernetes.PluralType[resourceType]
-	switch resourceType {
-	case kubernetes.Gateways:
-		istioConfigDetail.Gateway = &models.Gateway{}
-		err = json.Unmarshal(body, istioConfigDetail.Gateway)
-	case kubernetes.VirtualServices:
-		istioConfigDetail.VirtualService = &models.VirtualService{}
-		err = json.Unmarshal(body, istioConfigDetail.VirtualService)
-	case kubernetes.DestinationRules:
-		istioConfigDetail.DestinationRule = &models.DestinationRule{}
-		err = json.Unmarshal(body, istioConfigDetail.DestinationRule)
-	case kubernetes.ServiceEntries:
-		istioConfigDetail.ServiceEntry = &models.ServiceEntry{}
-		err = json.Unmarshal(body, istioConfigDetail.ServiceEntry)
-	case kubernetes.Sidecars:
-		istioConfigDetail.Sidecar = &models.Sidecar{}
-		err = json.Unmarshal(body, istioConfigDetail.Sidecar)
-	case kubernetes.AuthorizationPolicies:
-		istioConfigDetail.AuthorizationPolicy = &models.AuthorizationPolicy{}
-		err = json.Unmarshal(body, istioConfigDetail.AuthorizationPolicy)
-	case kubernetes.PeerAuthentications:
-		istioConfigDetail.PeerAuthentication = &models.PeerAuthentication{}
-		err = json.Unmarshal(body, istioConfigDetail.PeerAuthentication)
-	case kubernetes.RequestAuthentications:
-		istioConfigDetail.RequestAuthentication = &models.RequestAuthentication{}
-		err = json.Unmarshal(body, istioConfigDetail.RequestAuthentication)
-	default:
+	known, err := parseIstioConfigModel(resourceType, body)
+	if !known {
 		err = fmt.Errorf("object type not found: %v", resourceType)
 	}
 	// Validation object against the scheme
@@ -502,12 +620,21 @@ func (in *IstioConfigService) ParseJsonForCreate(resourceType string, body []byt
 	return marshalled, nil
 }
 
-// DeleteIstioConfigDetail deletes the given Istio resource
-func (in *IstioConfigService) DeleteIstioConfigDetail(api, namespace, resourceType, name string) (err error) {
+// DeleteIstioConfigDetail deletes the given Istio resource. When resourceVersion is set, the resource is only deleted
+// if it wasn't modified since that version, otherwise a Conflict error is returned.
+func (in *IstioConfigService) DeleteIstioConfigDetail(api, namespace, resourceType, name, resourceVersion, user string) (err error) {
 	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "DeleteIstioConfigDetail")
 	defer promtimer.ObserveNow(&err)
 
-	err = in.k8s.DeleteIstioObject(api, namespace, resourceType, name)
+	before := in.getRecordedObject(namespace, resourceType, name)
+	if resourceVersion != "" {
+		err = in.k8s.DeleteIstioObjectWithPrecondition(api, namespace, resourceType, name, resourceVersion)
+	} else {
+		err = in.k8s.DeleteIstioObject(api, namespace, resourceType, name)
+	}
+	if err == nil {
+		in.recordRevision(models.RevisionDelete, namespace, resourceType, name, user, before, nil, 0)
+	}
 
 	// Cache is stopped after a Create/Update/Delete operation to force a refresh
 	if kialiCache != nil && err == nil {
@@ -516,19 +643,102 @@ func (in *IstioConfigService) DeleteIstioConfigDetail(api, namespace, resourceTy
 	return err
 }
 
-func (in *IstioConfigService) UpdateIstioConfigDetail(api, namespace, resourceType, name, jsonPatch string) (models.IstioConfigDetails, error) {
+// UpdateIstioConfigDetail applies the JSON merge patch to the given Istio resource. When resourceVersion is set, the
+// patch is only applied if the resource wasn't modified since that version, otherwise a Conflict error is returned.
+func (in *IstioConfigService) UpdateIstioConfigDetail(api, namespace, resourceType, name, jsonPatch, resourceVersion, user string) (models.IstioConfigDetails, error) {
 	var err error
 	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "UpdateIstioConfigDetail")
 	defer promtimer.ObserveNow(&err)
 
-	return in.modifyIstioConfigDetail(api, namespace, resourceType, name, jsonPatch, false)
+	if resourceVersion != "" {
+		// The API server checks the resourceVersion of the patch against the stored one
+		if jsonPatch, err = setMetadataFields(jsonPatch, map[string]string{"resourceVersion": resourceVersion}); err != nil {
+			return models.IstioConfigDetails{}, errors2.NewBadRequest(err.Error())
+		}
+	}
+	return in.modifyIstioConfigDetail(api, namespace, resourceType, name, jsonPatch, user, false)
 }
 
-func (in *IstioConfigService) modifyIstioConfigDetail(api, namespace, resourceType, name, json string, create bool) (models.IstioConfigDetails, error) {
+// ApplyIstioConfigDetail applies the full configuration of the given Istio resource with server-side apply, so the
+// fields it sets are owned by the Kiali field manager. The resource is created when it doesn't exist. Fields owned by
+// other managers make the apply fail with a Conflict error, unless force is true. When resourceVersion is set, the
+// resource is only applied if it wasn't modified since that version.
+func (in *IstioConfigService) ApplyIstioConfigDetail(api, namespace, resourceType, name string, body []byte, resourceVersion string, force bool, user string) (models.IstioConfigDetails, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "ApplyIstioConfigDetail")
+	defer promtimer.ObserveNow(&err)
+
+	istioConfigDetail := models.IstioConfigDetails{}
+	istioConfigDetail.Namespace = models.Namespace{Name: namespace}
+	istioConfigDetail.ObjectType = resourceType
+
+	jsonBody, err := in.ParseJsonForCreate(resourceType, body)
+	if err != nil {
+		return istioConfigDetail, errors2.NewBadRequest(err.Error())
+	}
+	object := &kubernetes.GenericIstioObject{}
+	if err = json.Unmarshal([]byte(jsonBody), object); err != nil {
+		return istioConfigDetail, errors2.NewBadRequest(err.Error())
+	}
+	if object.Name != "" && object.Name != name {
+		err = errors2.NewBadRequest(fmt.Sprintf("metadata.name %s doesn't match the applied object %s", object.Name, name))
+		return istioConfigDetail, err
+	}
+	// Server-side apply needs the identity of the object in the body
+	fields := map[string]string{"name": name, "namespace": namespace}
+	if resourceVersion != "" {
+		fields["resourceVersion"] = resourceVersion
+	}
+	if jsonBody, err = setMetadataFields(jsonBody, fields); err != nil {
+		return istioConfigDetail, errors2.NewBadRequest(err.Error())
+	}
+
+	before := in.getRecordedObject(namespace, resourceType, name)
+	result, err := in.k8s.ApplyIstioObject(api, namespace, resourceType, name, jsonBody, force)
+	if err != nil {
+		return istioConfigDetail, err
+	}
+	if before == nil {
+		in.recordRevision(models.RevisionCreate, namespace, resourceType, name, user, nil, result, 0)
+	} else {
+		in.recordRevision(models.RevisionUpdate, namespace, resourceType, name, user, before, result, 0)
+	}
+
+	err = parseIstioConfigDetail(&istioConfigDetail, resourceType, result)
+
+	// Cache is stopped after a Create/Update/Delete operation to force a refresh
+	if kialiCache != nil && err == nil {
+		kialiCache.RefreshNamespace(namespace)
+	}
+	return istioConfigDetail, err
+}
+
+// setMetadataFields returns the JSON object with the given fields set in its metadata
+func setMetadataFields(jsonObject string, fields map[string]string) (string, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonObject), &object); err != nil {
+		return "", err
+	}
+	if object == nil {
+		return "", errors.New("a JSON object is expected")
+	}
+	metadata, ok := object["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = map[string]interface{}{}
+		object["metadata"] = metadata
+	}
+	for k, v := range fields {
+		metadata[k] = v
+	}
+	marshalled, err := json.Marshal(object)
+	return string(marshalled), err
+}
+
+func (in *IstioConfigService) modifyIstioConfigDetail(api, namespace, resourceType, name, json, user string, create bool) (models.IstioConfigDetails, error) {
 	var err error
 	updatedType := resourceType
 
-	var result kubernetes.IstioObject
+	var result, before kubernetes.IstioObject
 	istioConfigDetail := models.IstioConfigDetails{}
 	istioConfigDetail.Namespace = models.Namespace{Name: namespace}
 	istioConfigDetail.ObjectType = resourceType
@@ -538,54 +748,140 @@ func (in *IstioConfigService) modifyIstioConfigDetail(api, namespace, resourceTy
 		result, err = in.k8s.CreateIstioObject(api, namespace, updatedType, json)
 	} else {
 		// Update/Path existing object
+		before = in.getRecordedObject(namespace, resourceType, name)
 		result, err = in.k8s.UpdateIstioObject(api, namespace, updatedType, name, json)
 	}
 	if err != nil {
 		return istioConfigDetail, err
 	}
 
+	if create {
+		in.recordRevision(models.RevisionCreate, namespace, resourceType, result.GetObjectMeta().Name, user, nil, result, 0)
+	} else {
+		in.recordRevision(models.RevisionUpdate, namespace, resourceType, name, user, before, result, 0)
+	}
+
+	err = parseIstioConfigDetail(&istioConfigDetail, resourceType, result)
+
+	// Cache is stopped after a Create/Update/Delete operation to force a refresh
+	if kialiCache != nil && err == nil {
+		kialiCache.RefreshNamespace(namespace)
+	}
+	return istioConfigDetail, err
+}
+
+// parseIstioConfigModel unmarshals the body into the model of its type, to validate it against the scheme.
+// It returns false when there is no model for the type.
+func parseIstioConfigModel(resourceType string, body []byte) (bool, error) {
+	var err error
+	istioConfigDetail := models.IstioConfigDetails{}
+	switch resourceType {
+	case kubernetes.Gateways:
+		istioConfigDetail.Gateway = &models.Gateway{}
+		err = json.Unmarshal(body, istioConfigDetail.Gateway)
+	case kubernetes.VirtualServices:
+		istioConfigDetail.VirtualService = &models.VirtualService{}
+		err = json.Unmarshal(body, istioConfigDetail.VirtualService)
+	case kubernetes.DestinationRules:
+		istioConfigDetail.DestinationRule = &models.DestinationRule{}
+		err = json.Unmarshal(body, istioConfigDetail.DestinationRule)
+	case kubernetes.ServiceEntries:
+		istioConfigDetail.ServiceEntry = &models.ServiceEntry{}
+		err = json.Unmarshal(body, istioConfigDetail.ServiceEntry)
+	case kubernetes.Sidecars:
+		istioConfigDetail.Sidecar = &models.Sidecar{}
+		err = json.Unmarshal(body, istioConfigDetail.Sidecar)
+	case kubernetes.AuthorizationPolicies:
+		istioConfigDetail.AuthorizationPolicy = &models.AuthorizationPolicy{}
+		err = json.Unmarshal(body, istioConfigDetail.AuthorizationPolicy)
+	case kubernetes.PeerAuthentications:
+		istioConfigDetail.PeerAuthentication = &models.PeerAuthentication{}
+		err = json.Unmarshal(body, istioConfigDetail.PeerAuthentication)
+	case kubernetes.RequestAuthentications:
+		istioConfigDetail.RequestAuthentication = &models.RequestAuthentication{}
+		err = json.Unmarshal(body, istioConfigDetail.RequestAuthentication)
+	case kubernetes.Telemetries:
+		istioConfigDetail.Telemetry = &models.Telemetry{}
+		err = json.Unmarshal(body, istioConfigDetail.Telemetry)
+	case kubernetes.WasmPlugins:
+		istioConfigDetail.WasmPlugin = &models.WasmPlugin{}
+		err = json.Unmarshal(body, istioConfigDetail.WasmPlugin)
+	case kubernetes.K8sGatewayClasses:
+		istioConfigDetail.K8sGatewayClass = &models.K8sGatewayClass{}
+		err = json.Unmarshal(body, istioConfigDetail.K8sGatewayClass)
+	case kubernetes.K8sGateways:
+		istioConfigDetail.K8sGateway = &models.K8sGateway{}
+		err = json.Unmarshal(body, istioConfigDetail.K8sGateway)
+	case kubernetes.K8sHTTPRoutes:
+		istioConfigDetail.K8sHTTPRoute = &models.K8sHTTPRoute{}
+		err = json.Unmarshal(body, istioConfigDetail.K8sHTTPRoute)
+	case kubernetes.K8sTCPRoutes:
+		istioConfigDetail.K8sTCPRoute = &models.K8sTCPRoute{}
+		err = json.Unmarshal(body, istioConfigDetail.K8sTCPRoute)
+	default:
+		return false, nil
+	}
+	return true, err
+}
+
+// parseIstioConfigDetail sets the object in the field of its type of the IstioConfigDetails
+func parseIstioConfigDetail(istioConfigDetail *models.IstioConfigDetails, resourceType string, object kubernetes.IstioObject) error {
 	switch resourceType {
 	case kubernetes.Gateways:
 		istioConfigDetail.Gateway = &models.Gateway{}
-		istioConfigDetail.Gateway.Parse(result)
+		istioConfigDetail.Gateway.Parse(object)
 	case kubernetes.VirtualServices:
 		istioConfigDetail.VirtualService = &models.VirtualService{}
-		istioConfigDetail.VirtualService.Parse(result)
+		istioConfigDetail.VirtualService.Parse(object)
 	case kubernetes.DestinationRules:
 		istioConfigDetail.DestinationRule = &models.DestinationRule{}
-		istioConfigDetail.DestinationRule.Parse(result)
+		istioConfigDetail.DestinationRule.Parse(object)
 	case kubernetes.ServiceEntries:
 		istioConfigDetail.ServiceEntry = &models.ServiceEntry{}
-		istioConfigDetail.ServiceEntry.Parse(result)
+		istioConfigDetail.ServiceEntry.Parse(object)
 	case kubernetes.Sidecars:
 		istioConfigDetail.Sidecar = &models.Sidecar{}
-		istioConfigDetail.Sidecar.Parse(result)
+		istioConfigDetail.Sidecar.Parse(object)
 	case kubernetes.AuthorizationPolicies:
 		istioConfigDetail.AuthorizationPolicy = &models.AuthorizationPolicy{}
-		istioConfigDetail.AuthorizationPolicy.Parse(result)
+		istioConfigDetail.AuthorizationPolicy.Parse(object)
 	case kubernetes.PeerAuthentications:
 		istioConfigDetail.PeerAuthentication = &models.PeerAuthentication{}
-		istioConfigDetail.PeerAuthentication.Parse(result)
+		istioConfigDetail.PeerAuthentication.Parse(object)
 	case kubernetes.RequestAuthentications:
 		istioConfigDetail.RequestAuthentication = &models.RequestAuthentication{}
-		istioConfigDetail.RequestAuthentication.Parse(result)
+		istioConfigDetail.RequestAuthentication.Parse(object)
 	case kubernetes.WorkloadEntries:
 		istioConfigDetail.WorkloadEntry = &models.WorkloadEntry{}
-		istioConfigDetail.WorkloadEntry.Parse(result)
+		istioConfigDetail.WorkloadEntry.Parse(object)
 	case kubernetes.EnvoyFilters:
 		istioConfigDetail.EnvoyFilter = &models.EnvoyFilter{}
-		istioConfigDetail.EnvoyFilter.Parse(result)
+		istioConfigDetail.EnvoyFilter.Parse(object)
+	case kubernetes.Telemetries:
+		istioConfigDetail.Telemetry = &models.Telemetry{}
+		istioConfigDetail.Telemetry.Parse(object)
+	case kubernetes.WasmPlugins:
+		istioConfigDetail.WasmPlugin = &models.WasmPlugin{}
+		istioConfigDetail.WasmPlugin.Parse(object)
+	case kubernetes.K8sGatewayClasses:
+		istioConfigDetail.K8sGatewayClass = &models.K8sGatewayClass{}
+		istioConfigDetail.K8sGatewayClass.Parse(object)
+	case kubernetes.K8sGateways:
+		istioConfigDetail.K8sGateway = &models.K8sGateway{}
+		istioConfigDetail.K8sGateway.Parse(object)
+	case kubernetes.K8sHTTPRoutes:
+		istioConfigDetail.K8sHTTPRoute = &models.K8sHTTPRoute{}
+		istioConfigDetail.K8sHTTPRoute.Parse(object)
+	case kubernetes.K8sTCPRoutes:
+		istioConfigDetail.K8sTCPRoute = &models.K8sTCPRoute{}
+		istioConfigDetail.K8sTCPRoute.Parse(object)
 	default:
-		err = fmt.Errorf("object type not found: %v", resourceType)
+		return fmt.Errorf("object type not found: %v", resourceType)
 	}
-	// Cache is stopped after a Create/Update/Delete operation to force a refresh
-	if kialiCache != nil && err == nil {
-		kialiCache.RefreshNamespace(namespace)
-	}
-	return istioConfigDetail, err
+	return nil
 }
 
-func (in *IstioConfigService) CreateIstioConfigDetail(api, namespace, resourceType string, body []byte) (models.IstioConfigDetails, error) {
+func (in *IstioConfigService) CreateIstioConfigDetail(api, namespace, resourceType string, body []byte, user string) (models.IstioConfigDetails, error) {
 	var err error
 	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "CreateIstioConfigDetail")
 	defer promtimer.ObserveNow(&err)
@@ -594,7 +890,83 @@ func (in *IstioConfigService) CreateIstioConfigDetail(api, namespace, resourceTy
 	if err != nil {
 		return models.IstioConfigDetails{}, errors2.NewBadRequest(err.Error())
 	}
-	return in.modifyIstioConfigDetail(api, namespace, resourceType, "", json, true)
+	return in.modifyIstioConfigDetail(api, namespace, resourceType, "", json, user, true)
+}
+
+// DryRunCreateIstioConfigDetail creates the given Istio resource in memory and returns the validations of its namespace
+// with the new object, along with the result of creating it in Kubernetes in dry-run mode. Nothing is persisted.
+func (in *IstioConfigService) DryRunCreateIstioConfigDetail(api, namespace, resourceType string, body []byte) (models.IstioConfigDryRun, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "DryRunCreateIstioConfigDetail")
+	defer promtimer.ObserveNow(&err)
+
+	jsonBody, err := in.ParseJsonForCreate(resourceType, body)
+	if err != nil {
+		return models.IstioConfigDryRun{}, errors2.NewBadRequest(err.Error())
+	}
+	object := &kubernetes.GenericIstioObject{}
+	if err = json.Unmarshal([]byte(jsonBody), object); err != nil {
+		return models.IstioConfigDryRun{}, errors2.NewBadRequest(err.Error())
+	}
+	object.Namespace = namespace
+
+	_, serverErr := in.k8s.DryRunCreateIstioObject(api, namespace, resourceType, jsonBody)
+	return in.dryRunIstioConfigDetail(namespace, resourceType, object, serverErr)
+}
+
+// DryRunUpdateIstioConfigDetail patches the given Istio resource in memory and returns the validations of its namespace
+// with the patched object, along with the result of patching it in Kubernetes in dry-run mode. Nothing is persisted.
+func (in *IstioConfigService) DryRunUpdateIstioConfigDetail(api, namespace, resourceType, name, jsonPatch string) (models.IstioConfigDryRun, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "DryRunUpdateIstioConfigDetail")
+	defer promtimer.ObserveNow(&err)
+
+	current, err := in.k8s.GetIstioObject(namespace, resourceType, name)
+	if err != nil {
+		return models.IstioConfigDryRun{}, err
+	}
+	object, err := kubernetes.MergePatchIstioObject(current, jsonPatch)
+	if err != nil {
+		return models.IstioConfigDryRun{}, err
+	}
+
+	_, serverErr := in.k8s.DryRunUpdateIstioObject(api, namespace, resourceType, name, jsonPatch)
+	return in.dryRunIstioConfigDetail(namespace, resourceType, object, serverErr)
+}
+
+func (in *IstioConfigService) dryRunIstioConfigDetail(namespace, resourceType string, object kubernetes.IstioObject, serverErr error) (models.IstioConfigDryRun, error) {
+	dryRun := models.IstioConfigDryRun{
+		Object: models.IstioConfigDetails{
+			Namespace:  models.Namespace{Name: namespace},
+			ObjectType: resourceType,
+		},
+		ServerDryRun: newServerDryRun(serverErr),
+	}
+	if err := parseIstioConfigDetail(&dryRun.Object, resourceType, object); err != nil {
+		return dryRun, err
+	}
+
+	validations, err := in.businessLayer.Validations.GetValidationsWithChange(namespace, resourceType, object)
+	if err != nil {
+		return dryRun, err
+	}
+	dryRun.Validations = validations
+	dryRun.Object.IstioValidation = validations[models.BuildKey(models.ObjectTypeSingular[resourceType], object.GetObjectMeta().Name, namespace)]
+	return dryRun, nil
+}
+
+// newServerDryRun returns the result of a server-side dry-run from the error returned by Kubernetes
+func newServerDryRun(serverErr error) models.ServerDryRun {
+	serverDryRun := models.ServerDryRun{Accepted: serverErr == nil}
+	if serverErr != nil {
+		serverDryRun.Message = serverErr.Error()
+		if apiStatus, ok := serverErr.(errors2.APIStatus); ok {
+			status := apiStatus.Status()
+			serverDryRun.Code = status.Code
+			serverDryRun.Reason = string(status.Reason)
+		}
+	}
+	return serverDryRun
 }
 
 func (in *IstioConfigService) GeIstioConfigPermissions(namespaces []string) models.IstioConfigPermissions {
@@ -630,7 +1002,10 @@ func (in *IstioConfigService) GeIstioConfigPermissions(namespaces []string) mode
 func getPermissions(k8s kubernetes.ClientInterface, namespace, objectType string) (bool, bool, bool) {
 	var canCreate, canPatch, canUpdate, canDelete bool
 	if api, ok := kubernetes.ResourceTypesToAPI[objectType]; ok {
-		resourceType := objectType
+		resourceType := kubernetes.APIResource(objectType)
+		if kubernetes.IsClusterScoped(objectType) {
+			namespace = ""
+		}
 		ssars, permErr := k8s.GetSelfSubjectAccessReview(namespace, api, resourceType, []string{"create", "patch", "update", "delete"})
 		if permErr == nil {
 			for _, ssar := range ssars {
@@ -677,6 +1052,12 @@ func ParseIstioConfigCriteria(namespace, objects, labelSelector, workloadSelecto
 	criteria.IncludeWorkloadEntries = defaultInclude
 	criteria.IncludeRequestAuthentications = defaultInclude
 	criteria.IncludeEnvoyFilters = defaultInclude
+	criteria.IncludeTelemetries = defaultInclude
+	criteria.IncludeWasmPlugins = defaultInclude
+	// GatewayClasses are cluster scoped, they are only listed on demand
+	criteria.IncludeK8sGateways = defaultInclude
+	criteria.IncludeK8sHTTPRoutes = defaultInclude
+	criteria.IncludeK8sTCPRoutes = defaultInclude
 	criteria.LabelSelector = labelSelector
 	criteria.WorkloadSelector = workloadSelector
 
@@ -715,5 +1096,23 @@ func ParseIstioConfigCriteria(namespace, objects, labelSelector, workloadSelecto
 	if checkType(types, kubernetes.EnvoyFilters) {
 		criteria.IncludeEnvoyFilters = true
 	}
+	if checkType(types, kubernetes.Telemetries) {
+		criteria.IncludeTelemetries = true
+	}
+	if checkType(types, kubernetes.WasmPlugins) {
+		criteria.IncludeWasmPlugins = true
+	}
+	if checkType(types, kubernetes.K8sGatewayClasses) {
+		criteria.IncludeK8sGatewayClasses = true
+	}
+	if checkType(types, kubernetes.K8sGateways) {
+		criteria.IncludeK8sGateways = true
+	}
+	if checkType(types, kubernetes.K8sHTTPRoutes) {
+		criteria.IncludeK8sHTTPRoutes = true
+	}
+	if checkType(types, kubernetes.K8sTCPRoutes) {
+		criteria.IncludeK8sTCPRoutes = true
+	}
 	return criteria
 }
@@ -0,0 +1,199 @@
+package business
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// Istio types selected by an IstioConfigCriteria, in the order they are exported. The objects referenced by
+// others come first, so the bundle can be applied in order.
+var exportTypes = []string{
+	kubernetes.Gateways,
+	kubernetes.ServiceEntries,
+	kubernetes.WorkloadEntries,
+	kubernetes.DestinationRules,
+	kubernetes.VirtualServices,
+	kubernetes.K8sGateways,
+	kubernetes.K8sHTTPRoutes,
+	kubernetes.K8sTCPRoutes,
+	kubernetes.Sidecars,
+	kubernetes.EnvoyFilters,
+	kubernetes.PeerAuthentications,
+	kubernetes.RequestAuthentications,
+	kubernetes.AuthorizationPolicies,
+	kubernetes.Telemetries,
+	kubernetes.WasmPlugins,
+}
+
+// Metadata that only makes sense in the cluster where the object was read
+var exportedMetadataStripped = []string{"ownerReferences"}
+var exportedAnnotationsStripped = []string{"kubectl.kubernetes.io/last-applied-configuration"}
+
+// ExportedIstioObject is an Istio object ready to be applied in another cluster or namespace
+type ExportedIstioObject struct {
+	ObjectType string
+	Name       string
+	Namespace  string
+	Object     map[string]interface{}
+}
+
+// ExportIstioConfig returns the Istio objects matching the criteria without the fields populated by the server
+// (resourceVersion, uid, managedFields, status...), so they can be applied as they are. When targetNamespace is set,
+// the objects are moved to that namespace. References to other namespaces inside the specs are not rewritten.
+func (in *IstioConfigService) ExportIstioConfig(criteria IstioConfigCriteria, targetNamespace string) ([]ExportedIstioObject, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "ExportIstioConfig")
+	defer promtimer.ObserveNow(&err)
+
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err = in.businessLayer.Namespace.GetNamespace(criteria.Namespace); err != nil {
+		return nil, err
+	}
+	if targetNamespace == "" {
+		targetNamespace = criteria.Namespace
+	}
+
+	objectsPerType := make([][]kubernetes.IstioObject, len(exportTypes))
+	errChan := make(chan error, len(exportTypes))
+	wg := sync.WaitGroup{}
+	for i, resourceType := range exportTypes {
+		if !criteria.Include(resourceType) {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, resourceType string) {
+			defer wg.Done()
+			var objects []kubernetes.IstioObject
+			var err error
+			// Check if namespace is cached
+			if IsResourceCached(criteria.Namespace, resourceType) {
+				objects, err = kialiCache.GetIstioObjects(criteria.Namespace, resourceType, criteria.LabelSelector)
+			} else {
+				objects, err = in.k8s.GetIstioObjects(criteria.Namespace, resourceType, criteria.LabelSelector)
+			}
+			if err != nil {
+				errChan <- err
+				return
+			}
+			if criteria.WorkloadSelector != "" {
+				objects = kubernetes.FilterIstioObjectsForWorkloadSelector(criteria.WorkloadSelector, objects)
+			}
+			objectsPerType[i] = objects
+		}(i, resourceType)
+	}
+	wg.Wait()
+	close(errChan)
+	for e := range errChan {
+		if e != nil {
+			err = e
+			return nil, err
+		}
+	}
+
+	exported := []ExportedIstioObject{}
+	for i, objects := range objectsPerType {
+		for _, o := range objects {
+			object, err := exportIstioObject(exportTypes[i], o, targetNamespace)
+			if err != nil {
+				return nil, err
+			}
+			exported = append(exported, ExportedIstioObject{
+				ObjectType: exportTypes[i],
+				Name:       o.GetObjectMeta().Name,
+				Namespace:  targetNamespace,
+				Object:     object,
+			})
+		}
+	}
+	return exported, nil
+}
+
+// exportIstioObject returns the object as a map ready to be applied. Numbers are kept as json.Number, so they are
+// written back exactly as they were read.
+func exportIstioObject(resourceType string, istioObject kubernetes.IstioObject, namespace string) (map[string]interface{}, error) {
+	raw, err := json.Marshal(istioObject)
+	if err != nil {
+		return nil, err
+	}
+	object := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err = decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	object = withoutServerManagedFields(object)
+	// Objects read from the cache don't have their type
+	object["apiVersion"] = kubernetes.ApiToVersion[kubernetes.ResourceTypesToAPI[resourceType]]
+	object["kind"] = kubernetes.PluralType[resourceType]
+
+	metadata := object["metadata"].(map[string]interface{})
+	metadata["namespace"] = namespace
+	for _, field := range exportedMetadataStripped {
+		delete(metadata, field)
+	}
+	if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+		for _, annotation := range exportedAnnotationsStripped {
+			delete(annotations, annotation)
+		}
+		if len(annotations) == 0 {
+			delete(metadata, "annotations")
+		}
+	}
+	return object, nil
+}
+
+// WriteIstioConfigYAML writes the objects as a multi-document YAML
+func WriteIstioConfigYAML(w io.Writer, objects []ExportedIstioObject) error {
+	for i, o := range objects {
+		document, err := yaml.Marshal(o.Object)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err = io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err = w.Write(document); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteIstioConfigTar writes the objects as a tar archive with a YAML file per object, named
+// <namespace>/<object type>/<name>.yaml
+func WriteIstioConfigTar(w io.Writer, objects []ExportedIstioObject) error {
+	archive := tar.NewWriter(w)
+	modTime := time.Now()
+	for _, o := range objects {
+		document, err := yaml.Marshal(o.Object)
+		if err != nil {
+			return err
+		}
+		header := &tar.Header{
+			Name:    o.Namespace + "/" + o.ObjectType + "/" + o.Name + ".yaml",
+			Mode:    0644,
+			Size:    int64(len(document)),
+			ModTime: modTime,
+		}
+		if err = archive.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err = archive.Write(document); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
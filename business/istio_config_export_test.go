@@ -0,0 +1,157 @@
+package business
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+func fakeExportLayer(t *testing.T) *Layer {
+	config.Set(config.NewConfig())
+	k8s := kubernetes.NewMemoryClient()
+	err := k8s.LoadYAML(strings.NewReader(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: bookinfo
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+  resourceVersion: "4211"
+  uid: 1e0c2b8a-6c5e-4b43-9d8e-3b1a5f0e6d7c
+  generation: 3
+  creationTimestamp: "2020-11-24T09:12:44Z"
+  labels:
+    team: reviews
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{"kind":"VirtualService"}'
+  managedFields:
+  - manager: kubectl
+    operation: Update
+spec:
+  hosts: [reviews]
+  http:
+  - route:
+    - destination:
+        host: reviews
+        subset: v1
+      weight: 80
+    - destination:
+        host: reviews
+        subset: v2
+      weight: 20
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: reviews
+  annotations:
+    owner: team-reviews
+spec:
+  host: reviews
+  trafficPolicy:
+    connectionPool:
+      http:
+        http1MaxPendingRequests: 1000000
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: Gateway
+metadata:
+  name: bookinfo-gateway
+  labels:
+    team: frontend
+spec:
+  selector:
+    istio: ingressgateway
+  servers: []
+`), "bookinfo")
+	assert.NoError(t, err)
+	return NewWithBackends(k8s, nil, nil)
+}
+
+func TestExportIstioConfig(t *testing.T) {
+	assert := assert.New(t)
+	layer := fakeExportLayer(t)
+
+	objects, err := layer.IstioConfig.ExportIstioConfig(ParseIstioConfigCriteria("bookinfo", "", "", ""), "")
+	assert.NoError(err)
+	assert.Len(objects, 3)
+	assert.Equal(kubernetes.Gateways, objects[0].ObjectType)
+	assert.Equal(kubernetes.DestinationRules, objects[1].ObjectType)
+	assert.Equal(kubernetes.VirtualServices, objects[2].ObjectType)
+
+	vs := objects[2].Object
+	assert.Equal("VirtualService", vs["kind"])
+	assert.Equal(kubernetes.ApiNetworkingVersion, vs["apiVersion"])
+	metadata := vs["metadata"].(map[string]interface{})
+	assert.Equal("bookinfo", metadata["namespace"])
+	assert.Equal(map[string]interface{}{"team": "reviews"}, metadata["labels"])
+	for _, field := range []string{"resourceVersion", "uid", "generation", "creationTimestamp", "managedFields", "annotations"} {
+		assert.NotContains(metadata, field)
+	}
+	assert.Equal(map[string]interface{}{"owner": "team-reviews"}, objects[1].Object["metadata"].(map[string]interface{})["annotations"])
+
+	// Criteria select the objects
+	objects, err = layer.IstioConfig.ExportIstioConfig(ParseIstioConfigCriteria("bookinfo", "gateways,virtualservices", "team=reviews", ""), "")
+	assert.NoError(err)
+	assert.Len(objects, 1)
+	assert.Equal("reviews", objects[0].Name)
+}
+
+func TestExportIstioConfigYAML(t *testing.T) {
+	assert := assert.New(t)
+	layer := fakeExportLayer(t)
+
+	objects, err := layer.IstioConfig.ExportIstioConfig(ParseIstioConfigCriteria("bookinfo", "", "", ""), "bookinfo-staging")
+	assert.NoError(err)
+	exported := bytes.Buffer{}
+	assert.NoError(WriteIstioConfigYAML(&exported, objects))
+	assert.Equal(2, strings.Count(exported.String(), "---\n"))
+	assert.Contains(exported.String(), "http1MaxPendingRequests: 1000000\n")
+	assert.Contains(exported.String(), "namespace: bookinfo-staging\n")
+	assert.NotContains(exported.String(), "namespace: bookinfo\n")
+
+	// The export can be imported as it is
+	objects, err = layer.IstioConfig.ExportIstioConfig(ParseIstioConfigCriteria("bookinfo", "", "", ""), "")
+	assert.NoError(err)
+	exported.Reset()
+	assert.NoError(WriteIstioConfigYAML(&exported, objects))
+	target, _ := fakeImportLayer(t, "")
+	result, err := target.IstioConfig.ImportIstioConfig("bookinfo", &exported, true, "alice")
+	assert.NoError(err)
+	assert.Len(result.Objects, 3)
+	for _, o := range result.Objects {
+		assert.Equal(models.ImportStatusReady, o.Status, o.Message)
+	}
+}
+
+func TestExportIstioConfigTar(t *testing.T) {
+	assert := assert.New(t)
+	layer := fakeExportLayer(t)
+
+	objects, err := layer.IstioConfig.ExportIstioConfig(ParseIstioConfigCriteria("bookinfo", "virtualservices,destinationrules", "", ""), "")
+	assert.NoError(err)
+	exported := bytes.Buffer{}
+	assert.NoError(WriteIstioConfigTar(&exported, objects))
+
+	files := map[string]string{}
+	archive := tar.NewReader(&exported)
+	for header, err := archive.Next(); err == nil; header, err = archive.Next() {
+		content, err := ioutil.ReadAll(archive)
+		assert.NoError(err)
+		files[header.Name] = string(content)
+	}
+	assert.Len(files, 2)
+	assert.Contains(files["bookinfo/virtualservices/reviews.yaml"], "kind: VirtualService\n")
+	assert.Contains(files["bookinfo/destinationrules/reviews.yaml"], "kind: DestinationRule\n")
+}
@@ -0,0 +1,569 @@
+package business
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+const (
+	configHistoryLabel            = "kiali.io/istio-config-history"
+	configHistoryNamespaceAnnot   = "kiali.io/istio-config-namespace"
+	configHistoryObjectTypeAnnot  = "kiali.io/istio-config-type"
+	configHistoryNameAnnot        = "kiali.io/istio-config-name"
+	configHistoryRevisionKeyFmt   = "revision-%06d"
+	configHistoryUpdateMaxRetries = 3
+)
+
+// Metadata managed by the server, ignored when comparing and restoring revisions
+var serverManagedPaths = map[string]bool{
+	"metadata/creationTimestamp": true,
+	"metadata/generation":        true,
+	"metadata/managedFields":     true,
+	"metadata/resourceVersion":   true,
+	"metadata/selfLink":          true,
+	"metadata/uid":               true,
+	"status":                     true,
+}
+
+// IstioConfigHistoryStore keeps the revisions of the Istio config objects changed through Kiali
+type IstioConfigHistoryStore interface {
+	// AddRevision stores the revision with the next number of its object, returning the stored revision
+	AddRevision(revision models.IstioConfigRevision) (models.IstioConfigRevision, error)
+	// GetRevisions returns the revisions kept for an object, sorted by number
+	GetRevisions(namespace, objectType, name string) ([]models.IstioConfigRevision, error)
+}
+
+var istioConfigHistory IstioConfigHistoryStore
+var istioConfigHistoryLock sync.Mutex
+
+// SetIstioConfigHistoryStore sets the store used to record the revisions of the Istio config.
+// Mock friendly. Used only with tests.
+func SetIstioConfigHistoryStore(store IstioConfigHistoryStore) {
+	istioConfigHistoryLock.Lock()
+	defer istioConfigHistoryLock.Unlock()
+	istioConfigHistory = store
+}
+
+// getIstioConfigHistoryStore returns the store configured for the history, or nil when the history is disabled
+func getIstioConfigHistoryStore() IstioConfigHistoryStore {
+	conf := config.Get()
+	if !conf.IstioConfigHistory.Enabled {
+		return nil
+	}
+	istioConfigHistoryLock.Lock()
+	defer istioConfigHistoryLock.Unlock()
+	if istioConfigHistory == nil {
+		if conf.IstioConfigHistory.Store == config.IstioConfigHistoryStoreMemory {
+			istioConfigHistory = NewMemoryHistoryStore(conf.IstioConfigHistory.MaxRevisions)
+		} else {
+			// Users may not be allowed to write in the Kiali namespace, revisions are stored by the Kiali ServiceAccount
+			istioConfigHistory = NewConfigMapHistoryStore(getKialiSAClient, conf.Deployment.Namespace, conf.IstioConfigHistory.MaxRevisions)
+		}
+	}
+	return istioConfigHistory
+}
+
+func getKialiSAClient() (kubernetes.ClientInterface, error) {
+	clientFactory, err := kubernetes.GetClientFactory()
+	if err != nil {
+		return nil, err
+	}
+	kialiToken, err := kubernetes.GetKialiToken()
+	if err != nil {
+		return nil, err
+	}
+	return clientFactory.GetClient(kialiToken)
+}
+
+// MemoryHistoryStore keeps the revisions in memory. They are lost when Kiali restarts.
+type MemoryHistoryStore struct {
+	lock         sync.Mutex
+	maxRevisions int
+	revisions    map[string][]models.IstioConfigRevision
+}
+
+// NewMemoryHistoryStore returns an empty store keeping up to maxRevisions revisions per object
+func NewMemoryHistoryStore(maxRevisions int) *MemoryHistoryStore {
+	return &MemoryHistoryStore{maxRevisions: maxRevisions, revisions: map[string][]models.IstioConfigRevision{}}
+}
+
+func (in *MemoryHistoryStore) AddRevision(revision models.IstioConfigRevision) (models.IstioConfigRevision, error) {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	key := historyObjectKey(revision.Namespace, revision.ObjectType, revision.Name)
+	revisions := in.revisions[key]
+	revision.Revision = 1
+	if len(revisions) > 0 {
+		revision.Revision = revisions[len(revisions)-1].Revision + 1
+	}
+	revisions = append(revisions, revision)
+	if in.maxRevisions > 0 && len(revisions) > in.maxRevisions {
+		revisions = revisions[len(revisions)-in.maxRevisions:]
+	}
+	in.revisions[key] = revisions
+	return revision, nil
+}
+
+func (in *MemoryHistoryStore) GetRevisions(namespace, objectType, name string) ([]models.IstioConfigRevision, error) {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	revisions := in.revisions[historyObjectKey(namespace, objectType, name)]
+	return append([]models.IstioConfigRevision{}, revisions...), nil
+}
+
+// ConfigMapHistoryStore keeps the revisions of each object in a ConfigMap, one revision per key
+type ConfigMapHistoryStore struct {
+	client       func() (kubernetes.ClientInterface, error)
+	namespace    string
+	maxRevisions int
+}
+
+// NewConfigMapHistoryStore returns a store writing its ConfigMaps in the namespace with the client returned by
+// the loader, keeping up to maxRevisions revisions per object
+func NewConfigMapHistoryStore(client func() (kubernetes.ClientInterface, error), namespace string, maxRevisions int) *ConfigMapHistoryStore {
+	return &ConfigMapHistoryStore{client: client, namespace: namespace, maxRevisions: maxRevisions}
+}
+
+func (in *ConfigMapHistoryStore) AddRevision(revision models.IstioConfigRevision) (models.IstioConfigRevision, error) {
+	k8s, err := in.client()
+	if err != nil {
+		return revision, err
+	}
+	name := in.configMapName(revision.Namespace, revision.ObjectType, revision.Name)
+
+	// The ConfigMap may be updated concurrently by other Kiali requests or replicas
+	for attempt := 1; ; attempt++ {
+		var configMap *core_v1.ConfigMap
+		if configMap, err = k8s.GetConfigMap(in.namespace, name); err != nil {
+			if !errors2.IsNotFound(err) {
+				return revision, err
+			}
+			configMap = &core_v1.ConfigMap{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      name,
+					Namespace: in.namespace,
+					Labels:    map[string]string{configHistoryLabel: "true"},
+					Annotations: map[string]string{
+						configHistoryNamespaceAnnot:  revision.Namespace,
+						configHistoryObjectTypeAnnot: revision.ObjectType,
+						configHistoryNameAnnot:       revision.Name,
+					},
+				},
+			}
+		}
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+
+		keys := revisionKeys(configMap)
+		revision.Revision = 1
+		if len(keys) > 0 {
+			revision.Revision = keys[len(keys)-1] + 1
+		}
+		raw, err := json.Marshal(revision)
+		if err != nil {
+			return revision, err
+		}
+		configMap.Data[fmt.Sprintf(configHistoryRevisionKeyFmt, revision.Revision)] = string(raw)
+		for i := 0; in.maxRevisions > 0 && i < len(keys)+1-in.maxRevisions; i++ {
+			delete(configMap.Data, fmt.Sprintf(configHistoryRevisionKeyFmt, keys[i]))
+		}
+
+		if configMap.ResourceVersion == "" {
+			_, err = k8s.CreateConfigMap(in.namespace, configMap)
+		} else {
+			_, err = k8s.UpdateConfigMap(in.namespace, configMap)
+		}
+		if err == nil || attempt == configHistoryUpdateMaxRetries || !(errors2.IsConflict(err) || errors2.IsAlreadyExists(err)) {
+			return revision, err
+		}
+	}
+}
+
+func (in *ConfigMapHistoryStore) GetRevisions(namespace, objectType, name string) ([]models.IstioConfigRevision, error) {
+	revisions := []models.IstioConfigRevision{}
+	k8s, err := in.client()
+	if err != nil {
+		return revisions, err
+	}
+	configMap, err := k8s.GetConfigMap(in.namespace, in.configMapName(namespace, objectType, name))
+	if err != nil {
+		if errors2.IsNotFound(err) {
+			return revisions, nil
+		}
+		return revisions, err
+	}
+	for _, key := range revisionKeys(configMap) {
+		revision := models.IstioConfigRevision{}
+		if err := json.Unmarshal([]byte(configMap.Data[fmt.Sprintf(configHistoryRevisionKeyFmt, key)]), &revision); err != nil {
+			log.Warningf("Skipping revision %d of %s %s.%s: %s", key, objectType, name, namespace, err)
+			continue
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// configMapName hashes the object, as its namespace, type and name may exceed the length allowed for a name
+func (in *ConfigMapHistoryStore) configMapName(namespace, objectType, name string) string {
+	hash := sha256.Sum256([]byte(historyObjectKey(namespace, objectType, name)))
+	return "kiali-istio-config-history-" + hex.EncodeToString(hash[:])[:16]
+}
+
+// revisionKeys returns the sorted numbers of the revisions stored in the ConfigMap
+func revisionKeys(configMap *core_v1.ConfigMap) []int {
+	keys := []int{}
+	for key := range configMap.Data {
+		if n, err := strconv.Atoi(strings.TrimPrefix(key, "revision-")); err == nil {
+			keys = append(keys, n)
+		}
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func historyObjectKey(namespace, objectType, name string) string {
+	return namespace + "/" + objectType + "/" + name
+}
+
+// recordRevision stores a change of an object in the history, if enabled. The change is already applied, so
+// failing to record it is logged instead of failing the request.
+func (in *IstioConfigService) recordRevision(operation, namespace, resourceType, name, user string, before, after kubernetes.IstioObject, rollbackOf int) {
+	if in.history == nil {
+		return
+	}
+	revision := models.IstioConfigRevision{
+		ObjectType: resourceType,
+		Name:       name,
+		Namespace:  namespace,
+		Operation:  operation,
+		User:       user,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		RollbackOf: rollbackOf,
+		Before:     toUnstructured(before),
+		After:      toUnstructured(after),
+	}
+	if _, err := in.history.AddRevision(revision); err != nil {
+		log.Errorf("Could not record the %s of %s %s.%s by user [%s] in the Istio config history: %s", operation, resourceType, name, namespace, user, err)
+	}
+}
+
+// getRecordedObject returns the object before it is changed, when the change is recorded in the history
+func (in *IstioConfigService) getRecordedObject(namespace, resourceType, name string) kubernetes.IstioObject {
+	if in.history == nil {
+		return nil
+	}
+	object, err := in.k8s.GetIstioObject(namespace, resourceType, name)
+	if err != nil {
+		log.Debugf("Could not fetch %s %s.%s before changing it: %s", resourceType, name, namespace, err)
+		return nil
+	}
+	return object
+}
+
+// GetIstioConfigHistory returns the revisions of an object, without the before and after objects
+func (in *IstioConfigService) GetIstioConfigHistory(namespace, resourceType, name string) ([]models.IstioConfigRevision, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "GetIstioConfigHistory")
+	defer promtimer.ObserveNow(&err)
+
+	// Revisions are read with the Kiali ServiceAccount: check if user has access to the namespace (RBAC) in cache
+	// scenarios and/or if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err = in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return nil, err
+	}
+	revisions, err := in.getRevisions(namespace, resourceType, name)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]models.IstioConfigRevision, 0, len(revisions))
+	for _, r := range revisions {
+		summaries = append(summaries, r.Summary())
+	}
+	return summaries, nil
+}
+
+// GetIstioConfigRevision returns a revision of an object, with the whole object before and after the change
+func (in *IstioConfigService) GetIstioConfigRevision(namespace, resourceType, name string, revision int) (models.IstioConfigRevision, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "GetIstioConfigRevision")
+	defer promtimer.ObserveNow(&err)
+
+	// Revisions are read with the Kiali ServiceAccount: check if user has access to the namespace (RBAC) in cache
+	// scenarios and/or if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err = in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return models.IstioConfigRevision{}, err
+	}
+	revisions, err := in.getRevisions(namespace, resourceType, name)
+	if err != nil {
+		return models.IstioConfigRevision{}, err
+	}
+	return findRevision(revisions, resourceType, name, revision)
+}
+
+// DiffIstioConfigRevisions compares an object as it was left by two of its revisions
+func (in *IstioConfigService) DiffIstioConfigRevisions(namespace, resourceType, name string, from, to int) (models.IstioConfigRevisionDiff, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "DiffIstioConfigRevisions")
+	defer promtimer.ObserveNow(&err)
+
+	diff := models.IstioConfigRevisionDiff{From: from, To: to, Changes: []models.IstioConfigChange{}}
+	// Revisions are read with the Kiali ServiceAccount: check if user has access to the namespace (RBAC) in cache
+	// scenarios and/or if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err = in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return diff, err
+	}
+	revisions, err := in.getRevisions(namespace, resourceType, name)
+	if err != nil {
+		return diff, err
+	}
+	fromRevision, err := findRevision(revisions, resourceType, name, from)
+	if err != nil {
+		return diff, err
+	}
+	toRevision, err := findRevision(revisions, resourceType, name, to)
+	if err != nil {
+		return diff, err
+	}
+	// A deleted object is compared as an empty one
+	diffMaps("", fromRevision.After, toRevision.After, &diff.Changes)
+	return diff, nil
+}
+
+// RollbackIstioConfig restores an object as it was left by one of its revisions: it is created again if it was
+// deleted since, or deleted if the revision deleted it. The rollback is recorded as a new revision.
+func (in *IstioConfigService) RollbackIstioConfig(api, namespace, resourceType, name string, revision int, user string) (models.IstioConfigDetails, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "RollbackIstioConfig")
+	defer promtimer.ObserveNow(&err)
+
+	istioConfigDetail := models.IstioConfigDetails{}
+	istioConfigDetail.Namespace = models.Namespace{Name: namespace}
+	istioConfigDetail.ObjectType = resourceType
+
+	// Revisions are read with the Kiali ServiceAccount: check if user has access to the namespace (RBAC) in cache
+	// scenarios and/or if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err = in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return istioConfigDetail, err
+	}
+	revisions, err := in.getRevisions(namespace, resourceType, name)
+	if err != nil {
+		return istioConfigDetail, err
+	}
+	target, err := findRevision(revisions, resourceType, name, revision)
+	if err != nil {
+		return istioConfigDetail, err
+	}
+
+	current, err := in.k8s.GetIstioObject(namespace, resourceType, name)
+	if err != nil {
+		if !errors2.IsNotFound(err) {
+			return istioConfigDetail, err
+		}
+		current = nil
+	}
+
+	var result kubernetes.IstioObject
+	switch {
+	case target.After == nil && current == nil:
+		return istioConfigDetail, errors2.NewBadRequest(fmt.Sprintf("%s %s is already deleted", resourceType, name))
+	case target.After == nil:
+		err = in.k8s.DeleteIstioObject(api, namespace, resourceType, name)
+	case current == nil:
+		var body []byte
+		if body, err = json.Marshal(withoutServerManagedFields(target.After)); err == nil {
+			result, err = in.k8s.CreateIstioObject(api, namespace, resourceType, string(body))
+		}
+	default:
+		var patch []byte
+		if patch, err = json.Marshal(replacePatch(toUnstructured(current), target.After)); err == nil {
+			result, err = in.k8s.UpdateIstioObject(api, namespace, resourceType, name, string(patch))
+		}
+	}
+	if err != nil {
+		return istioConfigDetail, err
+	}
+
+	in.recordRevision(models.RevisionRollback, namespace, resourceType, name, user, current, result, revision)
+	if kialiCache != nil {
+		kialiCache.RefreshNamespace(namespace)
+	}
+	if result == nil {
+		return istioConfigDetail, nil
+	}
+	err = parseIstioConfigDetail(&istioConfigDetail, resourceType, result)
+	return istioConfigDetail, err
+}
+
+func (in *IstioConfigService) getRevisions(namespace, resourceType, name string) ([]models.IstioConfigRevision, error) {
+	if in.history == nil {
+		return nil, errors2.NewServiceUnavailable("The Istio config history is disabled")
+	}
+	return in.history.GetRevisions(namespace, resourceType, name)
+}
+
+func findRevision(revisions []models.IstioConfigRevision, resourceType, name string, revision int) (models.IstioConfigRevision, error) {
+	for _, r := range revisions {
+		if r.Revision == revision {
+			return r, nil
+		}
+	}
+	return models.IstioConfigRevision{}, errors2.NewNotFound(schema.GroupResource{Resource: "revisions"}, fmt.Sprintf("%s/%s/%d", resourceType, name, revision))
+}
+
+// toUnstructured returns the object as a map, as it is serialized by the Kubernetes API
+func toUnstructured(object kubernetes.IstioObject) map[string]interface{} {
+	if object == nil {
+		return nil
+	}
+	raw, err := json.Marshal(object)
+	if err != nil {
+		log.Warningf("Could not serialize %s: %s", object.GetObjectMeta().Name, err)
+		return nil
+	}
+	result := map[string]interface{}{}
+	if err = json.Unmarshal(raw, &result); err != nil {
+		log.Warningf("Could not serialize %s: %s", object.GetObjectMeta().Name, err)
+		return nil
+	}
+	return result
+}
+
+// withoutServerManagedFields returns a copy of the object without the fields that can't be set when creating it
+func withoutServerManagedFields(object map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for k, v := range object {
+		if !serverManagedPaths[k] {
+			result[k] = v
+		}
+	}
+	if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+		cleanMetadata := map[string]interface{}{}
+		for k, v := range metadata {
+			if !serverManagedPaths["metadata/"+k] {
+				cleanMetadata[k] = v
+			}
+		}
+		result["metadata"] = cleanMetadata
+	}
+	return result
+}
+
+// replacePatch returns the JSON merge patch that replaces the spec, labels and annotations of the current object
+// with the ones of the target
+func replacePatch(current, target map[string]interface{}) map[string]interface{} {
+	project := func(object map[string]interface{}) map[string]interface{} {
+		metadata, _ := object["metadata"].(map[string]interface{})
+		return map[string]interface{}{
+			"spec": object["spec"],
+			"metadata": map[string]interface{}{
+				"labels":      metadata["labels"],
+				"annotations": metadata["annotations"],
+			},
+		}
+	}
+	return mergePatchFor(project(current), project(target))
+}
+
+// mergePatchFor returns the JSON merge patch (RFC 7386) that turns the original map into the target one
+func mergePatchFor(original, target map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for k := range original {
+		if _, found := target[k]; !found {
+			patch[k] = nil
+		}
+	}
+	for k, v := range target {
+		originalMap, originalIsMap := original[k].(map[string]interface{})
+		targetMap, targetIsMap := v.(map[string]interface{})
+		switch {
+		case originalIsMap && targetIsMap:
+			if nested := mergePatchFor(originalMap, targetMap); len(nested) > 0 {
+				patch[k] = nested
+			}
+		case !reflect.DeepEqual(original[k], v):
+			patch[k] = v
+		}
+	}
+	return patch
+}
+
+// diffMaps appends the changes between two objects, or nested objects at the path, ignoring server managed fields
+func diffMaps(path string, from, to map[string]interface{}, changes *[]models.IstioConfigChange) {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, found := from[k]; !found {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		childPath := k
+		if path != "" {
+			childPath = path + "/" + k
+		}
+		if serverManagedPaths[childPath] {
+			continue
+		}
+		fromValue, inFrom := from[k]
+		toValue, inTo := to[k]
+		switch {
+		case !inFrom:
+			*changes = append(*changes, models.IstioConfigChange{Path: childPath, Operation: models.ConfigChangeAdded, To: toValue})
+		case !inTo:
+			*changes = append(*changes, models.IstioConfigChange{Path: childPath, Operation: models.ConfigChangeRemoved, From: fromValue})
+		default:
+			diffValues(childPath, fromValue, toValue, changes)
+		}
+	}
+}
+
+func diffValues(path string, from, to interface{}, changes *[]models.IstioConfigChange) {
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+	if fromIsMap && toIsMap {
+		diffMaps(path, fromMap, toMap, changes)
+		return
+	}
+	fromSlice, fromIsSlice := from.([]interface{})
+	toSlice, toIsSlice := to.([]interface{})
+	if fromIsSlice && toIsSlice {
+		for i := 0; i < len(fromSlice) || i < len(toSlice); i++ {
+			childPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(fromSlice):
+				*changes = append(*changes, models.IstioConfigChange{Path: childPath, Operation: models.ConfigChangeAdded, To: toSlice[i]})
+			case i >= len(toSlice):
+				*changes = append(*changes, models.IstioConfigChange{Path: childPath, Operation: models.ConfigChangeRemoved, From: fromSlice[i]})
+			default:
+				diffValues(childPath, fromSlice[i], toSlice[i], changes)
+			}
+		}
+		return
+	}
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, models.IstioConfigChange{Path: path, Operation: models.ConfigChangeChanged, From: from, To: to})
+	}
+}
@@ -0,0 +1,204 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const reviewsVirtualService = `{
+	"apiVersion": "networking.istio.io/v1alpha3",
+	"kind": "VirtualService",
+	"metadata": {"name": "reviews", "labels": {"team": "reviews"}},
+	"spec": {
+		"hosts": ["reviews"],
+		"http": [{"route": [{"destination": {"host": "reviews", "subset": "v1"}, "weight": 80}, {"destination": {"host": "reviews", "subset": "v2"}, "weight": 20}]}]
+	}
+}`
+
+func fakeHistoryConfigService(t *testing.T) (*kubernetes.MemoryClient, IstioConfigService) {
+	k8s := kubernetes.NewMemoryClient()
+	err := k8s.LoadYAML(strings.NewReader(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: bookinfo
+`), "")
+	assert.NoError(t, err)
+	return k8s, IstioConfigService{k8s: k8s, history: NewMemoryHistoryStore(10), businessLayer: NewWithBackends(k8s, nil, nil)}
+}
+
+func TestIstioConfigHistory(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	_, configService := fakeHistoryConfigService(t)
+
+	_, err := configService.CreateIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", []byte(reviewsVirtualService), "alice")
+	assert.NoError(err)
+	_, err = configService.UpdateIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews",
+		`{"metadata": {"labels": {"team": null}}, "spec": {"http": [{"route": [{"destination": {"host": "reviews", "subset": "v2"}}]}]}}`, "", "bob")
+	assert.NoError(err)
+	err = configService.DeleteIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews", "", "alice")
+	assert.NoError(err)
+
+	history, err := configService.GetIstioConfigHistory("bookinfo", "virtualservices", "reviews")
+	assert.NoError(err)
+	assert.Len(history, 3)
+	assert.Equal([]string{models.RevisionCreate, models.RevisionUpdate, models.RevisionDelete},
+		[]string{history[0].Operation, history[1].Operation, history[2].Operation})
+	assert.Equal("bob", history[1].User)
+	assert.Equal(2, history[1].Revision)
+	assert.Nil(history[1].Before)
+
+	revision, err := configService.GetIstioConfigRevision("bookinfo", "virtualservices", "reviews", 2)
+	assert.NoError(err)
+	assert.NotNil(revision.Before)
+	assert.NotNil(revision.After)
+
+	revision, err = configService.GetIstioConfigRevision("bookinfo", "virtualservices", "reviews", 3)
+	assert.NoError(err)
+	assert.NotNil(revision.Before)
+	assert.Nil(revision.After)
+
+	_, err = configService.GetIstioConfigRevision("bookinfo", "virtualservices", "reviews", 4)
+	assert.Error(err)
+
+	// Revisions of namespaces not accessible to the user are not returned
+	_, err = configService.GetIstioConfigHistory("kube-system", "virtualservices", "reviews")
+	assert.Error(err)
+	_, err = configService.GetIstioConfigRevision("kube-system", "virtualservices", "reviews", 1)
+	assert.Error(err)
+	_, err = configService.DiffIstioConfigRevisions("kube-system", "virtualservices", "reviews", 1, 2)
+	assert.Error(err)
+}
+
+func TestDiffIstioConfigRevisions(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	_, configService := fakeHistoryConfigService(t)
+
+	_, err := configService.CreateIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", []byte(reviewsVirtualService), "alice")
+	assert.NoError(err)
+	_, err = configService.UpdateIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews",
+		`{"metadata": {"labels": {"team": null}, "resourceVersion": "1"}, "spec": {"gateways": ["bookinfo-gateway"], "http": [{"route": [{"destination": {"host": "reviews", "subset": "v1"}, "weight": 50}, {"destination": {"host": "reviews", "subset": "v2"}, "weight": 50}]}]}}`, "", "bob")
+	assert.NoError(err)
+
+	diff, err := configService.DiffIstioConfigRevisions("bookinfo", "virtualservices", "reviews", 1, 2)
+	assert.NoError(err)
+	changes := map[string]models.IstioConfigChange{}
+	for _, c := range diff.Changes {
+		changes[c.Path] = c
+	}
+	assert.Len(changes, 4)
+	assert.Equal(models.ConfigChangeRemoved, changes["metadata/labels"].Operation)
+	assert.Equal(models.ConfigChangeAdded, changes["spec/gateways"].Operation)
+	assert.Equal(models.ConfigChangeChanged, changes["spec/http[0]/route[0]/weight"].Operation)
+	assert.Equal(float64(80), changes["spec/http[0]/route[0]/weight"].From)
+	assert.Equal(float64(50), changes["spec/http[0]/route[0]/weight"].To)
+	assert.Equal(models.ConfigChangeChanged, changes["spec/http[0]/route[1]/weight"].Operation)
+	_, found := changes["metadata/resourceVersion"]
+	assert.False(found)
+
+	_, err = configService.DiffIstioConfigRevisions("bookinfo", "virtualservices", "reviews", 1, 7)
+	assert.Error(err)
+}
+
+func TestRollbackIstioConfig(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	k8s, configService := fakeHistoryConfigService(t)
+
+	_, err := configService.CreateIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", []byte(reviewsVirtualService), "alice")
+	assert.NoError(err)
+	_, err = configService.UpdateIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews",
+		`{"metadata": {"labels": {"team": null}}, "spec": {"gateways": ["bookinfo-gateway"], "http": [{"route": [{"destination": {"host": "reviews", "subset": "v2"}}]}]}}`, "", "bob")
+	assert.NoError(err)
+
+	// Rollback of an update restores the spec and labels, dropping the fields added since
+	rolledBack, err := configService.RollbackIstioConfig("networking.istio.io", "bookinfo", "virtualservices", "reviews", 1, "carol")
+	assert.NoError(err)
+	assert.Equal("reviews", rolledBack.VirtualService.Metadata.Name)
+	vs, err := k8s.GetIstioObject("bookinfo", "virtualservices", "reviews")
+	assert.NoError(err)
+	assert.Equal("reviews", vs.GetObjectMeta().Labels["team"])
+	assert.NotContains(vs.GetSpec(), "gateways")
+	assert.Len(vs.GetSpec()["http"].([]interface{})[0].(map[string]interface{})["route"], 2)
+
+	history, err := configService.GetIstioConfigHistory("bookinfo", "virtualservices", "reviews")
+	assert.NoError(err)
+	assert.Len(history, 3)
+	assert.Equal(models.RevisionRollback, history[2].Operation)
+	assert.Equal(1, history[2].RollbackOf)
+	assert.Equal("carol", history[2].User)
+
+	diff, err := configService.DiffIstioConfigRevisions("bookinfo", "virtualservices", "reviews", 1, 3)
+	assert.NoError(err)
+	assert.Empty(diff.Changes)
+
+	// Rollback of a deleted object creates it again
+	err = configService.DeleteIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews", "", "alice")
+	assert.NoError(err)
+	_, err = configService.RollbackIstioConfig("networking.istio.io", "bookinfo", "virtualservices", "reviews", 2, "carol")
+	assert.NoError(err)
+	vs, err = k8s.GetIstioObject("bookinfo", "virtualservices", "reviews")
+	assert.NoError(err)
+	assert.Contains(vs.GetSpec(), "gateways")
+
+	// Rollback to a delete deletes it
+	_, err = configService.RollbackIstioConfig("networking.istio.io", "bookinfo", "virtualservices", "reviews", 4, "carol")
+	assert.NoError(err)
+	_, err = k8s.GetIstioObject("bookinfo", "virtualservices", "reviews")
+	assert.Error(err)
+
+	// Revisions of namespaces not accessible to the user are not rolled back
+	_, err = configService.RollbackIstioConfig("networking.istio.io", "kube-system", "virtualservices", "reviews", 1, "carol")
+	assert.Error(err)
+}
+
+func TestIstioConfigHistoryDisabled(t *testing.T) {
+	assert := assert.New(t)
+	// Disabled by default
+	config.Set(config.NewConfig())
+
+	layer := NewWithBackends(kubernetes.NewMemoryClient(), nil, nil)
+	_, err := layer.IstioConfig.GetIstioConfigHistory("bookinfo", "virtualservices", "reviews")
+	assert.Error(err)
+}
+
+func TestConfigMapHistoryStore(t *testing.T) {
+	assert := assert.New(t)
+
+	k8s := kubernetes.NewMemoryClient()
+	store := NewConfigMapHistoryStore(func() (kubernetes.ClientInterface, error) { return k8s, nil }, "kiali", 2)
+
+	for _, operation := range []string{models.RevisionCreate, models.RevisionUpdate, models.RevisionUpdate} {
+		_, err := store.AddRevision(models.IstioConfigRevision{ObjectType: "virtualservices", Name: "reviews", Namespace: "bookinfo", Operation: operation})
+		assert.NoError(err)
+	}
+	revision, err := store.AddRevision(models.IstioConfigRevision{ObjectType: "virtualservices", Name: "ratings", Namespace: "bookinfo", Operation: models.RevisionCreate})
+	assert.NoError(err)
+	assert.Equal(1, revision.Revision)
+
+	// Only the last revisions are kept
+	revisions, err := store.GetRevisions("bookinfo", "virtualservices", "reviews")
+	assert.NoError(err)
+	assert.Len(revisions, 2)
+	assert.Equal(2, revisions[0].Revision)
+	assert.Equal(3, revisions[1].Revision)
+
+	name := store.configMapName("bookinfo", "virtualservices", "reviews")
+	assert.True(strings.HasPrefix(name, "kiali-istio-config-history-"))
+	assert.True(k8s.HasConfigMap("kiali", name))
+
+	revisions, err = store.GetRevisions("bookinfo", "virtualservices", "details")
+	assert.NoError(err)
+	assert.Empty(revisions)
+}
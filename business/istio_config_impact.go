@@ -0,0 +1,545 @@
+package business
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	core_v1 "k8s.io/api/core/v1"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/business/checkers/common"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// Istio object types applied to the workloads matched by their selector, or to every workload when they have none
+var selectorImpactTypes = []string{
+	kubernetes.Sidecars,
+	kubernetes.EnvoyFilters,
+	kubernetes.AuthorizationPolicies,
+	kubernetes.PeerAuthentications,
+	kubernetes.RequestAuthentications,
+	kubernetes.Telemetries,
+	kubernetes.WasmPlugins,
+}
+
+// GetIstioConfigImpact returns the workloads, pods, services and gateways affected by an Istio object, with the
+// current request rates through them.
+func (in *IstioConfigService) GetIstioConfigImpact(namespace, resourceType, name, rateInterval string, queryTime time.Time) (models.IstioConfigImpact, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "GetIstioConfigImpact")
+	defer promtimer.ObserveNow(&err)
+
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err = in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return models.IstioConfigImpact{}, err
+	}
+	object, err := in.k8s.GetIstioObject(namespace, resourceType, name)
+	if err != nil {
+		return models.IstioConfigImpact{}, err
+	}
+	return in.getIstioConfigImpact(resourceType, object, rateInterval, queryTime)
+}
+
+// GetProposedIstioConfigImpact returns the workloads, pods, services and gateways that would be affected by the given
+// Istio object if it was created, or if it replaced the existing object of the same name. Nothing is persisted.
+func (in *IstioConfigService) GetProposedIstioConfigImpact(namespace, resourceType string, body []byte, rateInterval string, queryTime time.Time) (models.IstioConfigImpact, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "GetProposedIstioConfigImpact")
+	defer promtimer.ObserveNow(&err)
+
+	if _, err = in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return models.IstioConfigImpact{}, err
+	}
+	jsonBody, err := in.ParseJsonForCreate(resourceType, body)
+	if err != nil {
+		return models.IstioConfigImpact{}, errors2.NewBadRequest(err.Error())
+	}
+	object := &kubernetes.GenericIstioObject{}
+	if err = json.Unmarshal([]byte(jsonBody), object); err != nil {
+		return models.IstioConfigImpact{}, errors2.NewBadRequest(err.Error())
+	}
+	object.Namespace = namespace
+	return in.getIstioConfigImpact(resourceType, object, rateInterval, queryTime)
+}
+
+func (in *IstioConfigService) getIstioConfigImpact(resourceType string, object kubernetes.IstioObject, rateInterval string, queryTime time.Time) (models.IstioConfigImpact, error) {
+	switch resourceType {
+	case kubernetes.VirtualServices, kubernetes.DestinationRules, kubernetes.ServiceEntries, kubernetes.Gateways:
+	default:
+		if !checkType(selectorImpactTypes, resourceType) {
+			return models.IstioConfigImpact{}, errors2.NewBadRequest(fmt.Sprintf("impact analysis is not supported for %s", resourceType))
+		}
+	}
+
+	data, err := in.fetchReferenceData()
+	if err != nil {
+		return models.IstioConfigImpact{}, err
+	}
+	// A proposed Sidecar or Gateway replaces the existing one when resolving the workloads
+	switch resourceType {
+	case kubernetes.Sidecars:
+		data.sidecars = replaceIstioObject(data.sidecars, object)
+	case kubernetes.Gateways:
+		data.gateways = replaceIstioObject(data.gateways, object)
+	}
+
+	ib := newImpactBuilder(models.IstioReference{
+		ObjectType: models.ObjectTypeSingular[resourceType],
+		Name:       object.GetObjectMeta().Name,
+		Namespace:  object.GetObjectMeta().Namespace,
+	})
+
+	switch resourceType {
+	case kubernetes.VirtualServices:
+		meshBound := true
+		if gateways, ok := object.GetSpec()["gateways"].([]interface{}); ok && len(gateways) > 0 {
+			meshBound = false
+			for _, g := range gateways {
+				gateway, _ := g.(string)
+				if gateway == "mesh" {
+					meshBound = true
+					continue
+				}
+				gwHost := kubernetes.ParseGatewayAsHost(gateway, object.GetObjectMeta().Namespace, "")
+				for _, gw := range data.gateways {
+					if gw.GetObjectMeta().Name == gwHost.Service && gw.GetObjectMeta().Namespace == gwHost.Namespace {
+						ib.addGateway(gw)
+					}
+				}
+			}
+		}
+		hosts := data.objectHosts(object, "hosts")
+		ib.addServices(data.hostsServices(hosts), models.ImpactReasonHost)
+		ib.addServices(data.hostsServices(data.destinationHosts(object)), models.ImpactReasonHost)
+		if meshBound {
+			ib.addClients(data, object, hosts)
+		}
+	case kubernetes.DestinationRules:
+		hosts := data.objectHosts(object, "host")
+		ib.addServices(data.hostsServices(hosts), models.ImpactReasonHost)
+		ib.addClients(data, object, hosts)
+	case kubernetes.ServiceEntries:
+		hosts := data.objectHosts(object, "hosts")
+		ib.addServices(data.hostsServices(hosts), models.ImpactReasonHost)
+		ib.addSelectedWorkloads(data, resourceType, object)
+		ib.addClients(data, object, hosts)
+	case kubernetes.Gateways:
+		ib.addGateway(object)
+		// Services exposed by the VirtualServices bound to the gateway
+		for _, vs := range data.virtualServices {
+			gateways, _ := vs.GetSpec()["gateways"].([]interface{})
+			for _, g := range gateways {
+				gateway, _ := g.(string)
+				gwHost := kubernetes.ParseGatewayAsHost(gateway, vs.GetObjectMeta().Namespace, "")
+				if gwHost.Service == object.GetObjectMeta().Name && gwHost.Namespace == object.GetObjectMeta().Namespace {
+					ib.addServices(data.hostsServices(data.destinationHosts(vs)), models.ImpactReasonGateway)
+					break
+				}
+			}
+		}
+	case kubernetes.Sidecars:
+		for _, ns := range data.namespaces {
+			for _, wl := range data.workloads[ns] {
+				if sc := data.sidecarFor(ns, wl.Labels); sc != nil && sameObject(sc, object) {
+					ib.addWorkload(ns, wl.Name, selectorImpactReason(resourceType, object, ns))
+				}
+			}
+		}
+	default:
+		ib.addSelectedWorkloads(data, resourceType, object)
+	}
+
+	ib.addRelatedEntities(data)
+	if in.prom != nil {
+		ib.addRequestRates(in.prom.GetAllRequestRates, rateInterval, queryTime)
+	}
+	return ib.impact, nil
+}
+
+// impactBuilder collects the entities affected by an Istio object, each one once with all the reasons it is affected
+type impactBuilder struct {
+	impact    models.IstioConfigImpact
+	workloads map[string]int
+	services  map[string]int
+	gateways  map[string]int
+}
+
+func newImpactBuilder(target models.IstioReference) *impactBuilder {
+	return &impactBuilder{
+		impact: models.IstioConfigImpact{
+			IstioReference: target,
+			Workloads:      []models.ImpactedEntity{},
+			Pods:           []models.ImpactedPod{},
+			Services:       []models.ImpactedEntity{},
+			Gateways:       []models.ImpactedEntity{},
+		},
+		workloads: map[string]int{},
+		services:  map[string]int{},
+		gateways:  map[string]int{},
+	}
+}
+
+func addImpactedEntity(entities *[]models.ImpactedEntity, index map[string]int, namespace, name string, reasons ...string) {
+	key := namespace + "/" + name
+	i, ok := index[key]
+	if !ok {
+		i = len(*entities)
+		index[key] = i
+		*entities = append(*entities, models.ImpactedEntity{Name: name, Namespace: namespace, Reasons: []string{}})
+	}
+	for _, reason := range reasons {
+		if !checkType((*entities)[i].Reasons, reason) {
+			(*entities)[i].Reasons = append((*entities)[i].Reasons, reason)
+		}
+	}
+}
+
+func (ib *impactBuilder) addWorkload(namespace, name string, reasons ...string) {
+	addImpactedEntity(&ib.impact.Workloads, ib.workloads, namespace, name, reasons...)
+}
+
+func (ib *impactBuilder) addGateway(gateway kubernetes.IstioObject) {
+	addImpactedEntity(&ib.impact.Gateways, ib.gateways, gateway.GetObjectMeta().Namespace, gateway.GetObjectMeta().Name, models.ImpactReasonGateway)
+}
+
+func (ib *impactBuilder) addServices(services []core_v1.Service, reasons ...string) {
+	for _, svc := range services {
+		addImpactedEntity(&ib.impact.Services, ib.services, svc.Namespace, svc.Name, reasons...)
+	}
+}
+
+// addSelectedWorkloads adds the workloads matched by the selector of an object. Objects without a selector apply to
+// every workload of their namespace, or of the mesh when they belong to the Istio root namespace, except Gateways
+// and ServiceEntries which then don't select any workload.
+func (ib *impactBuilder) addSelectedWorkloads(data referenceData, resourceType string, object kubernetes.IstioObject) {
+	selectorLabels, _ := workloadSelector(resourceType, object)
+	if len(selectorLabels) == 0 && (resourceType == kubernetes.Gateways || resourceType == kubernetes.ServiceEntries) {
+		return
+	}
+	selector := labels.SelectorFromSet(selectorLabels)
+	for _, ns := range data.namespaces {
+		if !selectsNamespace(resourceType, object.GetObjectMeta().Namespace, ns) {
+			continue
+		}
+		for _, wl := range data.workloads[ns] {
+			if selector.Matches(labels.Set(wl.Labels)) {
+				ib.addWorkload(ns, wl.Name, selectorImpactReason(resourceType, object, ns))
+			}
+		}
+	}
+}
+
+// addClients adds the workloads that can reach the hosts of an object: the object is exported to their namespace
+// and their Sidecar imports at least one of the hosts.
+func (ib *impactBuilder) addClients(data referenceData, object kubernetes.IstioObject, hosts []kubernetes.Host) {
+	if len(hosts) == 0 {
+		return
+	}
+	for _, ns := range data.namespaces {
+		if !isExportedTo(object, ns) {
+			continue
+		}
+		for _, wl := range data.workloads[ns] {
+			if data.importsHosts(data.sidecarFor(ns, wl.Labels), ns, object.GetObjectMeta().Namespace, hosts) {
+				ib.addWorkload(ns, wl.Name, models.ImpactReasonClient)
+			}
+		}
+	}
+}
+
+// addRelatedEntities completes the impact with the entities derived from the ones already found: the workloads behind
+// the affected services and gateways, the services in front of the affected workloads and the pods of the workloads.
+func (ib *impactBuilder) addRelatedEntities(data referenceData) {
+	for _, gw := range ib.impact.Gateways {
+		for _, g := range data.gateways {
+			if g.GetObjectMeta().Name != gw.Name || g.GetObjectMeta().Namespace != gw.Namespace {
+				continue
+			}
+			selectorLabels, _ := workloadSelector(kubernetes.Gateways, g)
+			if len(selectorLabels) == 0 {
+				continue
+			}
+			selector := labels.SelectorFromSet(selectorLabels)
+			for _, ns := range data.namespaces {
+				for _, wl := range data.workloads[ns] {
+					if selector.Matches(labels.Set(wl.Labels)) {
+						ib.addWorkload(ns, wl.Name, models.ImpactReasonGateway)
+					}
+				}
+			}
+		}
+	}
+
+	// Workloads found so far are selected by the object, their services are affected for the same reasons
+	selected := len(ib.impact.Workloads)
+	for i := 0; i < selected; i++ {
+		wl := ib.impact.Workloads[i]
+		if checkType(wl.Reasons, models.ImpactReasonClient) || checkType(wl.Reasons, models.ImpactReasonGateway) {
+			continue
+		}
+		for _, w := range data.workloads[wl.Namespace] {
+			if w.Name != wl.Name {
+				continue
+			}
+			for _, svc := range data.services[wl.Namespace] {
+				if len(svc.Spec.Selector) > 0 && labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(w.Labels)) {
+					ib.addServices([]core_v1.Service{svc}, wl.Reasons...)
+				}
+			}
+		}
+	}
+
+	// Services routed by the object, their workloads receive the traffic
+	for _, svc := range ib.impact.Services {
+		if !checkType(svc.Reasons, models.ImpactReasonHost) && !checkType(svc.Reasons, models.ImpactReasonGateway) {
+			continue
+		}
+		for _, s := range data.services[svc.Namespace] {
+			if s.Name != svc.Name || len(s.Spec.Selector) == 0 {
+				continue
+			}
+			selector := labels.SelectorFromSet(s.Spec.Selector)
+			for _, wl := range data.workloads[svc.Namespace] {
+				if selector.Matches(labels.Set(wl.Labels)) {
+					ib.addWorkload(svc.Namespace, wl.Name, models.ImpactReasonBackend)
+				}
+			}
+		}
+	}
+
+	for _, wl := range ib.impact.Workloads {
+		for _, w := range data.workloads[wl.Namespace] {
+			if w.Name != wl.Name {
+				continue
+			}
+			for _, pod := range w.Pods {
+				ib.impact.Pods = append(ib.impact.Pods, models.ImpactedPod{Name: pod.Name, Namespace: wl.Namespace, Workload: wl.Name})
+			}
+		}
+	}
+}
+
+// addRequestRates fills the current request rates of the affected workloads and services. Rates are fetched once per
+// namespace, and an entity only aggregates the rates of its own namespace so a request between two affected
+// namespaces is not counted twice. Prometheus errors are logged and leave the rates of the namespace empty.
+func (ib *impactBuilder) addRequestRates(getRates func(namespace, ratesInterval string, queryTime time.Time) (model.Vector, error), rateInterval string, queryTime time.Time) {
+	rates := map[string]model.Vector{}
+	fetch := func(namespace string) (model.Vector, bool) {
+		if r, ok := rates[namespace]; ok {
+			return r, r != nil
+		}
+		r, err := getRates(namespace, rateInterval, queryTime)
+		if err != nil {
+			log.Errorf("Error fetching request rates of namespace %s for impact analysis: %s", namespace, err)
+			r = nil
+		} else if r == nil {
+			r = model.Vector{}
+		}
+		rates[namespace] = r
+		return r, r != nil
+	}
+
+	lblDestSvc := model.LabelName("destination_service_name")
+	lblDestSvcNs := model.LabelName("destination_service_namespace")
+	lblDestWl := model.LabelName("destination_workload")
+	lblDestWlNs := model.LabelName("destination_workload_namespace")
+	lblSrcWl := model.LabelName("source_workload")
+	lblSrcWlNs := model.LabelName("source_workload_namespace")
+
+	for i, svc := range ib.impact.Services {
+		if vector, ok := fetch(svc.Namespace); ok {
+			requests := models.NewEmptyRequestHealth()
+			for _, sample := range vector {
+				if string(sample.Metric[lblDestSvc]) == svc.Name && string(sample.Metric[lblDestSvcNs]) == svc.Namespace {
+					requests.AggregateInbound(sample)
+				}
+			}
+			ib.impact.Services[i].Requests = &requests
+		}
+	}
+	for i, wl := range ib.impact.Workloads {
+		if vector, ok := fetch(wl.Namespace); ok {
+			requests := models.NewEmptyRequestHealth()
+			for _, sample := range vector {
+				if string(sample.Metric[lblDestWl]) == wl.Name && string(sample.Metric[lblDestWlNs]) == wl.Namespace {
+					requests.AggregateInbound(sample)
+				}
+				if string(sample.Metric[lblSrcWl]) == wl.Name && string(sample.Metric[lblSrcWlNs]) == wl.Namespace {
+					requests.AggregateOutbound(sample)
+				}
+			}
+			ib.impact.Workloads[i].Requests = &requests
+		}
+	}
+}
+
+// objectHosts returns the hosts of an object, held in a string or list field of its spec
+func (rd referenceData) objectHosts(object kubernetes.IstioObject, field string) []kubernetes.Host {
+	hosts := []kubernetes.Host{}
+	namespace := object.GetObjectMeta().Namespace
+	switch value := object.GetSpec()[field].(type) {
+	case string:
+		hosts = append(hosts, rd.getHost(value, namespace))
+	case []interface{}:
+		for _, h := range value {
+			if host, ok := h.(string); ok {
+				hosts = append(hosts, rd.getHost(host, namespace))
+			}
+		}
+	}
+	return hosts
+}
+
+// destinationHosts returns the route destination hosts of a VirtualService
+func (rd referenceData) destinationHosts(vs kubernetes.IstioObject) []kubernetes.Host {
+	hosts := []kubernetes.Host{}
+	for _, routeType := range virtualServiceRouteTypes {
+		routes, _ := vs.GetSpec()[routeType].([]interface{})
+		for _, r := range routes {
+			route, _ := r.(map[string]interface{})
+			destinations, _ := route["route"].([]interface{})
+			for _, d := range destinations {
+				destinationWeight, _ := d.(map[string]interface{})
+				destination, _ := destinationWeight["destination"].(map[string]interface{})
+				if host, ok := destination["host"].(string); ok {
+					hosts = append(hosts, rd.getHost(host, vs.GetObjectMeta().Namespace))
+				}
+			}
+		}
+	}
+	return hosts
+}
+
+// hostsServices returns the services matching any of the hosts
+func (rd referenceData) hostsServices(hosts []kubernetes.Host) []core_v1.Service {
+	services := []core_v1.Service{}
+	for _, ns := range rd.namespaces {
+		for _, svc := range rd.services[ns] {
+			svcHost := rd.getHost(svc.Name, svc.Namespace)
+			for _, h := range hosts {
+				if kubernetes.HostsMatch(h, svcHost) {
+					services = append(services, svc)
+					break
+				}
+			}
+		}
+	}
+	return services
+}
+
+// sidecarFor returns the Sidecar applied to a workload: the one of its namespace selecting it, or else the default
+// Sidecar of its namespace, or else the default Sidecar of the Istio root namespace. It returns nil when there is none.
+func (rd referenceData) sidecarFor(namespace string, workloadLabels map[string]string) kubernetes.IstioObject {
+	var namespaceDefault, rootDefault kubernetes.IstioObject
+	for _, sc := range rd.sidecars {
+		selectorLabels := common.GetWorkloadSelectorLabels(sc)
+		switch sc.GetObjectMeta().Namespace {
+		case namespace:
+			if len(selectorLabels) == 0 {
+				if namespaceDefault == nil {
+					namespaceDefault = sc
+				}
+			} else if labels.SelectorFromSet(selectorLabels).Matches(labels.Set(workloadLabels)) {
+				return sc
+			}
+		case config.Get().IstioNamespace:
+			if len(selectorLabels) == 0 && rootDefault == nil {
+				rootDefault = sc
+			}
+		}
+	}
+	if namespaceDefault != nil {
+		return namespaceDefault
+	}
+	return rootDefault
+}
+
+// importsHosts returns true when a Sidecar applied to a workload of a namespace imports any of the hosts defined in
+// another namespace. Workloads without Sidecar import every host.
+func (rd referenceData) importsHosts(sidecar kubernetes.IstioObject, namespace, hostsNamespace string, hosts []kubernetes.Host) bool {
+	if sidecar == nil {
+		return true
+	}
+	egress, ok := sidecar.GetSpec()["egress"].([]interface{})
+	if !ok {
+		return true
+	}
+	for _, e := range egress {
+		listener, _ := e.(map[string]interface{})
+		egressHosts, _ := listener["hosts"].([]interface{})
+		for _, eh := range egressHosts {
+			egressHost, _ := eh.(string)
+			parts := strings.SplitN(egressHost, "/", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			if parts[0] != "*" && parts[0] != hostsNamespace && !(parts[0] == "." && hostsNamespace == namespace) {
+				continue
+			}
+			if parts[1] == "*" {
+				return true
+			}
+			dnsHost := rd.getHost(parts[1], namespace)
+			for _, h := range hosts {
+				if kubernetes.HostsMatch(h, dnsHost) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isExportedTo returns true when an object is visible from a namespace according to its exportTo field
+func isExportedTo(object kubernetes.IstioObject, namespace string) bool {
+	exportTo, ok := object.GetSpec()["exportTo"].([]interface{})
+	if !ok || len(exportTo) == 0 {
+		return true
+	}
+	for _, e := range exportTo {
+		switch e {
+		case "*", namespace:
+			return true
+		case ".":
+			if object.GetObjectMeta().Namespace == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// selectorImpactReason returns why a workload of a namespace is affected by an object selecting workloads
+func selectorImpactReason(resourceType string, object kubernetes.IstioObject, namespace string) string {
+	if selectorLabels, _ := workloadSelector(resourceType, object); len(selectorLabels) > 0 {
+		return models.ImpactReasonSelector
+	}
+	if object.GetObjectMeta().Namespace == namespace {
+		return models.ImpactReasonNamespace
+	}
+	return models.ImpactReasonMesh
+}
+
+func sameObject(o1, o2 kubernetes.IstioObject) bool {
+	return o1.GetObjectMeta().Name == o2.GetObjectMeta().Name && o1.GetObjectMeta().Namespace == o2.GetObjectMeta().Namespace
+}
+
+// replaceIstioObject returns the objects with the given one replacing the object of the same name, or added when there is none
+func replaceIstioObject(objects []kubernetes.IstioObject, object kubernetes.IstioObject) []kubernetes.IstioObject {
+	replaced := make([]kubernetes.IstioObject, 0, len(objects)+1)
+	for _, o := range objects {
+		if !sameObject(o, object) {
+			replaced = append(replaced, o)
+		}
+	}
+	return append(replaced, object)
+}
@@ -0,0 +1,134 @@
+package business
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+const impactObjects = `
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: reviews
+spec:
+  host: reviews
+  trafficPolicy:
+    tls:
+      mode: ISTIO_MUTUAL
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: Sidecar
+metadata:
+  name: reviews-v2
+spec:
+  workloadSelector:
+    labels:
+      version: v2
+  egress:
+  - hosts:
+    - "./ratings.bookinfo.svc.cluster.local"
+`
+
+func TestGetIstioConfigImpactDestinationRule(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, reviewsPods+"---\n"+impactObjects)
+
+	queryTime := time.Date(2021, 06, 01, 0, 0, 0, 0, time.UTC)
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", "bookinfo", "1m", queryTime).Return(model.Vector{
+		&model.Sample{
+			Metric: model.Metric{
+				"destination_service_name":       "reviews",
+				"destination_service_namespace":  "bookinfo",
+				"destination_workload":           "reviews-v1-545db77b95-1",
+				"destination_workload_namespace": "bookinfo",
+				"source_workload":                "productpage-v1",
+				"source_workload_namespace":      "bookinfo",
+				"request_protocol":               "http",
+				"response_code":                  "200",
+			},
+			Value: 2,
+		},
+	}, nil)
+	layer.IstioConfig.prom = prom
+
+	impact, err := layer.IstioConfig.GetIstioConfigImpact("bookinfo", kubernetes.DestinationRules, "reviews", "1m", queryTime)
+	assert.NoError(err)
+	assert.Equal(models.IstioReference{ObjectType: "destinationrule", Name: "reviews", Namespace: "bookinfo"}, impact.IstioReference)
+
+	assert.Len(impact.Services, 1)
+	assert.Equal("reviews", impact.Services[0].Name)
+	assert.Equal([]string{models.ImpactReasonHost}, impact.Services[0].Reasons)
+	assert.Equal(2.0, impact.Services[0].Requests.Inbound["http"]["200"])
+
+	// The Sidecar of the v2 workloads doesn't import the reviews host, they are only affected as backends
+	reasons := map[string][]string{}
+	for _, wl := range impact.Workloads {
+		reasons[wl.Name] = wl.Reasons
+	}
+	assert.Equal(map[string][]string{
+		"reviews-v1-545db77b95-1": {models.ImpactReasonClient, models.ImpactReasonBackend},
+		"reviews-v2-7bf8c9648f-1": {models.ImpactReasonBackend},
+		"reviews-v2-7bf8c9648f-2": {models.ImpactReasonBackend},
+	}, reasons)
+	assert.Len(impact.Pods, 3)
+	assert.Empty(impact.Gateways)
+	for _, wl := range impact.Workloads {
+		if wl.Name == "reviews-v1-545db77b95-1" {
+			assert.Equal(2.0, wl.Requests.Inbound["http"]["200"])
+		} else {
+			assert.Empty(wl.Requests.Inbound)
+		}
+	}
+	prom.AssertNumberOfCalls(t, "GetAllRequestRates", 1)
+}
+
+func TestGetProposedIstioConfigImpactAuthorizationPolicy(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, reviewsPods)
+
+	queryTime := time.Date(2021, 06, 01, 0, 0, 0, 0, time.UTC)
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", "bookinfo", "1m", queryTime).Return(model.Vector{}, errors2.NewServiceUnavailable("prometheus is down"))
+	layer.IstioConfig.prom = prom
+
+	impact, err := layer.IstioConfig.GetProposedIstioConfigImpact("bookinfo", kubernetes.AuthorizationPolicies,
+		[]byte(`{"metadata": {"name": "deny-v1"}, "spec": {"selector": {"matchLabels": {"version": "v1"}}, "action": "DENY", "rules": [{}]}}`), "1m", queryTime)
+	assert.NoError(err)
+	assert.Equal("deny-v1", impact.Name)
+	assert.Len(impact.Workloads, 1)
+	assert.Equal("reviews-v1-545db77b95-1", impact.Workloads[0].Name)
+	assert.Equal([]string{models.ImpactReasonSelector}, impact.Workloads[0].Reasons)
+	assert.Nil(impact.Workloads[0].Requests)
+	assert.Equal([]models.ImpactedPod{{Name: "reviews-v1-545db77b95-1", Namespace: "bookinfo", Workload: "reviews-v1-545db77b95-1"}}, impact.Pods)
+	assert.Len(impact.Services, 1)
+	assert.Equal([]string{models.ImpactReasonSelector}, impact.Services[0].Reasons)
+
+	// Without selector, the policy applies to the whole namespace
+	impact, err = layer.IstioConfig.GetProposedIstioConfigImpact("bookinfo", kubernetes.AuthorizationPolicies,
+		[]byte(`{"metadata": {"name": "deny-all"}, "spec": {}}`), "1m", queryTime)
+	assert.NoError(err)
+	assert.Len(impact.Workloads, 3)
+	for _, wl := range impact.Workloads {
+		assert.Equal([]string{models.ImpactReasonNamespace}, wl.Reasons)
+	}
+}
+
+func TestGetIstioConfigImpactErrors(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, "")
+
+	_, err := layer.IstioConfig.GetIstioConfigImpact("bookinfo", kubernetes.DestinationRules, "reviews", "1m", time.Now())
+	assert.True(errors2.IsNotFound(err))
+
+	_, err = layer.IstioConfig.GetProposedIstioConfigImpact("bookinfo", kubernetes.K8sGateways, []byte(`{"metadata": {"name": "gw"}, "spec": {}}`), "1m", time.Now())
+	assert.True(errors2.IsBadRequest(err))
+}
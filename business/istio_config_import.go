@@ -0,0 +1,276 @@
+package business
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+	"github.com/kiali/kiali/util"
+)
+
+// Objects are imported in this order, so the objects referenced by others already exist when these are applied.
+// Types not listed are imported last, in the order of the bundle.
+var importOrder = map[string]int{
+	kubernetes.Gateways:         0,
+	kubernetes.ServiceEntries:   1,
+	kubernetes.DestinationRules: 2,
+	kubernetes.VirtualServices:  3,
+	kubernetes.K8sGateways:      4,
+}
+
+// importDocument is an Istio object parsed from a document of a bundle
+type importDocument struct {
+	result  models.IstioConfigImportResult
+	api     string
+	body    []byte
+	object  kubernetes.IstioObject
+	current kubernetes.IstioObject
+}
+
+// ImportIstioConfig creates or updates in the namespace the Istio objects of a multi-document YAML or JSON bundle.
+// Every document is validated first, and nothing is applied when any of them is invalid. Objects are applied in
+// dependency order, stopping at the first failure. Existing objects get the spec, labels and annotations of the
+// bundle. In dryRun mode, the objects are sent to Kubernetes in dry-run mode and nothing is persisted.
+func (in *IstioConfigService) ImportIstioConfig(namespace string, bundle io.Reader, dryRun bool, user string) (models.IstioConfigImport, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "ImportIstioConfig")
+	defer promtimer.ObserveNow(&err)
+
+	istioConfigImport := models.IstioConfigImport{DryRun: dryRun, Objects: []models.IstioConfigImportResult{}}
+	documents, err := parseImportBundle(namespace, bundle)
+	if err != nil {
+		return istioConfigImport, errors2.NewBadRequest("Bundle could not be parsed: " + err.Error())
+	}
+	if len(documents) == 0 {
+		return istioConfigImport, errors2.NewBadRequest("Bundle has no documents")
+	}
+
+	valid := true
+	changes := map[string][]kubernetes.IstioObject{}
+	for _, d := range documents {
+		if d.result.Status == models.ImportStatusInvalid {
+			valid = false
+			continue
+		}
+		current, err := in.k8s.GetIstioObject(namespace, d.result.ObjectType, d.result.Name)
+		switch {
+		case err == nil:
+			d.current = current
+			d.result.Operation = models.RevisionUpdate
+		case errors2.IsNotFound(err):
+			d.result.Operation = models.RevisionCreate
+		default:
+			return istioConfigImport, err
+		}
+		changes[d.result.ObjectType] = append(changes[d.result.ObjectType], d.object)
+	}
+
+	sort.SliceStable(documents, func(i, j int) bool {
+		return importRank(documents[i].result.ObjectType) < importRank(documents[j].result.ObjectType)
+	})
+
+	if len(changes) > 0 {
+		validations, err := in.businessLayer.Validations.GetValidationsWithChanges(namespace, changes)
+		if err != nil {
+			return istioConfigImport, err
+		}
+		istioConfigImport.Validations = validations
+		for _, d := range documents {
+			if d.object != nil {
+				d.result.Validation = validations[models.BuildKey(models.ObjectTypeSingular[d.result.ObjectType], d.result.Name, namespace)]
+			}
+		}
+	}
+
+	failed := !valid
+	applied := false
+	for _, d := range documents {
+		switch {
+		case d.result.Status == models.ImportStatusInvalid:
+			continue
+		case dryRun:
+			in.dryRunImportDocument(namespace, d)
+		case failed:
+			d.result.Status = models.ImportStatusSkipped
+			d.result.Message = "Not applied, as another object of the bundle is invalid or failed"
+		default:
+			in.applyImportDocument(namespace, d, user)
+			if d.result.Status == models.ImportStatusFailed {
+				failed = true
+			} else {
+				applied = true
+			}
+		}
+	}
+
+	// Cache is stopped after a Create/Update/Delete operation to force a refresh
+	if kialiCache != nil && applied {
+		kialiCache.RefreshNamespace(namespace)
+	}
+
+	istioConfigImport.Applied = !dryRun && !failed
+	for _, d := range documents {
+		istioConfigImport.Objects = append(istioConfigImport.Objects, d.result)
+	}
+	return istioConfigImport, nil
+}
+
+func (in *IstioConfigService) dryRunImportDocument(namespace string, d *importDocument) {
+	var serverErr error
+	if d.current == nil {
+		_, serverErr = in.k8s.DryRunCreateIstioObject(d.api, namespace, d.result.ObjectType, string(d.body))
+	} else {
+		var patch []byte
+		if patch, serverErr = json.Marshal(replacePatch(toUnstructured(d.current), toUnstructured(d.object))); serverErr == nil {
+			_, serverErr = in.k8s.DryRunUpdateIstioObject(d.api, namespace, d.result.ObjectType, d.result.Name, string(patch))
+		}
+	}
+	serverDryRun := newServerDryRun(serverErr)
+	d.result.ServerDryRun = &serverDryRun
+	if serverErr != nil {
+		d.result.Status = models.ImportStatusRejected
+		d.result.Message = serverErr.Error()
+	} else {
+		d.result.Status = models.ImportStatusReady
+	}
+}
+
+func (in *IstioConfigService) applyImportDocument(namespace string, d *importDocument, user string) {
+	var result kubernetes.IstioObject
+	var err error
+	if d.current == nil {
+		result, err = in.k8s.CreateIstioObject(d.api, namespace, d.result.ObjectType, string(d.body))
+	} else {
+		var patch []byte
+		if patch, err = json.Marshal(replacePatch(toUnstructured(d.current), toUnstructured(d.object))); err == nil {
+			result, err = in.k8s.UpdateIstioObject(d.api, namespace, d.result.ObjectType, d.result.Name, string(patch))
+		}
+	}
+	if err != nil {
+		d.result.Status = models.ImportStatusFailed
+		d.result.Message = err.Error()
+		return
+	}
+	if d.current == nil {
+		d.result.Status = models.ImportStatusCreated
+	} else {
+		d.result.Status = models.ImportStatusUpdated
+	}
+	in.recordRevision(d.result.Operation, namespace, d.result.ObjectType, d.result.Name, user, d.current, result, 0)
+}
+
+func importRank(resourceType string) int {
+	if rank, found := importOrder[resourceType]; found {
+		return rank
+	}
+	return len(importOrder)
+}
+
+// parseImportBundle reads the documents of the bundle, unwrapping "List" documents. Documents that aren't valid
+// Istio objects of the namespace are returned with the invalid status.
+func parseImportBundle(namespace string, bundle io.Reader) ([]*importDocument, error) {
+	documents := []*importDocument{}
+	seen := map[string]int{}
+	add := func(raw []byte) {
+		d := parseImportDocument(len(documents), namespace, raw)
+		if d.result.Status != models.ImportStatusInvalid {
+			key := d.result.ObjectType + "/" + d.result.Name
+			if first, found := seen[key]; found {
+				d.result.Status = models.ImportStatusInvalid
+				d.result.Message = fmt.Sprintf("Object is duplicated, it is also defined in document %d", first)
+			} else {
+				seen[key] = d.result.Document
+			}
+		}
+		documents = append(documents, d)
+	}
+
+	decoder := yaml.NewYAMLOrJSONDecoder(bundle, 4096)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return documents, nil
+			}
+			return nil, err
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		list := struct {
+			Kind  string            `json:"kind"`
+			Items []json.RawMessage `json:"items"`
+		}{}
+		if err := json.Unmarshal(raw, &list); err == nil && list.Kind == "List" {
+			for _, item := range list.Items {
+				add(item)
+			}
+			continue
+		}
+		add(raw)
+	}
+}
+
+// parseImportDocument validates a document against the scheme of its type and returns it ready to be applied in
+// the namespace, without the fields managed by the server
+func parseImportDocument(index int, namespace string, raw []byte) *importDocument {
+	d := &importDocument{result: models.IstioConfigImportResult{Document: index, Namespace: namespace}}
+	invalid := func(message string) *importDocument {
+		d.result.Status = models.ImportStatusInvalid
+		d.result.Message = message
+		return d
+	}
+
+	generic := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return invalid("Document is not an object: " + err.Error())
+	}
+	typeMeta := meta_v1.TypeMeta{}
+	typeMeta.APIVersion, _ = generic["apiVersion"].(string)
+	typeMeta.Kind, _ = generic["kind"].(string)
+	resourceType, found := kubernetes.IstioResourceType(typeMeta)
+	if !found {
+		return invalid(fmt.Sprintf("Kind %s of %s is not an Istio networking or security object", typeMeta.Kind, typeMeta.APIVersion))
+	}
+	if kubernetes.IsClusterScoped(resourceType) {
+		return invalid(fmt.Sprintf("Kind %s of %s is cluster scoped, it can't be imported in a namespace", typeMeta.Kind, typeMeta.APIVersion))
+	}
+	d.result.ObjectType = resourceType
+
+	metadata, _ := generic["metadata"].(map[string]interface{})
+	d.result.Name, _ = metadata["name"].(string)
+	if d.result.Name == "" {
+		return invalid("metadata.name is required")
+	}
+	if objectNamespace, _ := metadata["namespace"].(string); objectNamespace != "" && objectNamespace != namespace {
+		return invalid(fmt.Sprintf("Object belongs to namespace %s, not to %s", objectNamespace, namespace))
+	}
+	if _, err := parseIstioConfigModel(resourceType, raw); err != nil {
+		return invalid(err.Error())
+	}
+
+	generic = withoutServerManagedFields(generic)
+	util.RemoveNilValues(generic)
+	d.api = kubernetes.ResourceTypesToAPI[resourceType]
+	generic["apiVersion"] = kubernetes.ApiToVersion[d.api]
+	generic["metadata"].(map[string]interface{})["namespace"] = namespace
+
+	var err error
+	if d.body, err = json.Marshal(generic); err != nil {
+		return invalid(err.Error())
+	}
+	object := &kubernetes.GenericIstioObject{}
+	if err = json.Unmarshal(d.body, object); err != nil {
+		return invalid(err.Error())
+	}
+	d.object = object
+	return d
+}
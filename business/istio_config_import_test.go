@@ -0,0 +1,230 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const bookinfoBundle = `
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: reviews
+  resourceVersion: "1234"
+  uid: 1e0c2b8a-6c5e-4b43-9d8e-3b1a5f0e6d7c
+spec:
+  hosts: [reviews]
+  gateways: [bookinfo-gateway]
+  http:
+  - route:
+    - destination:
+        host: reviews
+        subset: v1
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: reviews
+spec:
+  host: reviews
+  subsets:
+  - name: v1
+    labels:
+      version: v1
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: networking.istio.io/v1alpha3
+  kind: Gateway
+  metadata:
+    name: bookinfo-gateway
+  spec:
+    selector:
+      istio: ingressgateway
+    servers:
+    - port:
+        number: 80
+        name: http
+        protocol: HTTP
+      hosts: ["*"]
+- apiVersion: networking.istio.io/v1alpha3
+  kind: ServiceEntry
+  metadata:
+    name: external-api
+  spec:
+    hosts: [api.example.com]
+    ports:
+    - number: 443
+      name: https
+      protocol: HTTPS
+    resolution: DNS
+    location: MESH_EXTERNAL
+`
+
+func fakeImportLayer(t *testing.T, objects string) (*Layer, *kubernetes.MemoryClient) {
+	conf := config.NewConfig()
+	config.Set(conf)
+	k8s := kubernetes.NewMemoryClient()
+	k8s.AddConfigMap(core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: conf.ExternalServices.Istio.ConfigMapName, Namespace: conf.IstioNamespace}})
+	err := k8s.LoadYAML(strings.NewReader(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: bookinfo
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: reviews
+spec:
+  selector:
+    app: reviews
+  ports:
+  - name: http
+    port: 9080
+---
+`+objects), "bookinfo")
+	assert.NoError(t, err)
+	layer := NewWithBackends(k8s, nil, nil)
+	layer.IstioConfig.history = NewMemoryHistoryStore(10)
+	return layer, k8s
+}
+
+func TestImportIstioConfigDryRun(t *testing.T) {
+	assert := assert.New(t)
+	layer, k8s := fakeImportLayer(t, "")
+
+	result, err := layer.IstioConfig.ImportIstioConfig("bookinfo", strings.NewReader(bookinfoBundle), true, "alice")
+	assert.NoError(err)
+	assert.True(result.DryRun)
+	assert.False(result.Applied)
+	assert.Len(result.Objects, 4)
+
+	// Applied in dependency order
+	types := []string{}
+	for _, o := range result.Objects {
+		types = append(types, o.ObjectType)
+		assert.Equal(models.ImportStatusReady, o.Status)
+		assert.Equal(models.RevisionCreate, o.Operation)
+		assert.True(o.ServerDryRun.Accepted)
+		assert.NotNil(o.Validation)
+	}
+	assert.Equal([]string{kubernetes.Gateways, kubernetes.ServiceEntries, kubernetes.DestinationRules, kubernetes.VirtualServices}, types)
+	assert.Equal(0, result.Objects[3].Document)
+	assert.Equal(2, result.Objects[0].Document)
+
+	// The virtual service refers to the gateway and the subset of the bundle
+	assert.True(result.Objects[3].Validation.Valid)
+
+	vss, err := k8s.GetIstioObjects("bookinfo", kubernetes.VirtualServices, "")
+	assert.NoError(err)
+	assert.Empty(vss)
+}
+
+func TestImportIstioConfig(t *testing.T) {
+	assert := assert.New(t)
+	layer, k8s := fakeImportLayer(t, `
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+  labels:
+    owner: team-a
+spec:
+  hosts: [reviews]
+  http:
+  - route:
+    - destination:
+        host: reviews
+    retries:
+      attempts: 3
+`)
+
+	result, err := layer.IstioConfig.ImportIstioConfig("bookinfo", strings.NewReader(bookinfoBundle), false, "alice")
+	assert.NoError(err)
+	assert.True(result.Applied)
+	for _, o := range result.Objects {
+		if o.ObjectType == kubernetes.VirtualServices {
+			assert.Equal(models.ImportStatusUpdated, o.Status)
+		} else {
+			assert.Equal(models.ImportStatusCreated, o.Status)
+		}
+	}
+
+	// The existing object gets the spec and labels of the bundle
+	vs, err := k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.NoError(err)
+	assert.Empty(vs.GetObjectMeta().Labels)
+	assert.Contains(vs.GetSpec(), "gateways")
+	assert.NotContains(vs.GetSpec()["http"].([]interface{})[0], "retries")
+
+	gw, err := k8s.GetIstioObject("bookinfo", kubernetes.Gateways, "bookinfo-gateway")
+	assert.NoError(err)
+	assert.Equal("bookinfo", gw.GetObjectMeta().Namespace)
+
+	history, err := layer.IstioConfig.GetIstioConfigHistory("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.NoError(err)
+	assert.Len(history, 1)
+	assert.Equal(models.RevisionUpdate, history[0].Operation)
+	assert.Equal("alice", history[0].User)
+}
+
+func TestImportIstioConfigInvalid(t *testing.T) {
+	assert := assert.New(t)
+	layer, k8s := fakeImportLayer(t, "")
+
+	bundle := bookinfoBundle + `
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: reviews
+spec:
+  host: reviews
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: ratings
+  namespace: default
+spec:
+  host: ratings
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: reviews
+`
+	result, err := layer.IstioConfig.ImportIstioConfig("bookinfo", strings.NewReader(bundle), false, "alice")
+	assert.NoError(err)
+	assert.False(result.Applied)
+	statuses := map[int]string{}
+	for _, o := range result.Objects {
+		statuses[o.Document] = o.Status
+	}
+	assert.Equal(map[int]string{
+		0: models.ImportStatusSkipped,
+		1: models.ImportStatusSkipped,
+		2: models.ImportStatusSkipped,
+		3: models.ImportStatusSkipped,
+		4: models.ImportStatusInvalid,
+		5: models.ImportStatusInvalid,
+		6: models.ImportStatusInvalid,
+	}, statuses)
+
+	gws, err := k8s.GetIstioObjects("bookinfo", kubernetes.Gateways, "")
+	assert.NoError(err)
+	assert.Empty(gws)
+
+	_, err = layer.IstioConfig.ImportIstioConfig("bookinfo", strings.NewReader("spec: [unclosed"), false, "alice")
+	assert.Error(err)
+}
@@ -0,0 +1,314 @@
+package business
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// Fields holding hosts in the spec of the Istio and Gateway API objects. Gateway servers and Sidecar egress listeners
+// prefix their hosts with a namespace, i.e. bookinfo/reviews.bookinfo.svc.cluster.local
+var hostFields = map[string]bool{"host": true, "hosts": true, "hostname": true, "hostnames": true}
+
+var fieldPathSegment = regexp.MustCompile(`^([^\[\]]+)(\[(\d+)\])?$`)
+
+// IstioConfigSearchCriteria selects Istio objects of all the accessible namespaces. Every criterion set must match.
+type IstioConfigSearchCriteria struct {
+	// Object types, label selector and workload selector, as in the Istio config list
+	IstioConfigCriteria
+	// Host used by the objects: FQDN, namespace qualified, short name or wildcard
+	Host string
+	// Field paths the objects must have
+	Fields []IstioConfigFieldCriterion
+}
+
+// IstioConfigFieldCriterion is a field path like spec/http/route[0]/destination/subset. A segment without index
+// matches any element of a list, and a "*" segment matches any key. With a Value, the field must hold it, otherwise
+// the field only has to exist.
+type IstioConfigFieldCriterion struct {
+	Path  string
+	Value *string
+}
+
+// ParseIstioConfigFieldCriterion parses a field criterion given as path or path=value
+func ParseIstioConfigFieldCriterion(field string) (IstioConfigFieldCriterion, error) {
+	criterion := IstioConfigFieldCriterion{Path: field}
+	if i := strings.Index(field, "="); i >= 0 {
+		value := field[i+1:]
+		criterion.Path = field[:i]
+		criterion.Value = &value
+	}
+	for _, segment := range strings.Split(criterion.Path, "/") {
+		if !fieldPathSegment.MatchString(segment) {
+			return criterion, fmt.Errorf("invalid field path %s", criterion.Path)
+		}
+	}
+	return criterion, nil
+}
+
+// SearchIstioConfig returns the Istio objects of all the accessible namespaces matching the criteria, with the
+// location of the matches, sorted by namespace, type and name.
+func (in *IstioConfigService) SearchIstioConfig(criteria IstioConfigSearchCriteria) (models.IstioConfigSearchResults, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "SearchIstioConfig")
+	defer promtimer.ObserveNow(&err)
+
+	if criteria.Host == "" && criteria.LabelSelector == "" && criteria.WorkloadSelector == "" && len(criteria.Fields) == 0 {
+		err = errors2.NewBadRequest("at least one of host, labelSelector, workloadSelector or field is required")
+		return nil, err
+	}
+	var labelSelector labels.Selector
+	if criteria.LabelSelector != "" {
+		if labelSelector, err = labels.Parse(criteria.LabelSelector); err != nil {
+			return nil, errors2.NewBadRequest(err.Error())
+		}
+	}
+
+	nss, err := in.businessLayer.Namespace.GetNamespaces()
+	if err != nil {
+		return nil, err
+	}
+	namespaces := make([]string, 0, len(nss))
+	for _, ns := range nss {
+		namespaces = append(namespaces, ns.Name)
+	}
+
+	objects := make([][][]kubernetes.IstioObject, len(namespaces))
+	wg := sync.WaitGroup{}
+	errChan := make(chan error, 1)
+	for j, namespace := range namespaces {
+		objects[j] = make([][]kubernetes.IstioObject, len(exportTypes))
+		for i, resourceType := range exportTypes {
+			if !criteria.Include(resourceType) {
+				continue
+			}
+			wg.Add(1)
+			go func(i, j int, namespace, resourceType string) {
+				defer wg.Done()
+				var err error
+				if IsResourceCached(namespace, resourceType) {
+					objects[j][i], err = kialiCache.GetIstioObjects(namespace, resourceType, criteria.LabelSelector)
+				} else {
+					objects[j][i], err = in.k8s.GetIstioObjects(namespace, resourceType, criteria.LabelSelector)
+				}
+				if err != nil {
+					select {
+					case errChan <- err:
+					default:
+					}
+					return
+				}
+				if criteria.WorkloadSelector != "" {
+					objects[j][i] = kubernetes.FilterIstioObjectsForWorkloadSelector(criteria.WorkloadSelector, objects[j][i])
+				}
+			}(i, j, namespace, resourceType)
+		}
+	}
+	wg.Wait()
+	close(errChan)
+	for e := range errChan {
+		if e != nil {
+			err = e
+			return nil, err
+		}
+	}
+
+	results := models.IstioConfigSearchResults{}
+	for j := range namespaces {
+		for i, resourceType := range exportTypes {
+			for _, object := range objects[j][i] {
+				matches, ok := matchIstioObject(criteria, labelSelector, namespaces, resourceType, object)
+				if !ok {
+					continue
+				}
+				results = append(results, models.IstioConfigSearchResult{
+					IstioReference: istioReference(resourceType, object, ""),
+					Matches:        matches,
+				})
+			}
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Namespace != results[j].Namespace {
+			return results[i].Namespace < results[j].Namespace
+		}
+		if results[i].ObjectType != results[j].ObjectType {
+			return results[i].ObjectType < results[j].ObjectType
+		}
+		return results[i].Name < results[j].Name
+	})
+	return results, nil
+}
+
+// matchIstioObject returns the matches of every criterion in the object, or false when a criterion doesn't match.
+// Label and workload selectors were already applied when fetching the objects, only their location is added.
+func matchIstioObject(criteria IstioConfigSearchCriteria, labelSelector labels.Selector, namespaces []string, resourceType string, object kubernetes.IstioObject) ([]models.IstioConfigMatch, bool) {
+	matches := []models.IstioConfigMatch{}
+
+	if labelSelector != nil {
+		requirements, _ := labelSelector.Requirements()
+		objectLabels := object.GetObjectMeta().Labels
+		for _, r := range requirements {
+			if value, found := objectLabels[r.Key()]; found && r.Operator() != selection.DoesNotExist {
+				matches = append(matches, models.IstioConfigMatch{
+					Criterion: models.SearchCriterionLabelSelector,
+					Path:      "metadata/labels/" + r.Key(),
+					Value:     value,
+				})
+			}
+		}
+	}
+
+	if criteria.WorkloadSelector != "" {
+		selectorLabels, path := workloadSelector(resourceType, object)
+		match := models.IstioConfigMatch{Criterion: models.SearchCriterionWorkloadSelector, Path: path}
+		if len(selectorLabels) > 0 {
+			match.Value = selectorLabels
+		}
+		matches = append(matches, match)
+	}
+
+	if criteria.Host != "" {
+		hostMatches := searchHosts(criteria.Host, namespaces, object)
+		if len(hostMatches) == 0 {
+			return nil, false
+		}
+		matches = append(matches, hostMatches...)
+	}
+
+	if len(criteria.Fields) > 0 {
+		unstructured := toUnstructured(object)
+		for _, field := range criteria.Fields {
+			fieldMatches := searchField(unstructured, "", strings.Split(field.Path, "/"), field.Value)
+			if len(fieldMatches) == 0 {
+				return nil, false
+			}
+			matches = append(matches, fieldMatches...)
+		}
+	}
+
+	return matches, true
+}
+
+// searchHosts returns the host fields of the object matching the searched host. Hosts of the object are resolved in
+// its namespace. A searched short name matches the services of that name in any namespace.
+func searchHosts(host string, namespaces []string, object kubernetes.IstioObject) []models.IstioConfigMatch {
+	domain := config.Get().ExternalServices.Istio.IstioIdentityDomain
+	namespace := object.GetObjectMeta().Namespace
+	searched := kubernetes.GetHost(host, "", domain, namespaces)
+	shortName := !strings.Contains(host, ".")
+
+	matchHost := func(objectHost string) bool {
+		if i := strings.Index(objectHost, "/"); i >= 0 {
+			objectHost = objectHost[i+1:]
+		}
+		h := kubernetes.GetHost(objectHost, namespace, domain, namespaces)
+		if shortName {
+			return h.CompleteInput && (h.Service == searched.Service || h.Service == "*")
+		}
+		return kubernetes.HostsMatch(searched, h)
+	}
+
+	matches := []models.IstioConfigMatch{}
+	var walk func(value interface{}, path string, isHost bool)
+	walk = func(value interface{}, path string, isHost bool) {
+		switch v := value.(type) {
+		case string:
+			if isHost && matchHost(v) {
+				matches = append(matches, models.IstioConfigMatch{Criterion: models.SearchCriterionHost, Path: path, Value: v})
+			}
+		case []interface{}:
+			for i, item := range v {
+				walk(item, fmt.Sprintf("%s[%d]", path, i), isHost)
+			}
+		case map[string]interface{}:
+			for _, k := range sortedMapKeys(v) {
+				walk(v[k], path+"/"+k, hostFields[k])
+			}
+		}
+	}
+	walk(object.GetSpec(), "spec", false)
+	return matches
+}
+
+// searchField returns the fields of the value reached by the path segments, holding the expected value if any
+func searchField(value interface{}, path string, segments []string, expected *string) []models.IstioConfigMatch {
+	if list, ok := value.([]interface{}); ok {
+		// Lists without index in the path match any element
+		matches := []models.IstioConfigMatch{}
+		for i, item := range list {
+			matches = append(matches, searchField(item, fmt.Sprintf("%s[%d]", path, i), segments, expected)...)
+		}
+		return matches
+	}
+	if len(segments) == 0 {
+		if expected == nil {
+			return []models.IstioConfigMatch{{Criterion: models.SearchCriterionField, Path: path, Value: value}}
+		}
+		switch value.(type) {
+		case map[string]interface{}, nil:
+			return nil
+		}
+		if fmt.Sprint(value) == *expected {
+			return []models.IstioConfigMatch{{Criterion: models.SearchCriterionField, Path: path, Value: value}}
+		}
+		return nil
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	parts := fieldPathSegment.FindStringSubmatch(segments[0])
+	if parts == nil {
+		return nil
+	}
+	keys := []string{parts[1]}
+	if parts[1] == "*" {
+		keys = sortedMapKeys(object)
+	}
+
+	matches := []models.IstioConfigMatch{}
+	for _, key := range keys {
+		child, found := object[key]
+		if !found {
+			continue
+		}
+		childPath := key
+		if path != "" {
+			childPath = path + "/" + key
+		}
+		if parts[3] != "" {
+			index, _ := strconv.Atoi(parts[3])
+			list, ok := child.([]interface{})
+			if !ok || index >= len(list) {
+				continue
+			}
+			child = list[index]
+			childPath = fmt.Sprintf("%s[%d]", childPath, index)
+		}
+		matches = append(matches, searchField(child, childPath, segments[1:], expected)...)
+	}
+	return matches
+}
+
+func sortedMapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,170 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/models"
+)
+
+const searchObjects = `
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+  labels:
+    team: reviews
+spec:
+  hosts:
+  - reviews
+  http:
+  - route:
+    - destination:
+        host: reviews
+        subset: v1
+      weight: 80
+    - destination:
+        host: reviews
+        subset: v2
+      weight: 20
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: reviews
+  labels:
+    team: reviews
+spec:
+  host: reviews.bookinfo.svc.cluster.local
+  subsets:
+  - name: v1
+    labels:
+      version: v1
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: Gateway
+metadata:
+  name: bookinfo-gateway
+spec:
+  selector:
+    istio: ingressgateway
+  servers:
+  - port:
+      number: 80
+      name: http
+      protocol: HTTP
+    hosts:
+    - "bookinfo/*.example.com"
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: ServiceEntry
+metadata:
+  name: external-api
+spec:
+  hosts:
+  - api.example.com
+  resolution: DNS
+---
+apiVersion: security.istio.io/v1beta1
+kind: AuthorizationPolicy
+metadata:
+  name: reviews-v1
+spec:
+  selector:
+    matchLabels:
+      version: v1
+`
+
+var allTypes = ParseIstioConfigCriteria("", "", "", "")
+
+func TestSearchIstioConfigByHost(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, searchObjects)
+
+	results, err := layer.IstioConfig.SearchIstioConfig(IstioConfigSearchCriteria{IstioConfigCriteria: allTypes, Host: "reviews.bookinfo.svc.cluster.local"})
+	assert.NoError(err)
+	assert.Len(results, 2)
+	assert.Equal(models.IstioReference{ObjectType: "destinationrule", Name: "reviews", Namespace: "bookinfo"}, results[0].IstioReference)
+	assert.Equal([]models.IstioConfigMatch{{Criterion: models.SearchCriterionHost, Path: "spec/host", Value: "reviews.bookinfo.svc.cluster.local"}}, results[0].Matches)
+	assert.Equal(models.IstioReference{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo"}, results[1].IstioReference)
+	assert.Equal([]models.IstioConfigMatch{
+		{Criterion: models.SearchCriterionHost, Path: "spec/hosts[0]", Value: "reviews"},
+		{Criterion: models.SearchCriterionHost, Path: "spec/http[0]/route[0]/destination/host", Value: "reviews"},
+		{Criterion: models.SearchCriterionHost, Path: "spec/http[0]/route[1]/destination/host", Value: "reviews"},
+	}, results[1].Matches)
+
+	// Short names match the service in any namespace
+	results, err = layer.IstioConfig.SearchIstioConfig(IstioConfigSearchCriteria{IstioConfigCriteria: allTypes, Host: "reviews"})
+	assert.NoError(err)
+	assert.Len(results, 2)
+
+	// Hosts of other services don't match
+	results, err = layer.IstioConfig.SearchIstioConfig(IstioConfigSearchCriteria{IstioConfigCriteria: allTypes, Host: "ratings.bookinfo.svc.cluster.local"})
+	assert.NoError(err)
+	assert.Empty(results)
+
+	// Wildcard hosts of the objects match, the namespace prefix of Gateway hosts is ignored
+	results, err = layer.IstioConfig.SearchIstioConfig(IstioConfigSearchCriteria{IstioConfigCriteria: allTypes, Host: "api.example.com"})
+	assert.NoError(err)
+	assert.Len(results, 2)
+	assert.Equal("bookinfo-gateway", results[0].Name)
+	assert.Equal([]models.IstioConfigMatch{{Criterion: models.SearchCriterionHost, Path: "spec/servers[0]/hosts[0]", Value: "bookinfo/*.example.com"}}, results[0].Matches)
+	assert.Equal("external-api", results[1].Name)
+}
+
+func TestSearchIstioConfigByFieldsAndSelectors(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, searchObjects)
+
+	subset, err := ParseIstioConfigFieldCriterion("spec/http/route/destination/subset=v2")
+	assert.NoError(err)
+	results, err := layer.IstioConfig.SearchIstioConfig(IstioConfigSearchCriteria{IstioConfigCriteria: allTypes, Fields: []IstioConfigFieldCriterion{subset}})
+	assert.NoError(err)
+	assert.Len(results, 1)
+	assert.Equal([]models.IstioConfigMatch{{Criterion: models.SearchCriterionField, Path: "spec/http[0]/route[1]/destination/subset", Value: "v2"}}, results[0].Matches)
+
+	// All the criteria must match
+	weight, err := ParseIstioConfigFieldCriterion("spec/http[0]/route[0]/weight=80")
+	assert.NoError(err)
+	subsets, err := ParseIstioConfigFieldCriterion("spec/subsets")
+	assert.NoError(err)
+	results, err = layer.IstioConfig.SearchIstioConfig(IstioConfigSearchCriteria{
+		IstioConfigCriteria: ParseIstioConfigCriteria("", "", "team=reviews", ""),
+		Fields:              []IstioConfigFieldCriterion{weight},
+	})
+	assert.NoError(err)
+	assert.Len(results, 1)
+	assert.Equal("virtualservice", results[0].ObjectType)
+	assert.Equal([]models.IstioConfigMatch{
+		{Criterion: models.SearchCriterionLabelSelector, Path: "metadata/labels/team", Value: "reviews"},
+		{Criterion: models.SearchCriterionField, Path: "spec/http[0]/route[0]/weight", Value: float64(80)},
+	}, results[0].Matches)
+	results, err = layer.IstioConfig.SearchIstioConfig(IstioConfigSearchCriteria{
+		IstioConfigCriteria: ParseIstioConfigCriteria("", "", "team=reviews", ""),
+		Fields:              []IstioConfigFieldCriterion{weight, subsets},
+	})
+	assert.NoError(err)
+	assert.Empty(results)
+
+	results, err = layer.IstioConfig.SearchIstioConfig(IstioConfigSearchCriteria{IstioConfigCriteria: ParseIstioConfigCriteria("", "", "", "app=reviews,version=v1")})
+	assert.NoError(err)
+	assert.Len(results, 1)
+	assert.Equal("authorizationpolicy", results[0].ObjectType)
+	assert.Equal([]models.IstioConfigMatch{{Criterion: models.SearchCriterionWorkloadSelector, Path: "spec/selector/matchLabels", Value: map[string]string{"version": "v1"}}}, results[0].Matches)
+}
+
+func TestSearchIstioConfigErrors(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, "")
+
+	_, err := layer.IstioConfig.SearchIstioConfig(IstioConfigSearchCriteria{IstioConfigCriteria: allTypes})
+	assert.True(errors2.IsBadRequest(err))
+
+	_, err = layer.IstioConfig.SearchIstioConfig(IstioConfigSearchCriteria{IstioConfigCriteria: ParseIstioConfigCriteria("", "", "team in (", "")})
+	assert.True(errors2.IsBadRequest(err))
+
+	_, err = ParseIstioConfigFieldCriterion("spec/http[a]/route")
+	assert.Error(err)
+}
@@ -2,12 +2,15 @@ package business
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	osproject_v1 "github.com/openshift/api/project/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	auth_v1 "k8s.io/api/authorization/v1"
+	core_v1 "k8s.io/api/core/v1"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kiali/kiali/config"
@@ -651,10 +654,10 @@ func TestDeleteIstioConfigDetails(t *testing.T) {
 	assert := assert.New(t)
 	configService := mockDeleteIstioConfigDetails()
 
-	err := configService.DeleteIstioConfigDetail("networking.istio.io", "test", "virtualservices", "reviews-to-delete")
+	err := configService.DeleteIstioConfigDetail("networking.istio.io", "test", "virtualservices", "reviews-to-delete", "", "admin")
 	assert.Nil(err)
 
-	err = configService.DeleteIstioConfigDetail("config.istio.io", "test", "templates", "listchecker-to-delete")
+	err = configService.DeleteIstioConfigDetail("config.istio.io", "test", "templates", "listchecker-to-delete", "", "admin")
 	assert.Nil(err)
 }
 
@@ -669,7 +672,7 @@ func TestUpdateIstioConfigDetails(t *testing.T) {
 	assert := assert.New(t)
 	configService := mockUpdateIstioConfigDetails()
 
-	updatedVirtualService, err := configService.UpdateIstioConfigDetail("networking.istio.io", "test", "virtualservices", "reviews-to-update", "{}")
+	updatedVirtualService, err := configService.UpdateIstioConfigDetail("networking.istio.io", "test", "virtualservices", "reviews-to-update", "{}", "", "admin")
 	assert.Equal("test", updatedVirtualService.Namespace.Name)
 	assert.Equal("virtualservices", updatedVirtualService.ObjectType)
 	assert.Equal("reviews-to-update", updatedVirtualService.VirtualService.Metadata.Name)
@@ -723,7 +726,7 @@ func TestCreateIstioConfigDetails(t *testing.T) {
 	assert := assert.New(t)
 	configService := mockCreateIstioConfigDetails()
 
-	createVirtualService, err := configService.CreateIstioConfigDetail("networking.istio.io", "test", "virtualservices", []byte("{}"))
+	createVirtualService, err := configService.CreateIstioConfigDetail("networking.istio.io", "test", "virtualservices", []byte("{}"), "admin")
 	assert.Equal("test", createVirtualService.Namespace.Name)
 	assert.Equal("virtualservices", createVirtualService.ObjectType)
 	assert.Equal("reviews-to-update", createVirtualService.VirtualService.Metadata.Name)
@@ -762,3 +765,158 @@ func TestFilterIstioObjectsForWorkloadSelector(t *testing.T) {
 	sec := kubernetes.FilterIstioObjectsForWorkloadSelector(s, istioObjects)
 	assert.Equal(3, len(sec))
 }
+
+func fakeDryRunClient(t *testing.T) *kubernetes.MemoryClient {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubernetes.NewMemoryClient()
+	err := k8s.LoadYAML(strings.NewReader(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: reviews
+spec:
+  selector:
+    app: reviews
+  ports:
+  - name: http
+    port: 9080
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: reviews
+spec:
+  host: reviews
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+spec:
+  hosts: [reviews]
+  http:
+  - route:
+    - destination:
+        host: reviews
+`), "bookinfo")
+	assert.NoError(t, err)
+	k8s.AddConfigMap(core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: conf.ExternalServices.Istio.ConfigMapName, Namespace: conf.IstioNamespace}})
+	return k8s
+}
+
+func TestDryRunUpdateIstioConfigDetail(t *testing.T) {
+	assert := assert.New(t)
+	k8s := fakeDryRunClient(t)
+	layer := NewWithBackends(k8s, nil, nil)
+
+	patch := `{"spec":{"http":[{"route":[{"destination":{"host":"ratings"}}]}]}}`
+	dryRun, err := layer.IstioConfig.DryRunUpdateIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews", patch)
+	assert.NoError(err)
+
+	assert.True(dryRun.ServerDryRun.Accepted)
+	assert.NotNil(dryRun.Object.VirtualService)
+	assert.Equal("reviews", dryRun.Object.VirtualService.Metadata.Name)
+
+	validation := dryRun.Object.IstioValidation
+	assert.NotNil(validation)
+	assert.False(validation.Valid)
+	assert.Equal("KIA1101", validation.Checks[0].Code())
+	assert.Equal(validation, dryRun.Validations[models.BuildKey("virtualservice", "reviews", "bookinfo")])
+
+	// The change is not persisted
+	vs, err := k8s.GetIstioObject("bookinfo", "virtualservices", "reviews")
+	assert.NoError(err)
+	assert.NotContains(fmt.Sprint(vs.GetSpec()), "ratings")
+	validations, err := layer.Validations.GetValidations("bookinfo", "")
+	assert.NoError(err)
+	assert.True(validations[models.BuildKey("virtualservice", "reviews", "bookinfo")].Valid)
+}
+
+func TestDryRunCreateIstioConfigDetail(t *testing.T) {
+	assert := assert.New(t)
+	k8s := fakeDryRunClient(t)
+	layer := NewWithBackends(k8s, nil, nil)
+
+	// A second DestinationRule for the same host is reported on both, the stored one and the new one
+	body := []byte(`{"metadata":{"name":"reviews-v2"},"spec":{"host":"reviews"}}`)
+	dryRun, err := layer.IstioConfig.DryRunCreateIstioConfigDetail("networking.istio.io", "bookinfo", "destinationrules", body)
+	assert.NoError(err)
+	assert.True(dryRun.ServerDryRun.Accepted)
+	assert.NotNil(dryRun.Object.IstioValidation)
+	assert.NotEmpty(dryRun.Object.IstioValidation.Checks)
+	assert.NotEmpty(dryRun.Validations[models.BuildKey("destinationrule", "reviews", "bookinfo")].Checks)
+
+	_, err = k8s.GetIstioObject("bookinfo", "destinationrules", "reviews-v2")
+	assert.Error(err)
+
+	// Rejections of Kubernetes are returned as part of the result
+	body = []byte(`{"metadata":{"name":"reviews"},"spec":{"host":"reviews"}}`)
+	dryRun, err = layer.IstioConfig.DryRunCreateIstioConfigDetail("networking.istio.io", "bookinfo", "destinationrules", body)
+	assert.NoError(err)
+	assert.False(dryRun.ServerDryRun.Accepted)
+	assert.Equal(int32(409), dryRun.ServerDryRun.Code)
+	assert.Equal("AlreadyExists", dryRun.ServerDryRun.Reason)
+}
+
+func TestUpdateIstioConfigDetailStaleResourceVersion(t *testing.T) {
+	assert := assert.New(t)
+	layer, k8s := fakeImportLayer(t, "")
+
+	created, err := layer.IstioConfig.CreateIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", []byte(reviewsVirtualService), "alice")
+	assert.NoError(err)
+	resourceVersion := created.VirtualService.Metadata.ResourceVersion
+	assert.NotEmpty(resourceVersion)
+
+	updated, err := layer.IstioConfig.UpdateIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews",
+		`{"spec": {"gateways": ["bookinfo-gateway"]}}`, resourceVersion, "alice")
+	assert.NoError(err)
+	assert.NotEqual(resourceVersion, updated.VirtualService.Metadata.ResourceVersion)
+
+	// A second change based on the original version is rejected
+	_, err = layer.IstioConfig.UpdateIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews",
+		`{"spec": {"gateways": null}}`, resourceVersion, "bob")
+	assert.True(errors2.IsConflict(err))
+	err = layer.IstioConfig.DeleteIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews", resourceVersion, "bob")
+	assert.True(errors2.IsConflict(err))
+
+	vs, err := k8s.GetIstioObject("bookinfo", "virtualservices", "reviews")
+	assert.NoError(err)
+	assert.Equal([]interface{}{"bookinfo-gateway"}, vs.GetSpec()["gateways"])
+
+	assert.NoError(layer.IstioConfig.DeleteIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews", updated.VirtualService.Metadata.ResourceVersion, "bob"))
+	history, err := layer.IstioConfig.GetIstioConfigHistory("bookinfo", "virtualservices", "reviews")
+	assert.NoError(err)
+	assert.Len(history, 3)
+}
+
+func TestApplyIstioConfigDetail(t *testing.T) {
+	assert := assert.New(t)
+	layer, k8s := fakeImportLayer(t, "")
+
+	applied, err := layer.IstioConfig.ApplyIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews",
+		[]byte(`{"spec": {"hosts": ["reviews"], "http": [{"route": [{"destination": {"host": "reviews"}}]}]}}`), "", false, "alice")
+	assert.NoError(err)
+	assert.Equal("reviews", applied.VirtualService.Metadata.Name)
+
+	_, err = layer.IstioConfig.ApplyIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews",
+		[]byte(`{"spec": {"gateways": ["bookinfo-gateway"]}}`), applied.VirtualService.Metadata.ResourceVersion, false, "bob")
+	assert.NoError(err)
+	vs, err := k8s.GetIstioObject("bookinfo", "virtualservices", "reviews")
+	assert.NoError(err)
+	assert.Equal([]interface{}{"bookinfo-gateway"}, vs.GetSpec()["gateways"])
+	assert.Equal([]interface{}{"reviews"}, vs.GetSpec()["hosts"])
+
+	_, err = layer.IstioConfig.ApplyIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews",
+		[]byte(`{"spec": {"gateways": ["mesh"]}}`), applied.VirtualService.Metadata.ResourceVersion, false, "bob")
+	assert.True(errors2.IsConflict(err))
+	_, err = layer.IstioConfig.ApplyIstioConfigDetail("networking.istio.io", "bookinfo", "virtualservices", "reviews",
+		[]byte(`{"metadata": {"name": "ratings"}, "spec": {}}`), "", false, "bob")
+	assert.True(errors2.IsBadRequest(err))
+
+	history, err := layer.IstioConfig.GetIstioConfigHistory("bookinfo", "virtualservices", "reviews")
+	assert.NoError(err)
+	assert.Len(history, 2)
+	assert.Equal(models.RevisionCreate, history[0].Operation)
+}
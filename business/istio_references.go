@@ -0,0 +1,328 @@
+package business
+
+import (
+	"fmt"
+	"sync"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/business/checkers/common"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// Route types of the VirtualServices, their destinations may reference services, ServiceEntries and subsets
+var virtualServiceRouteTypes = []string{"http", "tcp", "tls"}
+
+// referenceData holds the objects of every accessible namespace that can be part of a reference
+type referenceData struct {
+	namespaces       []string
+	virtualServices  []kubernetes.IstioObject
+	destinationRules []kubernetes.IstioObject
+	gateways         []kubernetes.IstioObject
+	serviceEntries   []kubernetes.IstioObject
+	sidecars         []kubernetes.IstioObject
+	services         map[string][]core_v1.Service
+	workloads        map[string]models.Workloads
+}
+
+// GetIstioConfigReferences returns the Istio objects, services and workloads referenced by an Istio object, and the
+// Istio objects referencing it, resolved across all the namespaces accessible by the user:
+// - VirtualServices reference Gateways, services, ServiceEntries and the DestinationRules defining their subsets
+// - DestinationRules reference services and ServiceEntries
+// - Objects with a workload selector reference the workloads they are applied to
+func (in *IstioConfigService) GetIstioConfigReferences(namespace, resourceType, name string) (models.IstioConfigReferences, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "GetIstioConfigReferences")
+	defer promtimer.ObserveNow(&err)
+
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err = in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
+		return models.IstioConfigReferences{}, err
+	}
+	object, err := in.k8s.GetIstioObject(namespace, resourceType, name)
+	if err != nil {
+		return models.IstioConfigReferences{}, err
+	}
+
+	data, err := in.fetchReferenceData()
+	if err != nil {
+		return models.IstioConfigReferences{}, err
+	}
+
+	target := models.IstioReference{ObjectType: models.ObjectTypeSingular[resourceType], Name: name, Namespace: namespace}
+	references := models.IstioConfigReferences{
+		IstioReference: target,
+		References:     data.references(resourceType, object),
+		ReferencedBy:   []models.IstioReference{},
+	}
+
+	// Only VirtualServices and DestinationRules reference other Istio objects
+	referrers := map[string][]kubernetes.IstioObject{
+		kubernetes.VirtualServices:  data.virtualServices,
+		kubernetes.DestinationRules: data.destinationRules,
+	}
+	for _, referrerType := range []string{kubernetes.VirtualServices, kubernetes.DestinationRules} {
+		for _, referrer := range referrers[referrerType] {
+			for _, ref := range data.references(referrerType, referrer) {
+				if ref.ObjectType == target.ObjectType && ref.Name == target.Name && ref.Namespace == target.Namespace {
+					references.ReferencedBy = append(references.ReferencedBy, models.IstioReference{
+						ObjectType: models.ObjectTypeSingular[referrerType],
+						Name:       referrer.GetObjectMeta().Name,
+						Namespace:  referrer.GetObjectMeta().Namespace,
+						Path:       ref.Path,
+					})
+				}
+			}
+		}
+	}
+
+	return references, nil
+}
+
+// fetchReferenceData fetches concurrently the objects of every accessible namespace that can be part of a reference
+func (in *IstioConfigService) fetchReferenceData() (referenceData, error) {
+	nss, err := in.businessLayer.Namespace.GetNamespaces()
+	if err != nil {
+		return referenceData{}, err
+	}
+
+	data := referenceData{
+		namespaces: make([]string, 0, len(nss)),
+		services:   make(map[string][]core_v1.Service, len(nss)),
+		workloads:  make(map[string]models.Workloads, len(nss)),
+	}
+	resourceTypes := []string{kubernetes.VirtualServices, kubernetes.DestinationRules, kubernetes.Gateways, kubernetes.ServiceEntries, kubernetes.Sidecars}
+	objects := make([][][]kubernetes.IstioObject, len(resourceTypes))
+	for i := range resourceTypes {
+		objects[i] = make([][]kubernetes.IstioObject, len(nss))
+	}
+	services := make([][]core_v1.Service, len(nss))
+	workloads := make([]models.Workloads, len(nss))
+
+	wg := sync.WaitGroup{}
+	errChan := make(chan error, 1)
+	reportErr := func(err error) {
+		select {
+		case errChan <- err:
+		default:
+		}
+	}
+	for j, ns := range nss {
+		data.namespaces = append(data.namespaces, ns.Name)
+		wg.Add(len(resourceTypes) + 2)
+		for i, resourceType := range resourceTypes {
+			go func(i, j int, namespace, resourceType string) {
+				defer wg.Done()
+				var err error
+				if IsResourceCached(namespace, resourceType) {
+					objects[i][j], err = kialiCache.GetIstioObjects(namespace, resourceType, "")
+				} else {
+					objects[i][j], err = in.k8s.GetIstioObjects(namespace, resourceType, "")
+				}
+				if err != nil {
+					reportErr(err)
+				}
+			}(i, j, ns.Name, resourceType)
+		}
+		go func(j int, namespace string) {
+			defer wg.Done()
+			var err error
+			if IsNamespaceCached(namespace) {
+				services[j], err = kialiCache.GetServices(namespace, nil)
+			} else {
+				services[j], err = in.k8s.GetServices(namespace, nil)
+			}
+			if err != nil {
+				reportErr(err)
+			}
+		}(j, ns.Name)
+		go func(j int, namespace string) {
+			defer wg.Done()
+			var err error
+			if workloads[j], err = fetchWorkloads(in.businessLayer, namespace, ""); err != nil {
+				reportErr(err)
+			}
+		}(j, ns.Name)
+	}
+	wg.Wait()
+	close(errChan)
+	for e := range errChan {
+		if e != nil {
+			return referenceData{}, e
+		}
+	}
+
+	for j := range nss {
+		data.virtualServices = append(data.virtualServices, objects[0][j]...)
+		data.destinationRules = append(data.destinationRules, objects[1][j]...)
+		data.gateways = append(data.gateways, objects[2][j]...)
+		data.serviceEntries = append(data.serviceEntries, objects[3][j]...)
+		data.sidecars = append(data.sidecars, objects[4][j]...)
+		data.services[nss[j].Name] = services[j]
+		data.workloads[nss[j].Name] = workloads[j]
+	}
+	return data, nil
+}
+
+// references returns the objects referenced by an Istio object, in the order of the fields of the object
+func (rd referenceData) references(resourceType string, object kubernetes.IstioObject) []models.IstioReference {
+	references := []models.IstioReference{}
+	namespace := object.GetObjectMeta().Namespace
+
+	switch resourceType {
+	case kubernetes.VirtualServices:
+		if gateways, ok := object.GetSpec()["gateways"].([]interface{}); ok {
+			for i, g := range gateways {
+				gateway, ok := g.(string)
+				if !ok || gateway == "mesh" {
+					continue
+				}
+				gwHost := kubernetes.ParseGatewayAsHost(gateway, namespace, "")
+				for _, gw := range rd.gateways {
+					if gw.GetObjectMeta().Name == gwHost.Service && gw.GetObjectMeta().Namespace == gwHost.Namespace {
+						references = append(references, istioReference(kubernetes.Gateways, gw, fmt.Sprintf("spec/gateways[%d]", i)))
+					}
+				}
+			}
+		}
+		for _, routeType := range virtualServiceRouteTypes {
+			routes, _ := object.GetSpec()[routeType].([]interface{})
+			for i, r := range routes {
+				route, _ := r.(map[string]interface{})
+				destinations, _ := route["route"].([]interface{})
+				for j, d := range destinations {
+					destinationWeight, _ := d.(map[string]interface{})
+					destination, _ := destinationWeight["destination"].(map[string]interface{})
+					host, ok := destination["host"].(string)
+					if !ok {
+						continue
+					}
+					path := fmt.Sprintf("spec/%s[%d]/route[%d]/destination", routeType, i, j)
+					references = append(references, rd.hostReferences(host, namespace, path+"/host")...)
+					if subset, ok := destination["subset"].(string); ok && subset != "" {
+						references = append(references, rd.subsetReferences(host, namespace, subset, path+"/subset")...)
+					}
+				}
+			}
+		}
+	case kubernetes.DestinationRules:
+		if host, ok := object.GetSpec()["host"].(string); ok {
+			references = append(references, rd.hostReferences(host, namespace, "spec/host")...)
+		}
+	default:
+		references = append(references, rd.workloadReferences(resourceType, object)...)
+	}
+
+	return references
+}
+
+// hostReferences returns the service or ServiceEntries of a host used in an Istio object
+func (rd referenceData) hostReferences(host, namespace, path string) []models.IstioReference {
+	references := []models.IstioReference{}
+	h := rd.getHost(host, namespace)
+	if h.CompleteInput {
+		for _, svc := range rd.services[h.Namespace] {
+			if svc.Name == h.Service {
+				references = append(references, models.IstioReference{ObjectType: "service", Name: svc.Name, Namespace: svc.Namespace, Path: path})
+			}
+		}
+	}
+	for _, se := range rd.serviceEntries {
+		seHosts, _ := se.GetSpec()["hosts"].([]interface{})
+		for _, sh := range seHosts {
+			if seHost, ok := sh.(string); ok && kubernetes.HostsMatch(h, rd.getHost(seHost, se.GetObjectMeta().Namespace)) {
+				references = append(references, istioReference(kubernetes.ServiceEntries, se, path))
+				break
+			}
+		}
+	}
+	return references
+}
+
+// subsetReferences returns the DestinationRules defining a subset of a host used in a VirtualService
+func (rd referenceData) subsetReferences(host, namespace, subset, path string) []models.IstioReference {
+	references := []models.IstioReference{}
+	h := rd.getHost(host, namespace)
+	for _, dr := range rd.destinationRules {
+		drHost, ok := dr.GetSpec()["host"].(string)
+		if !ok || !kubernetes.HostsMatch(h, rd.getHost(drHost, dr.GetObjectMeta().Namespace)) {
+			continue
+		}
+		subsets, _ := dr.GetSpec()["subsets"].([]interface{})
+		for _, s := range subsets {
+			if sMap, ok := s.(map[string]interface{}); ok && sMap["name"] == subset {
+				references = append(references, istioReference(kubernetes.DestinationRules, dr, path))
+				break
+			}
+		}
+	}
+	return references
+}
+
+// workloadReferences returns the workloads matched by the selector of an Istio object
+func (rd referenceData) workloadReferences(resourceType string, object kubernetes.IstioObject) []models.IstioReference {
+	references := []models.IstioReference{}
+	selectorLabels, path := workloadSelector(resourceType, object)
+	if len(selectorLabels) == 0 {
+		return references
+	}
+
+	selector := labels.SelectorFromSet(selectorLabels)
+	for _, ns := range rd.namespaces {
+		if !selectsNamespace(resourceType, object.GetObjectMeta().Namespace, ns) {
+			continue
+		}
+		for _, wl := range rd.workloads[ns] {
+			if selector.Matches(labels.Set(wl.Labels)) {
+				references = append(references, models.IstioReference{ObjectType: "workload", Name: wl.Name, Namespace: ns, Path: path})
+			}
+		}
+	}
+	return references
+}
+
+// workloadSelector returns the labels of the workload selector of an Istio object, and the path of the field holding them
+func workloadSelector(resourceType string, object kubernetes.IstioObject) (map[string]string, string) {
+	switch resourceType {
+	case kubernetes.Gateways:
+		selectorLabels := map[string]string{}
+		selector, _ := object.GetSpec()["selector"].(map[string]interface{})
+		for k, v := range selector {
+			if value, ok := v.(string); ok {
+				selectorLabels[k] = value
+			}
+		}
+		return selectorLabels, "spec/selector"
+	case kubernetes.Sidecars, kubernetes.EnvoyFilters, kubernetes.ServiceEntries:
+		return common.GetWorkloadSelectorLabels(object), "spec/workloadSelector/labels"
+	case kubernetes.AuthorizationPolicies, kubernetes.PeerAuthentications, kubernetes.RequestAuthentications,
+		kubernetes.Telemetries, kubernetes.WasmPlugins:
+		return common.GetSelectorLabels(object), "spec/selector/matchLabels"
+	}
+	return nil, ""
+}
+
+// selectsNamespace returns true when the workload selector of an object applies to the workloads of a namespace.
+// Gateways select workloads of any namespace, and so do the objects of the Istio root namespace. Other objects
+// only apply to their namespace.
+func selectsNamespace(resourceType, objectNamespace, namespace string) bool {
+	return namespace == objectNamespace || resourceType == kubernetes.Gateways || objectNamespace == config.Get().IstioNamespace
+}
+
+func (rd referenceData) getHost(host, namespace string) kubernetes.Host {
+	return kubernetes.GetHost(host, namespace, config.Get().ExternalServices.Istio.IstioIdentityDomain, rd.namespaces)
+}
+
+func istioReference(resourceType string, object kubernetes.IstioObject, path string) models.IstioReference {
+	return models.IstioReference{
+		ObjectType: models.ObjectTypeSingular[resourceType],
+		Name:       object.GetObjectMeta().Name,
+		Namespace:  object.GetObjectMeta().Namespace,
+		Path:       path,
+	}
+}
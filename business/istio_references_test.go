@@ -0,0 +1,143 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const referencedObjects = `
+apiVersion: networking.istio.io/v1alpha3
+kind: Gateway
+metadata:
+  name: bookinfo-gateway
+spec:
+  selector:
+    istio: ingressgateway
+  servers: []
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: reviews
+spec:
+  host: reviews.bookinfo.svc.cluster.local
+  subsets:
+  - name: v1
+    labels:
+      version: v1
+  - name: v2
+    labels:
+      version: v2
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+spec:
+  hosts: [reviews]
+  gateways: [bookinfo-gateway, mesh]
+  http:
+  - route:
+    - destination:
+        host: reviews
+        subset: v1
+      weight: 80
+    - destination:
+        host: reviews.bookinfo
+        subset: v2
+      weight: 20
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: ServiceEntry
+metadata:
+  name: external-api
+spec:
+  hosts: ["*.example.com"]
+  location: MESH_EXTERNAL
+  resolution: DNS
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: external-api
+spec:
+  hosts: [api.example.com]
+  tls:
+  - match:
+    - sniHosts: [api.example.com]
+    route:
+    - destination:
+        host: api.example.com
+---
+apiVersion: security.istio.io/v1beta1
+kind: AuthorizationPolicy
+metadata:
+  name: reviews-v2
+spec:
+  selector:
+    matchLabels:
+      app: reviews
+      version: v2
+`
+
+func TestGetIstioConfigReferencesDestinationRule(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, reviewsPods+"---"+referencedObjects)
+
+	references, err := layer.IstioConfig.GetIstioConfigReferences("bookinfo", kubernetes.DestinationRules, "reviews")
+	assert.NoError(err)
+	assert.Equal(models.IstioReference{ObjectType: "destinationrule", Name: "reviews", Namespace: "bookinfo"}, references.IstioReference)
+	assert.Equal([]models.IstioReference{{ObjectType: "service", Name: "reviews", Namespace: "bookinfo", Path: "spec/host"}}, references.References)
+
+	// Both subsets are used by the VirtualService
+	assert.Equal([]models.IstioReference{
+		{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo", Path: "spec/http[0]/route[0]/destination/subset"},
+		{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo", Path: "spec/http[0]/route[1]/destination/subset"},
+	}, references.ReferencedBy)
+}
+
+func TestGetIstioConfigReferencesVirtualService(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, reviewsPods+"---"+referencedObjects)
+
+	references, err := layer.IstioConfig.GetIstioConfigReferences("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.NoError(err)
+	assert.Equal([]models.IstioReference{
+		{ObjectType: "gateway", Name: "bookinfo-gateway", Namespace: "bookinfo", Path: "spec/gateways[0]"},
+		{ObjectType: "service", Name: "reviews", Namespace: "bookinfo", Path: "spec/http[0]/route[0]/destination/host"},
+		{ObjectType: "destinationrule", Name: "reviews", Namespace: "bookinfo", Path: "spec/http[0]/route[0]/destination/subset"},
+		{ObjectType: "service", Name: "reviews", Namespace: "bookinfo", Path: "spec/http[0]/route[1]/destination/host"},
+		{ObjectType: "destinationrule", Name: "reviews", Namespace: "bookinfo", Path: "spec/http[0]/route[1]/destination/subset"},
+	}, references.References)
+	assert.Empty(references.ReferencedBy)
+
+	references, err = layer.IstioConfig.GetIstioConfigReferences("bookinfo", kubernetes.ServiceEntries, "external-api")
+	assert.NoError(err)
+	assert.Empty(references.References)
+	assert.Equal([]models.IstioReference{{ObjectType: "virtualservice", Name: "external-api", Namespace: "bookinfo", Path: "spec/tls[0]/route[0]/destination/host"}}, references.ReferencedBy)
+
+	references, err = layer.IstioConfig.GetIstioConfigReferences("bookinfo", kubernetes.Gateways, "bookinfo-gateway")
+	assert.NoError(err)
+	assert.Equal([]models.IstioReference{{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo", Path: "spec/gateways[0]"}}, references.ReferencedBy)
+}
+
+func TestGetIstioConfigReferencesWorkloads(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, reviewsPods+"---"+referencedObjects)
+
+	references, err := layer.IstioConfig.GetIstioConfigReferences("bookinfo", kubernetes.AuthorizationPolicies, "reviews-v2")
+	assert.NoError(err)
+	assert.Len(references.References, 2)
+	for _, ref := range references.References {
+		assert.Equal("workload", ref.ObjectType)
+		assert.Equal("spec/selector/matchLabels", ref.Path)
+	}
+
+	_, err = layer.IstioConfig.GetIstioConfigReferences("bookinfo", kubernetes.DestinationRules, "ratings")
+	assert.True(errors2.IsNotFound(err))
+}
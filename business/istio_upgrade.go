@@ -0,0 +1,515 @@
+package business
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// IstioUpgradeService checks the readiness of the mesh to upgrade Istio
+type IstioUpgradeService struct {
+	k8s           kubernetes.ClientInterface
+	businessLayer *Layer
+}
+
+// deprecation describes something deprecated in an Istio version and, optionally, removed in a later one
+type deprecation struct {
+	DeprecatedIn string
+	RemovedIn    string
+	Replacement  string
+	Message      string
+}
+
+// deprecatedApiVersions are the API versions deprecated or removed, by apiVersion
+var deprecatedApiVersions = map[string]deprecation{
+	"authentication.istio.io/v1alpha1": {DeprecatedIn: "1.5", RemovedIn: "1.6", Replacement: "security.istio.io/v1beta1", Message: "Authentication policies are replaced by PeerAuthentication and RequestAuthentication"},
+	"rbac.istio.io/v1alpha1":           {DeprecatedIn: "1.4", RemovedIn: "1.6", Replacement: "security.istio.io/v1beta1", Message: "RBAC policies are replaced by AuthorizationPolicy"},
+	"config.istio.io/v1alpha2":         {DeprecatedIn: "1.5", RemovedIn: "1.8", Message: "Mixer configuration is removed along with Mixer"},
+	"networking.istio.io/v1alpha3":     {DeprecatedIn: "1.5", Replacement: "networking.istio.io/v1beta1"},
+}
+
+// deprecatedFields are the fields deprecated or removed, by resource type.
+// Fields are in dot notation, where [] stands for every item of an array.
+var deprecatedFields = map[string]map[string]deprecation{
+	kubernetes.VirtualServices: {
+		"spec.http[].mirror_percent":         {DeprecatedIn: "1.5", Replacement: "mirrorPercentage"},
+		"spec.http[].mirrorPercent":          {DeprecatedIn: "1.5", Replacement: "mirrorPercentage"},
+		"spec.http[].corsPolicy.allowOrigin": {DeprecatedIn: "1.4", Replacement: "allowOrigins"},
+		"spec.http[].fault.delay.percent":    {DeprecatedIn: "1.1", Replacement: "percentage"},
+		"spec.http[].fault.abort.percent":    {DeprecatedIn: "1.1", Replacement: "percentage"},
+	},
+	kubernetes.EnvoyFilters: {
+		"spec.workloadLabels": {DeprecatedIn: "1.3", RemovedIn: "1.5", Replacement: "workloadSelector"},
+		"spec.filters":        {DeprecatedIn: "1.3", RemovedIn: "1.5", Replacement: "configPatches"},
+	},
+}
+
+// deprecatedMeshConfigFields are the fields of the mesh configuration deprecated or removed
+var deprecatedMeshConfigFields = map[string]deprecation{
+	"disablePolicyChecks":               {DeprecatedIn: "1.5", RemovedIn: "1.8", Message: "Mixer policy checks are removed"},
+	"policyCheckFailOpen":               {DeprecatedIn: "1.5", RemovedIn: "1.8", Message: "Mixer policy checks are removed"},
+	"mixerCheckServer":                  {DeprecatedIn: "1.5", RemovedIn: "1.8", Message: "Mixer policy checks are removed"},
+	"mixerReportServer":                 {DeprecatedIn: "1.5", RemovedIn: "1.8", Replacement: "Telemetry V2", Message: "Mixer telemetry is removed"},
+	"disableMixerHttpReports":           {DeprecatedIn: "1.5", RemovedIn: "1.8", Replacement: "Telemetry V2", Message: "Mixer telemetry is removed"},
+	"sidecarToTelemetrySessionAffinity": {DeprecatedIn: "1.5", RemovedIn: "1.8", Replacement: "Telemetry V2", Message: "Mixer telemetry is removed"},
+}
+
+// removedComponents are the control plane deployments of features deprecated or removed, by deployment name
+var removedComponents = map[string]deprecation{
+	"istio-policy":    {DeprecatedIn: "1.5", RemovedIn: "1.8", Message: "Mixer policy component is removed"},
+	"istio-telemetry": {DeprecatedIn: "1.5", RemovedIn: "1.8", Replacement: "Telemetry V2", Message: "Mixer telemetry component is removed"},
+	"istio-citadel":   {DeprecatedIn: "1.5", RemovedIn: "1.6", Replacement: "istiod", Message: "Citadel is merged into istiod"},
+	"istio-galley":    {DeprecatedIn: "1.5", RemovedIn: "1.6", Replacement: "istiod", Message: "Galley is merged into istiod"},
+	"istio-pilot":     {DeprecatedIn: "1.5", RemovedIn: "1.6", Replacement: "istiod", Message: "Pilot is merged into istiod"},
+}
+
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+var majorMinorExpr = regexp.MustCompile(`^v?([0-9]+)\.([0-9]+)`)
+
+// GetUpgradeReadiness scans the Istio config of all the accessible namespaces, the mesh configuration, the control
+// plane components and the proxies, and reports what is deprecated or removed in the target version
+func (in *IstioUpgradeService) GetUpgradeReadiness(targetVersion string) (*models.IstioUpgradeReadiness, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioUpgradeService", "GetUpgradeReadiness")
+	defer promtimer.ObserveNow(&err)
+
+	target, err := version.NewVersion(targetVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target version [%s]: %v", targetVersion, err)
+	}
+
+	report := &models.IstioUpgradeReadiness{
+		TargetVersion: targetVersion,
+		Findings:      []models.UpgradeFinding{},
+		Proxies:       []models.ProxyVersionSkew{},
+	}
+
+	objectFindings, err := in.getObjectFindings(target)
+	if err != nil {
+		return nil, err
+	}
+	report.Findings = append(report.Findings, objectFindings...)
+
+	meshFindings, err := in.getMeshConfigFindings(target)
+	if err != nil {
+		return nil, err
+	}
+	report.Findings = append(report.Findings, meshFindings...)
+
+	componentFindings, err := in.getComponentFindings(target)
+	if err != nil {
+		return nil, err
+	}
+	report.Findings = append(report.Findings, componentFindings...)
+
+	// Proxy status requires access to istiod, the rest of the report is still useful without it
+	if proxyStatus, psErr := in.k8s.GetProxyStatus(); psErr == nil {
+		report.ControlPlaneVersion, report.Proxies = proxyVersionSkews(proxyStatus, target)
+	} else {
+		log.Warningf("Unable to get the proxy status for the upgrade readiness report: %v", psErr)
+	}
+
+	report.Ready = true
+	for _, f := range report.Findings {
+		if f.Severity == models.ErrorSeverity {
+			report.Ready = false
+		}
+	}
+	for _, p := range report.Proxies {
+		if !p.SupportedByTarget {
+			report.Ready = false
+		}
+	}
+	return report, nil
+}
+
+func (in *IstioUpgradeService) getObjectFindings(target *version.Version) ([]models.UpgradeFinding, error) {
+	namespaces, err := in.businessLayer.Namespace.GetNamespaces()
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTypes := []string{}
+	for resourceType, api := range kubernetes.ResourceTypesToAPI {
+		// Only Istio resources
+		if api == kubernetes.NetworkingGroupVersion.Group || api == kubernetes.SecurityGroupVersion.Group {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+	sort.Strings(resourceTypes)
+
+	findings := []models.UpgradeFinding{}
+	for _, resource := range kubernetes.LegacyIstioResources {
+		if resource.ClusterScoped {
+			legacyFindings, err := in.getLegacyObjectFindings("", resource, target)
+			if err != nil {
+				return nil, err
+			}
+			findings = append(findings, legacyFindings...)
+		}
+	}
+
+	wg := sync.WaitGroup{}
+	errChan := make(chan error, 1)
+	perNamespace := make([][]models.UpgradeFinding, len(namespaces))
+
+	wg.Add(len(namespaces))
+	for i, ns := range namespaces {
+		go func(i int, namespace string) {
+			defer wg.Done()
+			for _, resourceType := range resourceTypes {
+				if len(errChan) > 0 {
+					return
+				}
+				var objects []kubernetes.IstioObject
+				var err error
+				if IsResourceCached(namespace, resourceType) {
+					objects, err = kialiCache.GetIstioObjects(namespace, resourceType, "")
+				} else {
+					objects, err = in.k8s.GetIstioObjects(namespace, resourceType, "")
+				}
+				if err != nil {
+					select {
+					case errChan <- err:
+					default:
+					}
+					return
+				}
+				for _, object := range objects {
+					perNamespace[i] = append(perNamespace[i], objectFindings(resourceType, object, target)...)
+				}
+			}
+			for _, resource := range kubernetes.LegacyIstioResources {
+				if resource.ClusterScoped || len(errChan) > 0 {
+					continue
+				}
+				legacyFindings, err := in.getLegacyObjectFindings(namespace, resource, target)
+				if err != nil {
+					select {
+					case errChan <- err:
+					default:
+					}
+					return
+				}
+				perNamespace[i] = append(perNamespace[i], legacyFindings...)
+			}
+		}(i, ns.Name)
+	}
+	wg.Wait()
+	close(errChan)
+	for e := range errChan {
+		if e != nil {
+			return nil, e
+		}
+	}
+
+	for _, nsFindings := range perNamespace {
+		findings = append(findings, nsFindings...)
+	}
+	return findings, nil
+}
+
+// getLegacyObjectFindings reports the objects of a resource removed from Istio. Its API is usually no longer
+// served, a missing CRD is not an error.
+func (in *IstioUpgradeService) getLegacyObjectFindings(namespace string, resource kubernetes.LegacyIstioResource, target *version.Version) ([]models.UpgradeFinding, error) {
+	objects, err := in.k8s.GetLegacyIstioObjects(namespace, resource)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	findings := []models.UpgradeFinding{}
+	for _, object := range objects {
+		if f, ok := apiVersionFinding(strings.ToLower(resource.Kind), object, resource.APIVersion, target); ok {
+			findings = append(findings, f)
+		}
+	}
+	return findings, nil
+}
+
+func (in *IstioUpgradeService) getMeshConfigFindings(target *version.Version) ([]models.UpgradeFinding, error) {
+	cfg := config.Get()
+	var istioConfig *core_v1.ConfigMap
+	var err error
+	if IsNamespaceCached(cfg.IstioNamespace) {
+		istioConfig, err = kialiCache.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
+	} else {
+		istioConfig, err = in.k8s.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	fields, err := kubernetes.GetIstioMeshConfigFields(istioConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := []models.UpgradeFinding{}
+	for _, field := range sortedKeys(deprecatedMeshConfigFields) {
+		if _, found := fields[field]; found {
+			if f, ok := newUpgradeFinding(models.UpgradeFindingMeshConfig, deprecatedMeshConfigFields[field], target); ok {
+				f.Name = cfg.ExternalServices.Istio.ConfigMapName
+				f.Namespace = cfg.IstioNamespace
+				f.Path = "mesh/" + field
+				f.Message = fmt.Sprintf("Mesh config field %s: %s", field, f.Message)
+				findings = append(findings, f)
+			}
+		}
+	}
+	return findings, nil
+}
+
+func (in *IstioUpgradeService) getComponentFindings(target *version.Version) ([]models.UpgradeFinding, error) {
+	istioNamespace := config.Get().IstioNamespace
+	var deployments []apps_v1.Deployment
+	var err error
+	if IsNamespaceCached(istioNamespace) {
+		deployments, err = kialiCache.GetDeployments(istioNamespace)
+	} else {
+		deployments, err = in.k8s.GetDeployments(istioNamespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	findings := []models.UpgradeFinding{}
+	for _, d := range deployments {
+		if removed, found := removedComponents[d.Name]; found {
+			if f, ok := newUpgradeFinding(models.UpgradeFindingFeature, removed, target); ok {
+				f.Name = d.Name
+				f.Namespace = d.Namespace
+				findings = append(findings, f)
+			}
+		}
+	}
+	return findings, nil
+}
+
+// objectFindings returns the deprecated API version and fields used by the Istio object.
+// The API version is the one the object was applied with, when known, as the API server converts the objects
+// to the version requested by Kiali.
+func objectFindings(resourceType string, object kubernetes.IstioObject, target *version.Version) []models.UpgradeFinding {
+	findings := []models.UpgradeFinding{}
+	meta := object.GetObjectMeta()
+	objectType := models.ObjectTypeSingular[resourceType]
+
+	if apiVersion := appliedApiVersion(meta); apiVersion != "" {
+		if f, ok := apiVersionFinding(objectType, object, apiVersion, target); ok {
+			findings = append(findings, f)
+		}
+	}
+
+	document := map[string]interface{}{"spec": object.GetSpec()}
+	fields := deprecatedFields[resourceType]
+	for _, field := range sortedKeys(fields) {
+		for _, path := range fieldPaths(document, strings.Split(field, "."), "") {
+			if f, ok := newUpgradeFinding(models.UpgradeFindingField, fields[field], target); ok {
+				f.ObjectType, f.Name, f.Namespace, f.Path = objectType, meta.Name, meta.Namespace, path
+				findings = append(findings, f)
+			}
+		}
+	}
+
+	// Gateways of VirtualServices should use the <namespace>/<gateway> nomenclature, as reported by validations
+	if resourceType == kubernetes.VirtualServices {
+		if gateways, ok := object.GetSpec()["gateways"].([]interface{}); ok {
+			for i, g := range gateways {
+				if gateway, ok := g.(string); ok && strings.Contains(gateway, ".") && !strings.Contains(gateway, "/") {
+					findings = append(findings, models.UpgradeFinding{
+						Category:    models.UpgradeFindingField,
+						ObjectType:  objectType,
+						Name:        meta.Name,
+						Namespace:   meta.Namespace,
+						Path:        fmt.Sprintf("spec/gateways[%d]", i),
+						Message:     models.CheckMessage("virtualservices.gateway.oldnomenclature"),
+						Severity:    models.WarningSeverity,
+						Replacement: "<gateway namespace>/<gateway name>",
+					})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// appliedApiVersion returns the API version the object was last applied or updated with, or "" when unknown
+func appliedApiVersion(meta meta_v1.ObjectMeta) string {
+	if lastApplied, found := meta.Annotations[lastAppliedConfigAnnotation]; found {
+		applied := struct {
+			APIVersion string `json:"apiVersion"`
+		}{}
+		if err := json.Unmarshal([]byte(lastApplied), &applied); err == nil && applied.APIVersion != "" {
+			return applied.APIVersion
+		}
+	}
+	apiVersion := ""
+	var lastUpdate *meta_v1.Time
+	for _, field := range meta.ManagedFields {
+		if field.APIVersion != "" && (lastUpdate == nil || (field.Time != nil && !field.Time.Before(lastUpdate))) {
+			apiVersion, lastUpdate = field.APIVersion, field.Time
+		}
+	}
+	return apiVersion
+}
+
+// apiVersionFinding returns the finding of an object using a deprecated API version
+func apiVersionFinding(objectType string, object kubernetes.IstioObject, apiVersion string, target *version.Version) (models.UpgradeFinding, bool) {
+	deprecated, found := deprecatedApiVersions[apiVersion]
+	if !found {
+		return models.UpgradeFinding{}, false
+	}
+	f, ok := newUpgradeFinding(models.UpgradeFindingApiVersion, deprecated, target)
+	if ok {
+		meta := object.GetObjectMeta()
+		f.ObjectType, f.Name, f.Namespace = objectType, meta.Name, meta.Namespace
+		f.Message = fmt.Sprintf("API version %s: %s", apiVersion, f.Message)
+	}
+	return f, ok
+}
+
+// newUpgradeFinding returns the finding for something deprecated, or false if it isn't deprecated yet in the target version
+func newUpgradeFinding(category string, d deprecation, target *version.Version) (models.UpgradeFinding, bool) {
+	f := models.UpgradeFinding{
+		Category:     category,
+		DeprecatedIn: d.DeprecatedIn,
+		RemovedIn:    d.RemovedIn,
+		Replacement:  d.Replacement,
+		Severity:     models.WarningSeverity,
+	}
+	if d.RemovedIn != "" && !target.LessThan(version.Must(version.NewVersion(d.RemovedIn))) {
+		f.Severity = models.ErrorSeverity
+		f.Message = fmt.Sprintf("removed in Istio %s", d.RemovedIn)
+	} else if !target.LessThan(version.Must(version.NewVersion(d.DeprecatedIn))) {
+		f.Message = fmt.Sprintf("deprecated since Istio %s", d.DeprecatedIn)
+	} else {
+		return f, false
+	}
+	if d.Message != "" {
+		f.Message = d.Message + ", " + f.Message
+	}
+	if d.Replacement != "" {
+		f.Message += ", use " + d.Replacement
+	}
+	return f, true
+}
+
+// fieldPaths returns the paths where the field is set in the document. Fields ending with [] match every item of an array.
+func fieldPaths(document interface{}, field []string, path string) []string {
+	if len(field) == 0 {
+		return []string{path}
+	}
+	m, ok := document.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if path != "" {
+		path += "/"
+	}
+	name := strings.TrimSuffix(field[0], "[]")
+	value, found := m[name]
+	if !found {
+		return nil
+	}
+	if name == field[0] {
+		return fieldPaths(value, field[1:], path+name)
+	}
+
+	paths := []string{}
+	items, _ := value.([]interface{})
+	for i, item := range items {
+		paths = append(paths, fieldPaths(item, field[1:], fmt.Sprintf("%s%s[%d]", path, name, i))...)
+	}
+	return paths
+}
+
+// proxyVersionSkews returns the version of the control plane, as reported by most proxies, and the proxies
+// skewed from it or more than one minor version behind the target version
+func proxyVersionSkews(proxyStatus []*kubernetes.ProxyStatus, target *version.Version) (string, []models.ProxyVersionSkew) {
+	controlPlaneVersions := map[string]int{}
+	controlPlaneVersion := ""
+	for _, ps := range proxyStatus {
+		if ps.IstioVersion == "" {
+			continue
+		}
+		controlPlaneVersions[ps.IstioVersion]++
+		if count := controlPlaneVersions[ps.IstioVersion]; count > controlPlaneVersions[controlPlaneVersion] ||
+			(count == controlPlaneVersions[controlPlaneVersion] && ps.IstioVersion < controlPlaneVersion) {
+			controlPlaneVersion = ps.IstioVersion
+		}
+	}
+
+	targetSegments := target.Segments()
+	skews := []models.ProxyVersionSkew{}
+	for _, ps := range proxyStatus {
+		proxyMajor, proxyMinor, ok := majorMinor(ps.ProxyVersion)
+		if !ok {
+			continue
+		}
+		skew := models.ProxyVersionSkew{
+			ProxyVersion:        ps.ProxyVersion,
+			ControlPlaneVersion: ps.IstioVersion,
+			SupportedByTarget:   true,
+			Severity:            models.WarningSeverity,
+		}
+		// Proxy ids are <pod>.<namespace>
+		if i := strings.LastIndex(ps.ProxyID, "."); i > 0 {
+			skew.Pod, skew.Namespace = ps.ProxyID[:i], ps.ProxyID[i+1:]
+		} else {
+			skew.Pod = ps.ProxyID
+		}
+		if cpMajor, cpMinor, ok := majorMinor(ps.IstioVersion); ok {
+			skew.Skewed = cpMajor != proxyMajor || cpMinor != proxyMinor
+		}
+		// Proxies are supported up to one minor version behind the control plane
+		if proxyMajor != targetSegments[0] || targetSegments[1]-proxyMinor > 1 {
+			skew.SupportedByTarget = false
+			skew.Severity = models.ErrorSeverity
+		}
+		if skew.Skewed || !skew.SupportedByTarget {
+			skews = append(skews, skew)
+		}
+	}
+	sort.Slice(skews, func(i, j int) bool {
+		if skews[i].Namespace != skews[j].Namespace {
+			return skews[i].Namespace < skews[j].Namespace
+		}
+		return skews[i].Pod < skews[j].Pod
+	})
+	return controlPlaneVersion, skews
+}
+
+func majorMinor(v string) (int, int, bool) {
+	matches := majorMinorExpr.FindStringSubmatch(v)
+	if matches == nil {
+		return 0, 0, false
+	}
+	major, _ := strconv.Atoi(matches[1])
+	minor, _ := strconv.Atoi(matches[2])
+	return major, minor, true
+}
+
+func sortedKeys(m map[string]deprecation) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,213 @@
+package business
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// proxyStatusClient is a memory client with the status of some proxies
+type proxyStatusClient struct {
+	*kubernetes.MemoryClient
+	proxyStatus []*kubernetes.ProxyStatus
+}
+
+func (c proxyStatusClient) GetProxyStatus() ([]*kubernetes.ProxyStatus, error) {
+	return c.proxyStatus, nil
+}
+
+func proxyStatus(proxy, proxyVersion, istioVersion string) *kubernetes.ProxyStatus {
+	ps := &kubernetes.ProxyStatus{}
+	ps.ProxyID = proxy
+	ps.ProxyVersion = proxyVersion
+	ps.IstioVersion = istioVersion
+	return ps
+}
+
+func TestGetUpgradeReadiness(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubernetes.NewMemoryClient()
+	err := k8s.LoadYAML(strings.NewReader(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: bookinfo
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+spec:
+  hosts: [reviews]
+  gateways: [bookinfo-gateway.bookinfo]
+  http:
+  - route:
+    - destination:
+        host: reviews
+    mirror:
+      host: reviews
+    mirror_percent: 10
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: EnvoyFilter
+metadata:
+  name: lua
+spec:
+  workloadLabels:
+    app: reviews
+  filters: []
+`), "bookinfo")
+	assert.NoError(err)
+	err = k8s.LoadYAML(strings.NewReader(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-telemetry
+`), conf.IstioNamespace)
+	assert.NoError(err)
+	k8s.AddConfigMap(core_v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{Name: conf.ExternalServices.Istio.ConfigMapName, Namespace: conf.IstioNamespace},
+		Data:       map[string]string{"mesh": "disablePolicyChecks: true\nenableAutoMtls: true\n"},
+	})
+
+	client := proxyStatusClient{MemoryClient: k8s, proxyStatus: []*kubernetes.ProxyStatus{
+		proxyStatus("reviews-v1-1234.bookinfo", "1.7.4", "1.7.4"),
+		proxyStatus("details-v1-1234.bookinfo", "1.6.8", "1.7.4"),
+	}}
+	layer := NewWithBackends(client, nil, nil)
+
+	// Removed features are errors in the target version
+	report, err := layer.IstioUpgrade.GetUpgradeReadiness("1.8.0")
+	assert.NoError(err)
+	assert.False(report.Ready)
+	assert.Equal("1.7.4", report.ControlPlaneVersion)
+
+	findings := map[string]models.UpgradeFinding{}
+	for _, f := range report.Findings {
+		findings[f.Name+":"+f.Path] = f
+	}
+	assert.Len(findings, 6)
+	assert.Equal(models.WarningSeverity, findings["reviews:spec/http[0]/mirror_percent"].Severity)
+	assert.Equal("mirrorPercentage", findings["reviews:spec/http[0]/mirror_percent"].Replacement)
+	assert.Equal(models.CheckMessage("virtualservices.gateway.oldnomenclature"), findings["reviews:spec/gateways[0]"].Message)
+	assert.Equal(models.ErrorSeverity, findings["lua:spec/workloadLabels"].Severity)
+	assert.Equal(models.ErrorSeverity, findings["lua:spec/filters"].Severity)
+	assert.Equal(models.UpgradeFindingMeshConfig, findings["istio:mesh/disablePolicyChecks"].Category)
+	assert.Equal(models.ErrorSeverity, findings["istio:mesh/disablePolicyChecks"].Severity)
+	assert.Equal(models.UpgradeFindingFeature, findings["istio-telemetry:"].Category)
+
+	assert.Len(report.Proxies, 1)
+	assert.Equal("details-v1-1234", report.Proxies[0].Pod)
+	assert.Equal("bookinfo", report.Proxies[0].Namespace)
+	assert.True(report.Proxies[0].Skewed)
+	assert.False(report.Proxies[0].SupportedByTarget)
+
+	// Mixer is only deprecated before 1.8
+	report, err = layer.IstioUpgrade.GetUpgradeReadiness("1.6")
+	assert.NoError(err)
+	for _, f := range report.Findings {
+		if f.Category == models.UpgradeFindingMeshConfig || f.Category == models.UpgradeFindingFeature {
+			assert.Equal(models.WarningSeverity, f.Severity)
+		}
+	}
+}
+
+func TestProxyVersionSkews(t *testing.T) {
+	assert := assert.New(t)
+
+	controlPlane, skews := proxyVersionSkews([]*kubernetes.ProxyStatus{
+		proxyStatus("a.bookinfo", "1.8.1", "1.8.1"),
+		proxyStatus("b.bookinfo", "1.7.3", "1.8.1"),
+		proxyStatus("c.bookinfo", "1.8.0", "1.8.1"),
+		proxyStatus("d.bookinfo", "unknown", "1.8.1"),
+	}, version.Must(version.NewVersion("1.9")))
+
+	assert.Equal("1.8.1", controlPlane)
+	assert.Len(skews, 1)
+	assert.Equal("b", skews[0].Pod)
+	assert.True(skews[0].Skewed)
+	assert.False(skews[0].SupportedByTarget)
+	assert.Equal(models.ErrorSeverity, skews[0].Severity)
+}
+
+func TestGetUpgradeReadinessDeprecatedApiVersions(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubernetes.NewMemoryClient()
+	err := k8s.LoadYAML(strings.NewReader(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: bookinfo
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: reviews
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{"apiVersion":"networking.istio.io/v1alpha3","kind":"DestinationRule"}'
+spec:
+  host: reviews
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: details
+  managedFields:
+  - manager: kubectl
+    operation: Update
+    apiVersion: networking.istio.io/v1beta1
+spec:
+  host: details
+---
+apiVersion: authentication.istio.io/v1alpha1
+kind: Policy
+metadata:
+  name: default
+spec:
+  peers:
+  - mtls: {}
+---
+apiVersion: rbac.istio.io/v1alpha1
+kind: ClusterRbacConfig
+metadata:
+  name: default
+spec:
+  mode: 'ON'
+`), "bookinfo")
+	assert.NoError(err)
+	k8s.AddConfigMap(core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: conf.ExternalServices.Istio.ConfigMapName, Namespace: conf.IstioNamespace}})
+	layer := NewWithBackends(k8s, nil, nil)
+
+	report, err := layer.IstioUpgrade.GetUpgradeReadiness("1.8.0")
+	assert.NoError(err)
+	assert.False(report.Ready)
+
+	findings := map[string]models.UpgradeFinding{}
+	for _, f := range report.Findings {
+		assert.Equal(models.UpgradeFindingApiVersion, f.Category)
+		findings[f.ObjectType+":"+f.Name] = f
+	}
+	// Objects applied with a served version are reported, whatever the version requested by Kiali
+	assert.Len(findings, 3)
+	assert.Equal(models.WarningSeverity, findings["destinationrule:reviews"].Severity)
+	assert.Equal("networking.istio.io/v1beta1", findings["destinationrule:reviews"].Replacement)
+	assert.Equal(models.ErrorSeverity, findings["policy:default"].Severity)
+	assert.Equal("bookinfo", findings["policy:default"].Namespace)
+	assert.Equal("API version authentication.istio.io/v1alpha1: Authentication policies are replaced by PeerAuthentication and RequestAuthentication, removed in Istio 1.6, use security.istio.io/v1beta1", findings["policy:default"].Message)
+	assert.Equal(models.ErrorSeverity, findings["clusterrbacconfig:default"].Severity)
+	assert.Equal("", findings["clusterrbacconfig:default"].Namespace)
+}
@@ -3,21 +3,29 @@ package business
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/kiali/kiali/business/checkers"
+	"github.com/kiali/kiali/business/checkers/authorization"
+	"github.com/kiali/kiali/business/checkers/gateways"
+	"github.com/kiali/kiali/business/checkers/networkpolicies"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/prometheus/internalmetrics"
 )
 
 type IstioValidationsService struct {
 	k8s           kubernetes.ClientInterface
+	prom          prometheus.ClientInterface
 	businessLayer *Layer
 }
 
@@ -25,6 +33,30 @@ type ObjectChecker interface {
 	Check() models.IstioValidations
 }
 
+// pendingChange is an Istio object created or modified in memory, but not persisted. When validating, it replaces
+// the stored object with the same name or it is added to the objects of its type.
+type pendingChange struct {
+	resourceType string
+	object       kubernetes.IstioObject
+}
+
+// Interval of the traffic where the callers of the services are observed
+const observedCallersInterval = "10m"
+
+// Limit of the namespaces validated at the same time by GetMeshValidations, as each one fetches its own objects
+const maxNamespaceValidations = 10
+
+// meshValidationDetails are the objects of the whole mesh needed to validate a namespace
+type meshValidationDetails struct {
+	namespaces            models.Namespaces
+	workloadsPerNamespace map[string]models.WorkloadList
+	gatewaysPerNamespace  [][]kubernetes.IstioObject
+	// DestinationRules of every namespace, mesh-wide PeerAuthentications, auto mTLS and trust domains
+	mtlsDetails kubernetes.MTLSDetails
+	// Gateways of every namespace and GatewayClasses
+	gatewayAPIDetails kubernetes.GatewayAPIDetails
+}
+
 // GetValidations returns an IstioValidations object with all the checks found when running
 // all the enabled checkers. If service is "" then the whole namespace is validated.
 func (in *IstioValidationsService) GetValidations(namespace, service string) (models.IstioValidations, error) {
@@ -32,6 +64,106 @@ func (in *IstioValidationsService) GetValidations(namespace, service string) (mo
 	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioValidationsService", "GetValidations")
 	defer promtimer.ObserveNow(&err)
 
+	validations, err := in.getValidations(namespace, service, nil, nil)
+	return validations, err
+}
+
+// GetValidationsWithChange returns the validations of the namespace as if the given Istio object was stored,
+// replacing the object with the same name if it exists. The object is only changed in memory, nothing is persisted.
+func (in *IstioValidationsService) GetValidationsWithChange(namespace, resourceType string, object kubernetes.IstioObject) (models.IstioValidations, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioValidationsService", "GetValidationsWithChange")
+	defer promtimer.ObserveNow(&err)
+
+	validations, err := in.getValidations(namespace, "", []pendingChange{{resourceType: resourceType, object: object}}, nil)
+	return validations, err
+}
+
+// GetValidationsWithChanges returns the validations of the namespace as if all the given Istio objects, by resource
+// type, were stored. The objects are only changed in memory, nothing is persisted.
+func (in *IstioValidationsService) GetValidationsWithChanges(namespace string, objects map[string][]kubernetes.IstioObject) (models.IstioValidations, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioValidationsService", "GetValidationsWithChanges")
+	defer promtimer.ObserveNow(&err)
+
+	changes := []pendingChange{}
+	for resourceType, objs := range objects {
+		for _, object := range objs {
+			changes = append(changes, pendingChange{resourceType: resourceType, object: object})
+		}
+	}
+	validations, err := in.getValidations(namespace, "", changes, nil)
+	return validations, err
+}
+
+// GetMeshValidations returns the validations of every namespace accessible by the user. The objects shared by the
+// namespaces are fetched once, then namespaces are validated concurrently, at most maxNamespaceValidations at a time.
+// The validations of each namespace hold only the objects of that namespace.
+func (in *IstioValidationsService) GetMeshValidations() (models.NamespaceValidations, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioValidationsService", "GetMeshValidations")
+	defer promtimer.ObserveNow(&err)
+
+	wg := sync.WaitGroup{}
+	errChan := make(chan error, 1)
+
+	mesh := &meshValidationDetails{}
+	wg.Add(1)
+	go in.fetchMeshValidationDetails(mesh, errChan, &wg)
+	wg.Wait()
+	if len(errChan) > 0 {
+		err = <-errChan
+		return nil, err
+	}
+
+	perNamespace := make([]models.IstioValidations, len(mesh.namespaces))
+	running := make(chan struct{}, maxNamespaceValidations)
+	for i, ns := range mesh.namespaces {
+		if len(errChan) > 0 {
+			break
+		}
+		wg.Add(1)
+		running <- struct{}{}
+		go func(i int, namespace string) {
+			defer func() {
+				<-running
+				wg.Done()
+			}()
+			if len(errChan) > 0 {
+				return
+			}
+			validations, err := in.getValidations(namespace, "", nil, mesh)
+			if err != nil {
+				select {
+				case errChan <- err:
+				default:
+				}
+				return
+			}
+			perNamespace[i] = validations.FilterByNamespace(namespace)
+		}(i, ns.Name)
+	}
+	wg.Wait()
+	close(errChan)
+	for e := range errChan {
+		if e != nil {
+			err = e
+			return nil, err
+		}
+	}
+
+	meshValidations := make(models.NamespaceValidations, len(mesh.namespaces))
+	for i, ns := range mesh.namespaces {
+		meshValidations[ns.Name] = perNamespace[i]
+	}
+	return meshValidations, nil
+}
+
+// getValidations validates the namespace, or only the service when it is not "". The objects of other namespaces
+// are fetched unless they are given as mesh details, these are only read.
+func (in *IstioValidationsService) getValidations(namespace, service string, changes []pendingChange, mesh *meshValidationDetails) (models.IstioValidations, error) {
+	var err error
+
 	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
 	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
 	if _, err = in.businessLayer.Namespace.GetNamespace(namespace); err != nil {
@@ -54,16 +186,21 @@ func (in *IstioValidationsService) GetValidations(namespace, service string) (mo
 
 	var istioDetails kubernetes.IstioDetails
 	var services []core_v1.Service
-	var namespaces models.Namespaces
 	var pods []core_v1.Pod
 	var workloads models.WorkloadList
-	var workloadsPerNamespace map[string]models.WorkloadList
-	var gatewaysPerNamespace [][]kubernetes.IstioObject
 	var mtlsDetails kubernetes.MTLSDetails
 	var rbacDetails kubernetes.RBACDetails
 	var deployments []apps_v1.Deployment
+	var networkPolicies []networking_v1.NetworkPolicy
+	var gatewayAPIDetails kubernetes.GatewayAPIDetails
 
-	wg.Add(8) // We need to add these here to make sure we don't execute wg.Wait() before scheduler has started goroutines
+	wg.Add(7) // We need to add these here to make sure we don't execute wg.Wait() before scheduler has started goroutines
+
+	if mesh == nil {
+		mesh = &meshValidationDetails{}
+		wg.Add(1)
+		go in.fetchMeshValidationDetails(mesh, errChan, &wg)
+	}
 
 	if service != "" {
 		// These resources are not used if no service is targeted
@@ -74,13 +211,12 @@ func (in *IstioValidationsService) GetValidations(namespace, service string) (mo
 
 	// We fetch without target service as some validations will require full-namespace details
 	go in.fetchDetails(&istioDetails, namespace, errChan, &wg)
-	go in.fetchNamespaces(&namespaces, errChan, &wg)
 	go in.fetchWorkloads(&workloads, namespace, errChan, &wg)
-	go in.fetchAllWorkloads(&workloadsPerNamespace, errChan, &wg)
-	go in.fetchGatewaysPerNamespace(&gatewaysPerNamespace, errChan, &wg)
-	go in.fetchNonLocalmTLSConfigs(&mtlsDetails, namespace, errChan, &wg)
+	go in.fetchPeerAuthentications(&mtlsDetails.PeerAuthentications, namespace, errChan, &wg)
 	go in.fetchAuthorizationDetails(&rbacDetails, namespace, errChan, &wg)
 	go in.fetchServices(&services, namespace, errChan, &wg)
+	go in.fetchNetworkPolicies(&networkPolicies, namespace, errChan, &wg)
+	go in.fetchK8sRoutes(&gatewayAPIDetails, namespace, errChan, &wg)
 
 	wg.Wait()
 	close(errChan)
@@ -90,14 +226,60 @@ func (in *IstioValidationsService) GetValidations(namespace, service string) (mo
 		}
 	}
 
-	objectCheckers := in.getAllObjectCheckers(namespace, istioDetails, services, workloadsPerNamespace, workloads, gatewaysPerNamespace, mtlsDetails, rbacDetails, namespaces)
+	// The mesh details may be shared with the validations of other namespaces, changes only replace their slices
+	namespaces := mesh.namespaces
+	workloadsPerNamespace := mesh.workloadsPerNamespace
+	gatewaysPerNamespace := append([][]kubernetes.IstioObject{}, mesh.gatewaysPerNamespace...)
+	mtlsDetails.DestinationRules = mesh.mtlsDetails.DestinationRules
+	mtlsDetails.MeshPeerAuthentications = mesh.mtlsDetails.MeshPeerAuthentications
+	mtlsDetails.EnabledAutoMtls = mesh.mtlsDetails.EnabledAutoMtls
+	mtlsDetails.TrustDomains = mesh.mtlsDetails.TrustDomains
+	gatewayAPIDetails.Gateways = mesh.gatewayAPIDetails.Gateways
+	gatewayAPIDetails.GatewayClasses = mesh.gatewayAPIDetails.GatewayClasses
+
+	// Pods and callers are only used by the NetworkPolicy checker when there are NetworkPolicies
+	var observedCallers map[string][]networkpolicies.Peer
+	if len(networkPolicies) > 0 {
+		if service == "" {
+			// Namespace access is checked above
+			if IsNamespaceCached(namespace) {
+				pods, err = kialiCache.GetPods(namespace, "")
+			} else {
+				pods, err = in.k8s.GetPods(namespace, "")
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		observedCallers = in.fetchObservedCallers(namespace, namespaces, workloadsPerNamespace)
+	}
+
+	// The changes are applied before fetching the secrets and service accounts, as they depend on the objects validated
+	for _, change := range changes {
+		change.apply(&istioDetails, &gatewaysPerNamespace, &mtlsDetails, &rbacDetails, &gatewayAPIDetails)
+	}
+
+	secretsPerNamespace, err := in.fetchGatewaySecrets(namespace, gatewaysPerNamespace, workloadsPerNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccounts, err := in.fetchServiceAccounts(rbacDetails, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	objectCheckers := in.getAllObjectCheckers(namespace, istioDetails, services, workloadsPerNamespace, workloads, gatewaysPerNamespace, secretsPerNamespace, mtlsDetails, rbacDetails, serviceAccounts, namespaces)
+	objectCheckers = append(objectCheckers, checkers.NetworkPolicyChecker{Namespace: namespace, Namespaces: namespaces, NetworkPolicies: networkPolicies, Services: services, Pods: pods, VirtualServices: istioDetails.VirtualServices, GatewaysPerNamespace: gatewaysPerNamespace, ObservedCallers: observedCallers})
+	objectCheckers = append(objectCheckers, in.getGatewayAPICheckers(namespace, namespaces, gatewayAPIDetails, services)...)
 
 	if service != "" {
 		objectCheckers = append(objectCheckers, in.getServiceCheckers(namespace, services, deployments, pods)...)
 	}
 
 	// Get group validations for same kind istio objects
-	validations := runObjectCheckers(objectCheckers)
+	suppressions := getCheckSuppressions(istioDetails, gatewaysPerNamespace, mtlsDetails, rbacDetails, gatewayAPIDetails)
+	validations := runObjectCheckers(objectCheckers, suppressions)
 	if service != "" {
 		validations = validations.FilterBySingleType("service", service)
 	}
@@ -111,17 +293,26 @@ func (in *IstioValidationsService) getServiceCheckers(namespace string, services
 	}
 }
 
-func (in *IstioValidationsService) getAllObjectCheckers(namespace string, istioDetails kubernetes.IstioDetails, services []core_v1.Service, workloadsPerNamespace map[string]models.WorkloadList, workloads models.WorkloadList, gatewaysPerNamespace [][]kubernetes.IstioObject, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, namespaces []models.Namespace) []ObjectChecker {
+func (in *IstioValidationsService) getGatewayAPICheckers(namespace string, namespaces models.Namespaces, gatewayAPIDetails kubernetes.GatewayAPIDetails, services []core_v1.Service) []ObjectChecker {
+	return []ObjectChecker{
+		checkers.K8sGatewayChecker{Namespace: namespace, K8sGateways: gatewayAPIDetails.Gateways, GatewayClasses: gatewayAPIDetails.GatewayClasses},
+		checkers.K8sRouteChecker{Namespace: namespace, Namespaces: namespaces, K8sHTTPRoutes: gatewayAPIDetails.HTTPRoutes, K8sTCPRoutes: gatewayAPIDetails.TCPRoutes, K8sGateways: gatewayAPIDetails.Gateways, Services: services},
+	}
+}
+
+func (in *IstioValidationsService) getAllObjectCheckers(namespace string, istioDetails kubernetes.IstioDetails, services []core_v1.Service, workloadsPerNamespace map[string]models.WorkloadList, workloads models.WorkloadList, gatewaysPerNamespace [][]kubernetes.IstioObject, secretsPerNamespace map[string][]core_v1.Secret, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, serviceAccounts map[string][]string, namespaces []models.Namespace) []ObjectChecker {
 	return []ObjectChecker{
 		checkers.NoServiceChecker{Namespace: namespace, Namespaces: namespaces, IstioDetails: &istioDetails, Services: services, WorkloadList: workloads, GatewaysPerNamespace: gatewaysPerNamespace, AuthorizationDetails: &rbacDetails},
 		checkers.VirtualServiceChecker{Namespace: namespace, Namespaces: namespaces, DestinationRules: istioDetails.DestinationRules, VirtualServices: istioDetails.VirtualServices},
 		checkers.DestinationRulesChecker{Namespaces: namespaces, DestinationRules: istioDetails.DestinationRules, MTLSDetails: mtlsDetails, ServiceEntries: istioDetails.ServiceEntries},
-		checkers.GatewayChecker{GatewaysPerNamespace: gatewaysPerNamespace, Namespace: namespace, WorkloadsPerNamespace: workloadsPerNamespace},
+		checkers.GatewayChecker{GatewaysPerNamespace: gatewaysPerNamespace, Namespace: namespace, WorkloadsPerNamespace: workloadsPerNamespace, SecretsPerNamespace: secretsPerNamespace},
 		checkers.PeerAuthenticationChecker{PeerAuthentications: mtlsDetails.PeerAuthentications, MTLSDetails: mtlsDetails, WorkloadList: workloads},
 		checkers.ServiceEntryChecker{ServiceEntries: istioDetails.ServiceEntries},
-		checkers.AuthorizationPolicyChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Namespace: namespace, Namespaces: namespaces, Services: services, ServiceEntries: istioDetails.ServiceEntries, WorkloadList: workloads, MtlsDetails: mtlsDetails, VirtualServices: istioDetails.VirtualServices},
+		checkers.AuthorizationPolicyChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Namespace: namespace, Namespaces: namespaces, Services: services, ServiceEntries: istioDetails.ServiceEntries, WorkloadList: workloads, MtlsDetails: mtlsDetails, VirtualServices: istioDetails.VirtualServices, ServiceAccounts: serviceAccounts},
 		checkers.SidecarChecker{Sidecars: istioDetails.Sidecars, Namespaces: namespaces, WorkloadList: workloads, Services: services, ServiceEntries: istioDetails.ServiceEntries},
 		checkers.RequestAuthenticationChecker{RequestAuthentications: istioDetails.RequestAuthentications, WorkloadList: workloads},
+		checkers.TelemetryChecker{Telemetries: istioDetails.Telemetries, WorkloadList: workloads},
+		checkers.WasmPluginChecker{WasmPlugins: istioDetails.WasmPlugins, WorkloadList: workloads},
 	}
 }
 
@@ -138,6 +329,7 @@ func (in *IstioValidationsService) GetIstioObjectValidations(namespace string, o
 	var gatewaysPerNamespace [][]kubernetes.IstioObject
 	var mtlsDetails kubernetes.MTLSDetails
 	var rbacDetails kubernetes.RBACDetails
+	var gatewayAPIDetails kubernetes.GatewayAPIDetails
 
 	var objectCheckers []ObjectChecker
 
@@ -151,7 +343,7 @@ func (in *IstioValidationsService) GetIstioObjectValidations(namespace string, o
 	errChan := make(chan error, 1)
 
 	// Get all the Istio objects from a Namespace and all gateways from every namespace
-	wg.Add(8)
+	wg.Add(9)
 	go in.fetchNamespaces(&namespaces, errChan, &wg)
 	go in.fetchDetails(&istioDetails, namespace, errChan, &wg)
 	go in.fetchServices(&services, namespace, errChan, &wg)
@@ -160,14 +352,19 @@ func (in *IstioValidationsService) GetIstioObjectValidations(namespace string, o
 	go in.fetchGatewaysPerNamespace(&gatewaysPerNamespace, errChan, &wg)
 	go in.fetchNonLocalmTLSConfigs(&mtlsDetails, namespace, errChan, &wg)
 	go in.fetchAuthorizationDetails(&rbacDetails, namespace, errChan, &wg)
+	go in.fetchGatewayAPIDetails(&gatewayAPIDetails, namespace, errChan, &wg)
 	wg.Wait()
 
 	noServiceChecker := checkers.NoServiceChecker{Namespace: namespace, Namespaces: namespaces, IstioDetails: &istioDetails, Services: services, WorkloadList: workloads, GatewaysPerNamespace: gatewaysPerNamespace, AuthorizationDetails: &rbacDetails}
 
 	switch objectType {
 	case kubernetes.Gateways:
+		var secretsPerNamespace map[string][]core_v1.Secret
+		if secretsPerNamespace, err = in.fetchGatewaySecrets(namespace, gatewaysPerNamespace, workloadsPerNamespace); err != nil {
+			return nil, err
+		}
 		objectCheckers = []ObjectChecker{
-			checkers.GatewayChecker{GatewaysPerNamespace: gatewaysPerNamespace, Namespace: namespace, WorkloadsPerNamespace: workloadsPerNamespace},
+			checkers.GatewayChecker{GatewaysPerNamespace: gatewaysPerNamespace, Namespace: namespace, WorkloadsPerNamespace: workloadsPerNamespace, SecretsPerNamespace: secretsPerNamespace},
 		}
 	case kubernetes.VirtualServices:
 		virtualServiceChecker := checkers.VirtualServiceChecker{Namespace: namespace, Namespaces: namespaces, VirtualServices: istioDetails.VirtualServices, DestinationRules: istioDetails.DestinationRules}
@@ -183,9 +380,13 @@ func (in *IstioValidationsService) GetIstioObjectValidations(namespace string, o
 			WorkloadList: workloads, Services: services, ServiceEntries: istioDetails.ServiceEntries}
 		objectCheckers = []ObjectChecker{sidecarsChecker}
 	case kubernetes.AuthorizationPolicies:
+		var serviceAccounts map[string][]string
+		if serviceAccounts, err = in.fetchServiceAccounts(rbacDetails, namespaces); err != nil {
+			return nil, err
+		}
 		authPoliciesChecker := checkers.AuthorizationPolicyChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies,
 			Namespace: namespace, Namespaces: namespaces, Services: services, ServiceEntries: istioDetails.ServiceEntries,
-			WorkloadList: workloads, MtlsDetails: mtlsDetails, VirtualServices: istioDetails.VirtualServices}
+			WorkloadList: workloads, MtlsDetails: mtlsDetails, VirtualServices: istioDetails.VirtualServices, ServiceAccounts: serviceAccounts}
 		objectCheckers = []ObjectChecker{authPoliciesChecker}
 	case kubernetes.PeerAuthentications:
 		// Validations on PeerAuthentications
@@ -199,6 +400,16 @@ func (in *IstioValidationsService) GetIstioObjectValidations(namespace string, o
 		objectCheckers = []ObjectChecker{requestAuthnChecker}
 	case kubernetes.EnvoyFilters:
 		// Validation on EnvoyFilters are not yet in place
+	case kubernetes.Telemetries:
+		telemetryChecker := checkers.TelemetryChecker{Telemetries: istioDetails.Telemetries, WorkloadList: workloads}
+		objectCheckers = []ObjectChecker{telemetryChecker}
+	case kubernetes.WasmPlugins:
+		wasmPluginChecker := checkers.WasmPluginChecker{WasmPlugins: istioDetails.WasmPlugins, WorkloadList: workloads}
+		objectCheckers = []ObjectChecker{wasmPluginChecker}
+	case kubernetes.K8sGatewayClasses:
+		// Validation on GatewayClasses are not yet in place
+	case kubernetes.K8sGateways, kubernetes.K8sHTTPRoutes, kubernetes.K8sTCPRoutes:
+		objectCheckers = in.getGatewayAPICheckers(namespace, namespaces, gatewayAPIDetails, services)
 	default:
 		err = fmt.Errorf("object type not found: %v", objectType)
 	}
@@ -214,10 +425,69 @@ func (in *IstioValidationsService) GetIstioObjectValidations(namespace string, o
 		return models.IstioValidations{}, err
 	}
 
-	return runObjectCheckers(objectCheckers).FilterByKey(models.ObjectTypeSingular[objectType], object), nil
+	suppressions := getCheckSuppressions(istioDetails, gatewaysPerNamespace, mtlsDetails, rbacDetails, gatewayAPIDetails)
+	return runObjectCheckers(objectCheckers, suppressions).FilterByKey(models.ObjectTypeSingular[objectType], object), nil
+}
+
+// GetWorkloadValidations returns the validations of the workload, found by the workload checker
+func (in *IstioValidationsService) GetWorkloadValidations(namespace string, workload *models.Workload) (models.IstioValidations, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioValidationsService", "GetWorkloadValidations")
+	defer promtimer.ObserveNow(&err)
+
+	ns, err := in.businessLayer.Namespace.GetNamespace(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []core_v1.Pod
+	if len(workload.Labels) > 0 {
+		selector := labels.Set(workload.Labels).String()
+		if IsNamespaceCached(namespace) {
+			pods, err = kialiCache.GetPods(namespace, selector)
+		} else {
+			pods, err = in.k8s.GetPods(namespace, selector)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := models.WorkloadListItem{}
+	item.ParseWorkload(workload)
+	workloadChecker := checkers.WorkloadChecker{
+		Namespace:                       namespace,
+		Namespaces:                      models.Namespaces{*ns},
+		WorkloadList:                    models.WorkloadList{Namespace: *ns, Workloads: []models.WorkloadListItem{item}},
+		Pods:                            pods,
+		HoldApplicationUntilProxyStarts: in.isHoldApplicationUntilProxyStartsEnabled(),
+	}
+	return runObjectCheckers([]ObjectChecker{workloadChecker}, models.CheckSuppressions{}), nil
+}
+
+// isHoldApplicationUntilProxyStartsEnabled returns the mesh-wide holdApplicationUntilProxyStarts setting
+func (in *IstioValidationsService) isHoldApplicationUntilProxyStartsEnabled() bool {
+	cfg := config.Get()
+	var istioConfig *core_v1.ConfigMap
+	var err error
+	if IsNamespaceCached(cfg.IstioNamespace) {
+		istioConfig, err = kialiCache.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
+	} else {
+		istioConfig, err = in.k8s.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
+	}
+	if err != nil {
+		return false
+	}
+	icm, err := kubernetes.GetIstioConfigMap(istioConfig)
+	if err != nil {
+		return false
+	}
+	return icm.DefaultConfig.HoldApplicationUntilProxyStarts
 }
 
-func runObjectCheckers(objectCheckers []ObjectChecker) models.IstioValidations {
+// runObjectCheckers runs the checkers and merges their validations. Checks suppressed by the user are dropped or
+// downgraded, but kept as suppressed checks of the object.
+func runObjectCheckers(objectCheckers []ObjectChecker, suppressions models.CheckSuppressions) models.IstioValidations {
 	objectTypeValidations := models.IstioValidations{}
 
 	// Run checks for each IstioObject type
@@ -225,7 +495,105 @@ func runObjectCheckers(objectCheckers []ObjectChecker) models.IstioValidations {
 		objectTypeValidations.MergeValidations(objectChecker.Check())
 	}
 
-	return objectTypeValidations
+	return objectTypeValidations.ApplySuppressions(suppressions)
+}
+
+// getCheckSuppressions reads the checks suppressed through annotations on the validated Istio objects
+func getCheckSuppressions(istioDetails kubernetes.IstioDetails, gatewaysPerNamespace [][]kubernetes.IstioObject, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, gatewayAPIDetails kubernetes.GatewayAPIDetails) models.CheckSuppressions {
+	suppressions := models.CheckSuppressions{}
+	now := time.Now()
+	add := func(resourceType string, objects []kubernetes.IstioObject) {
+		for _, o := range objects {
+			meta := o.GetObjectMeta()
+			if suppression := models.ParseCheckSuppression(meta.Annotations, now); suppression != nil {
+				suppressions[models.BuildKey(models.ObjectTypeSingular[resourceType], meta.Name, meta.Namespace)] = suppression
+			}
+		}
+	}
+
+	add(kubernetes.VirtualServices, istioDetails.VirtualServices)
+	add(kubernetes.DestinationRules, istioDetails.DestinationRules)
+	add(kubernetes.ServiceEntries, istioDetails.ServiceEntries)
+	add(kubernetes.Sidecars, istioDetails.Sidecars)
+	add(kubernetes.RequestAuthentications, istioDetails.RequestAuthentications)
+	add(kubernetes.Telemetries, istioDetails.Telemetries)
+	add(kubernetes.WasmPlugins, istioDetails.WasmPlugins)
+	for _, gws := range gatewaysPerNamespace {
+		add(kubernetes.Gateways, gws)
+	}
+	add(kubernetes.PeerAuthentications, mtlsDetails.PeerAuthentications)
+	add(kubernetes.PeerAuthentications, mtlsDetails.MeshPeerAuthentications)
+	add(kubernetes.AuthorizationPolicies, rbacDetails.AuthorizationPolicies)
+	add(kubernetes.K8sGateways, gatewayAPIDetails.Gateways)
+	add(kubernetes.K8sHTTPRoutes, gatewayAPIDetails.HTTPRoutes)
+	add(kubernetes.K8sTCPRoutes, gatewayAPIDetails.TCPRoutes)
+	return suppressions
+}
+
+// apply replaces or adds the changed object on every set of fetched objects where the checkers look for it
+func (pc pendingChange) apply(istioDetails *kubernetes.IstioDetails, gatewaysPerNamespace *[][]kubernetes.IstioObject, mtlsDetails *kubernetes.MTLSDetails, rbacDetails *kubernetes.RBACDetails, gatewayAPIDetails *kubernetes.GatewayAPIDetails) {
+	switch pc.resourceType {
+	case kubernetes.VirtualServices:
+		istioDetails.VirtualServices = pc.replace(istioDetails.VirtualServices)
+	case kubernetes.DestinationRules:
+		istioDetails.DestinationRules = pc.replace(istioDetails.DestinationRules)
+		mtlsDetails.DestinationRules = pc.replace(mtlsDetails.DestinationRules)
+	case kubernetes.ServiceEntries:
+		istioDetails.ServiceEntries = pc.replace(istioDetails.ServiceEntries)
+	case kubernetes.Sidecars:
+		istioDetails.Sidecars = pc.replace(istioDetails.Sidecars)
+	case kubernetes.RequestAuthentications:
+		istioDetails.RequestAuthentications = pc.replace(istioDetails.RequestAuthentications)
+	case kubernetes.Telemetries:
+		istioDetails.Telemetries = pc.replace(istioDetails.Telemetries)
+	case kubernetes.WasmPlugins:
+		istioDetails.WasmPlugins = pc.replace(istioDetails.WasmPlugins)
+	case kubernetes.Gateways:
+		istioDetails.Gateways = pc.replace(istioDetails.Gateways)
+		namespace := pc.object.GetObjectMeta().Namespace
+		for i, gws := range *gatewaysPerNamespace {
+			for _, gw := range gws {
+				if gw.GetObjectMeta().Namespace == namespace {
+					(*gatewaysPerNamespace)[i] = pc.replace(gws)
+					return
+				}
+			}
+		}
+		*gatewaysPerNamespace = append(*gatewaysPerNamespace, []kubernetes.IstioObject{pc.object})
+	case kubernetes.PeerAuthentications:
+		mtlsDetails.PeerAuthentications = pc.replace(mtlsDetails.PeerAuthentications)
+		if pc.object.GetObjectMeta().Namespace == config.Get().IstioNamespace {
+			mtlsDetails.MeshPeerAuthentications = pc.replace(mtlsDetails.MeshPeerAuthentications)
+		}
+	case kubernetes.AuthorizationPolicies:
+		rbacDetails.AuthorizationPolicies = pc.replace(rbacDetails.AuthorizationPolicies)
+	case kubernetes.K8sGateways:
+		gatewayAPIDetails.Gateways = pc.replace(gatewayAPIDetails.Gateways)
+	case kubernetes.K8sHTTPRoutes:
+		gatewayAPIDetails.HTTPRoutes = pc.replace(gatewayAPIDetails.HTTPRoutes)
+	case kubernetes.K8sTCPRoutes:
+		gatewayAPIDetails.TCPRoutes = pc.replace(gatewayAPIDetails.TCPRoutes)
+	}
+}
+
+// replace returns a copy of the objects where the changed object replaces the one with the same name and namespace,
+// or where it is added if there is no such object. Fetched objects may be shared with the cache, so they aren't modified.
+func (pc pendingChange) replace(objects []kubernetes.IstioObject) []kubernetes.IstioObject {
+	meta := pc.object.GetObjectMeta()
+	result := make([]kubernetes.IstioObject, 0, len(objects)+1)
+	found := false
+	for _, o := range objects {
+		if o.GetObjectMeta().Name == meta.Name && o.GetObjectMeta().Namespace == meta.Namespace {
+			result = append(result, pc.object)
+			found = true
+		} else {
+			result = append(result, o)
+		}
+	}
+	if !found {
+		result = append(result, pc.object)
+	}
+	return result
 }
 
 // The following idea is used underneath: if errChan has at least one record, we'll effectively cancel the request (if scheduled in such order). On the other hand, if we can't
@@ -263,6 +631,74 @@ func (in *IstioValidationsService) fetchGatewaysPerNamespace(gatewaysPerNamespac
 	}
 }
 
+// fetchGatewayAPIDetails fetches the Kubernetes Gateway API objects when its CRDs are installed: the routes of the
+// namespace, the Gateways of every namespace, as routes can be attached to them, and the GatewayClasses
+func (in *IstioValidationsService) fetchGatewayAPIDetails(rValue *kubernetes.GatewayAPIDetails, namespace string, errChan chan error, wg *sync.WaitGroup) {
+	defer wg.Done()
+	wg.Add(2)
+	go in.fetchK8sGateways(rValue, errChan, wg)
+	go in.fetchK8sRoutes(rValue, namespace, errChan, wg)
+}
+
+// k8sGatewayAPIFetcher returns a fetcher of a Gateway API resource type, from the cache when it holds the namespace
+func (in *IstioValidationsService) k8sGatewayAPIFetcher(resourceType string) func(string) ([]kubernetes.IstioObject, error) {
+	return func(namespace string) ([]kubernetes.IstioObject, error) {
+		if IsResourceCached(namespace, resourceType) {
+			return kialiCache.GetIstioObjects(namespace, resourceType, "")
+		}
+		return in.k8s.GetIstioObjects(namespace, resourceType, "")
+	}
+}
+
+// fetchK8sGateways fetches the Gateway API Gateways of every namespace and the GatewayClasses, when its CRDs are installed
+func (in *IstioValidationsService) fetchK8sGateways(rValue *kubernetes.GatewayAPIDetails, errChan chan error, wg *sync.WaitGroup) {
+	defer wg.Done()
+	if len(errChan) > 0 || !in.k8s.IsGatewayAPI() {
+		return
+	}
+
+	nss, err := in.businessLayer.Namespace.GetNamespaces()
+	if err != nil {
+		select {
+		case errChan <- err:
+		default:
+		}
+		return
+	}
+
+	gwss := make([][]kubernetes.IstioObject, len(nss))
+	wg2 := sync.WaitGroup{}
+	wg2.Add(len(nss))
+	for i, ns := range nss {
+		go fetchIstioObjects(&gwss[i], ns.Name, in.k8sGatewayAPIFetcher(kubernetes.K8sGateways), &wg2, errChan)
+	}
+	wg2.Wait()
+	for _, gws := range gwss {
+		rValue.Gateways = append(rValue.Gateways, gws...)
+	}
+
+	// GatewayClasses are cluster scoped, users may not be allowed to read them
+	if gatewayClasses, err := in.k8s.GetIstioObjects("", kubernetes.K8sGatewayClasses, ""); err == nil {
+		rValue.GatewayClasses = gatewayClasses
+	} else {
+		log.Debugf("GatewayClasses can't be read, they won't be validated: %v", err)
+	}
+}
+
+// fetchK8sRoutes fetches the Gateway API routes of the namespace, when its CRDs are installed
+func (in *IstioValidationsService) fetchK8sRoutes(rValue *kubernetes.GatewayAPIDetails, namespace string, errChan chan error, wg *sync.WaitGroup) {
+	defer wg.Done()
+	if len(errChan) > 0 || !in.k8s.IsGatewayAPI() {
+		return
+	}
+
+	wg2 := sync.WaitGroup{}
+	wg2.Add(2)
+	go fetchIstioObjects(&rValue.HTTPRoutes, namespace, in.k8sGatewayAPIFetcher(kubernetes.K8sHTTPRoutes), &wg2, errChan)
+	go fetchIstioObjects(&rValue.TCPRoutes, namespace, in.k8sGatewayAPIFetcher(kubernetes.K8sTCPRoutes), &wg2, errChan)
+	wg2.Wait()
+}
+
 func fetchIstioObjects(rValue *[]kubernetes.IstioObject, namespace string, fetcher func(string) ([]kubernetes.IstioObject, error), wg *sync.WaitGroup, errChan chan error) {
 	defer wg.Done()
 	if len(errChan) == 0 {
@@ -316,6 +752,26 @@ func (in *IstioValidationsService) fetchServices(rValue *[]core_v1.Service, name
 	}
 }
 
+func (in *IstioValidationsService) fetchNetworkPolicies(rValue *[]networking_v1.NetworkPolicy, namespace string, errChan chan error, wg *sync.WaitGroup) {
+	defer wg.Done()
+	if len(errChan) == 0 {
+		networkPolicies, err := in.k8s.GetNetworkPolicies(namespace)
+		if err != nil {
+			// NetworkPolicies are only used for some checks, users not allowed to list them still get the rest
+			if errors.IsForbidden(err) {
+				log.Debugf("NetworkPolicies of namespace [%s] are not validated: %v", namespace, err)
+				return
+			}
+			select {
+			case errChan <- err:
+			default:
+			}
+		} else {
+			*rValue = networkPolicies
+		}
+	}
+}
+
 func (in *IstioValidationsService) fetchDeployments(rValue *[]apps_v1.Deployment, namespace string, errChan chan error, wg *sync.WaitGroup) {
 	defer wg.Done()
 	if len(errChan) == 0 {
@@ -383,7 +839,10 @@ func (in *IstioValidationsService) fetchAllWorkloads(rValue *map[string]models.W
 	if len(errChan) == 0 {
 		nss, err := in.businessLayer.Namespace.GetNamespaces()
 		if err != nil {
-			errChan <- err
+			select {
+			case errChan <- err:
+			default:
+			}
 			return
 
 		}
@@ -408,7 +867,7 @@ func (in *IstioValidationsService) fetchDetails(rValue *kubernetes.IstioDetails,
 	if len(errChan) == 0 {
 		var err error
 		wg2 := sync.WaitGroup{}
-		errChan2 := make(chan error, 5)
+		errChan2 := make(chan error, 8)
 		istioDetails := kubernetes.IstioDetails{}
 
 		if IsResourceCached(namespace, kubernetes.VirtualServices) {
@@ -465,6 +924,21 @@ func (in *IstioValidationsService) fetchDetails(rValue *kubernetes.IstioDetails,
 			}
 			go fetchIstioObjects(&istioDetails.RequestAuthentications, namespace, getRequestAuthentications, &wg2, errChan2)
 		}
+		// Telemetry and WasmPlugin CRDs are only present in recent Istio versions
+		if in.k8s.HasIstioResource(kubernetes.Telemetries) {
+			wg2.Add(1)
+			getTelemetries := func(namespace string) ([]kubernetes.IstioObject, error) {
+				return in.k8s.GetIstioObjects(namespace, kubernetes.Telemetries, "")
+			}
+			go fetchIstioObjects(&istioDetails.Telemetries, namespace, getTelemetries, &wg2, errChan2)
+		}
+		if in.k8s.HasIstioResource(kubernetes.WasmPlugins) {
+			wg2.Add(1)
+			getWasmPlugins := func(namespace string) ([]kubernetes.IstioObject, error) {
+				return in.k8s.GetIstioObjects(namespace, kubernetes.WasmPlugins, "")
+			}
+			go fetchIstioObjects(&istioDetails.WasmPlugins, namespace, getWasmPlugins, &wg2, errChan2)
+		}
 		wg2.Wait()
 
 		// Error may come either from errChan2 (when goroutines are used / without cache) or err (with cache / synchronous)
@@ -484,12 +958,21 @@ func (in *IstioValidationsService) fetchDetails(rValue *kubernetes.IstioDetails,
 }
 
 func (in *IstioValidationsService) fetchNonLocalmTLSConfigs(mtlsDetails *kubernetes.MTLSDetails, namespace string, errChan chan error, wg *sync.WaitGroup) {
+	defer wg.Done()
+	wg.Add(2)
+	go in.fetchMeshmTLSConfigs(mtlsDetails, errChan, wg)
+	go in.fetchPeerAuthentications(&mtlsDetails.PeerAuthentications, namespace, errChan, wg)
+}
+
+// fetchMeshmTLSConfigs fetches the mTLS configuration of the whole mesh: the mesh-wide PeerAuthentications, auto mTLS,
+// the trust domains and the DestinationRules of every namespace
+func (in *IstioValidationsService) fetchMeshmTLSConfigs(mtlsDetails *kubernetes.MTLSDetails, errChan chan error, wg *sync.WaitGroup) {
 	defer wg.Done()
 	if len(errChan) > 0 {
 		return
 	}
 
-	wg.Add(3)
+	wg.Add(2)
 
 	go func(details *kubernetes.MTLSDetails) {
 		defer wg.Done()
@@ -500,29 +983,18 @@ func (in *IstioValidationsService) fetchNonLocalmTLSConfigs(mtlsDetails *kuberne
 			if meshpeerauths, iErr = kialiCache.GetIstioObjects(config.Get().IstioNamespace, kubernetes.PeerAuthentications, ""); iErr == nil {
 				details.MeshPeerAuthentications = meshpeerauths
 			} else {
-				errChan <- iErr
+				select {
+				case errChan <- iErr:
+				default:
+				}
 			}
 		} else if meshpeerauths, iErr = in.k8s.GetIstioObjects(config.Get().IstioNamespace, kubernetes.PeerAuthentications, ""); iErr == nil {
 			details.MeshPeerAuthentications = meshpeerauths
 		} else if !checkForbidden("GetMeshPolicies", iErr, "probably Kiali doesn't have cluster permissions") {
-			errChan <- iErr
-		}
-	}(mtlsDetails)
-
-	go func(details *kubernetes.MTLSDetails) {
-		defer wg.Done()
-
-		var peerAuthns []kubernetes.IstioObject
-		var err error
-		if IsResourceCached(namespace, kubernetes.PeerAuthentications) {
-			peerAuthns, err = kialiCache.GetIstioObjects(namespace, kubernetes.PeerAuthentications, "")
-		} else {
-			peerAuthns, err = in.k8s.GetIstioObjects(namespace, kubernetes.PeerAuthentications, "")
-		}
-		if err != nil {
-			errChan <- err
-		} else {
-			details.PeerAuthentications = peerAuthns
+			select {
+			case errChan <- iErr:
+			default:
+			}
 		}
 	}(mtlsDetails)
 
@@ -538,20 +1010,30 @@ func (in *IstioValidationsService) fetchNonLocalmTLSConfigs(mtlsDetails *kuberne
 			istioConfig, err = in.k8s.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
 		}
 		if err != nil {
-			errChan <- err
+			select {
+			case errChan <- err:
+			default:
+			}
 			return
 		}
 		icm, err := kubernetes.GetIstioConfigMap(istioConfig)
 		if err != nil {
-			errChan <- err
+			select {
+			case errChan <- err:
+			default:
+			}
 		} else {
 			details.EnabledAutoMtls = icm.GetEnableAutoMtls()
+			details.TrustDomains = icm.GetTrustDomains()
 		}
 	}(mtlsDetails)
 
 	namespaces, err := in.businessLayer.Namespace.GetNamespaces()
 	if err != nil {
-		errChan <- err
+		select {
+		case errChan <- err:
+		default:
+		}
 		return
 	}
 
@@ -562,12 +1044,167 @@ func (in *IstioValidationsService) fetchNonLocalmTLSConfigs(mtlsDetails *kuberne
 
 	destinationRules, err := in.businessLayer.TLS.getAllDestinationRules(nsNames)
 	if err != nil {
-		errChan <- err
+		select {
+		case errChan <- err:
+		default:
+		}
 	} else {
 		mtlsDetails.DestinationRules = destinationRules
 	}
 }
 
+// fetchPeerAuthentications fetches the PeerAuthentications of the namespace
+func (in *IstioValidationsService) fetchPeerAuthentications(rValue *[]kubernetes.IstioObject, namespace string, errChan chan error, wg *sync.WaitGroup) {
+	defer wg.Done()
+	if len(errChan) == 0 {
+		var peerAuthns []kubernetes.IstioObject
+		var err error
+		if IsResourceCached(namespace, kubernetes.PeerAuthentications) {
+			peerAuthns, err = kialiCache.GetIstioObjects(namespace, kubernetes.PeerAuthentications, "")
+		} else {
+			peerAuthns, err = in.k8s.GetIstioObjects(namespace, kubernetes.PeerAuthentications, "")
+		}
+		if err != nil {
+			select {
+			case errChan <- err:
+			default:
+			}
+		} else {
+			*rValue = peerAuthns
+		}
+	}
+}
+
+// fetchMeshValidationDetails fetches the objects of the whole mesh needed to validate a namespace
+func (in *IstioValidationsService) fetchMeshValidationDetails(rValue *meshValidationDetails, errChan chan error, wg *sync.WaitGroup) {
+	defer wg.Done()
+	if len(errChan) > 0 {
+		return
+	}
+
+	wg.Add(5)
+	go in.fetchNamespaces(&rValue.namespaces, errChan, wg)
+	go in.fetchAllWorkloads(&rValue.workloadsPerNamespace, errChan, wg)
+	go in.fetchGatewaysPerNamespace(&rValue.gatewaysPerNamespace, errChan, wg)
+	go in.fetchMeshmTLSConfigs(&rValue.mtlsDetails, errChan, wg)
+	go in.fetchK8sGateways(&rValue.gatewayAPIDetails, errChan, wg)
+}
+
+// fetchObservedCallers returns the workloads observed sending HTTP or gRPC traffic to the services of the namespace,
+// by service name. TCP traffic is not observed. Callers are ignored when Prometheus can't be queried.
+func (in *IstioValidationsService) fetchObservedCallers(namespace string, namespaces models.Namespaces, workloadsPerNamespace map[string]models.WorkloadList) map[string][]networkpolicies.Peer {
+	callers := map[string][]networkpolicies.Peer{}
+	if in.prom == nil {
+		return callers
+	}
+	rates, err := in.prom.GetNamespaceServicesRequestRates(namespace, observedCallersInterval, time.Now())
+	if err != nil {
+		log.Debugf("Callers of the services of namespace [%s] can't be observed: %v", namespace, err)
+		return callers
+	}
+
+	seen := map[string]bool{}
+	for _, sample := range rates {
+		service := string(sample.Metric["destination_service_name"])
+		sourceNamespace := string(sample.Metric["source_workload_namespace"])
+		sourceWorkload := string(sample.Metric["source_workload"])
+		// Traffic from outside the mesh has unknown sources
+		if service == "" || sourceNamespace == "" || sourceNamespace == "unknown" || sourceWorkload == "" || sourceWorkload == "unknown" {
+			continue
+		}
+		key := service + "/" + sourceNamespace + "/" + sourceWorkload
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		// Namespaces or workloads not accessible by the user may be any of the selected ones
+		caller := networkpolicies.Peer{Namespace: models.Namespace{Name: sourceNamespace}, AnyNamespace: true}
+		for _, ns := range namespaces {
+			if ns.Name == sourceNamespace {
+				caller.Namespace, caller.AnyNamespace = ns, false
+			}
+		}
+		for _, w := range workloadsPerNamespace[sourceNamespace].Workloads {
+			if w.Name == sourceWorkload {
+				caller.Labels = w.Labels
+			}
+		}
+		callers[service] = append(callers[service], caller)
+	}
+	return callers
+}
+
+// fetchGatewaySecrets fetches the secrets referenced through credentialName by the Gateways of the namespace, in the
+// namespaces where their gateway workloads live. Only the metadata of the secrets found is kept. Namespaces whose
+// secrets can't be read are skipped.
+func (in *IstioValidationsService) fetchGatewaySecrets(namespace string, gatewaysPerNamespace [][]kubernetes.IstioObject, workloadsPerNamespace map[string]models.WorkloadList) (map[string][]core_v1.Secret, error) {
+	secretsPerNamespace := map[string][]core_v1.Secret{}
+	fetched := map[string]bool{}
+	forbidden := map[string]bool{}
+	for _, nsGws := range gatewaysPerNamespace {
+		for _, gw := range nsGws {
+			if gw.GetObjectMeta().Namespace != namespace {
+				continue
+			}
+			credentialNames := gateways.CredentialNames(gw)
+			if len(credentialNames) == 0 {
+				continue
+			}
+			for _, ns := range gateways.GatewayWorkloadNamespaces(gw, workloadsPerNamespace) {
+				for _, name := range credentialNames {
+					if forbidden[ns] || fetched[ns+"/"+name] {
+						continue
+					}
+					fetched[ns+"/"+name] = true
+					secret, err := in.k8s.GetSecret(ns, name)
+					switch {
+					case err == nil:
+						secretsPerNamespace[ns] = append(secretsPerNamespace[ns], core_v1.Secret{ObjectMeta: secret.ObjectMeta})
+					case errors.IsNotFound(err):
+						// The namespace is known, the checker reports the missing secret
+						if _, found := secretsPerNamespace[ns]; !found {
+							secretsPerNamespace[ns] = []core_v1.Secret{}
+						}
+					case checkForbidden("fetchGatewaySecrets", err, ""):
+						forbidden[ns] = true
+						delete(secretsPerNamespace, ns)
+					default:
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+	return secretsPerNamespace, nil
+}
+
+// fetchServiceAccounts fetches the names of the service accounts of the namespaces referenced by the principals
+// of the AuthorizationPolicies. Namespaces not accessible by the user are skipped.
+func (in *IstioValidationsService) fetchServiceAccounts(rbacDetails kubernetes.RBACDetails, namespaces models.Namespaces) (map[string][]string, error) {
+	serviceAccounts := map[string][]string{}
+	for _, ap := range rbacDetails.AuthorizationPolicies {
+		for _, ns := range authorization.PrincipalNamespaces(ap) {
+			if _, found := serviceAccounts[ns]; found || !namespaces.Includes(ns) {
+				continue
+			}
+			sas, err := in.k8s.GetServiceAccounts(ns)
+			if err != nil {
+				if checkForbidden("fetchServiceAccounts", err, "") {
+					continue
+				}
+				return nil, err
+			}
+			names := make([]string, 0, len(sas))
+			for _, sa := range sas {
+				names = append(names, sa.Name)
+			}
+			serviceAccounts[ns] = names
+		}
+	}
+	return serviceAccounts, nil
+}
+
 func (in *IstioValidationsService) fetchAuthorizationDetails(rValue *kubernetes.RBACDetails, namespace string, errChan chan error, wg *sync.WaitGroup) {
 	defer wg.Done()
 	if len(errChan) == 0 {
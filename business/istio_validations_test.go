@@ -1,21 +1,25 @@
 package business
 
 import (
+	"strings"
 	"testing"
 
 	osapps_v1 "github.com/openshift/api/apps/v1"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	apps_v1 "k8s.io/api/apps/v1"
 	batch_v1 "k8s.io/api/batch/v1"
 	batch_v1beta1 "k8s.io/api/batch/v1beta1"
 	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/kubetest"
 	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
 	"github.com/kiali/kiali/tests/data"
 )
 
@@ -44,6 +48,32 @@ func TestGetIstioObjectValidations(t *testing.T) {
 	assert.NotEmpty(validations)
 }
 
+func TestGetMeshValidations(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vs := mockCombinedValidationService(fakeCombinedIstioDetails(), []string{"details", "product", "customer"}, fakePods())
+
+	validations, err := vs.GetMeshValidations()
+	assert.NoError(err)
+	assert.Len(validations, 2)
+	assert.True(validations["test"][models.IstioValidationKey{ObjectType: "virtualservice", Namespace: "test", Name: "product-vs"}].Valid)
+	for key := range validations["test2"] {
+		assert.Equal("test2", key.Namespace)
+	}
+
+	// The Gateways of every namespace are fetched once, plus once for the details of their own namespace
+	k8s := vs.k8s.(*kubetest.K8SClientMock)
+	gatewayCalls := 0
+	for _, call := range k8s.Calls {
+		if call.Method == "GetIstioObjects" && call.Arguments.String(0) == "test2" && call.Arguments.String(1) == "gateways" {
+			gatewayCalls++
+		}
+	}
+	assert.Equal(2, gatewayCalls)
+}
+
 func TestGatewayValidation(t *testing.T) {
 	assert := assert.New(t)
 	conf := config.NewConfig()
@@ -79,6 +109,7 @@ func mockMultiNamespaceGatewaysValidationService() IstioValidationsService {
 	k8s.On("GetIstioObjects", "test", "gateways", "").Return(getGateway("first"), nil)
 	k8s.On("GetIstioObjects", "test2", "gateways", "").Return(getGateway("second"), nil)
 	k8s.On("GetNamespaces", mock.AnythingOfType("string")).Return(fakeNamespaces(), nil)
+	k8s.On("GetNetworkPolicies", mock.AnythingOfType("string")).Return([]networking_v1.NetworkPolicy{}, nil)
 	mockWorkLoadService(k8s)
 	k8s.On("GetIstioObjects", mock.AnythingOfType("string"), "destinationrules", "").Return(fakeCombinedIstioDetails().DestinationRules, nil)
 	k8s.On("GetIstioObjects", mock.AnythingOfType("string"), "sidecars", "").Return(fakeCombinedIstioDetails().Sidecars, nil)
@@ -120,6 +151,7 @@ func mockCombinedValidationService(istioObjects *kubernetes.IstioDetails, servic
 	k8s.On("GetIstioObjects", "test2", "gateways", "").Return(getGateway("second"), nil)
 	k8s.On("GetIstioObjects", mock.AnythingOfType("string"), "gateways", "").Return(fakeCombinedIstioDetails().Gateways, nil)
 	k8s.On("GetNamespaces", mock.AnythingOfType("string")).Return(fakeNamespaces(), nil)
+	k8s.On("GetNetworkPolicies", mock.AnythingOfType("string")).Return([]networking_v1.NetworkPolicy{}, nil)
 
 	mockWorkLoadService(k8s)
 
@@ -218,3 +250,341 @@ func getGateway(name string) []kubernetes.IstioObject {
 			"app": "real",
 		}))}
 }
+
+func TestValidationsSuppressedChecks(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubernetes.NewMemoryClient()
+	err := k8s.LoadYAML(strings.NewReader(`
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+  annotations:
+    kiali.io/suppress-checks: KIA1101
+    kiali.io/suppress-checks-reason: ratings is deployed later
+spec:
+  hosts: [reviews]
+  http:
+  - route:
+    - destination:
+        host: ratings
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: details
+  annotations:
+    kiali.io/suppress-checks: KIA1101
+    kiali.io/suppress-checks-action: downgrade
+spec:
+  hosts: [details]
+  http:
+  - route:
+    - destination:
+        host: ratings
+`), "bookinfo")
+	assert.NoError(err)
+	k8s.AddConfigMap(core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: conf.ExternalServices.Istio.ConfigMapName, Namespace: conf.IstioNamespace}})
+
+	layer := NewWithBackends(k8s, nil, nil)
+	validations, err := layer.Validations.GetValidations("bookinfo", "")
+	assert.NoError(err)
+
+	reviews := validations[models.BuildKey("virtualservice", "reviews", "bookinfo")]
+	assert.True(reviews.Valid)
+	assert.Empty(reviews.Checks)
+	assert.Len(reviews.SuppressedChecks, 1)
+	assert.Equal("ratings is deployed later", reviews.Suppression.Reason)
+
+	details := validations[models.BuildKey("virtualservice", "details", "bookinfo")]
+	assert.True(details.Valid)
+	assert.Len(details.Checks, 1)
+	assert.Equal(models.WarningSeverity, details.Checks[0].Severity)
+	assert.Len(details.SuppressedChecks, 1)
+}
+
+func TestWorkloadValidations(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubernetes.NewMemoryClient()
+	err := k8s.LoadYAML(strings.NewReader(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: bookinfo
+  labels:
+    istio-injection: enabled
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: reviews-v1
+spec:
+  selector:
+    matchLabels: {app: reviews, version: v1}
+  template:
+    metadata:
+      labels: {app: reviews, version: v1}
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: reviews-v1-1234
+  labels: {app: reviews, version: v1}
+spec:
+  containers:
+  - name: reviews
+    command: [sh, -c, "curl http://details:9080/health && /opt/reviews"]
+    ports:
+    - containerPort: 15021
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: details
+spec:
+  selector:
+    matchLabels: {app: details}
+  template:
+    metadata:
+      labels: {app: details}
+`), "bookinfo")
+	assert.NoError(err)
+	k8s.AddConfigMap(core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: conf.ExternalServices.Istio.ConfigMapName, Namespace: conf.IstioNamespace}})
+
+	prom := new(prometheustest.PromClientMock)
+	layer := NewWithBackends(k8s, prom, nil)
+	workload := &models.Workload{}
+	workload.Name = "reviews-v1"
+	workload.Labels = map[string]string{"app": "reviews", "version": "v1"}
+	validations, err := layer.Validations.GetWorkloadValidations("bookinfo", workload)
+	assert.NoError(err)
+
+	reviews := validations[models.BuildKey("workload", "reviews-v1", "bookinfo")]
+	assert.NotNil(reviews)
+	assert.False(reviews.Valid)
+	codes := []string{}
+	for _, check := range reviews.Checks {
+		codes = append(codes, check.Code())
+	}
+	assert.ElementsMatch([]string{"KIA1203", "KIA1204", "KIA1205", "KIA1206"}, codes)
+
+	workload = &models.Workload{}
+	workload.Name = "details"
+	workload.Labels = map[string]string{"app": "details"}
+	validations, err = layer.Validations.GetWorkloadValidations("bookinfo", workload)
+	assert.NoError(err)
+	details := validations[models.BuildKey("workload", "details", "bookinfo")]
+	assert.NotNil(details)
+	assert.True(details.Valid)
+	assert.Len(details.Checks, 1)
+	assert.Equal("KIA1202", details.Checks[0].Code())
+
+	prom.AssertExpectations(t)
+
+	// Workloads are not Istio config, they are not part of the namespace validations
+	validations, err = layer.Validations.GetValidations("bookinfo", "")
+	assert.NoError(err)
+	assert.NotContains(validations, models.BuildKey("workload", "reviews-v1", "bookinfo"))
+	assert.NotContains(validations, models.BuildKey("workload", "details", "bookinfo"))
+}
+
+func TestNetworkPolicyValidations(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubernetes.NewMemoryClient()
+	err := k8s.LoadYAML(strings.NewReader(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: reviews
+spec:
+  selector: {app: reviews}
+  ports:
+  - name: http
+    port: 9080
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: reviews-v1-1234
+  labels: {app: reviews, version: v1}
+spec:
+  containers:
+  - name: reviews
+    ports:
+    - containerPort: 9080
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+spec:
+  hosts: [reviews]
+  http:
+  - route:
+    - destination:
+        host: reviews
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: deny-all
+spec:
+  podSelector: {}
+`), "bookinfo")
+	assert.NoError(err)
+	k8s.AddConfigMap(core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: conf.ExternalServices.Istio.ConfigMapName, Namespace: conf.IstioNamespace}})
+
+	layer := NewWithBackends(k8s, nil, nil)
+	validations, err := layer.Validations.GetValidations("bookinfo", "")
+	assert.NoError(err)
+
+	policy := validations[models.BuildKey("networkpolicy", "deny-all", "bookinfo")]
+	assert.NotNil(policy)
+	assert.True(policy.Valid)
+
+	service := validations[models.BuildKey("service", "reviews", "bookinfo")]
+	assert.NotNil(service)
+	assert.Len(service.Checks, 1)
+	assert.Equal("KIA0602", service.Checks[0].Code())
+
+	vs := validations[models.BuildKey("virtualservice", "reviews", "bookinfo")]
+	assert.NotNil(vs)
+	codes := []string{}
+	for _, check := range vs.Checks {
+		codes = append(codes, check.Code())
+	}
+	assert.Contains(codes, "KIA1109")
+}
+
+func TestNetworkPolicyObservedCallers(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubernetes.NewMemoryClient()
+	err := k8s.LoadYAML(strings.NewReader(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: reviews
+spec:
+  selector: {app: reviews}
+  ports:
+  - name: http
+    port: 9080
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: reviews-v1-1234
+  labels: {app: reviews, version: v1}
+spec:
+  containers:
+  - name: reviews
+    ports:
+    - containerPort: 9080
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: productpage-v1
+spec:
+  selector:
+    matchLabels: {app: productpage}
+  template:
+    metadata:
+      labels: {app: productpage}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ratings-v1
+spec:
+  selector:
+    matchLabels: {app: ratings}
+  template:
+    metadata:
+      labels: {app: ratings}
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: reviews
+spec:
+  podSelector:
+    matchLabels: {app: reviews}
+  ingress:
+  - from:
+    - podSelector:
+        matchLabels: {app: productpage}
+`), "bookinfo")
+	assert.NoError(err)
+	k8s.AddConfigMap(core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: conf.ExternalServices.Istio.ConfigMapName, Namespace: conf.IstioNamespace}})
+
+	caller := func(workload string) *model.Sample {
+		return &model.Sample{Metric: model.Metric{"destination_service_name": "reviews", "source_workload_namespace": "bookinfo", "source_workload": model.LabelValue(workload)}, Value: 1}
+	}
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetNamespaceServicesRequestRates", "bookinfo", "10m", mock.AnythingOfType("time.Time")).Return(model.Vector{caller("productpage-v1")}, nil).Once()
+	layer := NewWithBackends(k8s, prom, nil)
+
+	// Callers allowed by the policy
+	validations, err := layer.Validations.GetValidations("bookinfo", "")
+	assert.NoError(err)
+	_, found := validations[models.BuildKey("service", "reviews", "bookinfo")]
+	assert.False(found)
+
+	prom.On("GetNamespaceServicesRequestRates", "bookinfo", "10m", mock.AnythingOfType("time.Time")).Return(model.Vector{caller("productpage-v1"), caller("ratings-v1"), caller("unknown")}, nil)
+	validations, err = layer.Validations.GetValidations("bookinfo", "")
+	assert.NoError(err)
+	service := validations[models.BuildKey("service", "reviews", "bookinfo")]
+	assert.NotNil(service)
+	assert.Len(service.Checks, 1)
+	assert.Equal("KIA0603", service.Checks[0].Code())
+}
+
+func TestFetchGatewaySecrets(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	k8s := kubernetes.NewMemoryClient()
+	err := k8s.LoadYAML(strings.NewReader(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: bookinfo-cert
+data:
+  tls.key: a2V5
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: other-cert
+`), "istio-system")
+	assert.NoError(err)
+
+	gw := data.AddServerToGateway(data.AddTLSToServer("SIMPLE", "missing-cert", data.CreateServer([]string{"reviews.example.com"}, 443, "https", "HTTPS")),
+		data.AddServerToGateway(data.AddTLSToServer("SIMPLE", "bookinfo-cert", data.CreateServer([]string{"bookinfo.example.com"}, 443, "https", "HTTPS")),
+			data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", map[string]string{"istio": "ingressgateway"})))
+	workloadsPerNamespace := map[string]models.WorkloadList{
+		"istio-system": {Workloads: []models.WorkloadListItem{{Name: "istio-ingressgateway", Labels: map[string]string{"istio": "ingressgateway"}}}},
+	}
+
+	vs := IstioValidationsService{k8s: k8s}
+	secrets, err := vs.fetchGatewaySecrets("bookinfo", [][]kubernetes.IstioObject{{gw}}, workloadsPerNamespace)
+	assert.NoError(err)
+
+	// Only the referenced secrets are fetched, without their data
+	assert.Len(secrets["istio-system"], 1)
+	assert.Equal("bookinfo-cert", secrets["istio-system"][0].Name)
+	assert.Nil(secrets["istio-system"][0].Data)
+}
@@ -0,0 +1,140 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const gatewayAPIObjects = `
+apiVersion: gateway.networking.k8s.io/v1alpha2
+kind: GatewayClass
+metadata:
+  name: istio
+spec:
+  controllerName: istio.io/gateway-controller
+---
+apiVersion: gateway.networking.k8s.io/v1alpha2
+kind: Gateway
+metadata:
+  name: bookinfo-gateway
+spec:
+  gatewayClassName: istio
+  listeners:
+  - name: http
+    hostname: bookinfo.example.com
+    port: 80
+    protocol: HTTP
+  - name: http-2
+    hostname: bookinfo.example.com
+    port: 80
+    protocol: HTTP
+---
+apiVersion: gateway.networking.k8s.io/v1alpha2
+kind: HTTPRoute
+metadata:
+  name: reviews
+spec:
+  parentRefs:
+  - name: bookinfo-gateway
+  hostnames: [bookinfo.example.com]
+  rules:
+  - backendRefs:
+    - name: reviews
+      port: 9080
+---
+apiVersion: gateway.networking.k8s.io/v1alpha2
+kind: TCPRoute
+metadata:
+  name: ratings
+spec:
+  parentRefs:
+  - name: tcp-gateway
+  rules:
+  - backendRefs:
+    - name: ratings
+      port: 9080
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: Gateway
+metadata:
+  name: bookinfo-gateway
+spec:
+  selector:
+    istio: ingressgateway
+  servers: []
+`
+
+func TestGetIstioConfigListGatewayAPI(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, gatewayAPIObjects)
+
+	list, err := layer.IstioConfig.GetIstioConfigList(ParseIstioConfigCriteria("bookinfo", "", "", ""))
+	assert.NoError(err)
+	assert.Len(list.Gateways, 1)
+	assert.Len(list.K8sGateways, 1)
+	assert.Equal("istio", list.K8sGateways[0].Spec.GatewayClassName)
+	assert.Len(list.K8sHTTPRoutes, 1)
+	assert.Len(list.K8sTCPRoutes, 1)
+	// Cluster scoped, only on demand
+	assert.Empty(list.K8sGatewayClasses)
+
+	list, err = layer.IstioConfig.GetIstioConfigList(ParseIstioConfigCriteria("bookinfo", "k8sgatewayclasses,k8shttproutes", "", ""))
+	assert.NoError(err)
+	assert.Len(list.K8sGatewayClasses, 1)
+	assert.Len(list.K8sHTTPRoutes, 1)
+	assert.Empty(list.K8sGateways)
+	assert.Empty(list.Gateways)
+}
+
+func TestGetIstioConfigDetailsGatewayAPI(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, gatewayAPIObjects)
+
+	details, err := layer.IstioConfig.GetIstioConfigDetails("bookinfo", kubernetes.K8sHTTPRoutes, "reviews")
+	assert.NoError(err)
+	assert.NotNil(details.K8sHTTPRoute)
+	assert.Equal([]interface{}{"bookinfo.example.com"}, details.K8sHTTPRoute.Spec.Hostnames)
+
+	details, err = layer.IstioConfig.GetIstioConfigDetails("bookinfo", kubernetes.K8sGatewayClasses, "istio")
+	assert.NoError(err)
+	assert.NotNil(details.K8sGatewayClass)
+	assert.Equal("istio.io/gateway-controller", details.K8sGatewayClass.Spec.ControllerName)
+}
+
+func TestGatewayAPIValidations(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, gatewayAPIObjects)
+
+	validations, err := layer.Validations.GetValidations("bookinfo", "")
+	assert.NoError(err)
+
+	gw := validations[models.BuildKey(models.ObjectTypeSingular[kubernetes.K8sGateways], "bookinfo-gateway", "bookinfo")]
+	assert.NotNil(gw)
+	assert.False(gw.Valid)
+	assert.Len(gw.Checks, 2)
+	assert.Equal(models.CheckMessage("k8sgateways.listener.conflict"), gw.Checks[0].Message)
+
+	httpRoute := validations[models.BuildKey(models.ObjectTypeSingular[kubernetes.K8sHTTPRoutes], "reviews", "bookinfo")]
+	assert.NotNil(httpRoute)
+	assert.True(httpRoute.Valid)
+	assert.Empty(httpRoute.Checks)
+
+	tcpRoute := validations[models.BuildKey(models.ObjectTypeSingular[kubernetes.K8sTCPRoutes], "ratings", "bookinfo")]
+	assert.NotNil(tcpRoute)
+	assert.False(tcpRoute.Valid)
+	assert.Len(tcpRoute.Checks, 2)
+	assert.Equal(models.CheckMessage("k8sroutes.nogateway"), tcpRoute.Checks[0].Message)
+	assert.Equal(models.CheckMessage("k8sroutes.nobackend"), tcpRoute.Checks[1].Message)
+
+	// Istio Gateways with the same name are validated on their own
+	istioGw := validations[models.BuildKey(models.ObjectTypeSingular[kubernetes.Gateways], "bookinfo-gateway", "bookinfo")]
+	assert.NotNil(istioGw)
+
+	validations, err = layer.Validations.GetIstioObjectValidations("bookinfo", kubernetes.K8sTCPRoutes, "ratings")
+	assert.NoError(err)
+	assert.Len(validations, 1)
+}
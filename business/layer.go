@@ -27,6 +27,7 @@ type Layer struct {
 	Iter8          Iter8Service
 	IstioStatus    IstioStatusService
 	ProxyStatus    ProxyStatus
+	IstioUpgrade   IstioUpgradeService
 }
 
 // Global clientfactory and prometheus clients.
@@ -101,6 +102,24 @@ func Get(token string) (*Layer, error) {
 	return NewWithBackends(k8s, prometheusClient, jaegerLoader), nil
 }
 
+// kialiSALayerLoader returns a loader of business layers with the permissions of the Kiali ServiceAccount, for the
+// background tasks not run on behalf of a user
+func kialiSALayerLoader() func() (*Layer, error) {
+	var prom prometheus.ClientInterface
+	return func() (*Layer, error) {
+		k8s, err := getKialiSAClient()
+		if err != nil {
+			return nil, err
+		}
+		if prom == nil {
+			if prom, err = prometheus.NewClient(); err != nil {
+				return nil, err
+			}
+		}
+		return NewWithBackends(k8s, prom, nil), nil
+	}
+}
+
 // SetWithBackends allows for specifying the ClientFactory and Prometheus clients to be used.
 // Mock friendly. Used only with tests.
 func SetWithBackends(cf kubernetes.ClientFactory, prom prometheus.ClientInterface) {
@@ -113,9 +132,9 @@ func NewWithBackends(k8s kubernetes.ClientInterface, prom prometheus.ClientInter
 	temporaryLayer := &Layer{}
 	temporaryLayer.Health = HealthService{prom: prom, k8s: k8s, businessLayer: temporaryLayer}
 	temporaryLayer.Svc = SvcService{prom: prom, k8s: k8s, businessLayer: temporaryLayer}
-	temporaryLayer.IstioConfig = IstioConfigService{k8s: k8s, businessLayer: temporaryLayer}
+	temporaryLayer.IstioConfig = IstioConfigService{k8s: k8s, prom: prom, businessLayer: temporaryLayer, history: getIstioConfigHistoryStore()}
 	temporaryLayer.Workload = WorkloadService{k8s: k8s, prom: prom, businessLayer: temporaryLayer}
-	temporaryLayer.Validations = IstioValidationsService{k8s: k8s, businessLayer: temporaryLayer}
+	temporaryLayer.Validations = IstioValidationsService{k8s: k8s, prom: prom, businessLayer: temporaryLayer}
 	temporaryLayer.App = AppService{prom: prom, k8s: k8s, businessLayer: temporaryLayer}
 	temporaryLayer.Namespace = NewNamespaceService(k8s)
 	temporaryLayer.Jaeger = JaegerService{loader: jaegerClient, businessLayer: temporaryLayer}
@@ -125,11 +144,14 @@ func NewWithBackends(k8s kubernetes.ClientInterface, prom prometheus.ClientInter
 	temporaryLayer.Iter8 = Iter8Service{k8s: k8s, businessLayer: temporaryLayer}
 	temporaryLayer.IstioStatus = IstioStatusService{k8s: k8s}
 	temporaryLayer.ProxyStatus = ProxyStatus{k8s: k8s}
+	temporaryLayer.IstioUpgrade = IstioUpgradeService{k8s: k8s, businessLayer: temporaryLayer}
 
 	return temporaryLayer
 }
 
 func Stop() {
+	StopCanaryController()
+	StopFaultExperimentWatcher()
 	if kialiCache != nil {
 		kialiCache.Stop()
 	}
@@ -0,0 +1,86 @@
+package business
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+)
+
+const (
+	leaseHolderAnnotation    = "kiali.io/lease-holder"
+	leaseRenewTimeAnnotation = "kiali.io/lease-renew-time"
+)
+
+// ConfigMapLease elects the Kiali replica running a background controller. The holder of the lease records its name
+// and the time it last renewed the lease in the annotations of a ConfigMap. The other replicas take it over once it
+// has not been renewed for the lease duration.
+type ConfigMapLease struct {
+	client    func() (kubernetes.ClientInterface, error)
+	namespace string
+	name      string
+	holder    string
+	duration  time.Duration
+	now       func() time.Time
+}
+
+// NewConfigMapLease returns the lease stored in the ConfigMap of the namespace, held by this Kiali replica
+func NewConfigMapLease(client func() (kubernetes.ClientInterface, error), namespace, name string, duration time.Duration) *ConfigMapLease {
+	holder, err := os.Hostname()
+	if err != nil || holder == "" {
+		holder = "kiali-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return &ConfigMapLease{client: client, namespace: namespace, name: name, holder: holder, duration: duration, now: time.Now}
+}
+
+// Acquire acquires or renews the lease. It returns false when another replica holds it.
+func (in *ConfigMapLease) Acquire() bool {
+	k8s, err := in.client()
+	if err != nil {
+		log.Errorf("Lease %s could not be acquired: %s", in.name, err)
+		return false
+	}
+	configMap, err := k8s.GetConfigMap(in.namespace, in.name)
+	if err != nil {
+		if !errors2.IsNotFound(err) {
+			log.Errorf("Lease %s could not be acquired: %s", in.name, err)
+			return false
+		}
+		configMap = &core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: in.name, Namespace: in.namespace}}
+	}
+
+	now := in.now()
+	holder := configMap.Annotations[leaseHolderAnnotation]
+	renewTime, _ := time.Parse(time.RFC3339Nano, configMap.Annotations[leaseRenewTimeAnnotation])
+	if holder != "" && holder != in.holder && now.Before(renewTime.Add(in.duration)) {
+		return false
+	}
+
+	if configMap.Annotations == nil {
+		configMap.Annotations = map[string]string{}
+	}
+	configMap.Annotations[leaseHolderAnnotation] = in.holder
+	configMap.Annotations[leaseRenewTimeAnnotation] = now.UTC().Format(time.RFC3339Nano)
+	if configMap.ResourceVersion == "" {
+		_, err = k8s.CreateConfigMap(in.namespace, configMap)
+	} else {
+		_, err = k8s.UpdateConfigMap(in.namespace, configMap)
+	}
+	if err != nil {
+		// Another replica acquired or renewed the lease in the meantime
+		if !errors2.IsConflict(err) && !errors2.IsAlreadyExists(err) {
+			log.Errorf("Lease %s could not be acquired: %s", in.name, err)
+		}
+		return false
+	}
+	if holder != in.holder {
+		log.Infof("Lease %s acquired by %s", in.name, in.holder)
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package business
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func TestConfigMapLease(t *testing.T) {
+	assert := assert.New(t)
+	k8s := kubernetes.NewMemoryClient()
+	client := func() (kubernetes.ClientInterface, error) { return k8s, nil }
+	now := time.Date(2021, 06, 01, 0, 0, 0, 0, time.UTC)
+
+	replicas := []*ConfigMapLease{}
+	for _, holder := range []string{"kiali-1", "kiali-2"} {
+		lease := NewConfigMapLease(client, "istio-system", "kiali-test-lease", time.Minute)
+		lease.holder = holder
+		lease.now = func() time.Time { return now }
+		replicas = append(replicas, lease)
+	}
+
+	assert.True(replicas[0].Acquire())
+	assert.False(replicas[1].Acquire())
+
+	// Renewed by its holder
+	now = now.Add(50 * time.Second)
+	assert.True(replicas[0].Acquire())
+	now = now.Add(50 * time.Second)
+	assert.False(replicas[1].Acquire())
+
+	// Taken over once expired
+	now = now.Add(time.Minute)
+	assert.True(replicas[1].Acquire())
+	assert.False(replicas[0].Acquire())
+
+	configMap, err := k8s.GetConfigMap("istio-system", "kiali-test-lease")
+	assert.NoError(err)
+	assert.Equal("kiali-2", configMap.Annotations[leaseHolderAnnotation])
+}
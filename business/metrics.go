@@ -138,6 +138,9 @@ func (in *MetricsService) fetchAllMetrics(q models.IstioMetricsQuery, lb *Metric
 	return metrics, nil
 }
 
+// Quantile of the p99 response times
+const p99Quantile = "0.99"
+
 // GetStats computes metrics stats, currently response times, for a set of queries
 func (in *MetricsService) GetStats(queries []models.MetricsStatsQuery) (map[string]models.MetricsStats, error) {
 	type statsChanResult struct {
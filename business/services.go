@@ -149,7 +149,7 @@ func (in *SvcService) GetService(namespace, service, interval string, queryTime
 
 	var pods []core_v1.Pod
 	var hth models.ServiceHealth
-	var vs, dr []kubernetes.IstioObject
+	var vs, dr, httpRoutes, tcpRoutes []kubernetes.IstioObject
 	var ws models.Workloads
 	var nsmtls models.MTLSStatus
 
@@ -158,7 +158,7 @@ func (in *SvcService) GetService(namespace, service, interval string, queryTime
 
 	wg := sync.WaitGroup{}
 	wg.Add(6)
-	errChan := make(chan error, 6)
+	errChan := make(chan error, 10)
 
 	labelsSelector := labels.Set(svc.Spec.Selector).String()
 	// If service doesn't have any selector, we can't know which are the pods and workloads applying.
@@ -241,6 +241,41 @@ func (in *SvcService) GetService(namespace, service, interval string, queryTime
 		}
 	}()
 
+	// HTTPRoutes and TCPRoutes sending traffic to the service
+	if in.k8s.IsGatewayAPI() {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			var err2 error
+			if IsResourceCached(namespace, kubernetes.K8sHTTPRoutes) {
+				httpRoutes, err2 = kialiCache.GetIstioObjects(namespace, kubernetes.K8sHTTPRoutes, "")
+			} else {
+				httpRoutes, err2 = in.k8s.GetIstioObjects(namespace, kubernetes.K8sHTTPRoutes, "")
+			}
+			if err2 != nil {
+				errChan <- err2
+			} else {
+				httpRoutes = kubernetes.FilterK8sRoutes(httpRoutes, namespace, service)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			var err2 error
+			if IsResourceCached(namespace, kubernetes.K8sTCPRoutes) {
+				tcpRoutes, err2 = kialiCache.GetIstioObjects(namespace, kubernetes.K8sTCPRoutes, "")
+			} else {
+				tcpRoutes, err2 = in.k8s.GetIstioObjects(namespace, kubernetes.K8sTCPRoutes, "")
+			}
+			if err2 != nil {
+				errChan <- err2
+			} else {
+				tcpRoutes = kubernetes.FilterK8sRoutes(tcpRoutes, namespace, service)
+			}
+		}()
+	}
+
 	var vsCreate, vsUpdate, vsDelete bool
 	go func() {
 		defer wg.Done()
@@ -272,6 +307,9 @@ func (in *SvcService) GetService(namespace, service, interval string, queryTime
 	s.SetEndpoints(eps)
 	s.SetVirtualServices(vs, vsCreate, vsUpdate, vsDelete)
 	s.SetDestinationRules(dr, drCreate, drUpdate, drDelete)
+	s.K8sHTTPRoutes, s.K8sTCPRoutes = models.K8sHTTPRoutes{}, models.K8sTCPRoutes{}
+	(&s.K8sHTTPRoutes).Parse(httpRoutes)
+	(&s.K8sTCPRoutes).Parse(tcpRoutes)
 	return &s, nil
 }
 
@@ -0,0 +1,116 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const telemetryObjects = `
+apiVersion: telemetry.istio.io/v1alpha1
+kind: Telemetry
+metadata:
+  name: reviews
+spec:
+  selector:
+    matchLabels:
+      app: reviews
+  metrics:
+  - providers:
+    - name: prometheus
+    overrides:
+    - match:
+        metric: REQUEST_COUNT
+      disabled: true
+---
+apiVersion: telemetry.istio.io/v1alpha1
+kind: Telemetry
+metadata:
+  name: reviews-v2
+spec:
+  selector:
+    matchLabels:
+      app: reviews
+      version: v2
+  tracing:
+  - randomSamplingPercentage: 10
+---
+apiVersion: extensions.istio.io/v1alpha1
+kind: WasmPlugin
+metadata:
+  name: basic-auth
+spec:
+  selector:
+    matchLabels:
+      app: reviews
+  url: oci://ghcr.io/istio-ecosystem/wasm-extensions/basic_auth:1.12.0
+  phase: AUTHN
+---
+apiVersion: extensions.istio.io/v1alpha1
+kind: WasmPlugin
+metadata:
+  name: ratings-auth
+spec:
+  selector:
+    matchLabels:
+      app: ratings
+  url: oci://ghcr.io/istio-ecosystem/wasm-extensions/basic_auth:1.12.0
+`
+
+func TestGetIstioConfigListTelemetry(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, reviewsPods+"---"+telemetryObjects)
+
+	list, err := layer.IstioConfig.GetIstioConfigList(ParseIstioConfigCriteria("bookinfo", "", "", ""))
+	assert.NoError(err)
+	assert.Len(list.Telemetries, 2)
+	assert.Len(list.WasmPlugins, 2)
+
+	// Workload details
+	list, err = layer.IstioConfig.GetIstioConfigList(ParseIstioConfigCriteria("bookinfo", "", "", "app=reviews,version=v1"))
+	assert.NoError(err)
+	assert.Len(list.Telemetries, 1)
+	assert.Equal("reviews", list.Telemetries[0].Metadata.Name)
+	assert.Len(list.WasmPlugins, 1)
+	assert.Equal("basic-auth", list.WasmPlugins[0].Metadata.Name)
+
+	details, err := layer.IstioConfig.GetIstioConfigDetails("bookinfo", kubernetes.WasmPlugins, "basic-auth")
+	assert.NoError(err)
+	assert.NotNil(details.WasmPlugin)
+	assert.Equal("AUTHN", details.WasmPlugin.Spec.Phase)
+}
+
+func TestTelemetryValidations(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, reviewsPods+"---"+telemetryObjects)
+
+	validations, err := layer.Validations.GetValidations("bookinfo", "")
+	assert.NoError(err)
+
+	// Both Telemetries apply to reviews-v2
+	reviews := validations[models.BuildKey(models.ObjectTypeSingular[kubernetes.Telemetries], "reviews", "bookinfo")]
+	assert.NotNil(reviews)
+	assert.False(reviews.Valid)
+	assert.Len(reviews.Checks, 2)
+	assert.Equal(models.CheckMessage("generic.multimatch.selector"), reviews.Checks[0].Message)
+	assert.Equal(models.CheckMessage("telemetry.metrics.disabled"), reviews.Checks[1].Message)
+
+	reviewsV2 := validations[models.BuildKey(models.ObjectTypeSingular[kubernetes.Telemetries], "reviews-v2", "bookinfo")]
+	assert.NotNil(reviewsV2)
+	assert.Len(reviewsV2.Checks, 1)
+
+	basicAuth := validations[models.BuildKey(models.ObjectTypeSingular[kubernetes.WasmPlugins], "basic-auth", "bookinfo")]
+	assert.NotNil(basicAuth)
+	assert.True(basicAuth.Valid)
+	assert.Empty(basicAuth.Checks)
+
+	validations, err = layer.Validations.GetIstioObjectValidations("bookinfo", kubernetes.WasmPlugins, "ratings-auth")
+	assert.NoError(err)
+	ratingsAuth := validations[models.BuildKey(models.ObjectTypeSingular[kubernetes.WasmPlugins], "ratings-auth", "bookinfo")]
+	assert.NotNil(ratingsAuth)
+	assert.Len(ratingsAuth.Checks, 1)
+	assert.Equal(models.CheckMessage("generic.selector.workloadnotfound"), ratingsAuth.Checks[0].Message)
+}
@@ -4,10 +4,12 @@ import (
 	"sync"
 
 	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
 	"github.com/kiali/kiali/util/mtls"
 )
 
@@ -134,6 +136,116 @@ func (in TLSService) NamespaceWidemTLSStatus(namespace string) (models.MTLSStatu
 	}, nil
 }
 
+// NamespaceWorkloadsMTLSStatus returns the effective mTLS status of every workload of the namespace,
+// on every port of the services of each workload
+func (in *TLSService) NamespaceWorkloadsMTLSStatus(namespace string) ([]models.WorkloadMTLSStatus, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "TLSService", "NamespaceWorkloadsMTLSStatus")
+	defer promtimer.ObserveNow(&err)
+
+	workloads, err := in.businessLayer.Workload.GetWorkloadList(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []core_v1.Service
+	// Namespace access is checked in GetWorkloadList
+	if IsNamespaceCached(namespace) {
+		services, err = kialiCache.GetServices(namespace, nil)
+	} else {
+		services, err = in.k8s.GetServices(namespace, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := in.getPods(namespace, "")
+	if err != nil {
+		return nil, err
+	}
+
+	workloadMtls, err := in.getWorkloadMtls(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]models.WorkloadMTLSStatus, 0, len(workloads.Workloads))
+	for _, w := range workloads.Workloads {
+		workloadServices := []core_v1.Service{}
+		for _, svc := range services {
+			if len(svc.Spec.Selector) > 0 && labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(w.Labels)) {
+				workloadServices = append(workloadServices, svc)
+			}
+		}
+		workloadPods := []core_v1.Pod{}
+		for _, pod := range pods {
+			if len(w.Labels) > 0 && labels.SelectorFromSet(w.Labels).Matches(labels.Set(pod.Labels)) {
+				workloadPods = append(workloadPods, pod)
+			}
+		}
+		statuses = append(statuses, workloadMtls.WorkloadStatus(w.Name, w.Labels, w.IstioSidecar, workloadServices, workloadPods))
+	}
+	return statuses, nil
+}
+
+// workloadMTLSStatus returns the effective mTLS status of a workload, on every port of the given services
+func (in *TLSService) workloadMTLSStatus(namespace string, workload *models.Workload, services []core_v1.Service) (models.WorkloadMTLSStatus, error) {
+	workloadMtls, err := in.getWorkloadMtls(namespace)
+	if err != nil {
+		return models.WorkloadMTLSStatus{}, err
+	}
+	// Pods are only needed to resolve the named target ports
+	pods := []core_v1.Pod{}
+	if len(workload.Labels) > 0 {
+		if pods, err = in.getPods(namespace, labels.Set(workload.Labels).String()); err != nil {
+			return models.WorkloadMTLSStatus{}, err
+		}
+	}
+	return workloadMtls.WorkloadStatus(workload.Name, workload.Labels, workload.HasIstioSidecar(), services, pods), nil
+}
+
+// getPods returns the pods of the namespace matching the selector, from the cache when it holds them
+func (in *TLSService) getPods(namespace, selector string) ([]core_v1.Pod, error) {
+	if IsNamespaceCached(namespace) {
+		return kialiCache.GetPods(namespace, selector)
+	}
+	return in.k8s.GetPods(namespace, selector)
+}
+
+// getWorkloadMtls fetches the PeerAuthentications and DestinationRules that decide the mTLS of the workloads of the
+// namespace, from the cache when it holds them
+func (in *TLSService) getWorkloadMtls(namespace string) (mtls.WorkloadMtls, error) {
+	meshPAs, err := in.getMeshPeerAuthentications()
+	if err != nil {
+		return mtls.WorkloadMtls{}, err
+	}
+
+	pas := meshPAs
+	if namespace != config.Get().IstioNamespace {
+		if pas, err = in.getPeerAuthentications(namespace); err != nil {
+			return mtls.WorkloadMtls{}, err
+		}
+	}
+
+	// Clients of the namespace only use its DestinationRules and the ones of the root namespace
+	nss := []string{namespace}
+	if namespace != config.Get().IstioNamespace {
+		nss = append(nss, config.Get().IstioNamespace)
+	}
+	drs, err := in.getAllDestinationRules(nss)
+	if err != nil {
+		return mtls.WorkloadMtls{}, err
+	}
+
+	return mtls.WorkloadMtls{
+		Namespace:               namespace,
+		MeshPeerAuthentications: meshPAs,
+		PeerAuthentications:     pas,
+		DestinationRules:        drs,
+		AutoMtlsEnabled:         in.hasAutoMTLSEnabled(),
+	}, nil
+}
+
 func (in TLSService) getPeerAuthentications(namespace string) ([]kubernetes.IstioObject, error) {
 	if namespace == config.Get().IstioNamespace {
 		return []kubernetes.IstioObject{}, nil
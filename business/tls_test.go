@@ -1,16 +1,19 @@
 package business
 
 import (
+	"strings"
 	"testing"
 
 	osproject_v1 "github.com/openshift/api/project/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/tests/data"
 )
 
@@ -316,3 +319,325 @@ func fakeMeshPeerAuthenticationWithMtlsMode(name, mTLSmode string) []kubernetes.
 func fakeMeshPeerAuthentication(name string, mtls interface{}) []kubernetes.IstioObject {
 	return []kubernetes.IstioObject{data.CreateEmptyMeshPeerAuthentication(name, mtls)}
 }
+
+const workloadsMtlsConfig = `
+apiVersion: security.istio.io/v1beta1
+kind: PeerAuthentication
+metadata:
+  name: default
+  namespace: istio-system
+spec:
+  mtls:
+    mode: STRICT
+---
+apiVersion: security.istio.io/v1beta1
+kind: PeerAuthentication
+metadata:
+  name: ratings
+spec:
+  selector:
+    matchLabels:
+      app: ratings
+  mtls:
+    mode: UNSET
+  portLevelMtls:
+    9090:
+      mode: DISABLE
+---
+apiVersion: security.istio.io/v1beta1
+kind: PeerAuthentication
+metadata:
+  name: details
+spec:
+  selector:
+    matchLabels:
+      app: details
+  mtls:
+    mode: PERMISSIVE
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: reviews
+spec:
+  host: reviews.bookinfo.svc.cluster.local
+  trafficPolicy:
+    tls:
+      mode: DISABLE
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: reviews
+spec:
+  selector:
+    app: reviews
+  ports:
+  - name: http
+    port: 9080
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: ratings
+spec:
+  selector:
+    app: ratings
+  ports:
+  - name: http
+    port: 9080
+  - name: http-metrics
+    port: 8080
+    targetPort: 9090
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: details
+spec:
+  selector:
+    app: details
+  ports:
+  - name: http
+    port: 9080
+`
+
+func fakeWorkloadsMtlsLayer(t *testing.T) *Layer {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubernetes.NewMemoryClient()
+	assert.NoError(t, k8s.LoadYAML(strings.NewReader(workloadsMtlsConfig), "bookinfo"))
+	for _, app := range []string{"reviews", "ratings", "details", "sleep"} {
+		assert.NoError(t, k8s.LoadYAML(strings.NewReader(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: `+app+`-v1
+spec:
+  selector:
+    matchLabels:
+      app: `+app+`
+  template:
+    metadata:
+      labels:
+        app: `+app+`
+        version: v1
+`), "bookinfo"))
+	}
+	k8s.AddConfigMap(core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: conf.ExternalServices.Istio.ConfigMapName, Namespace: conf.IstioNamespace}})
+	return NewWithBackends(k8s, nil, nil)
+}
+
+func TestNamespaceWorkloadsMTLSStatus(t *testing.T) {
+	assert := assert.New(t)
+	layer := fakeWorkloadsMtlsLayer(t)
+
+	statuses, err := layer.TLS.NamespaceWorkloadsMTLSStatus("bookinfo")
+	assert.NoError(err)
+	assert.Len(statuses, 4)
+
+	perWorkload := map[string]models.WorkloadMTLSStatus{}
+	for _, status := range statuses {
+		perWorkload[status.Workload] = status
+	}
+
+	// Clients don't use mTLS because of the DestinationRule, but the mesh requires it
+	reviews := perWorkload["reviews-v1"]
+	assert.Equal(MTLSPartiallyEnabled, reviews.Status)
+	assert.Len(reviews.Ports, 1)
+	assert.True(reviews.Ports[0].Conflict)
+	assert.Equal("STRICT", reviews.Ports[0].ServerMode)
+	assert.Equal(&models.MTLSPolicyRef{ObjectType: "peerauthentication", Name: "default", Namespace: "istio-system", Scope: "mesh"}, reviews.Ports[0].ServerPolicy)
+	assert.Equal("DISABLE", reviews.Ports[0].ClientMode)
+	assert.Equal(&models.MTLSPolicyRef{ObjectType: "destinationrule", Name: "reviews", Namespace: "bookinfo", Scope: "service"}, reviews.Ports[0].ClientPolicy)
+
+	// UNSET inherits the mesh mode, but the metrics port disables mTLS
+	ratings := perWorkload["ratings-v1"]
+	assert.Equal(MTLSPartiallyEnabled, ratings.Status)
+	assert.Len(ratings.Ports, 2)
+	assert.Equal(int32(9080), ratings.Ports[0].TargetPort)
+	assert.Equal(MTLSEnabled, ratings.Ports[0].Status)
+	assert.Equal("mesh", ratings.Ports[0].ServerPolicy.Scope)
+	assert.True(ratings.Ports[0].AutoMtls)
+	assert.Equal(int32(9090), ratings.Ports[1].TargetPort)
+	assert.Equal(MTLSDisabled, ratings.Ports[1].Status)
+	assert.Equal(&models.MTLSPolicyRef{ObjectType: "peerauthentication", Name: "ratings", Namespace: "bookinfo", Scope: "port"}, ratings.Ports[1].ServerPolicy)
+	assert.Equal("DISABLE", ratings.Ports[1].ClientMode)
+	assert.False(ratings.Ports[1].Conflict)
+
+	details := perWorkload["details-v1"]
+	assert.Equal(MTLSPartiallyEnabled, details.Status)
+	assert.Equal("PERMISSIVE", details.Ports[0].ServerMode)
+	assert.Equal("workload", details.Ports[0].ServerPolicy.Scope)
+	assert.False(details.Ports[0].Conflict)
+
+	// Workloads without services are reached through auto mTLS
+	sleep := perWorkload["sleep-v1"]
+	assert.Equal(MTLSEnabled, sleep.Status)
+	assert.Len(sleep.Ports, 1)
+	assert.Empty(sleep.Ports[0].Service)
+	assert.Equal("ISTIO_MUTUAL", sleep.Ports[0].ClientMode)
+}
+
+func TestWorkloadDetailsMTLSStatus(t *testing.T) {
+	assert := assert.New(t)
+	layer := fakeWorkloadsMtlsLayer(t)
+
+	workload, err := layer.Workload.GetWorkload("bookinfo", "ratings-v1", "", true)
+	assert.NoError(err)
+	assert.NotNil(workload.MTLSStatus)
+	assert.Equal(MTLSPartiallyEnabled, workload.MTLSStatus.Status)
+	assert.Len(workload.MTLSStatus.Ports, 2)
+
+	workload, err = layer.Workload.GetWorkload("bookinfo", "ratings-v1", "", false)
+	assert.NoError(err)
+	assert.Nil(workload.MTLSStatus)
+}
+
+func TestWorkloadsMTLSStatusDestinationRuleVisibility(t *testing.T) {
+	assert := assert.New(t)
+	layer := fakeWorkloadsMtlsLayer(t)
+	k8s := layer.k8s.(*kubernetes.MemoryClient)
+
+	// DestinationRules of other namespaces, or not exported to the namespace of the service, are not used by its clients
+	assert.NoError(k8s.LoadYAML(strings.NewReader(`
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: ratings
+spec:
+  host: ratings
+  trafficPolicy:
+    tls:
+      mode: DISABLE
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: details
+spec:
+  host: details.bookinfo.svc.cluster.local
+  trafficPolicy:
+    tls:
+      mode: DISABLE
+`), "other"))
+	assert.NoError(k8s.LoadYAML(strings.NewReader(`
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: details
+spec:
+  host: details
+  exportTo: [other]
+  trafficPolicy:
+    tls:
+      mode: DISABLE
+`), "bookinfo"))
+	assert.NoError(k8s.LoadYAML(strings.NewReader(`
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: default
+spec:
+  host: "*.local"
+  exportTo: ["."]
+  trafficPolicy:
+    tls:
+      mode: DISABLE
+`), "istio-system"))
+
+	statuses, err := layer.TLS.NamespaceWorkloadsMTLSStatus("bookinfo")
+	assert.NoError(err)
+	for _, status := range statuses {
+		if status.Workload == "ratings-v1" || status.Workload == "details-v1" {
+			assert.Equal("ISTIO_MUTUAL", status.Ports[0].ClientMode, status.Workload)
+			assert.Nil(status.Ports[0].ClientPolicy, status.Workload)
+		}
+	}
+
+	// Short hosts are resolved in the namespace of the DestinationRule
+	assert.NoError(k8s.LoadYAML(strings.NewReader(`
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: ratings
+spec:
+  host: ratings
+  trafficPolicy:
+    tls:
+      mode: DISABLE
+`), "bookinfo"))
+	statuses, err = layer.TLS.NamespaceWorkloadsMTLSStatus("bookinfo")
+	assert.NoError(err)
+	for _, status := range statuses {
+		if status.Workload == "ratings-v1" {
+			assert.Equal("DISABLE", status.Ports[0].ClientMode)
+			assert.Equal(&models.MTLSPolicyRef{ObjectType: "destinationrule", Name: "ratings", Namespace: "bookinfo", Scope: "service"}, status.Ports[0].ClientPolicy)
+		}
+	}
+}
+
+func TestWorkloadsMTLSStatusNamedTargetPorts(t *testing.T) {
+	assert := assert.New(t)
+	layer := fakeWorkloadsMtlsLayer(t)
+	assert.NoError(layer.k8s.(*kubernetes.MemoryClient).LoadYAML(strings.NewReader(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: ratings-admin
+spec:
+  selector:
+    app: ratings
+  ports:
+  - name: http-metrics
+    port: 80
+    targetPort: metrics
+  - name: http-admin
+    port: 8081
+    targetPort: admin
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: ratings-v1-1234
+  labels:
+    app: ratings
+    version: v1
+  annotations:
+    sidecar.istio.io/status: '{"containers":["istio-proxy"]}'
+  ownerReferences:
+  - apiVersion: apps/v1
+    kind: Deployment
+    name: ratings-v1
+    controller: true
+spec:
+  containers:
+  - name: ratings
+    ports:
+    - name: metrics
+      containerPort: 9090
+  - name: istio-proxy
+`), "bookinfo"))
+
+	statuses, err := layer.TLS.NamespaceWorkloadsMTLSStatus("bookinfo")
+	assert.NoError(err)
+	ports := map[string]models.PortMTLSStatus{}
+	for _, status := range statuses {
+		for _, port := range status.Ports {
+			if port.Service == "ratings-admin" {
+				ports[port.TargetPortName] = port
+			}
+		}
+	}
+
+	// Resolved from the container ports, the port-level mode applies
+	assert.Equal(int32(9090), ports["metrics"].TargetPort)
+	assert.Equal("DISABLE", ports["metrics"].ServerMode)
+	assert.Equal("port", ports["metrics"].ServerPolicy.Scope)
+
+	// Not defined by the containers, the workload mode is shown
+	assert.Equal(int32(0), ports["admin"].TargetPort)
+	assert.Equal("STRICT", ports["admin"].ServerMode)
+	assert.Equal("mesh", ports["admin"].ServerPolicy.Scope)
+}
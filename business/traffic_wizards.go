@@ -0,0 +1,357 @@
+package business
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// Objects generated by a wizard, in the order they are applied
+var wizardTypes = []string{kubernetes.DestinationRules, kubernetes.VirtualServices}
+
+// ApplyTrafficWizard generates the VirtualService and DestinationRule of the service for the wizard, validates them and
+// creates or updates them. Objects not generated by a wizard are never overwritten. Nothing is applied when Kiali
+// validations find errors in the generated objects. In dryRun mode, nothing is persisted.
+func (in *IstioConfigService) ApplyTrafficWizard(namespace, service string, wizard models.TrafficWizard, dryRun bool, user string) (models.TrafficWizardResult, error) {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "ApplyTrafficWizard")
+	defer promtimer.ObserveNow(&err)
+
+	result := models.TrafficWizardResult{Wizard: wizard}
+	versions, err := in.getServiceVersions(namespace, service)
+	if err != nil {
+		return result, err
+	}
+	if err = validateTrafficWizard(wizard, versions); err != nil {
+		return result, err
+	}
+	for _, resourceType := range wizardTypes {
+		if checkErr := in.checkWizardObject(namespace, resourceType, service); checkErr != nil && !errors2.IsNotFound(checkErr) {
+			err = checkErr
+			return result, err
+		}
+	}
+
+	result.DestinationRule = buildWizardDestinationRule(namespace, service, wizard, versions)
+	result.VirtualService = buildWizardVirtualService(namespace, service, wizard)
+	generated := []map[string]interface{}{result.DestinationRule, result.VirtualService}
+
+	changes := map[string][]kubernetes.IstioObject{}
+	for i, resourceType := range wizardTypes {
+		object, err := toIstioObject(generated[i])
+		if err != nil {
+			return result, err
+		}
+		changes[resourceType] = []kubernetes.IstioObject{object}
+	}
+	validations, err := in.businessLayer.Validations.GetValidationsWithChanges(namespace, changes)
+	if err != nil {
+		return result, err
+	}
+
+	result.Import = models.IstioConfigImport{DryRun: dryRun, Objects: []models.IstioConfigImportResult{}, Validations: validations}
+	valid := true
+	for i, resourceType := range wizardTypes {
+		validation := validations[models.BuildKey(models.ObjectTypeSingular[resourceType], service, namespace)]
+		if validation != nil && !validation.Valid {
+			valid = false
+		}
+		result.Import.Objects = append(result.Import.Objects, models.IstioConfigImportResult{
+			Document:   i,
+			ObjectType: resourceType,
+			Name:       service,
+			Namespace:  namespace,
+			Status:     models.ImportStatusInvalid,
+			Message:    "Kiali validations found errors in the generated objects",
+			Validation: validation,
+		})
+	}
+	if !valid {
+		return result, nil
+	}
+
+	bundle, err := json.Marshal(map[string]interface{}{"apiVersion": "v1", "kind": "List", "items": generated})
+	if err != nil {
+		return result, err
+	}
+	result.Import, err = in.ImportIstioConfig(namespace, bytes.NewReader(bundle), dryRun, user)
+	return result, err
+}
+
+// DeleteTrafficWizard deletes the VirtualService and DestinationRule generated by a wizard for the service.
+// Objects not generated by a wizard are never deleted.
+func (in *IstioConfigService) DeleteTrafficWizard(namespace, service, user string) error {
+	var err error
+	promtimer := internalmetrics.GetGoFunctionMetric("business", "IstioConfigService", "DeleteTrafficWizard")
+	defer promtimer.ObserveNow(&err)
+
+	found := []string{}
+	for _, resourceType := range wizardTypes {
+		switch checkErr := in.checkWizardObject(namespace, resourceType, service); {
+		case checkErr == nil:
+			found = append(found, resourceType)
+		case errors2.IsNotFound(checkErr):
+			err = checkErr
+		default:
+			err = checkErr
+			return err
+		}
+	}
+	if len(found) == 0 {
+		return err
+	}
+
+	// VirtualService first, so no route refers to a missing subset
+	for i := len(found) - 1; i >= 0; i-- {
+		if err = in.DeleteIstioConfigDetail(kubernetes.ResourceTypesToAPI[found[i]], namespace, found[i], service, "", user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkWizardObject returns a NotFound error when the object doesn't exist and a Conflict error when it wasn't
+// generated by a wizard
+func (in *IstioConfigService) checkWizardObject(namespace, resourceType, name string) error {
+	object, err := in.k8s.GetIstioObject(namespace, resourceType, name)
+	if err != nil {
+		return err
+	}
+	if _, found := object.GetObjectMeta().Labels[models.WizardLabel]; !found {
+		return errors2.NewConflict(schema.GroupResource{Group: kubernetes.ResourceTypesToAPI[resourceType], Resource: resourceType}, name,
+			fmt.Errorf("it was not generated by a Kiali wizard"))
+	}
+	return nil
+}
+
+// getServiceVersions returns the sorted values of the version label of the pods of the service
+func (in *IstioConfigService) getServiceVersions(namespace, service string) ([]string, error) {
+	svc, err := in.businessLayer.Svc.getService(namespace, service)
+	if err != nil {
+		return nil, err
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return nil, errors2.NewBadRequest(fmt.Sprintf("Service %s has no selector", service))
+	}
+	pods, err := in.businessLayer.Workload.GetPods(namespace, labels.Set(svc.Spec.Selector).String())
+	if err != nil {
+		return nil, err
+	}
+
+	versionLabel := config.Get().IstioLabels.VersionLabelName
+	versions := []string{}
+	seen := map[string]bool{}
+	for _, pod := range pods {
+		if version, found := pod.Labels[versionLabel]; found && !seen[version] {
+			seen[version] = true
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, errors2.NewBadRequest(fmt.Sprintf("Service %s has no workloads with the %s label", service, versionLabel))
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
+
+func validateTrafficWizard(wizard models.TrafficWizard, versions []string) error {
+	known := map[string]bool{}
+	for _, v := range versions {
+		known[v] = true
+	}
+	invalid := func(format string, a ...interface{}) error {
+		return errors2.NewBadRequest(fmt.Sprintf(format, a...))
+	}
+
+	switch wizard.Type {
+	case models.WizardWeightedRouting:
+		if len(wizard.Routes) == 0 {
+			return invalid("%s requires routes", wizard.Type)
+		}
+	case models.WizardRequestRouting:
+		if len(wizard.Matches) == 0 {
+			return invalid("%s requires matches", wizard.Type)
+		}
+	case models.WizardFaultInjection:
+		if wizard.Fault == nil || (wizard.Fault.Abort == nil && wizard.Fault.Delay == nil) {
+			return invalid("%s requires an abort or a delay", wizard.Type)
+		}
+	default:
+		return invalid("Wizard type %s is not one of %s, %s or %s", wizard.Type, models.WizardWeightedRouting, models.WizardRequestRouting, models.WizardFaultInjection)
+	}
+	if len(wizard.Matches) > 0 && wizard.Type != models.WizardRequestRouting {
+		return invalid("Matches are only supported by %s", models.WizardRequestRouting)
+	}
+	if wizard.Fault != nil && wizard.Type != models.WizardFaultInjection {
+		return invalid("Faults are only supported by %s", models.WizardFaultInjection)
+	}
+
+	total := 0
+	routed := map[string]bool{}
+	for _, r := range wizard.Routes {
+		if !known[r.Version] {
+			return invalid("Version %s has no workloads", r.Version)
+		}
+		if routed[r.Version] {
+			return invalid("Version %s has more than one route", r.Version)
+		}
+		if r.Weight < 0 || r.Weight > 100 {
+			return invalid("Weight of version %s must be between 0 and 100", r.Version)
+		}
+		routed[r.Version] = true
+		total += r.Weight
+	}
+	if len(wizard.Routes) > 0 && total != 100 {
+		return invalid("Weights add up to %d instead of 100", total)
+	}
+
+	for _, m := range wizard.Matches {
+		if len(m.Headers) == 0 {
+			return invalid("Matches require at least one header")
+		}
+		if !known[m.Version] {
+			return invalid("Version %s has no workloads", m.Version)
+		}
+	}
+
+	if wizard.Fault != nil {
+		if abort := wizard.Fault.Abort; abort != nil {
+			if abort.Percentage <= 0 || abort.Percentage > 100 {
+				return invalid("Abort percentage must be greater than 0 and up to 100")
+			}
+			if abort.HttpStatus < 200 || abort.HttpStatus > 599 {
+				return invalid("Abort HTTP status %d is not valid", abort.HttpStatus)
+			}
+		}
+		if delay := wizard.Fault.Delay; delay != nil {
+			if delay.Percentage <= 0 || delay.Percentage > 100 {
+				return invalid("Delay percentage must be greater than 0 and up to 100")
+			}
+			if d, err := time.ParseDuration(delay.FixedDelay); err != nil || d <= 0 {
+				return invalid("Fixed delay %s is not a valid duration", delay.FixedDelay)
+			}
+		}
+	}
+	return nil
+}
+
+// buildWizardDestinationRule returns a DestinationRule with a subset per version of the service, named after the version
+func buildWizardDestinationRule(namespace, service string, wizard models.TrafficWizard, versions []string) map[string]interface{} {
+	versionLabel := config.Get().IstioLabels.VersionLabelName
+	subsets := []interface{}{}
+	for _, v := range versions {
+		subsets = append(subsets, map[string]interface{}{
+			"name":   v,
+			"labels": map[string]interface{}{versionLabel: v},
+		})
+	}
+	return map[string]interface{}{
+		"apiVersion": kubernetes.ApiToVersion[kubernetes.ResourceTypesToAPI[kubernetes.DestinationRules]],
+		"kind":       kubernetes.PluralType[kubernetes.DestinationRules],
+		"metadata":   wizardMetadata(namespace, service, wizard),
+		"spec": map[string]interface{}{
+			"host":    service,
+			"subsets": subsets,
+		},
+	}
+}
+
+func buildWizardVirtualService(namespace, service string, wizard models.TrafficWizard) map[string]interface{} {
+	http := []interface{}{}
+	for _, m := range wizard.Matches {
+		headers := map[string]interface{}{}
+		for name, value := range m.Headers {
+			headers[name] = map[string]interface{}{"exact": value}
+		}
+		http = append(http, map[string]interface{}{
+			"match": []interface{}{map[string]interface{}{"headers": headers}},
+			"route": []interface{}{wizardDestination(service, m.Version, -1)},
+		})
+	}
+
+	route := []interface{}{}
+	for _, r := range wizard.Routes {
+		route = append(route, wizardDestination(service, r.Version, r.Weight))
+	}
+	if len(route) == 0 {
+		route = append(route, wizardDestination(service, "", -1))
+	}
+	defaultRoute := map[string]interface{}{"route": route}
+	if wizard.Fault != nil {
+		defaultRoute["fault"] = wizardFault(*wizard.Fault)
+	}
+	http = append(http, defaultRoute)
+
+	return map[string]interface{}{
+		"apiVersion": kubernetes.ApiToVersion[kubernetes.ResourceTypesToAPI[kubernetes.VirtualServices]],
+		"kind":       kubernetes.PluralType[kubernetes.VirtualServices],
+		"metadata":   wizardMetadata(namespace, service, wizard),
+		"spec": map[string]interface{}{
+			"hosts": []interface{}{service},
+			"http":  http,
+		},
+	}
+}
+
+// wizardFault returns the fault of a VirtualService HTTP route
+func wizardFault(fault models.TrafficWizardFault) map[string]interface{} {
+	faultSpec := map[string]interface{}{}
+	if fault.Abort != nil {
+		faultSpec["abort"] = map[string]interface{}{
+			"percentage": map[string]interface{}{"value": fault.Abort.Percentage},
+			"httpStatus": fault.Abort.HttpStatus,
+		}
+	}
+	if fault.Delay != nil {
+		d, _ := time.ParseDuration(fault.Delay.FixedDelay)
+		faultSpec["delay"] = map[string]interface{}{
+			"percentage": map[string]interface{}{"value": fault.Delay.Percentage},
+			// Istio durations are in seconds
+			"fixedDelay": fmt.Sprintf("%gs", d.Seconds()),
+		}
+	}
+	return faultSpec
+}
+
+func wizardMetadata(namespace, service string, wizard models.TrafficWizard) map[string]interface{} {
+	return map[string]interface{}{
+		"name":      service,
+		"namespace": namespace,
+		"labels":    map[string]interface{}{models.WizardLabel: wizard.Type},
+	}
+}
+
+// wizardDestination returns a route to the subset of the version, or to every version when it's empty.
+// Weight is omitted when it's negative.
+func wizardDestination(service, version string, weight int) map[string]interface{} {
+	destination := map[string]interface{}{"host": service}
+	if version != "" {
+		destination["subset"] = version
+	}
+	route := map[string]interface{}{"destination": destination}
+	if weight >= 0 {
+		route["weight"] = weight
+	}
+	return route
+}
+
+func toIstioObject(object map[string]interface{}) (kubernetes.IstioObject, error) {
+	raw, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	istioObject := &kubernetes.GenericIstioObject{}
+	err = json.Unmarshal(raw, istioObject)
+	return istioObject, err
+}
@@ -0,0 +1,166 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const reviewsPods = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: reviews-v1-545db77b95-1
+  labels:
+    app: reviews
+    version: v1
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: reviews-v2-7bf8c9648f-1
+  labels:
+    app: reviews
+    version: v2
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: reviews-v2-7bf8c9648f-2
+  labels:
+    app: reviews
+    version: v2
+`
+
+func TestApplyTrafficWizardWeightedRouting(t *testing.T) {
+	assert := assert.New(t)
+	layer, k8s := fakeImportLayer(t, reviewsPods)
+
+	wizard := models.TrafficWizard{
+		Type:   models.WizardWeightedRouting,
+		Routes: []models.TrafficWizardRoute{{Version: "v1", Weight: 80}, {Version: "v2", Weight: 20}},
+	}
+	result, err := layer.IstioConfig.ApplyTrafficWizard("bookinfo", "reviews", wizard, false, "alice")
+	assert.NoError(err)
+	assert.True(result.Import.Applied)
+
+	dr, err := k8s.GetIstioObject("bookinfo", kubernetes.DestinationRules, "reviews")
+	assert.NoError(err)
+	assert.Equal(models.WizardWeightedRouting, dr.GetObjectMeta().Labels[models.WizardLabel])
+	assert.Len(dr.GetSpec()["subsets"], 2)
+
+	vs, err := k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.NoError(err)
+	route := vs.GetSpec()["http"].([]interface{})[0].(map[string]interface{})["route"].([]interface{})
+	assert.Len(route, 2)
+	assert.Equal(map[string]interface{}{"host": "reviews", "subset": "v2"}, route[1].(map[string]interface{})["destination"])
+	assert.EqualValues(20, route[1].(map[string]interface{})["weight"])
+
+	// Update to inject faults in all the versions
+	wizard = models.TrafficWizard{
+		Type:  models.WizardFaultInjection,
+		Fault: &models.TrafficWizardFault{Abort: &models.TrafficWizardAbort{Percentage: 5, HttpStatus: 503}, Delay: &models.TrafficWizardDelay{Percentage: 10, FixedDelay: "500ms"}},
+	}
+	result, err = layer.IstioConfig.ApplyTrafficWizard("bookinfo", "reviews", wizard, false, "alice")
+	assert.NoError(err)
+	assert.True(result.Import.Applied)
+	for _, o := range result.Import.Objects {
+		assert.Equal(models.ImportStatusUpdated, o.Status)
+	}
+
+	vs, err = k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.NoError(err)
+	assert.Equal(models.WizardFaultInjection, vs.GetObjectMeta().Labels[models.WizardLabel])
+	http := vs.GetSpec()["http"].([]interface{})[0].(map[string]interface{})
+	assert.Equal([]interface{}{map[string]interface{}{"destination": map[string]interface{}{"host": "reviews"}}}, http["route"])
+	fault := http["fault"].(map[string]interface{})
+	assert.Equal("0.5s", fault["delay"].(map[string]interface{})["fixedDelay"])
+	assert.EqualValues(503, fault["abort"].(map[string]interface{})["httpStatus"])
+
+	history, err := layer.IstioConfig.GetIstioConfigHistory("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.NoError(err)
+	assert.Len(history, 2)
+
+	// Remove
+	assert.NoError(layer.IstioConfig.DeleteTrafficWizard("bookinfo", "reviews", "alice"))
+	_, err = k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.True(errors2.IsNotFound(err))
+	_, err = k8s.GetIstioObject("bookinfo", kubernetes.DestinationRules, "reviews")
+	assert.True(errors2.IsNotFound(err))
+	assert.True(errors2.IsNotFound(layer.IstioConfig.DeleteTrafficWizard("bookinfo", "reviews", "alice")))
+}
+
+func TestApplyTrafficWizardRequestRoutingDryRun(t *testing.T) {
+	assert := assert.New(t)
+	layer, k8s := fakeImportLayer(t, reviewsPods)
+
+	wizard := models.TrafficWizard{
+		Type:    models.WizardRequestRouting,
+		Matches: []models.TrafficWizardMatch{{Headers: map[string]string{"end-user": "jason"}, Version: "v2"}},
+		Routes:  []models.TrafficWizardRoute{{Version: "v1", Weight: 100}},
+	}
+	result, err := layer.IstioConfig.ApplyTrafficWizard("bookinfo", "reviews", wizard, true, "alice")
+	assert.NoError(err)
+	assert.True(result.Import.DryRun)
+	assert.False(result.Import.Applied)
+	for _, o := range result.Import.Objects {
+		assert.Equal(models.ImportStatusReady, o.Status)
+		assert.True(o.Validation.Valid)
+	}
+
+	http := result.VirtualService["spec"].(map[string]interface{})["http"].([]interface{})
+	assert.Len(http, 2)
+	assert.Equal([]interface{}{map[string]interface{}{"headers": map[string]interface{}{"end-user": map[string]interface{}{"exact": "jason"}}}}, http[0].(map[string]interface{})["match"])
+
+	vss, err := k8s.GetIstioObjects("bookinfo", kubernetes.VirtualServices, "")
+	assert.NoError(err)
+	assert.Empty(vss)
+}
+
+func TestApplyTrafficWizardInvalid(t *testing.T) {
+	assert := assert.New(t)
+	layer, _ := fakeImportLayer(t, reviewsPods)
+
+	for _, wizard := range []models.TrafficWizard{
+		{Type: "canary"},
+		{Type: models.WizardWeightedRouting},
+		{Type: models.WizardWeightedRouting, Routes: []models.TrafficWizardRoute{{Version: "v1", Weight: 80}, {Version: "v2", Weight: 30}}},
+		{Type: models.WizardWeightedRouting, Routes: []models.TrafficWizardRoute{{Version: "v3", Weight: 100}}},
+		{Type: models.WizardRequestRouting, Matches: []models.TrafficWizardMatch{{Version: "v2"}}},
+		{Type: models.WizardFaultInjection, Fault: &models.TrafficWizardFault{Abort: &models.TrafficWizardAbort{Percentage: 5, HttpStatus: 99}}},
+		{Type: models.WizardFaultInjection, Fault: &models.TrafficWizardFault{Delay: &models.TrafficWizardDelay{Percentage: 5, FixedDelay: "5"}}},
+	} {
+		_, err := layer.IstioConfig.ApplyTrafficWizard("bookinfo", "reviews", wizard, true, "alice")
+		assert.True(errors2.IsBadRequest(err), wizard.Type)
+	}
+}
+
+func TestApplyTrafficWizardNotOwned(t *testing.T) {
+	assert := assert.New(t)
+	layer, k8s := fakeImportLayer(t, reviewsPods+`
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+spec:
+  hosts: [reviews]
+  http:
+  - route:
+    - destination:
+        host: reviews
+`)
+
+	wizard := models.TrafficWizard{Type: models.WizardWeightedRouting, Routes: []models.TrafficWizardRoute{{Version: "v1", Weight: 100}}}
+	_, err := layer.IstioConfig.ApplyTrafficWizard("bookinfo", "reviews", wizard, false, "alice")
+	assert.True(errors2.IsConflict(err))
+	assert.True(errors2.IsConflict(layer.IstioConfig.DeleteTrafficWizard("bookinfo", "reviews", "alice")))
+
+	vs, err := k8s.GetIstioObject("bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.NoError(err)
+	assert.Empty(vs.GetObjectMeta().Labels)
+}
@@ -129,6 +129,29 @@ func (in *WorkloadService) GetWorkload(namespace string, workloadName string, wo
 			return nil, err
 		}
 		workload.SetServices(services)
+
+		// Workload details can be shown without the mTLS status, i.e. when PeerAuthentications can't be read
+		if mtlsStatus, err := in.businessLayer.TLS.workloadMTLSStatus(namespace, workload, services); err == nil {
+			workload.MTLSStatus = &mtlsStatus
+		} else {
+			log.Warningf("Error computing the mTLS status of workload [namespace: %s] [name: %s]: %v", namespace, workloadName, err)
+		}
+
+		if validations, err := in.businessLayer.Validations.GetWorkloadValidations(namespace, workload); err == nil {
+			workload.Validations = validations
+		} else {
+			log.Warningf("Error validating workload [namespace: %s] [name: %s]: %v", namespace, workloadName, err)
+		}
+	}
+
+	// Gateways of the Kubernetes Gateway API are deployed by Istio with the name of the Gateway as label
+	if gatewayName, ok := workload.Labels[kubernetes.GatewayAPIGatewayNameLabel]; ok && in.k8s.IsGatewayAPI() {
+		if gw, err := in.k8s.GetIstioObject(namespace, kubernetes.K8sGateways, gatewayName); err == nil {
+			workload.K8sGateway = &models.K8sGateway{}
+			workload.K8sGateway.Parse(gw)
+		} else {
+			log.Debugf("Gateway [namespace: %s] [name: %s] of workload %s not found: %v", namespace, gatewayName, workloadName, err)
+		}
 	}
 
 	wg.Wait()
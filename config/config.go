@@ -52,6 +52,12 @@ const (
 	AuthTypeNone   = "none"
 )
 
+// The stores where the revisions of the Istio config are kept
+const (
+	IstioConfigHistoryStoreConfigMap = "configmap"
+	IstioConfigHistoryStoreMemory    = "memory"
+)
+
 const (
 	IstioMultiClusterHostSuffix = "global"
 	OidcClientSecretFile        = "/kiali-secret/oidc-secret"
@@ -227,6 +233,7 @@ type KubernetesConfig struct {
 	CacheEnabled bool `yaml:"cache_enabled,omitempty"`
 	// Kiali can cache VirtualService,DestinationRule,Gateway and ServiceEntry Istio resources if they are present
 	// on this list of Istio types. Other Istio types are not yet supported.
+	// Kubernetes Gateway API kinds are prefixed with K8s, i.e. K8sGateway, K8sHTTPRoute and K8sTCPRoute.
 	CacheIstioTypes []string `yaml:"cache_istio_types,omitempty"`
 	// List of namespaces or regex defining namespaces to include in a cache
 	CacheNamespaces []string `yaml:"cache_namespaces,omitempty"`
@@ -290,6 +297,32 @@ type DeploymentConfig struct {
 // defaults to the namespace configured for IstioNamespace (which itself defaults to 'istio-system').
 type IstioComponentNamespaces map[string]string
 
+// CanaryController defines how the services opted in with the kiali.io/canary annotation are progressively shifted
+// to their canary version. Services can override the step weight and the thresholds with annotations.
+type CanaryController struct {
+	Enabled       bool     `yaml:"enabled"`
+	Interval      string   `yaml:"interval,omitempty"`        // Time between two steps, as a duration. Metrics are checked over it.
+	Namespaces    []string `yaml:"namespaces,omitempty"`      // Namespaces where services can opt in. None are reconciled when empty.
+	StepWeight    int      `yaml:"step_weight,omitempty"`     // Weight added to the canary at each step
+	MaxErrorRate  float64  `yaml:"max_error_rate,omitempty"`  // Percentage of 5xx responses of the canary triggering a rollback
+	MaxP99Latency float64  `yaml:"max_p99_latency,omitempty"` // p99 response time of the canary, in milliseconds, triggering a rollback
+}
+
+// FaultExperiments defines the limits of the time-boxed fault injection experiments
+type FaultExperiments struct {
+	Enabled       bool   `yaml:"enabled"`
+	CheckInterval string `yaml:"check_interval,omitempty"` // Time between two checks of the running experiments, as a duration
+	MaxDuration   string `yaml:"max_duration,omitempty"`   // Longest experiment allowed, as a duration
+}
+
+// IstioConfigHistory defines how the changes made through Kiali to the Istio config are recorded.
+// Disabled by default: revisions are stored and read with the Kiali ServiceAccount.
+type IstioConfigHistory struct {
+	Enabled      bool   `yaml:"enabled"`
+	MaxRevisions int    `yaml:"max_revisions,omitempty"` // Revisions kept per object, older ones are dropped
+	Store        string `yaml:"store,omitempty"`         // "configmap" (ConfigMaps in the Kiali namespace) or "memory"
+}
+
 type KialiFeatureFlags struct {
 	IstioInjectionAction bool `yaml:"istio_injection_action,omitempty" json:"istioInjectionAction"`
 }
@@ -321,14 +354,17 @@ type Config struct {
 	AdditionalDisplayDetails []AdditionalDisplayItem  `yaml:"additional_display_details,omitempty"`
 	API                      ApiConfig                `yaml:"api,omitempty"`
 	Auth                     AuthConfig               `yaml:"auth,omitempty"`
+	CanaryController         CanaryController         `yaml:"canary_controller,omitempty"`
 	Deployment               DeploymentConfig         `yaml:"deployment,omitempty"`
 	Extensions               Extensions               `yaml:"extensions,omitempty"`
 	ExternalServices         ExternalServices         `yaml:"external_services,omitempty"`
+	FaultExperiments         FaultExperiments         `yaml:"fault_experiments,omitempty"`
 	HealthConfig             HealthConfig             `yaml:"health_config,omitempty" json:"healthConfig"`
 	Identity                 security.Identity        `yaml:",omitempty"`
 	InCluster                bool                     `yaml:"in_cluster,omitempty"`
 	InstallationTag          string                   `yaml:"installation_tag,omitempty"`
 	IstioComponentNamespaces IstioComponentNamespaces `yaml:"istio_component_namespaces,omitempty"`
+	IstioConfigHistory       IstioConfigHistory       `yaml:"istio_config_history,omitempty"`
 	IstioLabels              IstioLabels              `yaml:"istio_labels,omitempty"`
 	IstioNamespace           string                   `yaml:"istio_namespace,omitempty"` // default component namespace
 	KialiFeatureFlags        KialiFeatureFlags        `yaml:"kiali_feature_flags,omitempty"`
@@ -372,6 +408,13 @@ func NewConfig() (c *Config) {
 				ClientIdPrefix: "kiali",
 			},
 		},
+		CanaryController: CanaryController{
+			Enabled:       false,
+			Interval:      "1m",
+			StepWeight:    10,
+			MaxErrorRate:  5,
+			MaxP99Latency: 500,
+		},
 		Deployment: DeploymentConfig{
 			AccessibleNamespaces: []string{"**"},
 			Namespace:            "istio-system",
@@ -442,6 +485,16 @@ func NewConfig() (c *Config) {
 				WhiteListIstioSystem: []string{"jaeger-query", "istio-ingressgateway"},
 			},
 		},
+		FaultExperiments: FaultExperiments{
+			Enabled:       false,
+			CheckInterval: "15s",
+			MaxDuration:   "1h",
+		},
+		IstioConfigHistory: IstioConfigHistory{
+			Enabled:      false,
+			MaxRevisions: 20,
+			Store:        IstioConfigHistoryStoreConfigMap,
+		},
 		IstioLabels: IstioLabels{
 			AppLabelName:       "app",
 			InjectionLabelName: "istio-injection",
@@ -454,7 +507,7 @@ func NewConfig() (c *Config) {
 			Burst:                       200,
 			CacheDuration:               5 * 60,
 			CacheEnabled:                true,
-			CacheIstioTypes:             []string{"DestinationRule", "Gateway", "ServiceEntry", "VirtualService", "Sidecar", "PeerAuthentication", "RequestAuthentication", "AuthorizationPolicy"},
+			CacheIstioTypes:             []string{"DestinationRule", "Gateway", "ServiceEntry", "VirtualService", "Sidecar", "PeerAuthentication", "RequestAuthentication", "AuthorizationPolicy", "K8sGateway", "K8sHTTPRoute", "K8sTCPRoute"},
 			CacheNamespaces:             []string{".*"},
 			CacheTokenNamespaceDuration: 10,
 			ExcludeWorkloads:            []string{"CronJob", "DeploymentConfig", "Job", "ReplicationController"},
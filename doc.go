@@ -34,7 +34,7 @@ type AggregateValueParam struct {
 	Name string `json:"aggregateValue"`
 }
 
-// swagger:parameters appMetrics appDetails graphApp graphAppVersion appDashboard appSpans appTraces errorTraces
+// swagger:parameters appMetrics appDetails graphApp graphAppVersion appDashboard appSpans appTraces errorTraces appHealthHistory
 type AppParam struct {
 	// The app name (label value).
 	//
@@ -61,7 +61,7 @@ type ContainerParam struct {
 	Name string `json:"container"`
 }
 
-// swagger:parameters istioConfigList workloadList workloadDetails workloadUpdate serviceDetails appSpans serviceSpans workloadSpans appTraces serviceTraces workloadTraces errorTraces workloadValidations appList serviceMetrics aggregateMetrics appMetrics workloadMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype serviceList appDetails graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype namespaceUpdate namespaceTls podDetails podLogs namespaceValidations getIter8Experiments postIter8Experiments patchIter8Experiments deleteIter8Experiments podProxyDump podProxyResource
+// swagger:parameters istioConfigList workloadList workloadDetails workloadUpdate serviceDetails appSpans serviceSpans workloadSpans appTraces serviceTraces workloadTraces errorTraces workloadValidations appList serviceMetrics aggregateMetrics appMetrics workloadMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype serviceList appDetails graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype namespaceUpdate namespaceTls namespaceWorkloadsTls podDetails podLogs namespaceValidations getIter8Experiments postIter8Experiments patchIter8Experiments deleteIter8Experiments podProxyDump podProxyResource istioConfigHistory istioConfigRevision istioConfigRevisionsDiff istioConfigRollback istioConfigImport istioConfigExport serviceTrafficWizard serviceTrafficWizardDelete istioConfigReferences istioConfigImpact istioConfigProposedImpact serviceFaultExperimentStart serviceFaultExperimentList serviceFaultExperimentDetails serviceFaultExperimentStop
 type NamespaceParam struct {
 	// The namespace name.
 	//
@@ -79,7 +79,7 @@ type NameParam struct {
 	Name string `json:"name"`
 }
 
-// swagger:parameters istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype
+// swagger:parameters istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype istioConfigHistory istioConfigRevision istioConfigRevisionsDiff istioConfigRollback istioConfigReferences istioConfigImpact
 type ObjectNameParam struct {
 	// The Istio object name.
 	//
@@ -88,16 +88,95 @@ type ObjectNameParam struct {
 	Name string `json:"object"`
 }
 
-// swagger:parameters istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype istioConfigCreate istioConfigCreateSubtype
+// swagger:parameters istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype istioConfigCreate istioConfigCreateSubtype istioConfigHistory istioConfigRevision istioConfigRevisionsDiff istioConfigRollback istioConfigReferences istioConfigImpact istioConfigProposedImpact
 type ObjectTypeParam struct {
 	// The Istio object type.
 	//
 	// in: path
 	// required: true
-	// pattern: ^(gateways|virtualservices|destinationrules|serviceentries|rules|quotaspecs|quotaspecbindings)$
+	// pattern: ^(gateways|virtualservices|destinationrules|serviceentries|rules|quotaspecs|quotaspecbindings|k8sgatewayclasses|k8sgateways|k8shttproutes|k8stcproutes|telemetries|wasmplugins)$
 	Name string `json:"object_type"`
 }
 
+// swagger:parameters serviceTrafficWizard
+type TrafficWizardParam struct {
+	// The traffic management intent for the service.
+	//
+	// in: body
+	// required: true
+	Body models.TrafficWizard
+}
+
+// swagger:parameters serviceFaultExperimentStart
+type FaultExperimentRequestParam struct {
+	// The faults to inject and the limits of the experiment.
+	//
+	// in: body
+	// required: true
+	Body models.FaultExperimentRequest
+}
+
+// swagger:parameters serviceFaultExperimentDetails serviceFaultExperimentStop
+type FaultExperimentParam struct {
+	// The fault experiment id.
+	//
+	// in: path
+	// required: true
+	Name string `json:"experiment"`
+}
+
+// swagger:parameters istioConfigExport
+type IstioConfigExportParam struct {
+	// Comma separated list of the Istio types to export. All types are exported when empty.
+	//
+	// in: query
+	// required: false
+	Objects string `json:"objects"`
+	// Only the objects with these labels are exported.
+	//
+	// in: query
+	// required: false
+	LabelSelector string `json:"labelSelector"`
+	// Only the objects applied to the workloads with these labels are exported.
+	//
+	// in: query
+	// required: false
+	WorkloadSelector string `json:"workloadSelector"`
+	// Format of the export: yaml (default) or tar.
+	//
+	// in: query
+	// required: false
+	Format string `json:"format"`
+	// Namespace set in the exported objects, instead of the namespace they are read from.
+	//
+	// in: query
+	// required: false
+	TargetNamespace string `json:"targetNamespace"`
+}
+
+// swagger:parameters istioConfigRevision istioConfigRollback
+type RevisionParam struct {
+	// The number of the revision.
+	//
+	// in: path
+	// required: true
+	Name string `json:"revision"`
+}
+
+// swagger:parameters istioConfigRevisionsDiff
+type RevisionsDiffParam struct {
+	// The revision compared from.
+	//
+	// in: query
+	// required: true
+	From string `json:"from"`
+	// The revision compared to.
+	//
+	// in: query
+	// required: true
+	To string `json:"to"`
+}
+
 // swagger:parameters podDetails podLogs podProxyDump podProxyResource
 type PodParam struct {
 	// The pod name.
@@ -116,7 +195,7 @@ type ResourceParam struct {
 	Name string `json:"resource"`
 }
 
-// swagger:parameters serviceDetails serviceMetrics graphService graphAggregateByService serviceDashboard serviceSpans serviceTraces
+// swagger:parameters serviceDetails serviceMetrics graphService graphAggregateByService serviceDashboard serviceSpans serviceTraces serviceTrafficWizard serviceTrafficWizardDelete serviceFaultExperimentStart serviceFaultExperimentList serviceFaultExperimentDetails serviceFaultExperimentStop serviceHealthHistory
 type ServiceParam struct {
 	// The service name.
 	//
@@ -144,6 +223,114 @@ type DurationLogParam struct {
 	Name string `json:"duration"`
 }
 
+// swagger:parameters namespaceValidations
+type ValidationsFormatParam struct {
+	// Returns the checks of the validations as a report: sarif or junit. Default is the validation summary.
+	//
+	// in: query
+	// required: false
+	Name string `json:"format"`
+}
+
+// swagger:parameters istioConfigUpdate istioConfigCreate istioConfigImport serviceTrafficWizard
+type IstioConfigDryRunParam struct {
+	// When true, the change is validated but not persisted. The response holds the validations of the namespace with the change and the result of a server-side dry-run in Kubernetes.
+	//
+	// in: query
+	// required: false
+	Name bool `json:"dryRun"`
+}
+
+// swagger:parameters istioConfigUpdate istioConfigDelete
+type IstioConfigResourceVersionParam struct {
+	// The expected resourceVersion of the object. The change is rejected with a 409 holding the current object when the object was modified since then.
+	//
+	// in: query
+	// required: false
+	Name string `json:"resourceVersion"`
+}
+
+// swagger:parameters istioConfigUpdate
+type IstioConfigApplyParam struct {
+	// When true, the body is the full object and it is applied with server-side apply, owned by the kiali field manager.
+	//
+	// in: query
+	// required: false
+	Name bool `json:"apply"`
+}
+
+// swagger:parameters istioConfigUpdate
+type IstioConfigForceParam struct {
+	// With apply=true, take the ownership of the fields owned by other field managers instead of failing with a 409.
+	//
+	// in: query
+	// required: false
+	Name bool `json:"force"`
+}
+
+// swagger:parameters meshValidations
+type MeshValidationsLimitParam struct {
+	// Maximum number of checks and objects ranked. Default is 10, zero or negative returns all.
+	//
+	// in: query
+	// required: false
+	Name int `json:"limit"`
+}
+
+// swagger:parameters istioConfigSearch
+type IstioConfigSearchHostParam struct {
+	// Host used by the Istio objects. Short names match the services of that name in any namespace, and wildcard hosts of the objects match too.
+	//
+	// in: query
+	// required: false
+	Name string `json:"host"`
+}
+
+// swagger:parameters istioConfigSearch
+type IstioConfigSearchLabelSelectorParam struct {
+	// Kubernetes label selector of the Istio objects.
+	//
+	// in: query
+	// required: false
+	Name string `json:"labelSelector"`
+}
+
+// swagger:parameters istioConfigSearch
+type IstioConfigSearchWorkloadSelectorParam struct {
+	// Labels of a workload the Istio objects apply to, i.e. app=reviews,version=v1.
+	//
+	// in: query
+	// required: false
+	Name string `json:"workloadSelector"`
+}
+
+// swagger:parameters istioConfigSearch
+type IstioConfigSearchFieldParam struct {
+	// Field paths of the Istio objects, as path or path=value, i.e. spec/http/route/destination/subset=v1. Lists are matched element by element unless an index is given, and "*" matches any key. Can be repeated, all fields must match.
+	//
+	// in: query
+	// required: false
+	Name []string `json:"field"`
+}
+
+// swagger:parameters istioConfigSearch
+type IstioConfigSearchObjectsParam struct {
+	// Comma separated Istio object types to search. Default is all types.
+	//
+	// in: query
+	// required: false
+	Name string `json:"objects"`
+}
+
+// swagger:parameters istioUpgradeReadiness
+type IstioUpgradeTargetVersionParam struct {
+	// Istio version the mesh is upgraded to.
+	//
+	// in: query
+	// required: true
+	Name string `json:"targetVersion"`
+}
+
 // swagger:parameters traceDetails
 type TraceIDParam struct {
 	// The trace ID.
@@ -162,7 +349,7 @@ type DashboardParam struct {
 	Name string `json:"dashboard"`
 }
 
-// swagger:parameters workloadDetails workloadUpdate workloadValidations workloadMetrics graphWorkload workloadDashboard workloadSpans workloadTraces
+// swagger:parameters workloadDetails workloadUpdate workloadValidations workloadMetrics graphWorkload workloadDashboard workloadSpans workloadTraces workloadHealthHistory
 type WorkloadParam struct {
 	// The workload name.
 	//
@@ -235,7 +422,7 @@ type NamespacesParam struct {
 	Name string `json:"namespaces"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload istioConfigImpact istioConfigProposedImpact
 type QueryTimeParam struct {
 	// Unix time (seconds) for query such that time range is [queryTime-duration..queryTime]. Default is now.
 	//
@@ -339,7 +526,7 @@ type RateFuncParam struct {
 	Name string `json:"rateFunc"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics customDashboard appDashboard serviceDashboard workloadDashboard
+// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigImpact istioConfigProposedImpact
 type RateIntervalParam struct {
 	// Interval used for rate and histogram calculation.
 	//
@@ -419,6 +606,20 @@ type BadRequestError struct {
 	} `json:"body"`
 }
 
+// ConflictError: the request conflicts with the current state of the resource
+//
+// swagger:response conflictError
+type ConflictError struct {
+	// in: body
+	Body struct {
+		// HTTP status code
+		// example: 409
+		// default: 409
+		Code    int32 `json:"code"`
+		Message error `json:"message"`
+	} `json:"body"`
+}
+
 // A NotFoundError is the error message that is generated when server could not find what was requested.
 //
 // swagger:response notFoundError
@@ -552,6 +753,20 @@ type namespaceAppHealthResponse struct {
 	Body models.NamespaceAppHealth
 }
 
+// namespaceHealthStatusResponse is the health status of a namespace
+// swagger:response namespaceHealthStatusResponse
+type namespaceHealthStatusResponse struct {
+	// in:body
+	Body models.NamespaceHealthStatus
+}
+
+// healthHistoryResponse is the health status of an entity or a namespace over time
+// swagger:response healthHistoryResponse
+type healthHistoryResponse struct {
+	// in:body
+	Body models.HealthHistory
+}
+
 // namespaceResponse is a basic namespace
 // swagger:response namespaceResponse
 type namespaceResponse struct {
@@ -615,6 +830,90 @@ type IstioConfigDetailsResponse struct {
 	Body models.IstioConfigDetails
 }
 
+// Result of a create or update of an Istio Object in dry-run mode
+// swagger:response istioConfigDryRunResponse
+type IstioConfigDryRunResponse struct {
+	// in:body
+	Body models.IstioConfigDryRun
+}
+
+// Result of importing a bundle of Istio objects
+// swagger:response istioConfigImportResponse
+type IstioConfigImportResponse struct {
+	// in:body
+	Body models.IstioConfigImport
+}
+
+// Objects generated by a traffic management wizard and the result of applying them
+// swagger:response trafficWizardResponse
+type TrafficWizardResponse struct {
+	// in:body
+	Body models.TrafficWizardResult
+}
+
+// Record of a fault experiment
+// swagger:response faultExperimentResponse
+type FaultExperimentResponse struct {
+	// in:body
+	Body models.FaultExperiment
+}
+
+// Records of the fault experiments of a service
+// swagger:response faultExperimentsResponse
+type FaultExperimentsResponse struct {
+	// in:body
+	Body []models.FaultExperiment
+}
+
+// Change rejected because of a stale resourceVersion or fields owned by another field manager, with the current object
+// swagger:response istioConfigConflictResponse
+type IstioConfigConflictResponse struct {
+	// in:body
+	Body models.IstioConfigConflict
+}
+
+// Objects referenced by an Istio object and Istio objects referencing it
+// swagger:response istioConfigReferencesResponse
+type IstioConfigReferencesResponse struct {
+	// in:body
+	Body models.IstioConfigReferences
+}
+
+// Workloads, pods, services and gateways affected by an Istio object
+// swagger:response istioConfigImpactResponse
+type IstioConfigImpactResponse struct {
+	// in:body
+	Body models.IstioConfigImpact
+}
+
+// Istio objects of all the accessible namespaces matching a search, with the location of the matches
+// swagger:response istioConfigSearchResponse
+type IstioConfigSearchResponse struct {
+	// in:body
+	Body models.IstioConfigSearchResults
+}
+
+// Revisions recorded for the changes of an Istio object
+// swagger:response istioConfigHistoryResponse
+type IstioConfigHistoryResponse struct {
+	// in:body
+	Body []models.IstioConfigRevision
+}
+
+// Revision of an Istio object, with the whole object before and after the change
+// swagger:response istioConfigRevisionResponse
+type IstioConfigRevisionResponse struct {
+	// in:body
+	Body models.IstioConfigRevision
+}
+
+// Differences of an Istio object between two revisions
+// swagger:response istioConfigRevisionDiffResponse
+type IstioConfigRevisionDiffResponse struct {
+	// in:body
+	Body models.IstioConfigRevisionDiff
+}
+
 // Detailed information of an specific app
 // swagger:response appDetails
 type AppDetailsResponse struct {
@@ -664,6 +963,13 @@ type NamespaceTlsResponse struct {
 	Body models.MTLSStatus
 }
 
+// Return the effective mTLS status of the workloads of a specific Namespace
+// swagger:response namespaceWorkloadsTlsResponse
+type NamespaceWorkloadsTlsResponse struct {
+	// in:body
+	Body []models.WorkloadMTLSStatus
+}
+
 // Return the validation status of a specific Namespace
 // swagger:response namespaceValidationSummaryResponse
 type NamespaceValidationSummaryResponse struct {
@@ -671,6 +977,20 @@ type NamespaceValidationSummaryResponse struct {
 	Body models.IstioValidationSummary
 }
 
+// Return the validation status of all the namespaces of the mesh
+// swagger:response meshValidationSummaryResponse
+type MeshValidationSummaryResponse struct {
+	// in:body
+	Body models.MeshValidationSummary
+}
+
+// Return the readiness of the mesh to upgrade Istio
+// swagger:response istioUpgradeReadinessResponse
+type IstioUpgradeReadinessResponse struct {
+	// in:body
+	Body models.IstioUpgradeReadiness
+}
+
 // Return a dump of the configuration of a given envoy proxy
 // swagger:response configDump
 type ConfigDumpResponse struct {
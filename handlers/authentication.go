@@ -338,7 +338,7 @@ func checkOpenshiftSession(w http.ResponseWriter, r *http.Request) (int, string)
 		_, err = business.OpenshiftOAuth.GetUserInfo(claims.SessionId)
 		if err == nil {
 			// Internal header used to propagate the subject of the request for audit purposes
-			r.Header.Add("Kiali-User", claims.Subject)
+			r.Header.Set("Kiali-User", claims.Subject)
 			return http.StatusOK, claims.SessionId
 		}
 
@@ -406,7 +406,7 @@ func checkOpenIdSession(w http.ResponseWriter, r *http.Request) (int, string) {
 	}
 
 	// Internal header used to propagate the subject of the request for audit purposes
-	r.Header.Add("Kiali-User", claims.Subject)
+	r.Header.Set("Kiali-User", claims.Subject)
 	return http.StatusOK, claims.SessionId
 }
 
@@ -430,7 +430,7 @@ func checkTokenSession(w http.ResponseWriter, r *http.Request) (int, string) {
 		_, err = business.Namespace.GetNamespaces()
 		if err == nil {
 			// Internal header used to propagate the subject of the request for audit purposes
-			r.Header.Add("Kiali-User", claims.Subject)
+			r.Header.Set("Kiali-User", claims.Subject)
 			return http.StatusOK, claims.SessionId
 		}
 
@@ -454,6 +454,9 @@ func (aHandler AuthenticationHandler) Handle(next http.Handler) http.Handler {
 		statusCode := http.StatusOK
 		conf := config.Get()
 
+		// The user is only propagated from the session, never from the client
+		r.Header.Del("Kiali-User")
+
 		var token string
 
 		switch conf.Auth.Strategy {
@@ -489,6 +492,7 @@ func (aHandler AuthenticationHandler) Handle(next http.Handler) http.Handler {
 
 func (aHandler AuthenticationHandler) HandleUnauthenticated(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del("Kiali-User")
 		context := context.WithValue(r.Context(), "token", "")
 		next.ServeHTTP(w, r.WithContext(context))
 	})
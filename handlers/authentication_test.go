@@ -388,6 +388,27 @@ func TestLogout(t *testing.T) {
 	assert.True(t, cookie.Expires.Before(clockTime))
 }
 
+// TestAnonymousIgnoresKialiUserHeader checks that the user sent by a client
+// in the Kiali-User header is not trusted by the protected endpoints.
+func TestAnonymousIgnoresKialiUserHeader(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Auth.Strategy = config.AuthStrategyAnonymous
+	config.Set(cfg)
+
+	request := httptest.NewRequest("GET", "http://kiali/api/foo", nil)
+	request.Header.Set("Kiali-User", "mallory")
+
+	var user, header string
+	handler := AuthenticationHandler{}.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user = kialiUser(r)
+		header = r.Header.Get("Kiali-User")
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+
+	assert.Equal(t, config.AuthStrategyAnonymous, user)
+	assert.Equal(t, "", header)
+}
+
 func mockK8s(reject bool) {
 	k8s := kubetest.NewK8SClientMock()
 	prom := new(prometheustest.PromClientMock)
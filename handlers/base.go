@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"net/http"
 )
 
@@ -34,6 +35,19 @@ func RespondWithJSONIndent(w http.ResponseWriter, code int, payload interface{})
 	_, _ = w.Write(response)
 }
 
+func RespondWithXML(w http.ResponseWriter, code int, payload interface{}) {
+	response, err := xml.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(code)
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(response)
+}
+
 func RespondWithError(w http.ResponseWriter, code int, message string) {
 	RespondWithJSON(w, code, responseError{Error: message})
 }
@@ -2,16 +2,23 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/util"
 )
 
-const defaultHealthRateInterval = "10m"
+const (
+	defaultHealthRateInterval    = "10m"
+	defaultHealthHistoryDuration = time.Hour
+	defaultHealthHistoryStep     = time.Minute
+)
 
 // NamespaceHealth is the API handler to get app-based health of every services in the given namespace
 func NamespaceHealth(w http.ResponseWriter, r *http.Request) {
@@ -61,6 +68,34 @@ func NamespaceHealth(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// NamespaceHealthStatus is the API handler to get the health status of a namespace, evaluated from its apps, services
+// or workloads
+func NamespaceHealthStatus(w http.ResponseWriter, r *http.Request) {
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	p := namespaceHealthParams{}
+	if ok, err := p.extract(r); !ok {
+		// Bad request
+		RespondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Adjust rate interval
+	rateInterval, err := adjustRateInterval(business, p.Namespace, p.RateInterval, p.QueryTime)
+	if err != nil {
+		handleErrorResponse(w, err, "Adjust rate interval error: "+err.Error())
+		return
+	}
+
+	status, err := business.Health.GetNamespaceHealthStatus(p.Namespace, p.Type, rateInterval, p.QueryTime)
+	handleHealthResponse(w, status, err)
+}
+
 // AppHealth is the API handler to get health of a single app
 func AppHealth(w http.ResponseWriter, r *http.Request) {
 	business, err := getBusiness(r)
@@ -122,6 +157,50 @@ func ServiceHealth(w http.ResponseWriter, r *http.Request) {
 	handleHealthResponse(w, health, err)
 }
 
+// NamespaceHealthHistory is the API handler to get the health status over time of a namespace, evaluated from its
+// apps, services or workloads
+func NamespaceHealthHistory(w http.ResponseWriter, r *http.Request) {
+	healthHistory(w, r, "")
+}
+
+// AppHealthHistory is the API handler to get the health status over time of a single app
+func AppHealthHistory(w http.ResponseWriter, r *http.Request) {
+	healthHistory(w, r, "app")
+}
+
+// ServiceHealthHistory is the API handler to get the health status over time of a single service
+func ServiceHealthHistory(w http.ResponseWriter, r *http.Request) {
+	healthHistory(w, r, "service")
+}
+
+// WorkloadHealthHistory is the API handler to get the health status over time of a single workload
+func WorkloadHealthHistory(w http.ResponseWriter, r *http.Request) {
+	healthHistory(w, r, "workload")
+}
+
+// healthHistory gets the health history of the entity of the given type in the path, or of the namespace when empty
+func healthHistory(w http.ResponseWriter, r *http.Request, entityType string) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	p := healthHistoryParams{}
+	if ok, err := p.extract(r, entityType); !ok {
+		// Bad request
+		RespondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	history, err := business.Health.GetHealthHistory(p.Namespace, p.Type, p.Name, p.Range)
+	if errors.IsBadRequest(err) {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	handleHealthResponse(w, history, err)
+}
+
 func handleHealthResponse(w http.ResponseWriter, health interface{}, err error) {
 	if err != nil {
 		handleErrorResponse(w, err)
@@ -157,7 +236,7 @@ func (p *baseHealthParams) baseExtract(r *http.Request, vars map[string]string)
 
 // namespaceHealthParams holds the path and query parameters for NamespaceHealth
 //
-// swagger:parameters namespaceHealth
+// swagger:parameters namespaceHealth namespaceHealthStatus
 type namespaceHealthParams struct {
 	baseHealthParams
 	// The type of health, "app", "service" or "workload".
@@ -236,6 +315,81 @@ func (p *workloadHealthParams) extract(r *http.Request) {
 	p.WorkloadType = query.Get("type")
 }
 
+// healthHistoryParams holds the path and query parameters for the health histories
+//
+// swagger:parameters namespaceHealthHistory appHealthHistory serviceHealthHistory workloadHealthHistory
+type healthHistoryParams struct {
+	// The namespace scope
+	//
+	// in: path
+	Namespace string `json:"namespace"`
+	// The type of health of a namespace history, "app", "service" or "workload".
+	//
+	// in: query
+	// pattern: ^(app|service|workload)$
+	// default: app
+	Type string `json:"type"`
+	// Duration of the history, in seconds
+	//
+	// in: query
+	// default: 3600
+	Duration int `json:"duration"`
+	// Duration of a bucket, in seconds
+	//
+	// in: query
+	// default: 60
+	Step int `json:"step"`
+	// End of the history, as a UNIX time in seconds. Default is now.
+	//
+	// in: query
+	QueryTime int64 `json:"queryTime"`
+
+	Name  string
+	Range prom_v1.Range
+}
+
+func (p *healthHistoryParams) extract(r *http.Request, entityType string) (bool, string) {
+	vars := mux.Vars(r)
+	queryParams := r.URL.Query()
+	p.Namespace = vars["namespace"]
+	p.Type = "app"
+	p.Range = prom_v1.Range{End: util.Clock.Now(), Step: defaultHealthHistoryStep}
+	duration := defaultHealthHistoryDuration
+
+	if entityType != "" {
+		p.Type = entityType
+		p.Name = vars[entityType]
+	} else if healthType := queryParams.Get("type"); healthType != "" {
+		if healthType != "app" && healthType != "service" && healthType != "workload" {
+			return false, "Bad request, query parameter 'type' must be one of ['app','service','workload']"
+		}
+		p.Type = healthType
+	}
+	if queryTime := queryParams.Get("queryTime"); queryTime != "" {
+		num, err := strconv.ParseInt(queryTime, 10, 64)
+		if err != nil {
+			return false, "Bad request, cannot parse query parameter 'queryTime'"
+		}
+		p.Range.End = time.Unix(num, 0)
+	}
+	if dur := queryParams.Get("duration"); dur != "" {
+		num, err := strconv.ParseInt(dur, 10, 64)
+		if err != nil {
+			return false, "Bad request, cannot parse query parameter 'duration'"
+		}
+		duration = time.Duration(num) * time.Second
+	}
+	if step := queryParams.Get("step"); step != "" {
+		num, err := strconv.Atoi(step)
+		if err != nil {
+			return false, "Bad request, cannot parse query parameter 'step'"
+		}
+		p.Range.Step = time.Duration(num) * time.Second
+	}
+	p.Range.Start = p.Range.End.Add(-duration)
+	return true, ""
+}
+
 func adjustRateInterval(business *business.Layer, namespace, rateInterval string, queryTime time.Time) (string, error) {
 	namespaceInfo, err := business.Namespace.GetNamespace(namespace)
 	if err != nil {
@@ -1,19 +1,28 @@
 package handlers
 
 import (
+	"bytes"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/util"
 )
 
+const defaultImpactRateInterval = "1m"
+
 func IstioConfigList(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	namespace := params["namespace"]
@@ -144,6 +153,150 @@ func IstioConfigDetails(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, istioConfigDetails)
 }
 
+// IstioConfigReferences returns the objects referenced by an Istio object and the Istio objects referencing it
+func IstioConfigReferences(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+	object := params["object"]
+
+	if !checkObjectType(objectType) {
+		RespondWithError(w, http.StatusBadRequest, "Object type not managed: "+objectType)
+		return
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+	references, err := business.IstioConfig.GetIstioConfigReferences(namespace, objectType, object)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, references)
+}
+
+// IstioConfigSearch returns the Istio objects of all the accessible namespaces matching a host, label selector,
+// workload selector or spec fields
+func IstioConfigSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	criteria := business.IstioConfigSearchCriteria{
+		IstioConfigCriteria: business.ParseIstioConfigCriteria("", strings.ToLower(query.Get("objects")), query.Get("labelSelector"), query.Get("workloadSelector")),
+		Host:                query.Get("host"),
+	}
+	for _, field := range query["field"] {
+		criterion, err := business.ParseIstioConfigFieldCriterion(field)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		criteria.Fields = append(criteria.Fields, criterion)
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+	results, err := business.IstioConfig.SearchIstioConfig(criteria)
+	if errors.IsBadRequest(err) {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, results)
+}
+
+// IstioConfigImpact returns the workloads, pods, services and gateways affected by an Istio object
+func IstioConfigImpact(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+	object := params["object"]
+
+	if !checkObjectType(objectType) {
+		RespondWithError(w, http.StatusBadRequest, "Object type not managed: "+objectType)
+		return
+	}
+	rateInterval, queryTime, err := extractImpactQueryParams(r)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+	impact, err := business.IstioConfig.GetIstioConfigImpact(namespace, objectType, object, rateInterval, queryTime)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, impact)
+}
+
+// IstioConfigProposedImpact returns the workloads, pods, services and gateways that would be affected by the Istio
+// object of the request body
+func IstioConfigProposedImpact(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+
+	if !checkObjectType(objectType) {
+		RespondWithError(w, http.StatusBadRequest, "Object type not managed: "+objectType)
+		return
+	}
+	rateInterval, queryTime, err := extractImpactQueryParams(r)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Impact request could not be read: "+err.Error())
+		return
+	}
+	impact, err := business.IstioConfig.GetProposedIstioConfigImpact(namespace, objectType, body, rateInterval, queryTime)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, impact)
+}
+
+func extractImpactQueryParams(r *http.Request) (string, time.Time, error) {
+	query := r.URL.Query()
+	rateInterval := defaultImpactRateInterval
+	if ri := query.Get("rateInterval"); ri != "" {
+		rateInterval = ri
+	}
+	queryTime := util.Clock.Now()
+	if qt := query.Get("queryTime"); qt != "" {
+		num, err := strconv.ParseInt(qt, 10, 64)
+		if err != nil {
+			return "", queryTime, fmt.Errorf("cannot parse query parameter 'queryTime'")
+		}
+		queryTime = time.Unix(num, 0)
+	}
+	return rateInterval, queryTime, nil
+}
+
 func IstioConfigDelete(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	namespace := params["namespace"]
@@ -162,8 +315,11 @@ func IstioConfigDelete(w http.ResponseWriter, r *http.Request) {
 		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
 		return
 	}
-	err = business.IstioConfig.DeleteIstioConfigDetail(api, namespace, objectType, object)
-	if err != nil {
+	err = business.IstioConfig.DeleteIstioConfigDetail(api, namespace, objectType, object, r.URL.Query().Get("resourceVersion"), kialiUser(r))
+	if errors.IsConflict(err) {
+		respondIstioConfigConflict(w, business, namespace, objectType, object, err)
+		return
+	} else if err != nil {
 		handleErrorResponse(w, err)
 		return
 	} else {
@@ -196,9 +352,42 @@ func IstioConfigUpdate(w http.ResponseWriter, r *http.Request) {
 		RespondWithError(w, http.StatusBadRequest, "Update request with bad update patch: "+err.Error())
 	}
 	jsonPatch := string(body)
-	updatedConfigDetails, err := business.IstioConfig.UpdateIstioConfigDetail(api, namespace, objectType, object, jsonPatch)
+	resourceVersion := r.URL.Query().Get("resourceVersion")
 
-	if err != nil {
+	if isQueryParamTrue(r, "apply") {
+		if isDryRun(r) {
+			RespondWithError(w, http.StatusBadRequest, "dryRun is not supported with apply")
+			return
+		}
+		appliedConfigDetails, err := business.IstioConfig.ApplyIstioConfigDetail(api, namespace, objectType, object, body, resourceVersion, isQueryParamTrue(r, "force"), kialiUser(r))
+		if errors.IsConflict(err) {
+			respondIstioConfigConflict(w, business, namespace, objectType, object, err)
+			return
+		} else if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		audit(r, "APPLY on Namespace: "+namespace+" Type: "+objectType+" Name: "+object+" Object: "+jsonPatch)
+		RespondWithJSON(w, http.StatusOK, appliedConfigDetails)
+		return
+	}
+
+	if isDryRun(r) {
+		dryRun, err := business.IstioConfig.DryRunUpdateIstioConfigDetail(api, namespace, objectType, object, jsonPatch)
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, dryRun)
+		return
+	}
+
+	updatedConfigDetails, err := business.IstioConfig.UpdateIstioConfigDetail(api, namespace, objectType, object, jsonPatch, resourceVersion, kialiUser(r))
+
+	if errors.IsConflict(err) {
+		respondIstioConfigConflict(w, business, namespace, objectType, object, err)
+		return
+	} else if err != nil {
 		handleErrorResponse(w, err)
 		return
 	}
@@ -231,7 +420,17 @@ func IstioConfigCreate(w http.ResponseWriter, r *http.Request) {
 		RespondWithError(w, http.StatusBadRequest, "Create request could not be read: "+err.Error())
 	}
 
-	createdConfigDetails, err := business.IstioConfig.CreateIstioConfigDetail(api, namespace, objectType, body)
+	if isDryRun(r) {
+		dryRun, err := business.IstioConfig.DryRunCreateIstioConfigDetail(api, namespace, objectType, body)
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, dryRun)
+		return
+	}
+
+	createdConfigDetails, err := business.IstioConfig.CreateIstioConfigDetail(api, namespace, objectType, body, kialiUser(r))
 	if err != nil {
 		handleErrorResponse(w, err)
 		return
@@ -241,17 +440,139 @@ func IstioConfigCreate(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, createdConfigDetails)
 }
 
+// IstioConfigExport returns the Istio objects of the namespace matching the criteria as apply-ready YAML or tar
+func IstioConfigExport(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	if format == "" {
+		format = "yaml"
+	}
+	if format != "yaml" && format != "tar" {
+		RespondWithError(w, http.StatusBadRequest, "Invalid format: "+format+", use yaml or tar")
+		return
+	}
+	targetNamespace := query.Get("targetNamespace")
+	if targetNamespace != "" {
+		if errs := validation.IsDNS1123Label(targetNamespace); len(errs) > 0 {
+			RespondWithError(w, http.StatusBadRequest, "Invalid targetNamespace: "+strings.Join(errs, ", "))
+			return
+		}
+	}
+
+	criteria := business.ParseIstioConfigCriteria(namespace, strings.ToLower(query.Get("objects")), query.Get("labelSelector"), query.Get("workloadSelector"))
+
+	// Get business layer
+	layer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+	objects, err := layer.IstioConfig.ExportIstioConfig(criteria, targetNamespace)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	var exported bytes.Buffer
+	contentType := "application/yaml"
+	if format == "tar" {
+		contentType = "application/x-tar"
+		err = business.WriteIstioConfigTar(&exported, objects)
+	} else {
+		err = business.WriteIstioConfigYAML(&exported, objects)
+	}
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Export could not be written: "+err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+namespace+"-istio-config."+format+"\"")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(exported.Bytes())
+}
+
+// IstioConfigImport creates or updates the Istio objects of a multi-document YAML bundle in the namespace
+func IstioConfigImport(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	dryRun := isDryRun(r)
+	istioConfigImport, err := business.IstioConfig.ImportIstioConfig(namespace, r.Body, dryRun, kialiUser(r))
+	if err != nil {
+		if errors.IsBadRequest(err) {
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+		} else {
+			handleErrorResponse(w, err)
+		}
+		return
+	}
+
+	if !dryRun {
+		objects := make([]string, 0, len(istioConfigImport.Objects))
+		for _, o := range istioConfigImport.Objects {
+			objects = append(objects, o.ObjectType+"/"+o.Name+":"+o.Status)
+		}
+		audit(r, "IMPORT on Namespace: "+namespace+" Objects: "+strings.Join(objects, ","))
+	}
+	RespondWithJSON(w, http.StatusOK, istioConfigImport)
+}
+
+// isDryRun returns true when the request asks to validate a change without persisting it
+func isDryRun(r *http.Request) bool {
+	return isQueryParamTrue(r, "dryRun")
+}
+
+func isQueryParamTrue(r *http.Request, param string) bool {
+	value, err := strconv.ParseBool(r.URL.Query().Get(param))
+	return err == nil && value
+}
+
+// respondIstioConfigConflict responds a change rejected with a Conflict along with the current version of the object,
+// so the client can merge its change and retry with the new resourceVersion
+func respondIstioConfigConflict(w http.ResponseWriter, layer *business.Layer, namespace, objectType, object string, err error) {
+	conflict := models.IstioConfigConflict{Message: err.Error()}
+	if statusError, ok := err.(*errors.StatusError); ok {
+		conflict.Message = statusError.ErrStatus.Message
+	}
+	current, getErr := layer.IstioConfig.GetIstioConfigDetails(namespace, objectType, object)
+	if getErr == nil {
+		conflict.Current = &current
+	} else if !errors.IsNotFound(getErr) {
+		log.Errorf("Error fetching the current version of %s %s.%s after a conflict: %s", objectType, object, namespace, getErr)
+	}
+	RespondWithJSON(w, http.StatusConflict, conflict)
+}
+
 func checkObjectType(objectType string) bool {
 	return business.GetIstioAPI(objectType) != ""
 }
 
 func audit(r *http.Request, message string) {
 	if config.Get().Server.AuditLog {
-		user := r.Header.Get("Kiali-User")
+		user := kialiUser(r)
 		log.Infof("AUDIT User [%s] Msg [%s]", user, message)
 	}
 }
 
+// kialiUser returns the user performing the request, as propagated by the authentication handlers
+func kialiUser(r *http.Request) string {
+	// There is no session with anonymous access, the header can't be trusted
+	if config.Get().Auth.Strategy == config.AuthStrategyAnonymous {
+		return config.AuthStrategyAnonymous
+	}
+	return r.Header.Get("Kiali-User")
+}
+
 func IstioConfigPermissions(w http.ResponseWriter, r *http.Request) {
 	// query params
 	params := r.URL.Query()
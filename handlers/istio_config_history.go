@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/business"
+)
+
+// IstioConfigHistory lists the revisions recorded for an Istio object
+func IstioConfigHistory(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+	object := params["object"]
+
+	if !checkObjectType(objectType) {
+		RespondWithError(w, http.StatusBadRequest, "Object type not managed: "+objectType)
+		return
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+	revisions, err := business.IstioConfig.GetIstioConfigHistory(namespace, objectType, object)
+	if err != nil {
+		handleHistoryErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, revisions)
+}
+
+// IstioConfigRevision returns a revision of an Istio object, with the object before and after the change
+func IstioConfigRevision(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+	object := params["object"]
+
+	if !checkObjectType(objectType) {
+		RespondWithError(w, http.StatusBadRequest, "Object type not managed: "+objectType)
+		return
+	}
+	revision, err := strconv.Atoi(params["revision"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid revision: "+params["revision"])
+		return
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+	istioConfigRevision, err := business.IstioConfig.GetIstioConfigRevision(namespace, objectType, object, revision)
+	if err != nil {
+		handleHistoryErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, istioConfigRevision)
+}
+
+// IstioConfigRevisionsDiff compares an Istio object as it was left by two of its revisions
+func IstioConfigRevisionsDiff(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+	object := params["object"]
+	query := r.URL.Query()
+
+	if !checkObjectType(objectType) {
+		RespondWithError(w, http.StatusBadRequest, "Object type not managed: "+objectType)
+		return
+	}
+	from, err := strconv.Atoi(query.Get("from"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid from revision: "+query.Get("from"))
+		return
+	}
+	to, err := strconv.Atoi(query.Get("to"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid to revision: "+query.Get("to"))
+		return
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+	diff, err := business.IstioConfig.DiffIstioConfigRevisions(namespace, objectType, object, from, to)
+	if err != nil {
+		handleHistoryErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, diff)
+}
+
+// IstioConfigRollback restores an Istio object as it was left by one of its revisions
+func IstioConfigRollback(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+	object := params["object"]
+
+	api := business.GetIstioAPI(objectType)
+	if api == "" {
+		RespondWithError(w, http.StatusBadRequest, "Object type not managed: "+objectType)
+		return
+	}
+	revision, err := strconv.Atoi(params["revision"])
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid revision: "+params["revision"])
+		return
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+	rolledBackConfigDetails, err := business.IstioConfig.RollbackIstioConfig(api, namespace, objectType, object, revision, kialiUser(r))
+	if err != nil {
+		handleHistoryErrorResponse(w, err)
+		return
+	}
+
+	audit(r, "ROLLBACK on Namespace: "+namespace+" Type: "+objectType+" Name: "+object+" Revision: "+params["revision"])
+	RespondWithJSON(w, http.StatusOK, rolledBackConfigDetails)
+}
+
+func handleHistoryErrorResponse(w http.ResponseWriter, err error) {
+	switch {
+	case errors.IsServiceUnavailable(err):
+		RespondWithError(w, http.StatusServiceUnavailable, err.Error())
+	case errors.IsBadRequest(err):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	default:
+		handleErrorResponse(w, err)
+	}
+}
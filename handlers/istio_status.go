@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"net/http"
+
+	"github.com/hashicorp/go-version"
 )
 
 // IstioStatus returns a list of istio components and its status
@@ -21,3 +23,26 @@ func IstioStatus(w http.ResponseWriter, r *http.Request) {
 
 	RespondWithJSON(w, http.StatusOK, istioStatus)
 }
+
+// IstioUpgradeReadiness returns what needs attention before upgrading the mesh to a target Istio version
+func IstioUpgradeReadiness(w http.ResponseWriter, r *http.Request) {
+	targetVersion := r.URL.Query().Get("targetVersion")
+	if _, err := version.NewVersion(targetVersion); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid targetVersion: "+targetVersion)
+		return
+	}
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	readiness, err := business.IstioUpgrade.GetUpgradeReadiness(targetVersion)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, readiness)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -30,10 +31,19 @@ func NamespaceList(w http.ResponseWriter, r *http.Request) {
 }
 
 // NamespaceValidationSummary is the API handler to fetch validations summary to be displayed.
-// It is related to all the Istio Objects within the namespace
+// It is related to all the Istio Objects within the namespace.
+// The "format" query param allows to fetch the detailed checks as a SARIF (format=sarif) or JUnit (format=junit) report.
 func NamespaceValidationSummary(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	namespace := vars["namespace"]
+	format := r.URL.Query().Get("format")
+
+	switch format {
+	case "", "sarif", "junit":
+	default:
+		RespondWithError(w, http.StatusBadRequest, "Invalid format: "+format)
+		return
+	}
 
 	business, err := getBusiness(r)
 	if err != nil {
@@ -48,11 +58,46 @@ func NamespaceValidationSummary(w http.ResponseWriter, r *http.Request) {
 	if errValidations != nil {
 		log.Error(errValidations)
 		RespondWithError(w, http.StatusInternalServerError, errValidations.Error())
-	} else {
+		return
+	}
+
+	switch format {
+	case "sarif":
+		RespondWithJSON(w, http.StatusOK, istioConfigValidationResults.FilterByNamespace(namespace).ToSarif())
+	case "junit":
+		RespondWithXML(w, http.StatusOK, istioConfigValidationResults.FilterByNamespace(namespace).ToJUnit("Kiali validations: "+namespace))
+	default:
 		validationSummary = istioConfigValidationResults.SummarizeValidation(namespace)
+		RespondWithJSON(w, http.StatusOK, validationSummary)
+	}
+}
+
+// MeshValidationSummary is the API to get the validation summary of all the namespaces accessible by the user,
+// ranking the most common checks and the objects with more errors and warnings
+func MeshValidationSummary(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitParam); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Invalid limit: "+limitParam)
+			return
+		}
+	}
+
+	business, err := getBusiness(r)
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	meshValidations, err := business.Validations.GetMeshValidations()
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
 	}
 
-	RespondWithJSON(w, http.StatusOK, validationSummary)
+	RespondWithJSON(w, http.StatusOK, models.SummarizeMeshValidations(meshValidations, limit))
 }
 
 // NamespaceUpdate is the API to perform a patch on a Namespace configuration
@@ -2,11 +2,14 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -22,7 +25,9 @@ import (
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/prometheus/prometheustest"
 )
@@ -212,3 +217,108 @@ func setupMocked() (*prometheus.Client, *prometheustest.PromAPIMock, *kubetest.K
 
 	return client, api, k8s, nil
 }
+
+func TestNamespaceValidationsFormats(t *testing.T) {
+	assert := assert.New(t)
+	ts := setupNamespaceValidationsEndpoint(t)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/namespaces/bookinfo/validations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(200, resp.StatusCode, string(actual))
+	assert.JSONEq(`{"errors":1,"warnings":1,"objectCount":2}`, string(actual))
+
+	resp, err = http.Get(ts.URL + "/api/namespaces/bookinfo/validations?format=sarif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, _ = ioutil.ReadAll(resp.Body)
+	assert.Equal(200, resp.StatusCode, string(actual))
+	sarif := models.SarifLog{}
+	assert.NoError(json.Unmarshal(actual, &sarif))
+	assert.Len(sarif.Runs[0].Results, 2)
+	assert.Equal("KIA1101", sarif.Runs[0].Results[0].RuleID)
+
+	resp, err = http.Get(ts.URL + "/api/namespaces/bookinfo/validations?format=junit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, _ = ioutil.ReadAll(resp.Body)
+	assert.Equal(200, resp.StatusCode, string(actual))
+	assert.Equal("application/xml", resp.Header.Get("Content-Type"))
+	junit := models.JUnitTestSuites{}
+	assert.NoError(xml.Unmarshal(actual, &junit))
+	assert.Equal(3, junit.Tests)
+	assert.Equal(1, junit.Failures)
+
+	resp, err = http.Get(ts.URL + "/api/namespaces/bookinfo/validations?format=html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(400, resp.StatusCode)
+}
+
+func TestMeshValidationSummary(t *testing.T) {
+	assert := assert.New(t)
+	ts := setupNamespaceValidationsEndpoint(t)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/mesh/validations?limit=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(200, resp.StatusCode, string(actual))
+
+	summary := models.MeshValidationSummary{}
+	assert.NoError(json.Unmarshal(actual, &summary))
+	assert.Equal(models.IstioValidationSummary{Errors: 1, Warnings: 1, ObjectCount: 2}, summary.Namespaces["bookinfo"])
+	assert.Equal(summary.Namespaces["bookinfo"], summary.Total)
+	assert.Len(summary.TopChecks, 1)
+	assert.Equal("KIA1101", summary.TopChecks[0].Code)
+	assert.Len(summary.WorstObjects, 1)
+	assert.Equal("reviews", summary.WorstObjects[0].Name)
+
+	resp, err = http.Get(ts.URL + "/api/mesh/validations?limit=all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(400, resp.StatusCode)
+}
+
+func setupNamespaceValidationsEndpoint(t *testing.T) *httptest.Server {
+	conf := config.NewConfig()
+	conf.KubernetesConfig.CacheEnabled = false
+	config.Set(conf)
+
+	k8s := kubernetes.NewMemoryClient()
+	manifests, err := os.Open("../tests/data/validations/offline/bookinfo.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer manifests.Close()
+	if err = k8s.LoadYAML(manifests, "bookinfo"); err != nil {
+		t.Fatal(err)
+	}
+	k8s.AddConfigMap(core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"}})
+
+	mockClientFactory := kubetest.NewK8SClientFactoryMock(k8s)
+	business.SetWithBackends(mockClientFactory, new(prometheustest.PromClientMock))
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/validations", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			context := context.WithValue(r.Context(), "token", "test")
+			NamespaceValidationSummary(w, r.WithContext(context))
+		}))
+	mr.HandleFunc("/api/mesh/validations", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			context := context.WithValue(r.Context(), "token", "test")
+			MeshValidationSummary(w, r.WithContext(context))
+		}))
+
+	return httptest.NewServer(mr)
+}
@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"encoding/json"
 	"net/http"
 	"sync"
 
 	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/util"
@@ -89,3 +91,161 @@ func ServiceDetails(w http.ResponseWriter, r *http.Request) {
 
 	RespondWithJSON(w, http.StatusOK, serviceDetails)
 }
+
+// ServiceTrafficWizard is the API handler to generate and apply the VirtualService and DestinationRule of a service
+// from a traffic management wizard
+func ServiceTrafficWizard(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	service := params["service"]
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	wizard := models.TrafficWizard{}
+	if err := json.NewDecoder(r.Body).Decode(&wizard); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Traffic wizard request is not valid: "+err.Error())
+		return
+	}
+
+	dryRun := isDryRun(r)
+	result, err := business.IstioConfig.ApplyTrafficWizard(namespace, service, wizard, dryRun, kialiUser(r))
+	if err != nil {
+		handleTrafficWizardErrorResponse(w, err)
+		return
+	}
+
+	if !dryRun && result.Import.Applied {
+		audit(r, "TRAFFIC WIZARD on Namespace: "+namespace+" Service: "+service+" Type: "+wizard.Type)
+	}
+	RespondWithJSON(w, http.StatusOK, result)
+}
+
+// ServiceTrafficWizardDelete is the API handler to delete the VirtualService and DestinationRule generated by a
+// traffic management wizard for a service
+func ServiceTrafficWizardDelete(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	service := params["service"]
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	if err = business.IstioConfig.DeleteTrafficWizard(namespace, service, kialiUser(r)); err != nil {
+		handleTrafficWizardErrorResponse(w, err)
+		return
+	}
+
+	audit(r, "DELETE TRAFFIC WIZARD on Namespace: "+namespace+" Service: "+service)
+	RespondWithCode(w, http.StatusOK)
+}
+
+// ServiceFaultExperimentStart is the API handler to inject faults in the requests to a service for a limited time
+func ServiceFaultExperimentStart(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	service := params["service"]
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	request := models.FaultExperimentRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Fault experiment request is not valid: "+err.Error())
+		return
+	}
+
+	experiment, err := business.IstioConfig.StartFaultExperiment(namespace, service, request, kialiUser(r))
+	if err != nil {
+		handleTrafficWizardErrorResponse(w, err)
+		return
+	}
+
+	audit(r, "START FAULT EXPERIMENT on Namespace: "+namespace+" Service: "+service+" Experiment: "+experiment.ID)
+	RespondWithJSON(w, http.StatusOK, experiment)
+}
+
+// ServiceFaultExperimentList is the API handler to fetch the fault experiments of a service
+func ServiceFaultExperimentList(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	experiments, err := business.IstioConfig.GetFaultExperiments(params["namespace"], params["service"])
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, experiments)
+}
+
+// ServiceFaultExperimentDetails is the API handler to fetch a fault experiment of a service
+func ServiceFaultExperimentDetails(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	experiment, err := business.IstioConfig.GetFaultExperiment(params["namespace"], params["service"], params["experiment"])
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, experiment)
+}
+
+// ServiceFaultExperimentStop is the API handler to remove the faults of a running experiment before it expires
+func ServiceFaultExperimentStop(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	service := params["service"]
+	id := params["experiment"]
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	experiment, err := business.IstioConfig.StopFaultExperiment(namespace, service, id, kialiUser(r))
+	if err != nil {
+		handleTrafficWizardErrorResponse(w, err)
+		return
+	}
+
+	audit(r, "STOP FAULT EXPERIMENT on Namespace: "+namespace+" Service: "+service+" Experiment: "+id)
+	RespondWithJSON(w, http.StatusOK, experiment)
+}
+
+func handleTrafficWizardErrorResponse(w http.ResponseWriter, err error) {
+	switch {
+	case errors.IsBadRequest(err):
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.IsConflict(err):
+		RespondWithError(w, http.StatusConflict, err.Error())
+	default:
+		handleErrorResponse(w, err)
+	}
+}
@@ -31,6 +31,28 @@ func NamespaceTls(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, status)
 }
 
+// NamespaceWorkloadsTls is the API to get the effective mTLS status of every workload of a namespace
+func NamespaceWorkloadsTls(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	namespace := params["namespace"]
+
+	statuses, err := business.TLS.NamespaceWorkloadsMTLSStatus(namespace)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, statuses)
+}
+
 // MeshTls is the API to get mesh-wide mTLS status
 func MeshTls(w http.ResponseWriter, r *http.Request) {
 	// Get business layer
@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/log"
@@ -59,22 +60,19 @@ func main() {
 	flag.Parse()
 	validateFlags()
 
+	// offline validation of Istio config files, it doesn't start the server
+	if flag.Arg(0) == ValidateCommand {
+		// stdout is reserved for the results, which may be parsed (json, sarif, junit)
+		log.RedirectToStderr()
+		loadConfig()
+		os.Exit(runValidate(flag.Args()[1:], os.Stdout, os.Stderr))
+	}
+
 	// log startup information
 	log.Infof("Kiali: Version: %v, Commit: %v\n", version, commitHash)
 	log.Debugf("Kiali: Command line: [%v]", strings.Join(os.Args, " "))
 
-	// load config file if specified, otherwise, rely on environment variables to configure us
-	if *argConfigFile != "" {
-		c, err := config.LoadFromFile(*argConfigFile)
-		if err != nil {
-			log.Fatal(err)
-		}
-		config.Set(c)
-	} else {
-		log.Infof("No configuration file specified. Will rely on environment for configuration.")
-		config.Set(config.NewConfig())
-	}
-	log.Tracef("Kiali Configuration:\n%s", config.Get())
+	loadConfig()
 
 	if err := validateConfig(); err != nil {
 		log.Fatal(err)
@@ -106,6 +104,21 @@ func main() {
 	server.Stop()
 }
 
+// loadConfig loads the config file if specified, otherwise, relies on environment variables to configure us
+func loadConfig() {
+	if *argConfigFile != "" {
+		c, err := config.LoadFromFile(*argConfigFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		config.Set(c)
+	} else {
+		log.Infof("No configuration file specified. Will rely on environment for configuration.")
+		config.Set(config.NewConfig())
+	}
+	log.Tracef("Kiali Configuration:\n%s", config.Get())
+}
+
 func waitForTermination() {
 	// Channel that is notified when we are done and should exit
 	// TODO: may want to make this a package variable - other things might want to tell us to exit
@@ -164,6 +177,23 @@ func validateConfig() error {
 		return err
 	}
 
+	if faultExperiments := config.Get().FaultExperiments; faultExperiments.Enabled {
+		for name, value := range map[string]string{"check interval": faultExperiments.CheckInterval, "max duration": faultExperiments.MaxDuration} {
+			if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+				return fmt.Errorf("fault experiments %s is not a valid duration: %v", name, value)
+			}
+		}
+	}
+
+	if canary := config.Get().CanaryController; canary.Enabled {
+		if d, err := time.ParseDuration(canary.Interval); err != nil || d <= 0 {
+			return fmt.Errorf("canary controller interval is not a valid duration: %v", canary.Interval)
+		}
+		if canary.StepWeight <= 0 || canary.StepWeight > 100 {
+			return fmt.Errorf("canary controller step weight must be between 1 and 100: %v", canary.StepWeight)
+		}
+	}
+
 	return nil
 }
 
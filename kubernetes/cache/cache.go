@@ -58,6 +58,8 @@ type (
 		k8sApi                 kube.Interface
 		istioNetworkingGetter  cache.Getter
 		istioSecurityGetter    cache.Getter
+		gatewayAPIGetter       cache.Getter
+		isGatewayAPI           bool
 		refreshDuration        time.Duration
 		cacheNamespaces        []string
 		cacheIstioTypes        map[string]bool
@@ -135,6 +137,8 @@ func NewKialiCache() (KialiCache, error) {
 	kialiCacheImpl.k8sApi = istioClient.GetK8sApi()
 	kialiCacheImpl.istioNetworkingGetter = istioClient.GetIstioNetworkingApi()
 	kialiCacheImpl.istioSecurityGetter = istioClient.GetIstioSecurityApi()
+	kialiCacheImpl.gatewayAPIGetter = istioClient.GetGatewayAPIApi()
+	kialiCacheImpl.isGatewayAPI = istioClient.IsGatewayAPI()
 
 	log.Infof("Kiali Cache is active for namespaces %v", cacheNamespaces)
 	return &kialiCacheImpl, nil
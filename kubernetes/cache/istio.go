@@ -21,7 +21,15 @@ type (
 
 func (c *kialiCacheImpl) CheckIstioResource(resourceType string) bool {
 	// cacheIstioTypes stores the single types but for compatibility with kubernetes api resourceType will use plurals
-	_, exist := c.cacheIstioTypes[kubernetes.PluralType[resourceType]]
+	kind := kubernetes.PluralType[resourceType]
+	if kubernetes.ResourceTypesToAPI[resourceType] == kubernetes.GatewayAPIGroupVersion.Group {
+		if !c.isGatewayAPI {
+			return false
+		}
+		// Gateway API kinds are prefixed to not clash with the Istio ones, i.e. K8sGateway
+		kind = "K8s" + kind
+	}
+	_, exist := c.cacheIstioTypes[kind]
 	return exist
 }
 
@@ -51,6 +59,16 @@ func (c *kialiCacheImpl) createIstioInformers(namespace string, informer *typeCa
 	if c.CheckIstioResource(kubernetes.AuthorizationPolicies) {
 		(*informer)[kubernetes.AuthorizationPolicies] = createIstioIndexInformer(c.istioSecurityGetter, kubernetes.AuthorizationPolicies, c.refreshDuration, namespace)
 	}
+	// Gateway API
+	if c.CheckIstioResource(kubernetes.K8sGateways) {
+		(*informer)[kubernetes.K8sGateways] = createIstioIndexInformer(c.gatewayAPIGetter, kubernetes.APIResource(kubernetes.K8sGateways), c.refreshDuration, namespace)
+	}
+	if c.CheckIstioResource(kubernetes.K8sHTTPRoutes) {
+		(*informer)[kubernetes.K8sHTTPRoutes] = createIstioIndexInformer(c.gatewayAPIGetter, kubernetes.APIResource(kubernetes.K8sHTTPRoutes), c.refreshDuration, namespace)
+	}
+	if c.CheckIstioResource(kubernetes.K8sTCPRoutes) {
+		(*informer)[kubernetes.K8sTCPRoutes] = createIstioIndexInformer(c.gatewayAPIGetter, kubernetes.APIResource(kubernetes.K8sTCPRoutes), c.refreshDuration, namespace)
+	}
 }
 
 func (c *kialiCacheImpl) isIstioSynced(namespace string) bool {
@@ -81,6 +99,15 @@ func (c *kialiCacheImpl) isIstioSynced(namespace string) bool {
 		if c.CheckIstioResource(kubernetes.AuthorizationPolicies) {
 			isSynced = isSynced && nsCache[kubernetes.AuthorizationPolicies].HasSynced()
 		}
+		if c.CheckIstioResource(kubernetes.K8sGateways) {
+			isSynced = isSynced && nsCache[kubernetes.K8sGateways].HasSynced()
+		}
+		if c.CheckIstioResource(kubernetes.K8sHTTPRoutes) {
+			isSynced = isSynced && nsCache[kubernetes.K8sHTTPRoutes].HasSynced()
+		}
+		if c.CheckIstioResource(kubernetes.K8sTCPRoutes) {
+			isSynced = isSynced && nsCache[kubernetes.K8sTCPRoutes].HasSynced()
+		}
 	} else {
 		isSynced = false
 	}
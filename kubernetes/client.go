@@ -16,6 +16,7 @@ import (
 	batch_v1 "k8s.io/api/batch/v1"
 	batch_v1beta1 "k8s.io/api/batch/v1beta1"
 	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -30,6 +31,9 @@ import (
 
 const RemoteSecretData = "/kiali-remote-secret/kiali"
 
+// KialiFieldManager is the field manager of the Istio objects applied by Kiali with server-side apply
+const KialiFieldManager = "kiali"
+
 var (
 	emptyListOptions = meta_v1.ListOptions{}
 	emptyGetOptions  = meta_v1.GetOptions{}
@@ -42,14 +46,23 @@ type PodLogs struct {
 type IstioClientInterface interface {
 	CreateIstioObject(api, namespace, resourceType, json string) (IstioObject, error)
 	DeleteIstioObject(api, namespace, resourceType, name string) error
+	DeleteIstioObjectWithPrecondition(api, namespace, resourceType, name, resourceVersion string) error
 	GetIstioObject(namespace, resourceType, name string) (IstioObject, error)
 	GetIstioObjects(namespace, resourceType, labelSelector string) ([]IstioObject, error)
 	UpdateIstioObject(api, namespace, resourceType, name, jsonPatch string) (IstioObject, error)
+	ApplyIstioObject(api, namespace, resourceType, name, body string, force bool) (IstioObject, error)
+	DryRunCreateIstioObject(api, namespace, resourceType, json string) (IstioObject, error)
+	DryRunUpdateIstioObject(api, namespace, resourceType, name, jsonPatch string) (IstioObject, error)
 	GetProxyStatus() ([]*ProxyStatus, error)
+	GetLegacyIstioObjects(namespace string, resource LegacyIstioResource) ([]IstioObject, error)
 	GetConfigDump(namespace, podName string) (*ConfigDump, error)
+	IsGatewayAPI() bool
+	HasIstioResource(resourceType string) bool
 }
 
 type K8SClientInterface interface {
+	CreateConfigMap(namespace string, configMap *core_v1.ConfigMap) (*core_v1.ConfigMap, error)
+	CreateEvent(namespace string, event *core_v1.Event) (*core_v1.Event, error)
 	GetConfigMap(namespace, configName string) (*core_v1.ConfigMap, error)
 	GetCronJobs(namespace string) ([]batch_v1beta1.CronJob, error)
 	GetDeployment(namespace string, deploymentName string) (*apps_v1.Deployment, error)
@@ -61,16 +74,20 @@ type K8SClientInterface interface {
 	GetJobs(namespace string) ([]batch_v1.Job, error)
 	GetNamespace(namespace string) (*core_v1.Namespace, error)
 	GetNamespaces(labelSelector string) ([]core_v1.Namespace, error)
+	GetNetworkPolicies(namespace string) ([]networking_v1.NetworkPolicy, error)
 	GetPod(namespace, name string) (*core_v1.Pod, error)
 	GetPodLogs(namespace, name string, opts *core_v1.PodLogOptions) (*PodLogs, error)
 	GetPods(namespace, labelSelector string) ([]core_v1.Pod, error)
 	GetReplicationControllers(namespace string) ([]core_v1.ReplicationController, error)
 	GetReplicaSets(namespace string) ([]apps_v1.ReplicaSet, error)
+	GetSecret(namespace, name string) (*core_v1.Secret, error)
+	GetServiceAccounts(namespace string) ([]core_v1.ServiceAccount, error)
 	GetSelfSubjectAccessReview(namespace, api, resourceType string, verbs []string) ([]*auth_v1.SelfSubjectAccessReview, error)
 	GetService(namespace string, serviceName string) (*core_v1.Service, error)
 	GetServices(namespace string, selectorLabels map[string]string) ([]core_v1.Service, error)
 	GetStatefulSet(namespace string, statefulsetName string) (*apps_v1.StatefulSet, error)
 	GetStatefulSets(namespace string) ([]apps_v1.StatefulSet, error)
+	UpdateConfigMap(namespace string, configMap *core_v1.ConfigMap) (*core_v1.ConfigMap, error)
 	UpdateNamespace(namespace string, jsonPatch string) (*core_v1.Namespace, error)
 	UpdateWorkload(namespace string, workloadName string, workloadType string, jsonPatch string) error
 }
@@ -101,6 +118,9 @@ type K8SClient struct {
 	k8s                *kube.Clientset
 	istioNetworkingApi *rest.RESTClient
 	istioSecurityApi   *rest.RESTClient
+	istioTelemetryApi  *rest.RESTClient
+	extensionsApi      *rest.RESTClient
+	gatewayApi         *rest.RESTClient
 	iter8Api           *rest.RESTClient
 	// isOpenShift private variable will check if kiali is deployed under an OpenShift cluster or not
 	// It is represented as a pointer to include the initialization phase.
@@ -121,6 +141,21 @@ type K8SClient struct {
 	// It is represented as a pointer to include the initialization phase.
 	// See istio_details_service.go#hasSecurityResource() for more details.
 	securityResources *map[string]bool
+
+	// telemetryResources private variable will check which resources kiali has access to from telemetry.istio.io group
+	// It is represented as a pointer to include the initialization phase.
+	// See istio.go#hasTelemetryResource() for more details.
+	telemetryResources *map[string]bool
+
+	// extensionsResources private variable will check which resources kiali has access to from extensions.istio.io group
+	// It is represented as a pointer to include the initialization phase.
+	// See istio.go#hasExtensionsResource() for more details.
+	extensionsResources *map[string]bool
+
+	// gatewayAPIResources private variable will check which resources kiali has access to from gateway.networking.k8s.io group
+	// It is represented as a pointer to include the initialization phase.
+	// See gateway_api.go#IsGatewayAPI() for more details.
+	gatewayAPIResources *map[string]bool
 }
 
 // GetK8sApi returns the clientset referencing all K8s rest clients
@@ -138,6 +173,21 @@ func (client *K8SClient) GetIstioSecurityApi() *rest.RESTClient {
 	return client.istioSecurityApi
 }
 
+// GetIstioTelemetryApi returns the istio telemetry rest client
+func (client *K8SClient) GetIstioTelemetryApi() *rest.RESTClient {
+	return client.istioTelemetryApi
+}
+
+// GetExtensionsApi returns the istio extensions rest client
+func (client *K8SClient) GetExtensionsApi() *rest.RESTClient {
+	return client.extensionsApi
+}
+
+// GetGatewayAPIApi returns the Kubernetes Gateway API rest client
+func (client *K8SClient) GetGatewayAPIApi() *rest.RESTClient {
+	return client.gatewayApi
+}
+
 // GetToken returns the BearerToken used from the config
 func (client *K8SClient) GetToken() string {
 	return client.token
@@ -240,6 +290,18 @@ func NewClientFromConfig(config *rest.Config) (*K8SClient, error) {
 				scheme.AddKnownTypeWithName(SecurityGroupVersion.WithKind(rt.objectKind), &GenericIstioObject{})
 				scheme.AddKnownTypeWithName(SecurityGroupVersion.WithKind(rt.collectionKind), &GenericIstioObjectList{})
 			}
+			for _, tt := range telemetryTypes {
+				scheme.AddKnownTypeWithName(TelemetryGroupVersion.WithKind(tt.objectKind), &GenericIstioObject{})
+				scheme.AddKnownTypeWithName(TelemetryGroupVersion.WithKind(tt.collectionKind), &GenericIstioObjectList{})
+			}
+			for _, et := range extensionsTypes {
+				scheme.AddKnownTypeWithName(ExtensionsGroupVersion.WithKind(et.objectKind), &GenericIstioObject{})
+				scheme.AddKnownTypeWithName(ExtensionsGroupVersion.WithKind(et.collectionKind), &GenericIstioObjectList{})
+			}
+			for _, gt := range gatewayAPITypes {
+				scheme.AddKnownTypeWithName(GatewayAPIGroupVersion.WithKind(gt.objectKind), &GenericIstioObject{})
+				scheme.AddKnownTypeWithName(GatewayAPIGroupVersion.WithKind(gt.collectionKind), &GenericIstioObjectList{})
+			}
 			// Register Extension (iter8) types
 			for _, rt := range iter8Types {
 				// We will use a Iter8ExperimentObject which only contains metadata and spec with interfaces
@@ -250,6 +312,9 @@ func NewClientFromConfig(config *rest.Config) (*K8SClient, error) {
 
 			meta_v1.AddToGroupVersion(scheme, NetworkingGroupVersion)
 			meta_v1.AddToGroupVersion(scheme, SecurityGroupVersion)
+			meta_v1.AddToGroupVersion(scheme, TelemetryGroupVersion)
+			meta_v1.AddToGroupVersion(scheme, ExtensionsGroupVersion)
+			meta_v1.AddToGroupVersion(scheme, GatewayAPIGroupVersion)
 			meta_v1.AddToGroupVersion(scheme, Iter8GroupVersion)
 			return nil
 		})
@@ -269,6 +334,21 @@ func NewClientFromConfig(config *rest.Config) (*K8SClient, error) {
 		return nil, err
 	}
 
+	istioTelemetryApi, err := newClientForAPI(config, TelemetryGroupVersion, types)
+	if err != nil {
+		return nil, err
+	}
+
+	extensionsApi, err := newClientForAPI(config, ExtensionsGroupVersion, types)
+	if err != nil {
+		return nil, err
+	}
+
+	gatewayApi, err := newClientForAPI(config, GatewayAPIGroupVersion, types)
+	if err != nil {
+		return nil, err
+	}
+
 	iter8Api, err := newClientForAPI(config, Iter8GroupVersion, types)
 	if err != nil {
 		return nil, err
@@ -276,6 +356,9 @@ func NewClientFromConfig(config *rest.Config) (*K8SClient, error) {
 
 	client.istioNetworkingApi = istioNetworkingAPI
 	client.istioSecurityApi = istioSecurityApi
+	client.istioTelemetryApi = istioTelemetryApi
+	client.extensionsApi = extensionsApi
+	client.gatewayApi = gatewayApi
 	client.iter8Api = iter8Api
 	return &client, nil
 }
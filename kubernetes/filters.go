@@ -205,6 +205,10 @@ func FilterIstioObjectsForWorkloadSelector(workloadSelector string, allObjects [
 	// - RequestAuthentications -> spec/selector (istio.type.v1beta1.WorkloadSelector) -> map<string, string> match_labels
 	// - PeerAuthentications	-> spec/selector (istio.type.v1beta1.WorkloadSelector) -> map<string, string> match_labels
 	// - AuthorizationPolicies	-> spec/selector (istio.type.v1beta1.WorkloadSelector) -> map<string, string> match_labels
+	// Telemetry:
+	// - Telemetries			-> spec/selector (istio.type.v1beta1.WorkloadSelector) -> map<string, string> match_labels
+	// Extensions:
+	// - WasmPlugins			-> spec/selector (istio.type.v1beta1.WorkloadSelector) -> map<string, string> match_labels
 	istioObjects := []IstioObject{}
 
 	// workloadSelector is a representation of the template labels of a workload
@@ -239,7 +243,7 @@ func FilterIstioObjectsForWorkloadSelector(workloadSelector string, allObjects [
 					}
 				}
 			}
-		case RequestAuthenticationsType, PeerAuthenticationsType, AuthorizationPoliciesType:
+		case RequestAuthenticationsType, PeerAuthenticationsType, AuthorizationPoliciesType, TelemetryType, WasmPluginType:
 			if workloadSelectorField, ok := object.GetSpec()["selector"]; ok {
 				if workloadSelectorFieldM, ok := workloadSelectorField.(map[string]interface{}); ok {
 					if labelsField, ok := workloadSelectorFieldM["matchLabels"]; ok {
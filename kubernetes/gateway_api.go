@@ -0,0 +1,128 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GatewayAPIReference is a reference from a Gateway API object to another object, i.e. the parentRefs and
+// backendRefs of the routes. Defaults are already resolved: the namespace is the one of the referrer when
+// the reference doesn't set it.
+type GatewayAPIReference struct {
+	Group       string
+	Kind        string
+	Namespace   string
+	Name        string
+	SectionName string
+}
+
+// IsGatewayAPI returns true when the Kubernetes Gateway API CRDs are installed in the cluster
+func (in *K8SClient) IsGatewayAPI() bool {
+	return len(in.getGatewayAPIResources()) > 0
+}
+
+func (in *K8SClient) hasGatewayAPIResource(resource string) bool {
+	return in.getGatewayAPIResources()[resource]
+}
+
+func (in *K8SClient) getGatewayAPIResources() map[string]bool {
+	if in.gatewayAPIResources != nil {
+		return *in.gatewayAPIResources
+	}
+
+	gatewayAPIResources := map[string]bool{}
+	path := fmt.Sprintf("/apis/%s", ApiGatewayAPIVersion)
+	resourceListRaw, err := in.k8s.RESTClient().Get().AbsPath(path).Do().Raw()
+	if err == nil {
+		resourceList := meta_v1.APIResourceList{}
+		if errMarshall := json.Unmarshal(resourceListRaw, &resourceList); errMarshall == nil {
+			for _, resource := range resourceList.APIResources {
+				gatewayAPIResources[resource.Name] = true
+			}
+		}
+	}
+	in.gatewayAPIResources = &gatewayAPIResources
+
+	return *in.gatewayAPIResources
+}
+
+// APIResource returns the resource name used by the API server for a resource type,
+// i.e. k8sgateways -> gateways. Istio resource types are already the API resource names.
+func APIResource(resourceType string) string {
+	if ResourceTypesToAPI[resourceType] == GatewayAPIGroupVersion.Group {
+		return strings.TrimPrefix(resourceType, "k8s")
+	}
+	return resourceType
+}
+
+// IsClusterScoped returns true for the resource types that don't belong to a namespace
+func IsClusterScoped(resourceType string) bool {
+	return resourceType == K8sGatewayClasses
+}
+
+// GetK8sRouteParentRefs returns the parentRefs of a HTTPRoute or TCPRoute, in the order of the spec
+func GetK8sRouteParentRefs(route IstioObject) []GatewayAPIReference {
+	parentRefs, _ := route.GetSpec()["parentRefs"].([]interface{})
+	refs := make([]GatewayAPIReference, 0, len(parentRefs))
+	for _, parentRef := range parentRefs {
+		refs = append(refs, parseGatewayAPIReference(parentRef, GatewayAPIGroupVersion.Group, K8sGatewayType, route.GetObjectMeta().Namespace))
+	}
+	return refs
+}
+
+// GetK8sRouteBackendRefs returns the backendRefs of every rule of a HTTPRoute or TCPRoute, in the order of the spec
+func GetK8sRouteBackendRefs(route IstioObject) [][]GatewayAPIReference {
+	rules, _ := route.GetSpec()["rules"].([]interface{})
+	refs := make([][]GatewayAPIReference, len(rules))
+	for i, rule := range rules {
+		ruleMap, _ := rule.(map[string]interface{})
+		backendRefs, _ := ruleMap["backendRefs"].([]interface{})
+		refs[i] = make([]GatewayAPIReference, 0, len(backendRefs))
+		for _, backendRef := range backendRefs {
+			refs[i] = append(refs[i], parseGatewayAPIReference(backendRef, "", ServiceType, route.GetObjectMeta().Namespace))
+		}
+	}
+	return refs
+}
+
+func parseGatewayAPIReference(ref interface{}, group, kind, namespace string) GatewayAPIReference {
+	reference := GatewayAPIReference{Group: group, Kind: kind, Namespace: namespace}
+	refMap, ok := ref.(map[string]interface{})
+	if !ok {
+		return reference
+	}
+	if g, ok := refMap["group"].(string); ok {
+		reference.Group = g
+	}
+	if k, ok := refMap["kind"].(string); ok && k != "" {
+		reference.Kind = k
+	}
+	if ns, ok := refMap["namespace"].(string); ok && ns != "" {
+		reference.Namespace = ns
+	}
+	reference.Name, _ = refMap["name"].(string)
+	reference.SectionName, _ = refMap["sectionName"].(string)
+	return reference
+}
+
+// FilterK8sRoutes returns the HTTPRoutes or TCPRoutes sending traffic to a service
+func FilterK8sRoutes(allRoutes []IstioObject, namespace string, serviceName string) []IstioObject {
+	routes := make([]IstioObject, 0)
+	for _, route := range allRoutes {
+		found := false
+		for _, rule := range GetK8sRouteBackendRefs(route) {
+			for _, ref := range rule {
+				if ref.Group == "" && ref.Kind == ServiceType && ref.Namespace == namespace && ref.Name == serviceName {
+					found = true
+				}
+			}
+		}
+		if found {
+			routes = append(routes, route.DeepCopyIstioObject())
+		}
+	}
+	return routes
+}
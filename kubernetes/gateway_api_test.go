@@ -0,0 +1,50 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGatewayAPIResourceType(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("gateways", APIResource(K8sGateways))
+	assert.Equal("httproutes", APIResource(K8sHTTPRoutes))
+	assert.Equal(Gateways, APIResource(Gateways))
+
+	resourceType, found := IstioResourceType(meta_v1.TypeMeta{Kind: "Gateway", APIVersion: ApiGatewayAPIVersion})
+	assert.True(found)
+	assert.Equal(K8sGateways, resourceType)
+	resourceType, found = IstioResourceType(meta_v1.TypeMeta{Kind: "Gateway", APIVersion: ApiNetworkingVersion})
+	assert.True(found)
+	assert.Equal(Gateways, resourceType)
+}
+
+func TestFilterK8sRoutes(t *testing.T) {
+	assert := assert.New(t)
+
+	route := func(name string, backendRefs ...interface{}) IstioObject {
+		return &GenericIstioObject{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo"},
+			Spec: map[string]interface{}{
+				"parentRefs": []interface{}{map[string]interface{}{"name": "bookinfo-gateway"}},
+				"rules":      []interface{}{map[string]interface{}{"backendRefs": backendRefs}},
+			},
+		}
+	}
+	routes := []IstioObject{
+		route("reviews", map[string]interface{}{"name": "reviews", "port": 9080}),
+		route("reviews-other", map[string]interface{}{"name": "reviews", "namespace": "other"}),
+		route("reviews-import", map[string]interface{}{"name": "reviews", "group": "multicluster.x-k8s.io", "kind": "ServiceImport"}),
+		route("ratings", map[string]interface{}{"name": "ratings"}),
+	}
+
+	filtered := FilterK8sRoutes(routes, "bookinfo", "reviews")
+	assert.Len(filtered, 1)
+	assert.Equal("reviews", filtered[0].GetObjectMeta().Name)
+
+	parentRefs := GetK8sRouteParentRefs(routes[0])
+	assert.Equal([]GatewayAPIReference{{Group: GatewayAPIGroupVersion.Group, Kind: K8sGatewayType, Namespace: "bookinfo", Name: "bookinfo-gateway"}}, parentRefs)
+}
@@ -222,6 +222,17 @@ func HostWithinWildcardHost(subdomain, wildcardDomain string) bool {
 	return len(wildcardDomain) > 2 && strings.HasSuffix(subdomain, wildcardDomain[2:])
 }
 
+// HostsMatch returns true when both hosts are the same, or when one of them is a wildcard host including the other one.
+// Hosts are expected to be resolved with ParseHost or GetHost.
+func HostsMatch(h1, h2 Host) bool {
+	if h1.CompleteInput && h2.CompleteInput {
+		return h1.Namespace == h2.Namespace && h1.Cluster == h2.Cluster &&
+			(h1.Service == h2.Service || h1.Service == "*" || h2.Service == "*")
+	}
+	s1, s2 := h1.String(), h2.String()
+	return s1 == s2 || HostWithinWildcardHost(s1, s2) || HostWithinWildcardHost(s2, s1)
+}
+
 func ParseGatewayAsHost(gateway, currentNamespace, currentCluster string) Host {
 	host := Host{
 		Service:       gateway,
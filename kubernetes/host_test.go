@@ -67,3 +67,22 @@ func createVirtualService(namespace string, hosts []string) IstioObject {
 		},
 	}).DeepCopyIstioObject()
 }
+
+func TestHostsMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	nss := []string{"bookinfo", "istio-system"}
+	reviews := GetHost("reviews", "bookinfo", "svc.cluster.local", nss)
+	assert.True(HostsMatch(reviews, GetHost("reviews.bookinfo", "istio-system", "svc.cluster.local", nss)))
+	assert.True(HostsMatch(reviews, GetHost("reviews.bookinfo.svc.cluster.local", "istio-system", "svc.cluster.local", nss)))
+	assert.True(HostsMatch(reviews, GetHost("*.bookinfo.svc.cluster.local", "istio-system", "svc.cluster.local", nss)))
+	assert.False(HostsMatch(reviews, GetHost("reviews", "istio-system", "svc.cluster.local", nss)))
+	assert.False(HostsMatch(reviews, GetHost("ratings", "bookinfo", "svc.cluster.local", nss)))
+
+	// External hosts
+	assert.True(HostsMatch(GetHost("api.example.com", "bookinfo", "svc.cluster.local", nss), GetHost("*.example.com", "istio-system", "svc.cluster.local", nss)))
+	assert.False(HostsMatch(GetHost("api.example.com", "bookinfo", "svc.cluster.local", nss), GetHost("api.example.org", "bookinfo", "svc.cluster.local", nss)))
+}
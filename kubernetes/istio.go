@@ -34,12 +34,28 @@ func (in *K8SClient) getApiClientVersion(apiGroup string) (*rest.RESTClient, str
 		return in.istioNetworkingApi, ApiNetworkingVersion
 	} else if apiGroup == SecurityGroupVersion.Group {
 		return in.istioSecurityApi, ApiSecurityVersion
+	} else if apiGroup == TelemetryGroupVersion.Group {
+		return in.istioTelemetryApi, ApiTelemetryVersion
+	} else if apiGroup == ExtensionsGroupVersion.Group {
+		return in.extensionsApi, ApiExtensionsVersion
+	} else if apiGroup == GatewayAPIGroupVersion.Group {
+		return in.gatewayApi, ApiGatewayAPIVersion
 	}
 	return nil, ""
 }
 
 // CreateIstioObject creates an Istio object
 func (in *K8SClient) CreateIstioObject(api, namespace, resourceType, json string) (IstioObject, error) {
+	return in.createIstioObject(api, namespace, resourceType, json, false)
+}
+
+// DryRunCreateIstioObject sends the creation of an Istio object to the API server in dry-run mode:
+// the request goes through validation and admission but nothing is persisted
+func (in *K8SClient) DryRunCreateIstioObject(api, namespace, resourceType, json string) (IstioObject, error) {
+	return in.createIstioObject(api, namespace, resourceType, json, true)
+}
+
+func (in *K8SClient) createIstioObject(api, namespace, resourceType, json string, dryRun bool) (IstioObject, error) {
 	var result runtime.Object
 	var err error
 
@@ -56,7 +72,14 @@ func (in *K8SClient) CreateIstioObject(api, namespace, resourceType, json string
 		return nil, fmt.Errorf("%s is not supported in CreateIstioObject operation", api)
 	}
 
-	result, err = apiClient.Post().Namespace(namespace).Resource(resourceType).Body(byteJson).Do().Get()
+	if IsClusterScoped(resourceType) {
+		namespace = ""
+	}
+	request := apiClient.Post().Namespace(namespace).Resource(APIResource(resourceType)).Body(byteJson)
+	if dryRun {
+		request = request.Param("dryRun", "All")
+	}
+	result, err = request.Do().Get()
 	if err != nil {
 		return nil, err
 	}
@@ -72,18 +95,53 @@ func (in *K8SClient) CreateIstioObject(api, namespace, resourceType, json string
 // DeleteIstioObject deletes an Istio object from either config api or networking api
 func (in *K8SClient) DeleteIstioObject(api, namespace, resourceType, name string) error {
 	log.Debugf("DeleteIstioObject input: %s / %s / %s / %s", api, namespace, resourceType, name)
+	return in.deleteIstioObject(api, namespace, resourceType, name, nil)
+}
+
+// DeleteIstioObjectWithPrecondition deletes an Istio object only if its resourceVersion is still the given one.
+// The API server returns a Conflict error otherwise.
+func (in *K8SClient) DeleteIstioObjectWithPrecondition(api, namespace, resourceType, name, resourceVersion string) error {
+	log.Debugf("DeleteIstioObjectWithPrecondition input: %s / %s / %s / %s / %s", api, namespace, resourceType, name, resourceVersion)
+	return in.deleteIstioObject(api, namespace, resourceType, name, &meta_v1.DeleteOptions{
+		Preconditions: &meta_v1.Preconditions{ResourceVersion: &resourceVersion},
+	})
+}
+
+func (in *K8SClient) deleteIstioObject(api, namespace, resourceType, name string, options *meta_v1.DeleteOptions) error {
 	var err error
 	apiClient, _ := in.getApiClientVersion(api)
 	if apiClient == nil {
 		return fmt.Errorf("%s is not supported in DeleteIstioObject operation", api)
 	}
-	_, err = apiClient.Delete().Namespace(namespace).Resource(resourceType).Name(name).Do().Get()
+	if IsClusterScoped(resourceType) {
+		namespace = ""
+	}
+	request := apiClient.Delete().Namespace(namespace).Resource(APIResource(resourceType)).Name(name)
+	if options != nil {
+		body, err := json.Marshal(options)
+		if err != nil {
+			return err
+		}
+		request = request.Body(body)
+	}
+	_, err = request.Do().Get()
 	return err
 }
 
 // UpdateIstioObject updates an Istio object from either config api or networking api
 func (in *K8SClient) UpdateIstioObject(api, namespace, resourceType, name, jsonPatch string) (IstioObject, error) {
 	log.Debugf("UpdateIstioObject input: %s / %s / %s / %s", api, namespace, resourceType, name)
+	return in.updateIstioObject(api, namespace, resourceType, name, jsonPatch, false)
+}
+
+// DryRunUpdateIstioObject sends the patch of an Istio object to the API server in dry-run mode:
+// the request goes through validation and admission but nothing is persisted
+func (in *K8SClient) DryRunUpdateIstioObject(api, namespace, resourceType, name, jsonPatch string) (IstioObject, error) {
+	log.Debugf("DryRunUpdateIstioObject input: %s / %s / %s / %s", api, namespace, resourceType, name)
+	return in.updateIstioObject(api, namespace, resourceType, name, jsonPatch, true)
+}
+
+func (in *K8SClient) updateIstioObject(api, namespace, resourceType, name, jsonPatch string, dryRun bool) (IstioObject, error) {
 	var result runtime.Object
 	var err error
 
@@ -98,7 +156,14 @@ func (in *K8SClient) UpdateIstioObject(api, namespace, resourceType, name, jsonP
 	if apiClient == nil {
 		return nil, fmt.Errorf("%s is not supported in UpdateIstioObject operation", api)
 	}
-	result, err = apiClient.Patch(types.MergePatchType).Namespace(namespace).Resource(resourceType).SubResource(name).Body(bytePatch).Do().Get()
+	if IsClusterScoped(resourceType) {
+		namespace = ""
+	}
+	request := apiClient.Patch(types.MergePatchType).Namespace(namespace).Resource(APIResource(resourceType)).SubResource(name).Body(bytePatch)
+	if dryRun {
+		request = request.Param("dryRun", "All")
+	}
+	result, err = request.Do().Get()
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +175,39 @@ func (in *K8SClient) UpdateIstioObject(api, namespace, resourceType, name, jsonP
 	return istioObject, err
 }
 
+// ApplyIstioObject applies the full configuration of an Istio object with server-side apply, under the Kiali field
+// manager. The object is created when it doesn't exist. Fields owned by other managers are only overwritten when
+// force is true, otherwise the API server returns a Conflict error listing them.
+func (in *K8SClient) ApplyIstioObject(api, namespace, resourceType, name, body string, force bool) (IstioObject, error) {
+	log.Debugf("ApplyIstioObject input: %s / %s / %s / %s / force=%t", api, namespace, resourceType, name, force)
+	typeMeta := meta_v1.TypeMeta{
+		Kind: PluralType[resourceType],
+	}
+	var apiClient *rest.RESTClient
+	apiClient, typeMeta.APIVersion = in.getApiClientVersion(api)
+	if apiClient == nil {
+		return nil, fmt.Errorf("%s is not supported in ApplyIstioObject operation", api)
+	}
+	if IsClusterScoped(resourceType) {
+		namespace = ""
+	}
+	request := apiClient.Patch(types.ApplyPatchType).Namespace(namespace).Resource(APIResource(resourceType)).Name(name).
+		Param("fieldManager", KialiFieldManager).Body([]byte(body))
+	if force {
+		request = request.Param("force", "true")
+	}
+	result, err := request.Do().Get()
+	if err != nil {
+		return nil, err
+	}
+	istioObject, ok := result.(*GenericIstioObject)
+	if !ok {
+		return nil, fmt.Errorf("%s/%s doesn't return an IstioObject object", namespace, name)
+	}
+	istioObject.SetTypeMeta(typeMeta)
+	return istioObject, nil
+}
+
 func (in *K8SClient) GetIstioObjects(namespace, resourceType, labelSelector string) ([]IstioObject, error) {
 	var apiClient *rest.RESTClient
 	var apiGroup, apiVersion string
@@ -128,9 +226,25 @@ func (in *K8SClient) GetIstioObjects(namespace, resourceType, labelSelector stri
 		return []IstioObject{}, nil
 	}
 
+	if apiGroup == TelemetryGroupVersion.Group && !in.hasTelemetryResource(resourceType) {
+		return []IstioObject{}, nil
+	}
+
+	if apiGroup == ExtensionsGroupVersion.Group && !in.hasExtensionsResource(resourceType) {
+		return []IstioObject{}, nil
+	}
+
+	if apiGroup == GatewayAPIGroupVersion.Group && !in.hasGatewayAPIResource(APIResource(resourceType)) {
+		return []IstioObject{}, nil
+	}
+
+	if IsClusterScoped(resourceType) {
+		namespace = ""
+	}
+
 	var result runtime.Object
 	var err error
-	result, err = apiClient.Get().Namespace(namespace).Resource(resourceType).Param("labelSelector", labelSelector).Do().Get()
+	result, err = apiClient.Get().Namespace(namespace).Resource(APIResource(resourceType)).Param("labelSelector", labelSelector).Do().Get()
 	if err != nil {
 		return nil, err
 	}
@@ -161,9 +275,13 @@ func (in *K8SClient) GetIstioObject(namespace, resourceType, name string) (Istio
 		return nil, fmt.Errorf("%s not found in ResourcesTypeToAPI", resourceType)
 	}
 
+	if IsClusterScoped(resourceType) {
+		namespace = ""
+	}
+
 	var result runtime.Object
 	var err error
-	result, err = apiClient.Get().Namespace(namespace).Resource(resourceType).SubResource(name).Do().Get()
+	result, err = apiClient.Get().Namespace(namespace).Resource(APIResource(resourceType)).SubResource(name).Do().Get()
 	if err != nil {
 		return nil, err
 	}
@@ -313,6 +431,20 @@ func (in *K8SClient) EnvoyForward(namespace, podName, path string) ([]byte, erro
 	return resp, err
 }
 
+// HasIstioResource returns true when the CRD of a telemetry or extensions resource type is installed in the cluster.
+// Networking and security resource types are always expected, as they come with any Istio installation.
+func (in *K8SClient) HasIstioResource(resourceType string) bool {
+	switch ResourceTypesToAPI[resourceType] {
+	case TelemetryGroupVersion.Group:
+		return in.hasTelemetryResource(resourceType)
+	case ExtensionsGroupVersion.Group:
+		return in.hasExtensionsResource(resourceType)
+	case GatewayAPIGroupVersion.Group:
+		return in.hasGatewayAPIResource(APIResource(resourceType))
+	}
+	return true
+}
+
 func (in *K8SClient) hasNetworkingResource(resource string) bool {
 	return in.getNetworkingResources()[resource]
 }
@@ -363,6 +495,56 @@ func (in *K8SClient) getSecurityResources() map[string]bool {
 	return *in.securityResources
 }
 
+func (in *K8SClient) hasTelemetryResource(resource string) bool {
+	return in.getTelemetryResources()[resource]
+}
+
+func (in *K8SClient) getTelemetryResources() map[string]bool {
+	if in.telemetryResources != nil {
+		return *in.telemetryResources
+	}
+
+	telemetryResources := map[string]bool{}
+	path := fmt.Sprintf("/apis/%s", ApiTelemetryVersion)
+	resourceListRaw, err := in.k8s.RESTClient().Get().AbsPath(path).Do().Raw()
+	if err == nil {
+		resourceList := meta_v1.APIResourceList{}
+		if errMarshall := json.Unmarshal(resourceListRaw, &resourceList); errMarshall == nil {
+			for _, resource := range resourceList.APIResources {
+				telemetryResources[resource.Name] = true
+			}
+		}
+	}
+	in.telemetryResources = &telemetryResources
+
+	return *in.telemetryResources
+}
+
+func (in *K8SClient) hasExtensionsResource(resource string) bool {
+	return in.getExtensionsResources()[resource]
+}
+
+func (in *K8SClient) getExtensionsResources() map[string]bool {
+	if in.extensionsResources != nil {
+		return *in.extensionsResources
+	}
+
+	extensionsResources := map[string]bool{}
+	path := fmt.Sprintf("/apis/%s", ApiExtensionsVersion)
+	resourceListRaw, err := in.k8s.RESTClient().Get().AbsPath(path).Do().Raw()
+	if err == nil {
+		resourceList := meta_v1.APIResourceList{}
+		if errMarshall := json.Unmarshal(resourceListRaw, &resourceList); errMarshall == nil {
+			for _, resource := range resourceList.APIResources {
+				extensionsResources[resource.Name] = true
+			}
+		}
+	}
+	in.extensionsResources = &extensionsResources
+
+	return *in.extensionsResources
+}
+
 func GetIstioConfigMap(istioConfig *core_v1.ConfigMap) (*IstioMeshConfig, error) {
 	meshConfig := &IstioMeshConfig{}
 
@@ -389,6 +571,22 @@ func GetIstioConfigMap(istioConfig *core_v1.ConfigMap) (*IstioMeshConfig, error)
 	return meshConfig, nil
 }
 
+// GetIstioMeshConfigFields returns all the fields set in the Istio mesh configuration, including those
+// not mapped by IstioMeshConfig
+func GetIstioMeshConfigFields(istioConfig *core_v1.ConfigMap) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if _, err := GetIstioConfigMap(istioConfig); err != nil {
+		return nil, err
+	}
+	if istioConfig == nil || istioConfig.Data == nil {
+		return fields, nil
+	}
+	if err := yaml.Unmarshal([]byte(istioConfig.Data["mesh"]), &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
 // ServiceEntryHostnames returns a list of hostnames defined in the ServiceEntries Specs. Key in the resulting map is the protocol (in lowercase) + hostname
 // exported for test
 func ServiceEntryHostnames(serviceEntries []IstioObject) map[string][]string {
@@ -586,3 +784,80 @@ func DestinationRuleHasMTLSEnabledForHost(expectedHost string, destinationRule I
 
 	return false, ""
 }
+
+// LegacyIstioResource is a resource of an Istio API removed from Istio. Kiali doesn't manage them, they are only
+// listed to report the objects left behind
+type LegacyIstioResource struct {
+	APIVersion    string
+	Kind          string
+	Resource      string
+	ClusterScoped bool
+}
+
+// LegacyIstioResources are the resources of the authentication, rbac and Mixer APIs removed from Istio
+var LegacyIstioResources = []LegacyIstioResource{
+	{APIVersion: "authentication.istio.io/v1alpha1", Kind: "Policy", Resource: "policies"},
+	{APIVersion: "authentication.istio.io/v1alpha1", Kind: "MeshPolicy", Resource: "meshpolicies", ClusterScoped: true},
+	{APIVersion: "rbac.istio.io/v1alpha1", Kind: "ServiceRole", Resource: "serviceroles"},
+	{APIVersion: "rbac.istio.io/v1alpha1", Kind: "ServiceRoleBinding", Resource: "servicerolebindings"},
+	{APIVersion: "rbac.istio.io/v1alpha1", Kind: "RbacConfig", Resource: "rbacconfigs"},
+	{APIVersion: "rbac.istio.io/v1alpha1", Kind: "ClusterRbacConfig", Resource: "clusterrbacconfigs", ClusterScoped: true},
+	{APIVersion: "config.istio.io/v1alpha2", Kind: "rule", Resource: "rules"},
+	{APIVersion: "config.istio.io/v1alpha2", Kind: "handler", Resource: "handlers"},
+	{APIVersion: "config.istio.io/v1alpha2", Kind: "instance", Resource: "instances"},
+	{APIVersion: "config.istio.io/v1alpha2", Kind: "attributemanifest", Resource: "attributemanifests"},
+	{APIVersion: "config.istio.io/v1alpha2", Kind: "HTTPAPISpec", Resource: "httpapispecs"},
+	{APIVersion: "config.istio.io/v1alpha2", Kind: "HTTPAPISpecBinding", Resource: "httpapispecbindings"},
+	{APIVersion: "config.istio.io/v1alpha2", Kind: "QuotaSpec", Resource: "quotaspecs"},
+	{APIVersion: "config.istio.io/v1alpha2", Kind: "QuotaSpecBinding", Resource: "quotaspecbindings"},
+}
+
+// legacyIstioResource returns the legacy resource of a kind, i.e. ServiceRole -> serviceroles
+func legacyIstioResource(typeMeta meta_v1.TypeMeta) (LegacyIstioResource, bool) {
+	for _, r := range LegacyIstioResources {
+		if r.APIVersion == typeMeta.APIVersion && r.Kind == typeMeta.Kind {
+			return r, true
+		}
+	}
+	return LegacyIstioResource{}, false
+}
+
+// GetLegacyIstioObjects lists the objects of a legacy Istio resource. The API is requested without the Kiali
+// scheme, as its version isn't registered. A NotFound error is returned when its CRD is not installed.
+func (in *K8SClient) GetLegacyIstioObjects(namespace string, resource LegacyIstioResource) ([]IstioObject, error) {
+	path := []string{"/apis", resource.APIVersion}
+	if !resource.ClusterScoped {
+		path = append(path, "namespaces", namespace)
+	}
+	path = append(path, resource.Resource)
+	body, err := in.k8s.Discovery().RESTClient().Get().AbsPath(path...).DoRaw()
+	if err != nil {
+		return nil, err
+	}
+	istioList := &GenericIstioObjectList{}
+	if err = json.Unmarshal(body, istioList); err != nil {
+		return nil, err
+	}
+	list := make([]IstioObject, 0, len(istioList.Items))
+	for _, item := range istioList.GetItems() {
+		i := item.DeepCopyIstioObject()
+		i.SetTypeMeta(meta_v1.TypeMeta{Kind: resource.Kind, APIVersion: resource.APIVersion})
+		list = append(list, i)
+	}
+	return list, nil
+}
+
+// IstioResourceType returns the resource type of a networking, security, telemetry, extensions or Gateway API kind,
+// i.e. VirtualService -> virtualservices
+func IstioResourceType(typeMeta meta_v1.TypeMeta) (string, bool) {
+	group := strings.Split(typeMeta.APIVersion, "/")[0]
+	if _, ok := ApiToVersion[group]; !ok {
+		return "", false
+	}
+	for resourceType, kind := range PluralType {
+		if kind == typeMeta.Kind && ResourceTypesToAPI[resourceType] == group {
+			return resourceType, true
+		}
+	}
+	return "", false
+}
@@ -12,6 +12,7 @@ import (
 	batch_v1 "k8s.io/api/batch/v1"
 	batch_v1beta1 "k8s.io/api/batch/v1beta1"
 	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -32,6 +33,22 @@ func (in *K8SClient) GetConfigMap(namespace, configName string) (*core_v1.Config
 	return configMap, nil
 }
 
+// CreateConfigMap creates the ConfigMap in the namespace
+func (in *K8SClient) CreateConfigMap(namespace string, configMap *core_v1.ConfigMap) (*core_v1.ConfigMap, error) {
+	return in.k8s.CoreV1().ConfigMaps(namespace).Create(configMap)
+}
+
+// CreateEvent records the Event in the namespace
+func (in *K8SClient) CreateEvent(namespace string, event *core_v1.Event) (*core_v1.Event, error) {
+	return in.k8s.CoreV1().Events(namespace).Create(event)
+}
+
+// UpdateConfigMap replaces the ConfigMap in the namespace. It fails with a conflict error when the
+// resourceVersion of the ConfigMap is not the current one.
+func (in *K8SClient) UpdateConfigMap(namespace string, configMap *core_v1.ConfigMap) (*core_v1.ConfigMap, error) {
+	return in.k8s.CoreV1().ConfigMaps(namespace).Update(configMap)
+}
+
 // GetNamespace fetches and returns the specified namespace definition
 // from the cluster
 func (in *K8SClient) GetNamespace(namespace string) (*core_v1.Namespace, error) {
@@ -286,6 +303,30 @@ func (in *K8SClient) GetPodLogs(namespace, name string, opts *core_v1.PodLogOpti
 	return &PodLogs{Logs: buf.String()}, nil
 }
 
+// GetSecret returns the secret of a namespace.
+// It returns an error on any problem.
+func (in *K8SClient) GetSecret(namespace, name string) (*core_v1.Secret, error) {
+	return in.k8s.CoreV1().Secrets(namespace).Get(name, emptyGetOptions)
+}
+
+// GetServiceAccounts returns the service accounts of a given namespace.
+// It returns an error on any problem.
+func (in *K8SClient) GetServiceAccounts(namespace string) ([]core_v1.ServiceAccount, error) {
+	if saList, err := in.k8s.CoreV1().ServiceAccounts(namespace).List(emptyListOptions); err == nil {
+		return saList.Items, nil
+	} else {
+		return []core_v1.ServiceAccount{}, err
+	}
+}
+
+func (in *K8SClient) GetNetworkPolicies(namespace string) ([]networking_v1.NetworkPolicy, error) {
+	if npList, err := in.k8s.NetworkingV1().NetworkPolicies(namespace).List(emptyListOptions); err == nil {
+		return npList.Items, nil
+	} else {
+		return []networking_v1.NetworkPolicy{}, err
+	}
+}
+
 func (in *K8SClient) GetCronJobs(namespace string) ([]batch_v1beta1.CronJob, error) {
 	if cjList, err := in.k8s.BatchV1beta1().CronJobs(namespace).List(emptyListOptions); err == nil {
 		return cjList.Items, nil
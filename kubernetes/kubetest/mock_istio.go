@@ -14,6 +14,11 @@ func (o *K8SClientMock) DeleteIstioObject(api, namespace, objectType, objectName
 	return args.Error(0)
 }
 
+func (o *K8SClientMock) DeleteIstioObjectWithPrecondition(api, namespace, objectType, objectName, resourceVersion string) error {
+	args := o.Called(api, namespace, objectType, objectName, resourceVersion)
+	return args.Error(0)
+}
+
 func (o *K8SClientMock) GetIstioObject(namespace string, resourceType string, object string) (kubernetes.IstioObject, error) {
 	args := o.Called(namespace, resourceType, object)
 	return args.Get(0).(kubernetes.IstioObject), args.Error(1)
@@ -29,11 +34,53 @@ func (o *K8SClientMock) UpdateIstioObject(api, namespace, resourceType, name, js
 	return args.Get(0).(kubernetes.IstioObject), args.Error(1)
 }
 
+func (o *K8SClientMock) ApplyIstioObject(api, namespace, resourceType, name, body string, force bool) (kubernetes.IstioObject, error) {
+	args := o.Called(api, namespace, resourceType, name, body, force)
+	return args.Get(0).(kubernetes.IstioObject), args.Error(1)
+}
+
+func (o *K8SClientMock) DryRunCreateIstioObject(api, namespace, resourceType, json string) (kubernetes.IstioObject, error) {
+	args := o.Called(api, namespace, resourceType, json)
+	return args.Get(0).(kubernetes.IstioObject), args.Error(1)
+}
+
+func (o *K8SClientMock) DryRunUpdateIstioObject(api, namespace, resourceType, name, jsonPatch string) (kubernetes.IstioObject, error) {
+	args := o.Called(api, namespace, resourceType, name, jsonPatch)
+	return args.Get(0).(kubernetes.IstioObject), args.Error(1)
+}
+
 func (o *K8SClientMock) GetProxyStatus() ([]*kubernetes.ProxyStatus, error) {
 	args := o.Called()
 	return args.Get(0).([]*kubernetes.ProxyStatus), args.Error(1)
 }
 
+func (o *K8SClientMock) GetLegacyIstioObjects(namespace string, resource kubernetes.LegacyIstioResource) ([]kubernetes.IstioObject, error) {
+	args := o.Called(namespace, resource)
+	return args.Get(0).([]kubernetes.IstioObject), args.Error(1)
+}
+
+// IsGatewayAPI returns false unless the test mocks it, most of the tests don't care about the Gateway API objects
+func (o *K8SClientMock) IsGatewayAPI() bool {
+	for _, call := range o.ExpectedCalls {
+		if call.Method == "IsGatewayAPI" {
+			args := o.Called()
+			return args.Get(0).(bool)
+		}
+	}
+	return false
+}
+
+// HasIstioResource returns false unless the test mocks it, most of the tests don't care about the telemetry and extensions objects
+func (o *K8SClientMock) HasIstioResource(resourceType string) bool {
+	for _, call := range o.ExpectedCalls {
+		if call.Method == "HasIstioResource" {
+			args := o.Called(resourceType)
+			return args.Get(0).(bool)
+		}
+	}
+	return false
+}
+
 func (o *K8SClientMock) GetConfigDump(namespace string, podName string) (*kubernetes.ConfigDump, error) {
 	args := o.Called(namespace, podName)
 	return args.Get(0).(*kubernetes.ConfigDump), args.Error(1)
@@ -6,10 +6,26 @@ import (
 	batch_v1 "k8s.io/api/batch/v1"
 	batch_apps_v1 "k8s.io/api/batch/v1beta1"
 	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
 
 	"github.com/kiali/kiali/kubernetes"
 )
 
+func (o *K8SClientMock) CreateConfigMap(namespace string, configMap *core_v1.ConfigMap) (*core_v1.ConfigMap, error) {
+	args := o.Called(namespace, configMap)
+	return args.Get(0).(*core_v1.ConfigMap), args.Error(1)
+}
+
+func (o *K8SClientMock) UpdateConfigMap(namespace string, configMap *core_v1.ConfigMap) (*core_v1.ConfigMap, error) {
+	args := o.Called(namespace, configMap)
+	return args.Get(0).(*core_v1.ConfigMap), args.Error(1)
+}
+
+func (o *K8SClientMock) CreateEvent(namespace string, event *core_v1.Event) (*core_v1.Event, error) {
+	args := o.Called(namespace, event)
+	return args.Get(0).(*core_v1.Event), args.Error(1)
+}
+
 func (o *K8SClientMock) GetConfigMap(namespace, configName string) (*core_v1.ConfigMap, error) {
 	args := o.Called(namespace, configName)
 	return args.Get(0).(*core_v1.ConfigMap), args.Error(1)
@@ -80,6 +96,21 @@ func (o *K8SClientMock) GetReplicaSets(namespace string) ([]apps_v1.ReplicaSet,
 	return args.Get(0).([]apps_v1.ReplicaSet), args.Error(1)
 }
 
+func (o *K8SClientMock) GetSecret(namespace, name string) (*core_v1.Secret, error) {
+	args := o.Called(namespace, name)
+	return args.Get(0).(*core_v1.Secret), args.Error(1)
+}
+
+func (o *K8SClientMock) GetNetworkPolicies(namespace string) ([]networking_v1.NetworkPolicy, error) {
+	args := o.Called(namespace)
+	return args.Get(0).([]networking_v1.NetworkPolicy), args.Error(1)
+}
+
+func (o *K8SClientMock) GetServiceAccounts(namespace string) ([]core_v1.ServiceAccount, error) {
+	args := o.Called(namespace)
+	return args.Get(0).([]core_v1.ServiceAccount), args.Error(1)
+}
+
 func (o *K8SClientMock) GetSelfSubjectAccessReview(namespace, api, resourceType string, verbs []string) ([]*auth_v1.SelfSubjectAccessReview, error) {
 	args := o.Called(namespace, api, resourceType, verbs)
 	return args.Get(0).([]*auth_v1.SelfSubjectAccessReview), args.Error(1)
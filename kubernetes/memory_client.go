@@ -0,0 +1,901 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+
+	osapps_v1 "github.com/openshift/api/apps/v1"
+	osproject_v1 "github.com/openshift/api/project/v1"
+	osroutes_v1 "github.com/openshift/api/route/v1"
+	apps_v1 "k8s.io/api/apps/v1"
+	auth_v1 "k8s.io/api/authorization/v1"
+	batch_v1 "k8s.io/api/batch/v1"
+	batch_v1beta1 "k8s.io/api/batch/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	networking_v1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/version"
+
+	"github.com/kiali/kiali/log"
+)
+
+// MemoryClient is a ClientInterface backed by objects held in memory instead of a cluster.
+// It allows to run the business logic (i.e. validations) against a set of manifests loaded from disk.
+// OpenShift and Iter8 APIs are reported as not available.
+type MemoryClient struct {
+	lock       sync.RWMutex
+	namespaces map[string]*memoryNamespace
+	// Last resourceVersion set on an Istio object created or modified through the client
+	resourceVersion uint64
+}
+
+type memoryNamespace struct {
+	namespace              core_v1.Namespace
+	configMaps             []core_v1.ConfigMap
+	cronJobs               []batch_v1beta1.CronJob
+	deployments            []apps_v1.Deployment
+	endpoints              []core_v1.Endpoints
+	events                 []core_v1.Event
+	jobs                   []batch_v1.Job
+	networkPolicies        []networking_v1.NetworkPolicy
+	pods                   []core_v1.Pod
+	replicaSets            []apps_v1.ReplicaSet
+	replicationControllers []core_v1.ReplicationController
+	secrets                []core_v1.Secret
+	serviceAccounts        []core_v1.ServiceAccount
+	services               []core_v1.Service
+	statefulSets           []apps_v1.StatefulSet
+	istioObjects           map[string][]IstioObject
+}
+
+// NewMemoryClient returns an empty MemoryClient
+func NewMemoryClient() *MemoryClient {
+	return &MemoryClient{namespaces: map[string]*memoryNamespace{}}
+}
+
+// LoadYAML reads all the (multi-document) YAML or JSON objects of the reader into the client.
+// Objects without namespace are placed in defaultNamespace. "List" documents are unwrapped.
+// Kinds not used by Kiali are skipped.
+func (in *MemoryClient) LoadYAML(reader io.Reader, defaultNamespace string) error {
+	decoder := yaml.NewYAMLOrJSONDecoder(reader, 4096)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		if err := in.AddJSON(raw, defaultNamespace); err != nil {
+			return err
+		}
+	}
+}
+
+// AddJSON adds a single JSON encoded object into the client.
+// Objects without namespace are placed in defaultNamespace. Kinds not used by Kiali are skipped.
+func (in *MemoryClient) AddJSON(raw []byte, defaultNamespace string) error {
+	var typeMeta meta_v1.TypeMeta
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return err
+	}
+
+	if typeMeta.Kind == "List" {
+		list := struct {
+			Items []json.RawMessage `json:"items"`
+		}{}
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return err
+		}
+		for _, item := range list.Items {
+			if err := in.AddJSON(item, defaultNamespace); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if legacy, found := legacyIstioResource(typeMeta); found {
+		istioObject := &GenericIstioObject{}
+		if err := json.Unmarshal(raw, istioObject); err != nil {
+			return err
+		}
+		if legacy.ClusterScoped {
+			istioObject.Namespace = ""
+		} else if istioObject.Namespace == "" {
+			istioObject.Namespace = defaultNamespace
+		}
+		in.lock.Lock()
+		defer in.lock.Unlock()
+		ns := in.namespace(istioObject.Namespace)
+		key := legacyIstioObjectsKey(legacy)
+		ns.istioObjects[key] = append(ns.istioObjects[key], istioObject)
+		return nil
+	}
+
+	if resourceType, found := IstioResourceType(typeMeta); found {
+		istioObject := &GenericIstioObject{}
+		if err := json.Unmarshal(raw, istioObject); err != nil {
+			return err
+		}
+		if IsClusterScoped(resourceType) {
+			istioObject.Namespace = ""
+		} else if istioObject.Namespace == "" {
+			istioObject.Namespace = defaultNamespace
+		}
+		in.lock.Lock()
+		defer in.lock.Unlock()
+		ns := in.namespace(istioObject.Namespace)
+		ns.istioObjects[resourceType] = append(ns.istioObjects[resourceType], istioObject)
+		return nil
+	}
+
+	var target interface{}
+	var objectMeta *meta_v1.ObjectMeta
+	var add func(ns *memoryNamespace)
+
+	switch typeMeta.Kind {
+	case "Namespace":
+		obj := &core_v1.Namespace{}
+		if err := json.Unmarshal(raw, obj); err != nil {
+			return err
+		}
+		in.lock.Lock()
+		defer in.lock.Unlock()
+		in.namespace(obj.Name).namespace = *obj
+		return nil
+	case "ConfigMap":
+		obj := &core_v1.ConfigMap{}
+		target, objectMeta, add = obj, &obj.ObjectMeta, func(ns *memoryNamespace) { ns.configMaps = append(ns.configMaps, *obj) }
+	case "CronJob":
+		obj := &batch_v1beta1.CronJob{}
+		target, objectMeta, add = obj, &obj.ObjectMeta, func(ns *memoryNamespace) { ns.cronJobs = append(ns.cronJobs, *obj) }
+	case "Deployment":
+		obj := &apps_v1.Deployment{}
+		target, objectMeta, add = obj, &obj.ObjectMeta, func(ns *memoryNamespace) { ns.deployments = append(ns.deployments, *obj) }
+	case "Endpoints":
+		obj := &core_v1.Endpoints{}
+		target, objectMeta, add = obj, &obj.ObjectMeta, func(ns *memoryNamespace) { ns.endpoints = append(ns.endpoints, *obj) }
+	case "Job":
+		obj := &batch_v1.Job{}
+		target, objectMeta, add = obj, &obj.ObjectMeta, func(ns *memoryNamespace) { ns.jobs = append(ns.jobs, *obj) }
+	case "NetworkPolicy":
+		obj := &networking_v1.NetworkPolicy{}
+		target, objectMeta, add = obj, &obj.ObjectMeta, func(ns *memoryNamespace) { ns.networkPolicies = append(ns.networkPolicies, *obj) }
+	case "Pod":
+		obj := &core_v1.Pod{}
+		target, objectMeta, add = obj, &obj.ObjectMeta, func(ns *memoryNamespace) { ns.pods = append(ns.pods, *obj) }
+	case "ReplicaSet":
+		obj := &apps_v1.ReplicaSet{}
+		target, objectMeta, add = obj, &obj.ObjectMeta, func(ns *memoryNamespace) { ns.replicaSets = append(ns.replicaSets, *obj) }
+	case "ReplicationController":
+		obj := &core_v1.ReplicationController{}
+		target, objectMeta, add = obj, &obj.ObjectMeta, func(ns *memoryNamespace) { ns.replicationControllers = append(ns.replicationControllers, *obj) }
+	case "Secret":
+		obj := &core_v1.Secret{}
+		target, objectMeta, add = obj, &obj.ObjectMeta, func(ns *memoryNamespace) { ns.secrets = append(ns.secrets, *obj) }
+	case "ServiceAccount":
+		obj := &core_v1.ServiceAccount{}
+		target, objectMeta, add = obj, &obj.ObjectMeta, func(ns *memoryNamespace) { ns.serviceAccounts = append(ns.serviceAccounts, *obj) }
+	case "Service":
+		obj := &core_v1.Service{}
+		target, objectMeta, add = obj, &obj.ObjectMeta, func(ns *memoryNamespace) { ns.services = append(ns.services, *obj) }
+	case "StatefulSet":
+		obj := &apps_v1.StatefulSet{}
+		target, objectMeta, add = obj, &obj.ObjectMeta, func(ns *memoryNamespace) { ns.statefulSets = append(ns.statefulSets, *obj) }
+	default:
+		log.Debugf("MemoryClient: skipping object of kind [%s] [%s]", typeMeta.APIVersion, typeMeta.Kind)
+		return nil
+	}
+
+	if err := json.Unmarshal(raw, target); err != nil {
+		return err
+	}
+	if objectMeta.Namespace == "" {
+		objectMeta.Namespace = defaultNamespace
+	}
+
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	add(in.namespace(objectMeta.Namespace))
+	return nil
+}
+
+// HasConfigMap returns true if a ConfigMap with that name was loaded in the namespace
+func (in *MemoryClient) HasConfigMap(namespace, name string) bool {
+	_, err := in.GetConfigMap(namespace, name)
+	return err == nil
+}
+
+// AddConfigMap adds a ConfigMap into the client
+func (in *MemoryClient) AddConfigMap(configMap core_v1.ConfigMap) {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	ns := in.namespace(configMap.Namespace)
+	ns.configMaps = append(ns.configMaps, configMap)
+}
+
+// namespace returns the store of a namespace, creating it if it doesn't exist. Lock must be held by the caller.
+func (in *MemoryClient) namespace(name string) *memoryNamespace {
+	ns, found := in.namespaces[name]
+	if !found {
+		ns = &memoryNamespace{
+			namespace:    core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: name}},
+			istioObjects: map[string][]IstioObject{},
+		}
+		in.namespaces[name] = ns
+	}
+	return ns
+}
+
+// read runs the reader function over the store of a namespace, if it exists
+func (in *MemoryClient) read(namespace string, reader func(ns *memoryNamespace)) {
+	in.lock.RLock()
+	defer in.lock.RUnlock()
+	if ns, found := in.namespaces[namespace]; found {
+		reader(ns)
+	}
+}
+
+func selectorMatcher(labelSelector string) (func(map[string]string) bool, error) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	return func(objectLabels map[string]string) bool {
+		return selector.Matches(labels.Set(objectLabels))
+	}, nil
+}
+
+func notFound(resource, name string) error {
+	return errors.NewNotFound(schema.GroupResource{Resource: resource}, name)
+}
+
+func conflict(api, resourceType, name string) error {
+	return errors.NewConflict(schema.GroupResource{Group: api, Resource: resourceType}, name,
+		fmt.Errorf("the object has been modified; please apply your changes to the latest version and try again"))
+}
+
+// nextResourceVersion returns a new resourceVersion for an Istio object being written, the lock must be held
+func (in *MemoryClient) nextResourceVersion() string {
+	in.resourceVersion++
+	return strconv.FormatUint(in.resourceVersion, 10)
+}
+
+// patchResourceVersion returns the resourceVersion set in the metadata of a JSON patch, if any
+func patchResourceVersion(jsonPatch string) string {
+	patch := struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+	}{}
+	_ = json.Unmarshal([]byte(jsonPatch), &patch)
+	return patch.Metadata.ResourceVersion
+}
+
+func notSupported(operation string) error {
+	return fmt.Errorf("%s is not supported by the in-memory client", operation)
+}
+
+func (in *MemoryClient) GetServerVersion() (*version.Info, error) {
+	return &version.Info{}, nil
+}
+
+func (in *MemoryClient) GetToken() string {
+	return ""
+}
+
+func (in *MemoryClient) IsOpenShift() bool {
+	return false
+}
+
+func (in *MemoryClient) GetConfigMap(namespace, configName string) (*core_v1.ConfigMap, error) {
+	var result *core_v1.ConfigMap
+	in.read(namespace, func(ns *memoryNamespace) {
+		for i := range ns.configMaps {
+			if ns.configMaps[i].Name == configName {
+				result = ns.configMaps[i].DeepCopy()
+				return
+			}
+		}
+	})
+	if result == nil {
+		return nil, notFound("configmaps", configName)
+	}
+	return result, nil
+}
+
+// CreateConfigMap stores a copy of the ConfigMap with its first resourceVersion
+func (in *MemoryClient) CreateConfigMap(namespace string, configMap *core_v1.ConfigMap) (*core_v1.ConfigMap, error) {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	ns := in.namespace(namespace)
+	for _, cm := range ns.configMaps {
+		if cm.Name == configMap.Name {
+			return nil, errors.NewAlreadyExists(schema.GroupResource{Resource: "configmaps"}, configMap.Name)
+		}
+	}
+	created := configMap.DeepCopy()
+	created.Namespace = namespace
+	created.ResourceVersion = "1"
+	ns.configMaps = append(ns.configMaps, *created)
+	return created.DeepCopy(), nil
+}
+
+// CreateEvent stores a copy of the Event
+func (in *MemoryClient) CreateEvent(namespace string, event *core_v1.Event) (*core_v1.Event, error) {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	ns := in.namespace(namespace)
+	created := event.DeepCopy()
+	created.Namespace = namespace
+	ns.events = append(ns.events, *created)
+	return created.DeepCopy(), nil
+}
+
+// GetEvents returns the Events created in the namespace, in creation order
+func (in *MemoryClient) GetEvents(namespace string) []core_v1.Event {
+	events := []core_v1.Event{}
+	in.read(namespace, func(ns *memoryNamespace) {
+		for _, e := range ns.events {
+			events = append(events, *e.DeepCopy())
+		}
+	})
+	return events
+}
+
+// UpdateConfigMap replaces the ConfigMap, failing with a conflict when its resourceVersion is set and outdated
+func (in *MemoryClient) UpdateConfigMap(namespace string, configMap *core_v1.ConfigMap) (*core_v1.ConfigMap, error) {
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	if ns, found := in.namespaces[namespace]; found {
+		for i, cm := range ns.configMaps {
+			if cm.Name != configMap.Name {
+				continue
+			}
+			if configMap.ResourceVersion != "" && configMap.ResourceVersion != cm.ResourceVersion {
+				return nil, errors.NewConflict(schema.GroupResource{Resource: "configmaps"}, configMap.Name, fmt.Errorf("the object has been modified"))
+			}
+			current, _ := strconv.Atoi(cm.ResourceVersion)
+			updated := configMap.DeepCopy()
+			updated.Namespace = namespace
+			updated.ResourceVersion = strconv.Itoa(current + 1)
+			ns.configMaps[i] = *updated
+			return updated.DeepCopy(), nil
+		}
+	}
+	return nil, notFound("configmaps", configMap.Name)
+}
+
+func (in *MemoryClient) GetCronJobs(namespace string) ([]batch_v1beta1.CronJob, error) {
+	result := []batch_v1beta1.CronJob{}
+	in.read(namespace, func(ns *memoryNamespace) {
+		result = append(result, ns.cronJobs...)
+	})
+	return result, nil
+}
+
+func (in *MemoryClient) GetDeployment(namespace string, deploymentName string) (*apps_v1.Deployment, error) {
+	var result *apps_v1.Deployment
+	in.read(namespace, func(ns *memoryNamespace) {
+		for i := range ns.deployments {
+			if ns.deployments[i].Name == deploymentName {
+				result = ns.deployments[i].DeepCopy()
+				return
+			}
+		}
+	})
+	if result == nil {
+		return nil, notFound("deployments", deploymentName)
+	}
+	return result, nil
+}
+
+func (in *MemoryClient) GetDeployments(namespace string) ([]apps_v1.Deployment, error) {
+	return in.GetDeploymentsByLabel(namespace, "")
+}
+
+func (in *MemoryClient) GetDeploymentsByLabel(namespace string, labelSelector string) ([]apps_v1.Deployment, error) {
+	matches, err := selectorMatcher(labelSelector)
+	if err != nil {
+		return []apps_v1.Deployment{}, err
+	}
+	result := []apps_v1.Deployment{}
+	in.read(namespace, func(ns *memoryNamespace) {
+		for _, d := range ns.deployments {
+			if matches(d.Labels) {
+				result = append(result, d)
+			}
+		}
+	})
+	return result, nil
+}
+
+func (in *MemoryClient) GetDeploymentConfig(namespace string, deploymentconfigName string) (*osapps_v1.DeploymentConfig, error) {
+	return nil, notFound("deploymentconfigs", deploymentconfigName)
+}
+
+func (in *MemoryClient) GetDeploymentConfigs(namespace string) ([]osapps_v1.DeploymentConfig, error) {
+	return []osapps_v1.DeploymentConfig{}, nil
+}
+
+func (in *MemoryClient) GetEndpoints(namespace string, serviceName string) (*core_v1.Endpoints, error) {
+	var result *core_v1.Endpoints
+	in.read(namespace, func(ns *memoryNamespace) {
+		for i := range ns.endpoints {
+			if ns.endpoints[i].Name == serviceName {
+				result = ns.endpoints[i].DeepCopy()
+				return
+			}
+		}
+	})
+	if result == nil {
+		return nil, notFound("endpoints", serviceName)
+	}
+	return result, nil
+}
+
+func (in *MemoryClient) GetJobs(namespace string) ([]batch_v1.Job, error) {
+	result := []batch_v1.Job{}
+	in.read(namespace, func(ns *memoryNamespace) {
+		result = append(result, ns.jobs...)
+	})
+	return result, nil
+}
+
+func (in *MemoryClient) GetNamespace(namespace string) (*core_v1.Namespace, error) {
+	var result *core_v1.Namespace
+	in.read(namespace, func(ns *memoryNamespace) {
+		result = ns.namespace.DeepCopy()
+	})
+	if result == nil {
+		return nil, notFound("namespaces", namespace)
+	}
+	return result, nil
+}
+
+func (in *MemoryClient) GetNamespaces(labelSelector string) ([]core_v1.Namespace, error) {
+	matches, err := selectorMatcher(labelSelector)
+	if err != nil {
+		return []core_v1.Namespace{}, err
+	}
+	in.lock.RLock()
+	defer in.lock.RUnlock()
+	result := []core_v1.Namespace{}
+	for _, ns := range in.namespaces {
+		// Cluster scoped objects are stored without namespace
+		if ns.namespace.Name == "" {
+			continue
+		}
+		if matches(ns.namespace.Labels) {
+			result = append(result, ns.namespace)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func (in *MemoryClient) GetPod(namespace, name string) (*core_v1.Pod, error) {
+	var result *core_v1.Pod
+	in.read(namespace, func(ns *memoryNamespace) {
+		for i := range ns.pods {
+			if ns.pods[i].Name == name {
+				result = ns.pods[i].DeepCopy()
+				return
+			}
+		}
+	})
+	if result == nil {
+		return nil, notFound("pods", name)
+	}
+	return result, nil
+}
+
+func (in *MemoryClient) GetPodLogs(namespace, name string, opts *core_v1.PodLogOptions) (*PodLogs, error) {
+	return nil, notSupported("GetPodLogs")
+}
+
+func (in *MemoryClient) GetPods(namespace, labelSelector string) ([]core_v1.Pod, error) {
+	matches, err := selectorMatcher(labelSelector)
+	if err != nil {
+		return []core_v1.Pod{}, err
+	}
+	result := []core_v1.Pod{}
+	in.read(namespace, func(ns *memoryNamespace) {
+		for _, p := range ns.pods {
+			if matches(p.Labels) {
+				result = append(result, p)
+			}
+		}
+	})
+	return result, nil
+}
+
+func (in *MemoryClient) GetReplicationControllers(namespace string) ([]core_v1.ReplicationController, error) {
+	result := []core_v1.ReplicationController{}
+	in.read(namespace, func(ns *memoryNamespace) {
+		result = append(result, ns.replicationControllers...)
+	})
+	return result, nil
+}
+
+func (in *MemoryClient) GetReplicaSets(namespace string) ([]apps_v1.ReplicaSet, error) {
+	result := []apps_v1.ReplicaSet{}
+	in.read(namespace, func(ns *memoryNamespace) {
+		result = append(result, ns.replicaSets...)
+	})
+	return result, nil
+}
+
+func (in *MemoryClient) GetSecret(namespace, name string) (*core_v1.Secret, error) {
+	var result *core_v1.Secret
+	in.read(namespace, func(ns *memoryNamespace) {
+		for i := range ns.secrets {
+			if ns.secrets[i].Name == name {
+				result = ns.secrets[i].DeepCopy()
+				return
+			}
+		}
+	})
+	if result == nil {
+		return nil, notFound("secrets", name)
+	}
+	return result, nil
+}
+
+func (in *MemoryClient) GetNetworkPolicies(namespace string) ([]networking_v1.NetworkPolicy, error) {
+	result := []networking_v1.NetworkPolicy{}
+	in.read(namespace, func(ns *memoryNamespace) {
+		result = append(result, ns.networkPolicies...)
+	})
+	return result, nil
+}
+
+func (in *MemoryClient) GetServiceAccounts(namespace string) ([]core_v1.ServiceAccount, error) {
+	result := []core_v1.ServiceAccount{}
+	in.read(namespace, func(ns *memoryNamespace) {
+		result = append(result, ns.serviceAccounts...)
+	})
+	return result, nil
+}
+
+// GetSelfSubjectAccessReview allows every verb, as the in-memory objects are owned by the caller
+func (in *MemoryClient) GetSelfSubjectAccessReview(namespace, api, resourceType string, verbs []string) ([]*auth_v1.SelfSubjectAccessReview, error) {
+	result := make([]*auth_v1.SelfSubjectAccessReview, 0, len(verbs))
+	for _, verb := range verbs {
+		result = append(result, &auth_v1.SelfSubjectAccessReview{
+			Spec: auth_v1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &auth_v1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      verb,
+					Group:     api,
+					Resource:  resourceType,
+				},
+			},
+			Status: auth_v1.SubjectAccessReviewStatus{Allowed: true},
+		})
+	}
+	return result, nil
+}
+
+func (in *MemoryClient) GetService(namespace string, serviceName string) (*core_v1.Service, error) {
+	var result *core_v1.Service
+	in.read(namespace, func(ns *memoryNamespace) {
+		for i := range ns.services {
+			if ns.services[i].Name == serviceName {
+				result = ns.services[i].DeepCopy()
+				return
+			}
+		}
+	})
+	if result == nil {
+		return nil, notFound("services", serviceName)
+	}
+	return result, nil
+}
+
+func (in *MemoryClient) GetServices(namespace string, selectorLabels map[string]string) ([]core_v1.Service, error) {
+	result := []core_v1.Service{}
+	in.read(namespace, func(ns *memoryNamespace) {
+		for _, svc := range ns.services {
+			if selectorLabels == nil {
+				result = append(result, svc)
+				continue
+			}
+			svcSelector := labels.Set(svc.Spec.Selector).AsSelector()
+			if !svcSelector.Empty() && svcSelector.Matches(labels.Set(selectorLabels)) {
+				result = append(result, svc)
+			}
+		}
+	})
+	return result, nil
+}
+
+func (in *MemoryClient) GetStatefulSet(namespace string, statefulsetName string) (*apps_v1.StatefulSet, error) {
+	var result *apps_v1.StatefulSet
+	in.read(namespace, func(ns *memoryNamespace) {
+		for i := range ns.statefulSets {
+			if ns.statefulSets[i].Name == statefulsetName {
+				result = ns.statefulSets[i].DeepCopy()
+				return
+			}
+		}
+	})
+	if result == nil {
+		return nil, notFound("statefulsets", statefulsetName)
+	}
+	return result, nil
+}
+
+func (in *MemoryClient) GetStatefulSets(namespace string) ([]apps_v1.StatefulSet, error) {
+	result := []apps_v1.StatefulSet{}
+	in.read(namespace, func(ns *memoryNamespace) {
+		result = append(result, ns.statefulSets...)
+	})
+	return result, nil
+}
+
+func (in *MemoryClient) UpdateNamespace(namespace string, jsonPatch string) (*core_v1.Namespace, error) {
+	return nil, notSupported("UpdateNamespace")
+}
+
+func (in *MemoryClient) UpdateWorkload(namespace string, workloadName string, workloadType string, jsonPatch string) error {
+	return notSupported("UpdateWorkload")
+}
+
+func (in *MemoryClient) CreateIstioObject(api, namespace, resourceType, body string) (IstioObject, error) {
+	return in.createIstioObject(api, namespace, resourceType, body, false)
+}
+
+// DryRunCreateIstioObject checks the creation of the object without storing it
+func (in *MemoryClient) DryRunCreateIstioObject(api, namespace, resourceType, body string) (IstioObject, error) {
+	return in.createIstioObject(api, namespace, resourceType, body, true)
+}
+
+func (in *MemoryClient) createIstioObject(api, namespace, resourceType, body string, dryRun bool) (IstioObject, error) {
+	if ResourceTypesToAPI[resourceType] != api {
+		return nil, fmt.Errorf("%s is not supported in CreateIstioObject operation", api)
+	}
+	istioObject := &GenericIstioObject{}
+	if err := json.Unmarshal([]byte(body), istioObject); err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	if IsClusterScoped(resourceType) {
+		namespace = ""
+	}
+	istioObject.Namespace = namespace
+
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	ns := in.namespace(namespace)
+	for _, o := range ns.istioObjects[resourceType] {
+		if o.GetObjectMeta().Name == istioObject.Name {
+			return nil, errors.NewAlreadyExists(schema.GroupResource{Group: api, Resource: resourceType}, istioObject.Name)
+		}
+	}
+	if !dryRun {
+		istioObject.ResourceVersion = in.nextResourceVersion()
+		ns.istioObjects[resourceType] = append(ns.istioObjects[resourceType], istioObject)
+	}
+	return istioObject.DeepCopyIstioObject(), nil
+}
+
+func (in *MemoryClient) DeleteIstioObject(api, namespace, resourceType, name string) error {
+	return in.deleteIstioObject(api, namespace, resourceType, name, "")
+}
+
+// DeleteIstioObjectWithPrecondition deletes the object only if its resourceVersion is the given one
+func (in *MemoryClient) DeleteIstioObjectWithPrecondition(api, namespace, resourceType, name, resourceVersion string) error {
+	return in.deleteIstioObject(api, namespace, resourceType, name, resourceVersion)
+}
+
+func (in *MemoryClient) deleteIstioObject(api, namespace, resourceType, name, resourceVersion string) error {
+	if IsClusterScoped(resourceType) {
+		namespace = ""
+	}
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	if ns, found := in.namespaces[namespace]; found {
+		for i, o := range ns.istioObjects[resourceType] {
+			if o.GetObjectMeta().Name == name {
+				if resourceVersion != "" && resourceVersion != o.GetObjectMeta().ResourceVersion {
+					return conflict(api, resourceType, name)
+				}
+				ns.istioObjects[resourceType] = append(ns.istioObjects[resourceType][:i], ns.istioObjects[resourceType][i+1:]...)
+				return nil
+			}
+		}
+	}
+	return errors.NewNotFound(schema.GroupResource{Group: api, Resource: resourceType}, name)
+}
+
+func (in *MemoryClient) GetIstioObject(namespace, resourceType, name string) (IstioObject, error) {
+	if IsClusterScoped(resourceType) {
+		namespace = ""
+	}
+	var result IstioObject
+	in.read(namespace, func(ns *memoryNamespace) {
+		for _, o := range ns.istioObjects[resourceType] {
+			if o.GetObjectMeta().Name == name {
+				result = o.DeepCopyIstioObject()
+				return
+			}
+		}
+	})
+	if result == nil {
+		return nil, errors.NewNotFound(schema.GroupResource{Group: ResourceTypesToAPI[resourceType], Resource: resourceType}, name)
+	}
+	return result, nil
+}
+
+func (in *MemoryClient) GetIstioObjects(namespace, resourceType, labelSelector string) ([]IstioObject, error) {
+	if _, ok := ResourceTypesToAPI[resourceType]; !ok {
+		return []IstioObject{}, fmt.Errorf("%s not found in ResourcesTypeToAPI", resourceType)
+	}
+	if IsClusterScoped(resourceType) {
+		namespace = ""
+	}
+	matches, err := selectorMatcher(labelSelector)
+	if err != nil {
+		return []IstioObject{}, err
+	}
+	result := make([]IstioObject, 0)
+	in.read(namespace, func(ns *memoryNamespace) {
+		for _, o := range ns.istioObjects[resourceType] {
+			if matches(o.GetObjectMeta().Labels) {
+				result = append(result, o.DeepCopyIstioObject())
+			}
+		}
+	})
+	return result, nil
+}
+
+// UpdateIstioObject applies the jsonPatch as a JSON merge patch (RFC 7386), like the K8SClient does.
+// A resourceVersion in the metadata of the patch is a precondition, the update fails with a Conflict when it is stale.
+func (in *MemoryClient) UpdateIstioObject(api, namespace, resourceType, name, jsonPatch string) (IstioObject, error) {
+	return in.updateIstioObject(api, namespace, resourceType, name, jsonPatch, false)
+}
+
+// DryRunUpdateIstioObject returns the patched object without storing it
+func (in *MemoryClient) DryRunUpdateIstioObject(api, namespace, resourceType, name, jsonPatch string) (IstioObject, error) {
+	return in.updateIstioObject(api, namespace, resourceType, name, jsonPatch, true)
+}
+
+func (in *MemoryClient) updateIstioObject(api, namespace, resourceType, name, jsonPatch string, dryRun bool) (IstioObject, error) {
+	if IsClusterScoped(resourceType) {
+		namespace = ""
+	}
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	ns, found := in.namespaces[namespace]
+	if !found {
+		return nil, errors.NewNotFound(schema.GroupResource{Group: api, Resource: resourceType}, name)
+	}
+	for i, o := range ns.istioObjects[resourceType] {
+		if o.GetObjectMeta().Name != name {
+			continue
+		}
+		if rv := patchResourceVersion(jsonPatch); rv != "" && rv != o.GetObjectMeta().ResourceVersion {
+			return nil, conflict(api, resourceType, name)
+		}
+		istioObject, err := MergePatchIstioObject(o, jsonPatch)
+		if err != nil {
+			return nil, err
+		}
+		if !dryRun {
+			meta := istioObject.GetObjectMeta()
+			meta.ResourceVersion = in.nextResourceVersion()
+			istioObject.SetObjectMeta(meta)
+			ns.istioObjects[resourceType][i] = istioObject
+		}
+		return istioObject.DeepCopyIstioObject(), nil
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{Group: api, Resource: resourceType}, name)
+}
+
+// ApplyIstioObject approximates a server-side apply: the applied configuration is merged into the current object, or
+// creates it when it doesn't exist. Field ownership is not tracked, so force has no effect and there are no conflicts
+// other than a stale resourceVersion in the applied configuration.
+func (in *MemoryClient) ApplyIstioObject(api, namespace, resourceType, name, body string, force bool) (IstioObject, error) {
+	if ResourceTypesToAPI[resourceType] != api {
+		return nil, fmt.Errorf("%s is not supported in ApplyIstioObject operation", api)
+	}
+	if _, err := in.GetIstioObject(namespace, resourceType, name); errors.IsNotFound(err) {
+		return in.createIstioObject(api, namespace, resourceType, body, false)
+	}
+	return in.updateIstioObject(api, namespace, resourceType, name, body, false)
+}
+
+// Legacy objects are stored along with the Istio objects, their key can't collide with a resource type
+func legacyIstioObjectsKey(resource LegacyIstioResource) string {
+	return resource.APIVersion + "/" + resource.Resource
+}
+
+// GetLegacyIstioObjects returns the objects of a legacy Istio resource, as if its CRD was installed
+func (in *MemoryClient) GetLegacyIstioObjects(namespace string, resource LegacyIstioResource) ([]IstioObject, error) {
+	if resource.ClusterScoped {
+		namespace = ""
+	}
+	result := make([]IstioObject, 0)
+	in.read(namespace, func(ns *memoryNamespace) {
+		for _, o := range ns.istioObjects[legacyIstioObjectsKey(resource)] {
+			result = append(result, o.DeepCopyIstioObject())
+		}
+	})
+	return result, nil
+}
+
+func (in *MemoryClient) GetProxyStatus() ([]*ProxyStatus, error) {
+	return []*ProxyStatus{}, nil
+}
+
+// IsGatewayAPI returns true, the Gateway API objects are loaded like any other Istio object
+func (in *MemoryClient) IsGatewayAPI() bool {
+	return true
+}
+
+// HasIstioResource returns true, every Istio resource type can be loaded
+func (in *MemoryClient) HasIstioResource(resourceType string) bool {
+	return true
+}
+
+func (in *MemoryClient) GetConfigDump(namespace, podName string) (*ConfigDump, error) {
+	return nil, notSupported("GetConfigDump")
+}
+
+func (in *MemoryClient) CreateIter8Experiment(namespace string, body string) (Iter8Experiment, error) {
+	return nil, notSupported("CreateIter8Experiment")
+}
+
+func (in *MemoryClient) UpdateIter8Experiment(namespace string, name string, body string) (Iter8Experiment, error) {
+	return nil, notSupported("UpdateIter8Experiment")
+}
+
+func (in *MemoryClient) DeleteIter8Experiment(namespace string, name string) error {
+	return notSupported("DeleteIter8Experiment")
+}
+
+func (in *MemoryClient) GetIter8Experiment(namespace string, name string) (Iter8Experiment, error) {
+	return nil, notFound(Iter8Experiments, name)
+}
+
+func (in *MemoryClient) GetIter8Experiments(namespace string) ([]Iter8Experiment, error) {
+	return []Iter8Experiment{}, nil
+}
+
+func (in *MemoryClient) IsIter8Api() bool {
+	return false
+}
+
+func (in *MemoryClient) Iter8MetricMap() ([]string, error) {
+	return []string{}, nil
+}
+
+func (in *MemoryClient) GetProject(project string) (*osproject_v1.Project, error) {
+	return nil, notFound("projects", project)
+}
+
+func (in *MemoryClient) GetProjects(labelSelector string) ([]osproject_v1.Project, error) {
+	return []osproject_v1.Project{}, nil
+}
+
+func (in *MemoryClient) GetRoute(namespace string, name string) (*osroutes_v1.Route, error) {
+	return nil, notFound("routes", name)
+}
+
+func (in *MemoryClient) UpdateProject(project string, jsonPatch string) (*osproject_v1.Project, error) {
+	return nil, notSupported("UpdateProject")
+}
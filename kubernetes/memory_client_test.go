@@ -0,0 +1,159 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+const memoryClientManifests = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: bookinfo
+  labels:
+    istio-injection: enabled
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: reviews
+spec:
+  selector:
+    app: reviews
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: reviews-v1-1234
+    namespace: bookinfo
+    labels:
+      app: reviews
+      version: v1
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: reviews-v2-1234
+    namespace: bookinfo
+    labels:
+      app: reviews
+      version: v2
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+  namespace: bookinfo
+  labels:
+    team: reviews
+spec:
+  hosts:
+  - reviews
+  http:
+  - route:
+    - destination:
+        host: reviews
+      weight: 100
+---
+apiVersion: security.istio.io/v1beta1
+kind: AuthorizationPolicy
+metadata:
+  name: allow-nothing
+  namespace: istio-system
+spec: {}
+---
+apiVersion: autoscaling/v1
+kind: HorizontalPodAutoscaler
+metadata:
+  name: reviews
+  namespace: bookinfo
+`
+
+func loadMemoryClient(t *testing.T) *MemoryClient {
+	client := NewMemoryClient()
+	if err := client.LoadYAML(strings.NewReader(memoryClientManifests), "bookinfo"); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestMemoryClientLoad(t *testing.T) {
+	assert := assert.New(t)
+	client := loadMemoryClient(t)
+
+	nss, err := client.GetNamespaces("")
+	assert.NoError(err)
+	assert.Len(nss, 2)
+	assert.Equal("bookinfo", nss[0].Name)
+	assert.Equal("istio-system", nss[1].Name)
+
+	nss, err = client.GetNamespaces("istio-injection=enabled")
+	assert.NoError(err)
+	assert.Len(nss, 1)
+
+	svc, err := client.GetService("bookinfo", "reviews")
+	assert.NoError(err)
+	assert.Equal("bookinfo", svc.Namespace)
+
+	svcs, err := client.GetServices("bookinfo", map[string]string{"app": "reviews", "version": "v1"})
+	assert.NoError(err)
+	assert.Len(svcs, 1)
+
+	pods, err := client.GetPods("bookinfo", "version=v2")
+	assert.NoError(err)
+	assert.Len(pods, 1)
+	assert.Equal("reviews-v2-1234", pods[0].Name)
+
+	vss, err := client.GetIstioObjects("bookinfo", VirtualServices, "team=reviews")
+	assert.NoError(err)
+	assert.Len(vss, 1)
+	assert.Equal(VirtualServiceType, vss[0].GetTypeMeta().Kind)
+
+	aps, err := client.GetIstioObjects("istio-system", AuthorizationPolicies, "")
+	assert.NoError(err)
+	assert.Len(aps, 1)
+
+	_, err = client.GetDeployment("bookinfo", "reviews-v1")
+	assert.True(errors.IsNotFound(err))
+}
+
+func TestMemoryClientIstioObjectLifecycle(t *testing.T) {
+	assert := assert.New(t)
+	client := loadMemoryClient(t)
+
+	_, err := client.CreateIstioObject(NetworkingGroupVersion.Group, "bookinfo", DestinationRules, `{"metadata":{"name":"reviews"},"spec":{"host":"reviews"}}`)
+	assert.NoError(err)
+
+	_, err = client.CreateIstioObject(NetworkingGroupVersion.Group, "bookinfo", DestinationRules, `{"metadata":{"name":"reviews"},"spec":{"host":"reviews"}}`)
+	assert.True(errors.IsAlreadyExists(err))
+
+	updated, err := client.UpdateIstioObject(NetworkingGroupVersion.Group, "bookinfo", VirtualServices, "reviews", `{"metadata":{"labels":{"team":null}},"spec":{"gateways":["bookinfo-gateway"]}}`)
+	assert.NoError(err)
+	assert.Empty(updated.GetObjectMeta().Labels)
+	assert.Equal([]interface{}{"bookinfo-gateway"}, updated.GetSpec()["gateways"])
+	assert.Equal([]interface{}{"reviews"}, updated.GetSpec()["hosts"])
+
+	vs, err := client.GetIstioObject("bookinfo", VirtualServices, "reviews")
+	assert.NoError(err)
+	assert.Equal(updated.GetSpec(), vs.GetSpec())
+
+	dryRun, err := client.DryRunUpdateIstioObject(NetworkingGroupVersion.Group, "bookinfo", VirtualServices, "reviews", `{"spec":{"gateways":null}}`)
+	assert.NoError(err)
+	assert.NotContains(dryRun.GetSpec(), "gateways")
+	_, err = client.DryRunCreateIstioObject(NetworkingGroupVersion.Group, "bookinfo", DestinationRules, `{"metadata":{"name":"ratings"},"spec":{"host":"ratings"}}`)
+	assert.NoError(err)
+	vs, err = client.GetIstioObject("bookinfo", VirtualServices, "reviews")
+	assert.NoError(err)
+	assert.Equal(updated.GetSpec(), vs.GetSpec())
+	_, err = client.GetIstioObject("bookinfo", DestinationRules, "ratings")
+	assert.True(errors.IsNotFound(err))
+
+	assert.NoError(client.DeleteIstioObject(NetworkingGroupVersion.Group, "bookinfo", VirtualServices, "reviews"))
+	_, err = client.GetIstioObject("bookinfo", VirtualServices, "reviews")
+	assert.True(errors.IsNotFound(err))
+}
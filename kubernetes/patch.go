@@ -0,0 +1,55 @@
+package kubernetes
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// MergePatchIstioObject returns a copy of the object with the jsonPatch applied as a JSON merge patch (RFC 7386).
+// The name and namespace of the object can't be changed by the patch.
+func MergePatchIstioObject(istioObject IstioObject, jsonPatch string) (IstioObject, error) {
+	original, err := json.Marshal(istioObject)
+	if err != nil {
+		return nil, err
+	}
+	var current, patch map[string]interface{}
+	if err = json.Unmarshal(original, &current); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal([]byte(jsonPatch), &patch); err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	patched, err := json.Marshal(mergePatch(current, patch))
+	if err != nil {
+		return nil, err
+	}
+	result := &GenericIstioObject{}
+	if err = json.Unmarshal(patched, result); err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	result.Name = istioObject.GetObjectMeta().Name
+	result.Namespace = istioObject.GetObjectMeta().Namespace
+	return result, nil
+}
+
+// mergePatch applies a JSON merge patch (RFC 7386): null values remove keys, objects are merged recursively
+// and any other value replaces the original one.
+func mergePatch(original, patch map[string]interface{}) map[string]interface{} {
+	if original == nil {
+		original = map[string]interface{}{}
+	}
+	for k, v := range patch {
+		if v == nil {
+			delete(original, k)
+			continue
+		}
+		if patchMap, ok := v.(map[string]interface{}); ok {
+			originalMap, _ := original[k].(map[string]interface{})
+			original[k] = mergePatch(originalMap, patchMap)
+			continue
+		}
+		original[k] = v
+	}
+	return original
+}
@@ -0,0 +1,70 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func fakePatchVirtualService() IstioObject {
+	return &GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "reviews",
+			Namespace:   "bookinfo",
+			Labels:      map[string]string{"app": "reviews", "team": "reviews"},
+			Annotations: map[string]string{"owner": "alice"},
+		},
+		Spec: map[string]interface{}{
+			"hosts":    []interface{}{"reviews"},
+			"gateways": []interface{}{"bookinfo-gateway"},
+			"http": []interface{}{
+				map[string]interface{}{"route": []interface{}{map[string]interface{}{"destination": map[string]interface{}{"host": "reviews", "subset": "v1"}}}},
+			},
+		},
+	}
+}
+
+func TestMergePatchIstioObject(t *testing.T) {
+	assert := assert.New(t)
+	original := fakePatchVirtualService()
+
+	patched, err := MergePatchIstioObject(original, `{
+		"metadata": {"labels": {"team": null, "version": "v2"}},
+		"spec": {"gateways": null, "http": [{"route": [{"destination": {"host": "reviews", "subset": "v2"}}]}]}
+	}`)
+	assert.NoError(err)
+
+	// Objects are merged, null values remove keys
+	assert.Equal(map[string]string{"app": "reviews", "version": "v2"}, patched.GetObjectMeta().Labels)
+	assert.Equal(map[string]string{"owner": "alice"}, patched.GetObjectMeta().Annotations)
+	assert.NotContains(patched.GetSpec(), "gateways")
+	assert.Equal([]interface{}{"reviews"}, patched.GetSpec()["hosts"])
+
+	// Arrays are replaced
+	route := patched.GetSpec()["http"].([]interface{})[0].(map[string]interface{})["route"].([]interface{})
+	assert.Len(route, 1)
+	assert.Equal("v2", route[0].(map[string]interface{})["destination"].(map[string]interface{})["subset"])
+
+	// The original object is not changed
+	assert.Contains(original.GetSpec(), "gateways")
+	assert.Equal("reviews", original.GetObjectMeta().Labels["team"])
+}
+
+func TestMergePatchIstioObjectKeepsIdentity(t *testing.T) {
+	assert := assert.New(t)
+
+	patched, err := MergePatchIstioObject(fakePatchVirtualService(), `{"metadata": {"name": "ratings", "namespace": "default"}}`)
+	assert.NoError(err)
+	assert.Equal("reviews", patched.GetObjectMeta().Name)
+	assert.Equal("bookinfo", patched.GetObjectMeta().Namespace)
+}
+
+func TestMergePatchIstioObjectInvalidPatch(t *testing.T) {
+	_, err := MergePatchIstioObject(fakePatchVirtualService(), `{"spec": `)
+	assert.True(t, errors.IsBadRequest(err))
+
+	_, err = MergePatchIstioObject(fakePatchVirtualService(), `{"spec": "reviews"}`)
+	assert.True(t, errors.IsBadRequest(err))
+}
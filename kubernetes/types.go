@@ -68,6 +68,38 @@ const (
 	RequestAuthenticationsType     = "RequestAuthentication"
 	RequestAuthenticationsTypeList = "RequestAuthenticationList"
 
+	// Telemetry
+	Telemetries       = "telemetries"
+	TelemetryType     = "Telemetry"
+	TelemetryTypeList = "TelemetryList"
+
+	// Extensions
+	WasmPlugins        = "wasmplugins"
+	WasmPluginType     = "WasmPlugin"
+	WasmPluginTypeList = "WasmPluginList"
+
+	// Kubernetes Gateway API
+	// Resource types are prefixed to not clash with the Istio ones, the API resource is the plural of the kind
+
+	K8sGatewayClasses       = "k8sgatewayclasses"
+	K8sGatewayClassType     = "GatewayClass"
+	K8sGatewayClassTypeList = "GatewayClassList"
+
+	K8sGateways        = "k8sgateways"
+	K8sGatewayType     = "Gateway"
+	K8sGatewayTypeList = "GatewayList"
+
+	K8sHTTPRoutes        = "k8shttproutes"
+	K8sHTTPRouteType     = "HTTPRoute"
+	K8sHTTPRouteTypeList = "HTTPRouteList"
+
+	K8sTCPRoutes        = "k8stcproutes"
+	K8sTCPRouteType     = "TCPRoute"
+	K8sTCPRouteTypeList = "TCPRouteList"
+
+	// Label set by Istio in the workloads deployed for a Gateway API Gateway
+	GatewayAPIGatewayNameLabel = "istio.io/gateway-name"
+
 	// Iter8 types
 
 	Iter8Experiments        = "experiments"
@@ -89,6 +121,24 @@ var (
 	}
 	ApiSecurityVersion = SecurityGroupVersion.Group + "/" + SecurityGroupVersion.Version
 
+	TelemetryGroupVersion = schema.GroupVersion{
+		Group:   "telemetry.istio.io",
+		Version: "v1alpha1",
+	}
+	ApiTelemetryVersion = TelemetryGroupVersion.Group + "/" + TelemetryGroupVersion.Version
+
+	ExtensionsGroupVersion = schema.GroupVersion{
+		Group:   "extensions.istio.io",
+		Version: "v1alpha1",
+	}
+	ApiExtensionsVersion = ExtensionsGroupVersion.Group + "/" + ExtensionsGroupVersion.Version
+
+	GatewayAPIGroupVersion = schema.GroupVersion{
+		Group:   "gateway.networking.k8s.io",
+		Version: "v1alpha2",
+	}
+	ApiGatewayAPIVersion = GatewayAPIGroupVersion.Group + "/" + GatewayAPIGroupVersion.Version
+
 	// We will add a new extesion API in a similar way as we added the Kubernetes + Istio APIs
 	Iter8GroupVersion = schema.GroupVersion{
 		Group:   "iter8.tools",
@@ -148,6 +198,48 @@ var (
 		},
 	}
 
+	telemetryTypes = []struct {
+		objectKind     string
+		collectionKind string
+	}{
+		{
+			objectKind:     TelemetryType,
+			collectionKind: TelemetryTypeList,
+		},
+	}
+
+	extensionsTypes = []struct {
+		objectKind     string
+		collectionKind string
+	}{
+		{
+			objectKind:     WasmPluginType,
+			collectionKind: WasmPluginTypeList,
+		},
+	}
+
+	gatewayAPITypes = []struct {
+		objectKind     string
+		collectionKind string
+	}{
+		{
+			objectKind:     K8sGatewayClassType,
+			collectionKind: K8sGatewayClassTypeList,
+		},
+		{
+			objectKind:     K8sGatewayType,
+			collectionKind: K8sGatewayTypeList,
+		},
+		{
+			objectKind:     K8sHTTPRouteType,
+			collectionKind: K8sHTTPRouteTypeList,
+		},
+		{
+			objectKind:     K8sTCPRouteType,
+			collectionKind: K8sTCPRouteTypeList,
+		},
+	}
+
 	iter8Types = []struct {
 		objectKind     string
 		collectionKind string
@@ -174,6 +266,18 @@ var (
 		PeerAuthentications:    PeerAuthenticationsType,
 		RequestAuthentications: RequestAuthenticationsType,
 
+		// Telemetry
+		Telemetries: TelemetryType,
+
+		// Extensions
+		WasmPlugins: WasmPluginType,
+
+		// Gateway API
+		K8sGatewayClasses: K8sGatewayClassType,
+		K8sGateways:       K8sGatewayType,
+		K8sHTTPRoutes:     K8sHTTPRouteType,
+		K8sTCPRoutes:      K8sTCPRouteType,
+
 		// Iter8
 		Iter8Experiments: Iter8ExperimentType,
 	}
@@ -189,6 +293,12 @@ var (
 		AuthorizationPolicies:  SecurityGroupVersion.Group,
 		PeerAuthentications:    SecurityGroupVersion.Group,
 		RequestAuthentications: SecurityGroupVersion.Group,
+		Telemetries:            TelemetryGroupVersion.Group,
+		WasmPlugins:            ExtensionsGroupVersion.Group,
+		K8sGatewayClasses:      GatewayAPIGroupVersion.Group,
+		K8sGateways:            GatewayAPIGroupVersion.Group,
+		K8sHTTPRoutes:          GatewayAPIGroupVersion.Group,
+		K8sTCPRoutes:           GatewayAPIGroupVersion.Group,
 		// Extensions
 		Iter8Experiments: Iter8GroupVersion.Group,
 	}
@@ -196,6 +306,9 @@ var (
 	ApiToVersion = map[string]string{
 		NetworkingGroupVersion.Group: ApiNetworkingVersion,
 		SecurityGroupVersion.Group:   ApiSecurityVersion,
+		TelemetryGroupVersion.Group:  ApiTelemetryVersion,
+		ExtensionsGroupVersion.Group: ApiExtensionsVersion,
+		GatewayAPIGroupVersion.Group: ApiGatewayAPIVersion,
 	}
 )
 
@@ -224,8 +337,17 @@ type IstioObjectList interface {
 }
 
 type IstioMeshConfig struct {
-	DisableMixerHttpReports bool  `yaml:"disableMixerHttpReports,omitempty"`
-	EnableAutoMtls          *bool `yaml:"enableAutoMtls,omitempty"`
+	DisableMixerHttpReports bool        `yaml:"disableMixerHttpReports,omitempty"`
+	EnableAutoMtls          *bool       `yaml:"enableAutoMtls,omitempty"`
+	DefaultConfig           ProxyConfig `yaml:"defaultConfig,omitempty"`
+	TrustDomain             string      `yaml:"trustDomain,omitempty"`
+	TrustDomainAliases      []string    `yaml:"trustDomainAliases,omitempty"`
+}
+
+// ProxyConfig holds the proxy settings used by Kiali, set mesh-wide in the defaultConfig of the mesh config
+// or per workload in the proxy.istio.io/config annotation of the pods
+type ProxyConfig struct {
+	HoldApplicationUntilProxyStarts bool `yaml:"holdApplicationUntilProxyStarts,omitempty"`
 }
 
 // ServiceList holds list of services, pods and deployments
@@ -254,6 +376,16 @@ type IstioDetails struct {
 	Gateways               []IstioObject `json:"gateways"`
 	Sidecars               []IstioObject `json:"sidecars"`
 	RequestAuthentications []IstioObject `json:"requestauthentications"`
+	Telemetries            []IstioObject `json:"telemetries"`
+	WasmPlugins            []IstioObject `json:"wasmplugins"`
+}
+
+// GatewayAPIDetails is a wrapper to group the Kubernetes Gateway API objects used in the validations
+type GatewayAPIDetails struct {
+	GatewayClasses []IstioObject `json:"gatewayclasses"`
+	Gateways       []IstioObject `json:"gateways"`
+	HTTPRoutes     []IstioObject `json:"httproutes"`
+	TCPRoutes      []IstioObject `json:"tcproutes"`
 }
 
 // MTLSDetails is a wrapper to group all Istio objects related to non-local mTLS configurations
@@ -262,6 +394,8 @@ type MTLSDetails struct {
 	MeshPeerAuthentications []IstioObject `json:"meshpeerauthentications"`
 	PeerAuthentications     []IstioObject `json:"peerauthentications"`
 	EnabledAutoMtls         bool          `json:"enabledautomtls"`
+	// Trust domain of the mesh and its aliases
+	TrustDomains []string `json:"trustdomains"`
 }
 
 // RBACDetails is a wrapper for objects related to Istio RBAC (Role Based Access Control)
@@ -431,6 +565,15 @@ func (in *GenericIstioObjectList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// GetTrustDomains returns the trust domain of the mesh, when set, followed by its aliases
+func (imc IstioMeshConfig) GetTrustDomains() []string {
+	trustDomains := []string{}
+	if imc.TrustDomain != "" {
+		trustDomains = append(trustDomains, imc.TrustDomain)
+	}
+	return append(trustDomains, imc.TrustDomainAliases...)
+}
+
 func (imc IstioMeshConfig) GetEnableAutoMtls() bool {
 	if imc.EnableAutoMtls == nil {
 		return true
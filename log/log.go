@@ -62,6 +62,15 @@ func InitializeLogger() zerolog.Logger {
 	return log.Logger
 }
 
+// RedirectToStderr sends the logs to stderr, for the commands which write their results to stdout.
+func RedirectToStderr() {
+	if resolveLogFormatFromEnv() == "json" {
+		log.Logger = log.Logger.Output(os.Stderr)
+	} else {
+		log.Logger = log.Logger.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: zerolog.TimeFieldFormat, NoColor: true})
+	}
+}
+
 func Info(args ...interface{}) {
 	log.Info().Msgf("%s", args...)
 }
@@ -0,0 +1,31 @@
+package models
+
+// Annotations of the Services opted in the canary controller
+const (
+	// Value of the version label of the canary workloads. Setting it opts the service in.
+	CanaryAnnotation = "kiali.io/canary"
+	// Value of the version label of the stable workloads. Only required when the service has more than two versions.
+	CanaryStableAnnotation = "kiali.io/canary-stable"
+	// Overrides of the canary controller configuration for the service
+	CanaryStepWeightAnnotation    = "kiali.io/canary-step-weight"
+	CanaryMaxErrorRateAnnotation  = "kiali.io/canary-max-error-rate"
+	CanaryMaxP99LatencyAnnotation = "kiali.io/canary-max-p99-latency"
+)
+
+// CanaryStatusAnnotation is set by the canary controller in the VirtualService of the service once the canary is
+// promoted or rolled back, as <version>/<status>. The controller doesn't change the weights of that version anymore.
+const CanaryStatusAnnotation = "kiali.io/canary-status"
+
+// Final statuses of a canary
+const (
+	CanaryPromoted   = "Promoted"
+	CanaryRolledBack = "RolledBack"
+)
+
+// Reasons of the Kubernetes events recorded for the decisions of the canary controller
+const (
+	CanaryEventProgressing = "CanaryProgressing"
+	CanaryEventPromoted    = "CanaryPromoted"
+	CanaryEventRolledBack  = "CanaryRolledBack"
+	CanaryEventFailed      = "CanaryFailed"
+)
@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+// Annotations set in the VirtualService of a service while a fault experiment runs. They tell which experiment
+// injected the faults, only the faults of the experiments recorded by Kiali are removed by the watcher.
+const (
+	FaultExperimentAnnotation        = "kiali.io/fault-experiment"
+	FaultExperimentExpiresAnnotation = "kiali.io/fault-experiment-expires"
+)
+
+// Statuses of a fault experiment
+const (
+	// The faults are injected
+	FaultExperimentRunning = "Running"
+	// The faults were removed once the duration expired
+	FaultExperimentCompleted = "Completed"
+	// The faults were removed because the caller error rate crossed its threshold
+	FaultExperimentAborted = "Aborted"
+	// The faults were removed on request of a user
+	FaultExperimentStopped = "Stopped"
+	// The faults could not be removed, the VirtualService must be fixed manually
+	FaultExperimentFailed = "Failed"
+)
+
+// FaultExperimentRequest defines a fault injected in the requests to a service for a limited time
+// swagger:model
+type FaultExperimentRequest struct {
+	// Faults injected in the requests to every version of the service
+	// required: true
+	Fault TrafficWizardFault `json:"fault"`
+
+	// Time after which the faults are removed, as a duration
+	// required: true
+	// example: 5m
+	Duration string `json:"duration"`
+
+	// Percentage of error responses seen by the callers of the service, including the injected aborts, above which
+	// the faults are removed before the end of the experiment
+	// required: true
+	// example: 20
+	MaxCallerErrorRate float64 `json:"maxCallerErrorRate"`
+}
+
+// FaultExperiment is the record of a fault experiment
+// swagger:model
+type FaultExperiment struct {
+	FaultExperimentRequest
+
+	// example: reviews-1622505600
+	ID string `json:"id"`
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+	// example: reviews
+	Service string `json:"service"`
+	// User who started the experiment
+	User string `json:"user"`
+
+	// Running, Completed, Aborted, Stopped or Failed
+	// example: Completed
+	Status string `json:"status"`
+	// Why the experiment ended
+	Reason string `json:"reason,omitempty"`
+
+	StartTime time.Time  `json:"startTime"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+
+	// Traffic of the service during the same duration before the experiment
+	Before *FaultExperimentMetrics `json:"before,omitempty"`
+	// Traffic of the service while the faults were injected, set once the experiment ends
+	During *FaultExperimentMetrics `json:"during,omitempty"`
+	// Traffic of the service during the same duration after the experiment, set once that time has passed
+	After *FaultExperimentMetrics `json:"after,omitempty"`
+}
+
+// FaultExperimentMetrics is the traffic of a service seen by its callers over a period
+type FaultExperimentMetrics struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Requests per second
+	// example: 12.5
+	RequestRate float64 `json:"requestRate"`
+	// Percentage of responses with a status code of 400 or above, or without response
+	// example: 1.5
+	ErrorRate float64 `json:"errorRate"`
+	// p99 response time in milliseconds, when there was traffic
+	// example: 250
+	P99 *float64 `json:"p99,omitempty"`
+}
@@ -13,15 +13,46 @@ type NamespaceServiceHealth map[string]*ServiceHealth
 // NamespaceWorkloadHealth is an alias of map of workload name x health
 type NamespaceWorkloadHealth map[string]*WorkloadHealth
 
+// Health statuses, from the best to the worst
+const (
+	// Nothing to evaluate, i.e. a service without traffic
+	HealthNA      = "NA"
+	HealthHealthy = "Healthy"
+	// Workloads scaled to 0 replicas
+	HealthNotReady = "NotReady"
+	HealthDegraded = "Degraded"
+	HealthFailure  = "Failure"
+)
+
+// HealthStatus is the status of an entity evaluated from its health, with the reasons why it is not healthy
+type HealthStatus struct {
+	// NA, Healthy, NotReady, Degraded or Failure
+	// example: Degraded
+	Status string `json:"status"`
+	// Reasons of the status, from the worst
+	// example: ["reviews-v2: 1 of 2 replicas available"]
+	Reasons []string `json:"reasons"`
+}
+
+// NamespaceHealthStatus is the status of a namespace, the worst of the statuses of its entities
+type NamespaceHealthStatus struct {
+	HealthStatus
+	// Number of entities by status
+	// example: {"Healthy": 3, "Degraded": 1}
+	Entities map[string]int `json:"entities"`
+}
+
 // ServiceHealth contains aggregated health from various sources, for a given service
 type ServiceHealth struct {
 	Requests RequestHealth `json:"requests"`
+	Status   HealthStatus  `json:"status"`
 }
 
 // AppHealth contains aggregated health from various sources, for a given app
 type AppHealth struct {
 	WorkloadStatuses []*WorkloadStatus `json:"workloadStatuses"`
 	Requests         RequestHealth     `json:"requests"`
+	Status           HealthStatus      `json:"status"`
 }
 
 func NewEmptyRequestHealth() RequestHealth {
@@ -54,6 +85,7 @@ func EmptyWorkloadHealth() *WorkloadHealth {
 type WorkloadHealth struct {
 	WorkloadStatus *WorkloadStatus `json:"workloadStatus"`
 	Requests       RequestHealth   `json:"requests"`
+	Status         HealthStatus    `json:"status"`
 }
 
 // WorkloadStatus gives
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// HealthHistory is the health status of an entity or of a namespace over time, evaluated from the error rates of
+// consecutive buckets. Replica availability and proxy sync status are not part of it, their history is not kept.
+type HealthHistory struct {
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+	// app, service or workload
+	// example: app
+	Type string `json:"type"`
+	// Empty for the history of the namespace
+	// example: reviews
+	Name string `json:"name,omitempty"`
+
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Duration of a bucket, in seconds
+	// example: 60
+	Step int `json:"step"`
+
+	// Status of every bucket, at the end time of the bucket
+	Buckets []HealthBucket `json:"buckets"`
+	// Consecutive buckets with the same status, i.e. the transitions of the status
+	Periods []HealthPeriod `json:"periods"`
+}
+
+// HealthBucket is the health status evaluated from the error rates between Time-Step and Time
+type HealthBucket struct {
+	HealthStatus
+	Time time.Time `json:"time"`
+}
+
+// HealthPeriod is a period with the same health status
+type HealthPeriod struct {
+	// NA, Healthy, Degraded or Failure
+	// example: Degraded
+	Status string    `json:"status"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	// example: Degraded from 10:02 to 10:17
+	Description string `json:"description"`
+	// Distinct reasons of the status during the period
+	Reasons []string `json:"reasons"`
+}
@@ -2,7 +2,7 @@ package models
 
 // IstioConfigList istioConfigList
 //
-// This type is used for returning a response of IstioConfigList
+// # This type is used for returning a response of IstioConfigList
 //
 // swagger:model IstioConfigList
 type IstioConfigList struct {
@@ -20,6 +20,12 @@ type IstioConfigList struct {
 	AuthorizationPolicies  AuthorizationPolicies  `json:"authorizationPolicies"`
 	PeerAuthentications    PeerAuthentications    `json:"peerAuthentications"`
 	RequestAuthentications RequestAuthentications `json:"requestAuthentications"`
+	Telemetries            Telemetries            `json:"telemetries"`
+	WasmPlugins            WasmPlugins            `json:"wasmPlugins"`
+	K8sGatewayClasses      K8sGatewayClasses      `json:"k8sGatewayClasses"`
+	K8sGateways            K8sGateways            `json:"k8sGateways"`
+	K8sHTTPRoutes          K8sHTTPRoutes          `json:"k8sHTTPRoutes"`
+	K8sTCPRoutes           K8sTCPRoutes           `json:"k8sTCPRoutes"`
 	IstioValidations       IstioValidations       `json:"validations"`
 }
 
@@ -36,10 +42,48 @@ type IstioConfigDetails struct {
 	AuthorizationPolicy   *AuthorizationPolicy   `json:"authorizationPolicy"`
 	PeerAuthentication    *PeerAuthentication    `json:"peerAuthentication"`
 	RequestAuthentication *RequestAuthentication `json:"requestAuthentication"`
+	Telemetry             *Telemetry             `json:"telemetry"`
+	WasmPlugin            *WasmPlugin            `json:"wasmPlugin"`
+	K8sGatewayClass       *K8sGatewayClass       `json:"k8sGatewayClass"`
+	K8sGateway            *K8sGateway            `json:"k8sGateway"`
+	K8sHTTPRoute          *K8sHTTPRoute          `json:"k8sHTTPRoute"`
+	K8sTCPRoute           *K8sTCPRoute           `json:"k8sTCPRoute"`
 	Permissions           ResourcePermissions    `json:"permissions"`
 	IstioValidation       *IstioValidation       `json:"validation"`
 }
 
+// IstioConfigDryRun is the result of creating or updating an Istio object without persisting the change
+type IstioConfigDryRun struct {
+	// The object as it would be stored, with its validation
+	Object IstioConfigDetails `json:"object"`
+	// Validations of the namespace with the change applied
+	Validations IstioValidations `json:"validations"`
+	// Result of the server-side dry-run of the change in Kubernetes
+	ServerDryRun ServerDryRun `json:"serverDryRun"`
+}
+
+// IstioConfigConflict is the response of a change rejected because the Istio object was modified since the expected
+// resourceVersion, or because a server-side apply sets fields owned by another field manager
+type IstioConfigConflict struct {
+	// Reason of the conflict, as reported by Kubernetes
+	Message string `json:"message"`
+	// The current version of the object, missing when it doesn't exist anymore
+	Current *IstioConfigDetails `json:"current,omitempty"`
+}
+
+// ServerDryRun holds the result of sending a change to Kubernetes in dry-run mode, where it goes
+// through validation and admission but it isn't persisted
+type ServerDryRun struct {
+	// True when Kubernetes would accept the change
+	Accepted bool `json:"accepted"`
+	// HTTP status code returned by Kubernetes when the change is rejected
+	Code int32 `json:"code,omitempty"`
+	// Reason of the rejection (i.e. Invalid, AlreadyExists)
+	Reason string `json:"reason,omitempty"`
+	// Message of the rejection
+	Message string `json:"message,omitempty"`
+}
+
 // ResourcePermissions holds permission flags for an object type
 // True means allowed.
 type ResourcePermissions struct {
@@ -0,0 +1,107 @@
+package models
+
+const (
+	RevisionCreate   = "create"
+	RevisionUpdate   = "update"
+	RevisionDelete   = "delete"
+	RevisionRollback = "rollback"
+
+	ConfigChangeAdded   = "added"
+	ConfigChangeRemoved = "removed"
+	ConfigChangeChanged = "changed"
+)
+
+// IstioConfigRevision is a change of an Istio config object made through Kiali
+// swagger:model
+type IstioConfigRevision struct {
+	// Number of the revision, sequential per object and starting at 1
+	// required: true
+	// example: 3
+	Revision int `json:"revision"`
+
+	// Type of the Istio object
+	// required: true
+	// example: virtualservices
+	ObjectType string `json:"objectType"`
+
+	// Name of the Istio object
+	// required: true
+	// example: reviews
+	Name string `json:"name"`
+
+	// Namespace of the Istio object
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// Change performed: create, update, delete or rollback
+	// required: true
+	// example: update
+	Operation string `json:"operation"`
+
+	// User who performed the change
+	// required: true
+	// example: admin
+	User string `json:"user"`
+
+	// When the change was performed, in RFC 3339 format
+	// required: true
+	// example: 2020-11-24T09:12:44Z
+	Timestamp string `json:"timestamp"`
+
+	// Revision restored by a rollback
+	// example: 1
+	RollbackOf int `json:"rollbackOf,omitempty"`
+
+	// The whole object before the change. Empty for a create.
+	Before map[string]interface{} `json:"before,omitempty"`
+
+	// The whole object after the change. Empty for a delete.
+	After map[string]interface{} `json:"after,omitempty"`
+}
+
+// Summary returns the revision without the before and after objects
+func (r IstioConfigRevision) Summary() IstioConfigRevision {
+	r.Before = nil
+	r.After = nil
+	return r
+}
+
+// IstioConfigRevisionDiff lists the differences of an Istio config object between two revisions
+// swagger:model
+type IstioConfigRevisionDiff struct {
+	// Revision compared from
+	// required: true
+	// example: 1
+	From int `json:"from"`
+
+	// Revision compared to
+	// required: true
+	// example: 3
+	To int `json:"to"`
+
+	// Fields of the object that differ after both revisions. Server managed metadata and the status are ignored.
+	// required: true
+	Changes []IstioConfigChange `json:"changes"`
+}
+
+// IstioConfigChange is a field that differs between two revisions of an Istio config object
+type IstioConfigChange struct {
+	// Path of the field
+	// required: true
+	// example: spec/http[0]/route[1]/weight
+	Path string `json:"path"`
+
+	// Kind of the change: added, removed or changed
+	// required: true
+	// example: changed
+	Operation string `json:"operation"`
+
+	// Value in the revision compared from
+	// example: 20
+	From interface{} `json:"from,omitempty"`
+
+	// Value in the revision compared to
+	// example: 50
+	To interface{} `json:"to,omitempty"`
+}
@@ -0,0 +1,59 @@
+package models
+
+// Reasons for an entity to be affected by an Istio object
+const (
+	// The entity is matched by the workload selector of the object
+	ImpactReasonSelector = "selector"
+	// The object has no workload selector and applies to every workload of its namespace
+	ImpactReasonNamespace = "namespace"
+	// The object has no workload selector and belongs to the Istio root namespace, so it applies to the whole mesh
+	ImpactReasonMesh = "mesh"
+	// The entity is a host of the object, or a route destination of a VirtualService
+	ImpactReasonHost = "host"
+	// The workload backs a service affected by the object
+	ImpactReasonBackend = "backend"
+	// The workload is a client of a host of the object: the object is exported to its namespace and its Sidecar imports the host
+	ImpactReasonClient = "client"
+	// The entity is a gateway, or is exposed through a gateway, affected by the object
+	ImpactReasonGateway = "gateway"
+)
+
+// IstioConfigImpact holds the workloads, pods, services and gateways affected by an Istio object,
+// and the current traffic through them.
+//
+// swagger:model IstioConfigImpact
+type IstioConfigImpact struct {
+	// The analyzed Istio object, existing or proposed
+	IstioReference
+	// Workloads whose proxies receive the object configuration or whose traffic is changed by it
+	Workloads []ImpactedEntity `json:"workloads"`
+	// Pods of the affected workloads
+	Pods []ImpactedPod `json:"pods"`
+	// Services whose traffic is changed by the object
+	Services []ImpactedEntity `json:"services"`
+	// Istio Gateways bound to or defined by the object
+	Gateways []ImpactedEntity `json:"gateways"`
+}
+
+// ImpactedEntity is a workload, service or gateway affected by an Istio object
+type ImpactedEntity struct {
+	// example: reviews-v1
+	Name string `json:"name"`
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+	// Why the entity is affected: selector, namespace, mesh, host, backend, client or gateway
+	// example: ["selector"]
+	Reasons []string `json:"reasons"`
+	// Current request rates through the entity, only set when Prometheus could be queried
+	Requests *RequestHealth `json:"requests,omitempty"`
+}
+
+// ImpactedPod is a pod of a workload affected by an Istio object
+type ImpactedPod struct {
+	// example: reviews-v1-545db77b95-8x2vz
+	Name string `json:"name"`
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+	// example: reviews-v1
+	Workload string `json:"workload"`
+}
@@ -0,0 +1,68 @@
+package models
+
+const (
+	ImportStatusInvalid  = "invalid"
+	ImportStatusReady    = "ready"
+	ImportStatusRejected = "rejected"
+	ImportStatusCreated  = "created"
+	ImportStatusUpdated  = "updated"
+	ImportStatusFailed   = "failed"
+	ImportStatusSkipped  = "skipped"
+)
+
+// IstioConfigImport is the result of importing a bundle of Istio objects into a namespace
+// swagger:model
+type IstioConfigImport struct {
+	// True when the bundle was only previewed and nothing was persisted
+	// required: true
+	DryRun bool `json:"dryRun"`
+
+	// True when every object of the bundle was created or updated
+	// required: true
+	Applied bool `json:"applied"`
+
+	// Result of each object, in the order they are applied
+	// required: true
+	Objects []IstioConfigImportResult `json:"objects"`
+
+	// Validations of the namespace with all the objects of the bundle applied
+	Validations IstioValidations `json:"validations"`
+}
+
+// IstioConfigImportResult is the result of importing one document of a bundle
+type IstioConfigImportResult struct {
+	// Position of the document in the bundle, starting at 0
+	// required: true
+	// example: 2
+	Document int `json:"document"`
+
+	// Type of the Istio object
+	// example: virtualservices
+	ObjectType string `json:"objectType,omitempty"`
+
+	// Name of the Istio object
+	// example: reviews
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the Istio object
+	// example: bookinfo
+	Namespace string `json:"namespace,omitempty"`
+
+	// create when the object doesn't exist, update otherwise
+	// example: create
+	Operation string `json:"operation,omitempty"`
+
+	// invalid, ready or rejected in a dry-run; created, updated, failed or skipped otherwise
+	// required: true
+	// example: created
+	Status string `json:"status"`
+
+	// Why the object is invalid, rejected, failed or skipped
+	Message string `json:"message,omitempty"`
+
+	// Kiali validation of the object with the bundle applied
+	Validation *IstioValidation `json:"validation,omitempty"`
+
+	// Result of the server-side dry-run of the object in Kubernetes
+	ServerDryRun *ServerDryRun `json:"serverDryRun,omitempty"`
+}
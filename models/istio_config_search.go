@@ -0,0 +1,35 @@
+package models
+
+// Search criteria reported in the matches of an Istio config search
+const (
+	SearchCriterionHost             = "host"
+	SearchCriterionLabelSelector    = "labelSelector"
+	SearchCriterionWorkloadSelector = "workloadSelector"
+	SearchCriterionField            = "field"
+)
+
+// IstioConfigSearchResults are the Istio objects matching a search across namespaces
+//
+// swagger:model IstioConfigSearchResults
+type IstioConfigSearchResults []IstioConfigSearchResult
+
+// IstioConfigSearchResult is an Istio object matching all the criteria of a search, with the location of each match
+type IstioConfigSearchResult struct {
+	// The matching Istio object
+	IstioReference
+	// Where the object matches the search criteria
+	Matches []IstioConfigMatch `json:"matches"`
+}
+
+// IstioConfigMatch is the location of a search criterion match in an Istio object
+type IstioConfigMatch struct {
+	// Search criterion matched: host, labelSelector, workloadSelector or field
+	// example: host
+	Criterion string `json:"criterion"`
+	// Path of the matching field in the object
+	// example: spec/http[0]/route[0]/destination/host
+	Path string `json:"path"`
+	// Value of the matching field
+	// example: reviews
+	Value interface{} `json:"value,omitempty"`
+}
@@ -0,0 +1,27 @@
+package models
+
+// IstioConfigReferences holds the objects referenced by an Istio object and the Istio objects referencing it.
+//
+// swagger:model IstioConfigReferences
+type IstioConfigReferences struct {
+	// The referenced and referencing objects of this Istio object
+	IstioReference
+	// Istio objects, services and workloads referenced by the object
+	References []IstioReference `json:"references"`
+	// Istio objects referencing the object
+	ReferencedBy []IstioReference `json:"referencedBy"`
+}
+
+// IstioReference is an object in a reference between an Istio object and another object
+type IstioReference struct {
+	// Type of the object: an Istio object type in singular (i.e. virtualservice), service or workload
+	// example: destinationrule
+	ObjectType string `json:"objectType"`
+	// example: reviews
+	Name string `json:"name"`
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+	// Path of the field holding the reference in the referencing object, empty for the referenced object itself
+	// example: spec/http[0]/route[1]/destination/subset
+	Path string `json:"path,omitempty"`
+}
@@ -0,0 +1,113 @@
+package models
+
+const (
+	UpgradeFindingApiVersion = "apiVersion"
+	UpgradeFindingField      = "field"
+	UpgradeFindingMeshConfig = "meshConfig"
+	UpgradeFindingFeature    = "feature"
+)
+
+// IstioUpgradeReadiness reports what needs attention before upgrading the mesh to a target Istio version
+// swagger:model
+type IstioUpgradeReadiness struct {
+	// Istio version the mesh is upgraded to
+	// required: true
+	// example: 1.9.0
+	TargetVersion string `json:"targetVersion"`
+
+	// Istio version of the control plane, as reported by the proxy status
+	// example: 1.8.2
+	ControlPlaneVersion string `json:"controlPlaneVersion"`
+
+	// True when nothing used by the mesh is removed in the target version and all the proxies are supported by it
+	// required: true
+	Ready bool `json:"ready"`
+
+	// Deprecated and removed API versions, fields and features used by the mesh
+	// required: true
+	Findings []UpgradeFinding `json:"findings"`
+
+	// Proxies whose version is skewed from the control plane or not supported by the target version
+	// required: true
+	Proxies []ProxyVersionSkew `json:"proxies"`
+}
+
+// UpgradeFinding is a deprecated or removed API version, field or feature used by the mesh
+type UpgradeFinding struct {
+	// Kind of the finding: apiVersion, field, meshConfig or feature
+	// required: true
+	// example: field
+	Category string `json:"category"`
+
+	// Type of the Istio object where the finding is located, when located in an object
+	// example: virtualservice
+	ObjectType string `json:"objectType,omitempty"`
+
+	// Name of the object where the finding is located
+	// example: reviews
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the object where the finding is located
+	// example: bookinfo
+	Namespace string `json:"namespace,omitempty"`
+
+	// Path of the deprecated or removed field
+	// example: spec/http[0]/mirror_percent
+	Path string `json:"path,omitempty"`
+
+	// Description of the finding
+	// required: true
+	Message string `json:"message"`
+
+	// Error when removed in the target version, warning when only deprecated
+	// required: true
+	// example: warning
+	Severity SeverityLevel `json:"severity"`
+
+	// Istio version where it was deprecated
+	// example: 1.5
+	DeprecatedIn string `json:"deprecatedIn,omitempty"`
+
+	// Istio version where it is removed
+	// example: 1.8
+	RemovedIn string `json:"removedIn,omitempty"`
+
+	// What should be used instead
+	// example: mirrorPercentage
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// ProxyVersionSkew represents a proxy whose version doesn't match the control plane or the target version
+type ProxyVersionSkew struct {
+	// Name of the pod of the proxy
+	// required: true
+	// example: reviews-v1-5b4d6f7c9-x8k2q
+	Pod string `json:"pod"`
+
+	// Namespace of the pod of the proxy
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// Istio version of the proxy
+	// required: true
+	// example: 1.7.4
+	ProxyVersion string `json:"proxyVersion"`
+
+	// Istio version of the control plane the proxy is connected to
+	// example: 1.8.2
+	ControlPlaneVersion string `json:"controlPlaneVersion"`
+
+	// True when the minor version of the proxy differs from the control plane
+	// required: true
+	Skewed bool `json:"skewed"`
+
+	// False when the proxy is more than one minor version behind the target version
+	// required: true
+	SupportedByTarget bool `json:"supportedByTarget"`
+
+	// Error when not supported by the target version, warning otherwise
+	// required: true
+	// example: warning
+	Severity SeverityLevel `json:"severity"`
+}
@@ -56,6 +56,12 @@ type IstioValidation struct {
 
 	// Related objects (only validation errors)
 	References []IstioValidationKey `json:"references"`
+
+	// Checks suppressed through the kiali.io/suppress-checks annotation of the object, as originally reported
+	SuppressedChecks []*IstioCheck `json:"suppressedChecks,omitempty"`
+
+	// Suppression declared in the annotations of the object
+	Suppression *CheckSuppression `json:"suppression,omitempty"`
 }
 
 // IstioCheck represents an individual check.
@@ -99,8 +105,16 @@ var ObjectTypeSingular = map[string]string{
 	"clusterrbacconfigs":     "clusterrbacconfig",
 	"authorizationpolicies":  "authorizationpolicy",
 	"sidecars":               "sidecar",
+	"workloadentries":        "workloadentry",
+	"envoyfilters":           "envoyfilter",
 	"peerauthentications":    "peerauthentication",
 	"requestauthentications": "requestauthentication",
+	"k8sgatewayclasses":      "k8sgatewayclass",
+	"k8sgateways":            "k8sgateway",
+	"k8shttproutes":          "k8shttproute",
+	"k8stcproutes":           "k8stcproute",
+	"telemetries":            "telemetry",
+	"wasmplugins":            "wasmplugin",
 }
 
 var checkDescriptors = map[string]IstioCheck{
@@ -120,6 +134,26 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "KIA0105 This field requires mTLS to be enabled",
 		Severity: ErrorSeverity,
 	},
+	"authorizationpolicy.source.principalnotfound": {
+		Message:  "KIA0106 Service Account not found for this principal",
+		Severity: WarningSeverity,
+	},
+	"authorizationpolicy.source.principalformat": {
+		Message:  "KIA0107 Principal should follow <trust domain>/ns/<namespace>/sa/<service account> form",
+		Severity: WarningSeverity,
+	},
+	"authorizationpolicy.denyall": {
+		Message:  "KIA0108 This policy denies all the traffic to the selected workloads",
+		Severity: Unknown,
+	},
+	"authorizationpolicy.allowdeny.conflict": {
+		Message:  "KIA0109 Same rule found in ALLOW and DENY policies applied to the same workloads. DENY takes precedence",
+		Severity: WarningSeverity,
+	},
+	"authorizationpolicy.source.principaltrustdomain": {
+		Message:  "KIA0110 Trust domain of this principal is not the one of the mesh nor one of its aliases",
+		Severity: Unknown,
+	},
 	"destinationrules.multimatch": {
 		Message:  "KIA0201 More than one DestinationRules for the same host subset combination",
 		Severity: WarningSeverity,
@@ -164,6 +198,22 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "KIA0302 No matching workload found for gateway selector in this namespace",
 		Severity: WarningSeverity,
 	},
+	"gateways.tls.secretnotfound": {
+		Message:  "KIA0303 credentialName secret not found in the namespace of the gateway workload",
+		Severity: ErrorSeverity,
+	},
+	"gateways.tls.modeconflict": {
+		Message:  "KIA0304 Same host port combination is served with a different TLS mode by another Gateway",
+		Severity: ErrorSeverity,
+	},
+	"gateways.tls.missing": {
+		Message:  "KIA0305 HTTPS server requires TLS settings",
+		Severity: ErrorSeverity,
+	},
+	"gateways.tls.httpsredirect": {
+		Message:  "KIA0306 httpsRedirect should only be set on plain-text HTTP servers",
+		Severity: WarningSeverity,
+	},
 	"generic.multimatch.selectorless": {
 		Message:  "KIA0002 More than one selector-less object in the same namespace",
 		Severity: ErrorSeverity,
@@ -196,6 +246,14 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "KIA0601 Port name must follow <protocol>[-suffix] form",
 		Severity: ErrorSeverity,
 	},
+	"service.networkpolicy.unreachable": {
+		Message:  "KIA0602 NetworkPolicies block all the ingress traffic to the pods of this port",
+		Severity: WarningSeverity,
+	},
+	"service.networkpolicy.caller.blocked": {
+		Message:  "KIA0603 NetworkPolicies block the ingress traffic from observed callers to the pods of this port",
+		Severity: WarningSeverity,
+	},
 	"service.deployment.port.mismatch": {
 		Message:  "KIA0701 Deployment exposing same port as Service not found",
 		Severity: WarningSeverity,
@@ -228,6 +286,10 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "KIA1108 Preferred nomenclature: <gateway namespace>/<gateway name>",
 		Severity: Unknown,
 	},
+	"virtualservices.route.networkpolicy.blocked": {
+		Message:  "KIA1109 NetworkPolicies of the destination block traffic from the callers of this route",
+		Severity: WarningSeverity,
+	},
 	"virtualservices.nohost.hostnotfound": {
 		Message:  "KIA1101 DestinationWeight on route doesn't have a valid service (host not found)",
 		Severity: ErrorSeverity,
@@ -256,6 +318,62 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "KIA1107 Subset not found",
 		Severity: WarningSeverity,
 	},
+	"workload.label.app.missing": {
+		Message:  "KIA1201 Workload doesn't have the app label used by Istio",
+		Severity: WarningSeverity,
+	},
+	"workload.label.version.missing": {
+		Message:  "KIA1202 Workload doesn't have the version label used by Istio",
+		Severity: WarningSeverity,
+	},
+	"workload.container.readinessprobe.missing": {
+		Message:  "KIA1203 Container doesn't define a readiness probe",
+		Severity: WarningSeverity,
+	},
+	"workload.container.port.reserved": {
+		Message:  "KIA1204 Container port collides with the ports reserved by the Istio proxy (15000-15090)",
+		Severity: ErrorSeverity,
+	},
+	"workload.sidecar.missing": {
+		Message:  "KIA1205 Pod doesn't have an Istio sidecar but its namespace has sidecar injection enabled",
+		Severity: WarningSeverity,
+	},
+	"workload.proxy.holdapplication.missing": {
+		Message:  "KIA1206 Container calls out at startup but holdApplicationUntilProxyStarts is not set",
+		Severity: WarningSeverity,
+	},
+	"networkpolicy.istiod.xds.blocked": {
+		Message:  "KIA1301 NetworkPolicy blocks the ingress of istiod from the sidecars (port 15012): proxies can't get their configuration",
+		Severity: ErrorSeverity,
+	},
+	"networkpolicy.istiod.webhook.blocked": {
+		Message:  "KIA1302 NetworkPolicy blocks the traffic from the API server to istiod (port 15017): sidecar injection fails",
+		Severity: ErrorSeverity,
+	},
+	"networkpolicy.sidecar.xds.blocked": {
+		Message:  "KIA1303 NetworkPolicy blocks the egress of the sidecars to istiod (port 15012): proxies can't get their configuration",
+		Severity: ErrorSeverity,
+	},
+	"k8sroutes.nogateway": {
+		Message:  "KIA1401 Route references a parent Gateway that doesn't exist",
+		Severity: ErrorSeverity,
+	},
+	"k8sroutes.nobackend": {
+		Message:  "KIA1402 BackendRef references a Service that doesn't exist",
+		Severity: ErrorSeverity,
+	},
+	"k8sgateways.listener.conflict": {
+		Message:  "KIA1403 More than one listener of the Gateway for the same hostname and port",
+		Severity: ErrorSeverity,
+	},
+	"k8sgateways.gatewayclass.notfound": {
+		Message:  "KIA1404 GatewayClass not found",
+		Severity: ErrorSeverity,
+	},
+	"telemetry.metrics.disabled": {
+		Message:  "KIA1501 Telemetry disables metrics used by Kiali to build the graph",
+		Severity: WarningSeverity,
+	},
 	"validation.unable.cross-namespace": {
 		Message:  "KIA0001 Unable to verify the validity, cross-namespace validation is not supported for this field",
 		Severity: Unknown,
@@ -320,6 +438,18 @@ func (iv IstioValidations) FilterByTypes(objectTypes []string) IstioValidations
 	return fiv
 }
 
+// FilterByNamespace returns the validations of the objects of the namespace
+func (iv IstioValidations) FilterByNamespace(namespace string) IstioValidations {
+	fiv := IstioValidations{}
+	for k, v := range iv {
+		if k.Namespace == namespace {
+			fiv[k] = v
+		}
+	}
+
+	return fiv
+}
+
 func (iv IstioValidations) MergeValidations(validations IstioValidations) IstioValidations {
 	for key, validation := range validations {
 		v, ok := iv[key]
@@ -0,0 +1,245 @@
+package models
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	SarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	SarifVersion = "2.1.0"
+)
+
+// SarifLog is the root of a SARIF (Static Analysis Results Interchange Format) 2.1.0 report.
+// Only the subset of the format needed to report Istio validations is modeled.
+type SarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	ID                   string                 `json:"id"`
+	ShortDescription     SarifMessage           `json:"shortDescription"`
+	DefaultConfiguration SarifRuleConfiguration `json:"defaultConfiguration"`
+}
+
+type SarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    SarifMessage      `json:"message"`
+	Locations  []SarifLocation   `json:"locations"`
+	Properties map[string]string `json:"properties"`
+}
+
+type SarifLocation struct {
+	LogicalLocations []SarifLogicalLocation `json:"logicalLocations"`
+}
+
+type SarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// JUnitTestSuites is the root of a JUnit XML report
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+type JUnitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type JUnitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Code returns the Kiali code that identifies the check (i.e. KIA1101)
+func (ic IstioCheck) Code() string {
+	if i := strings.Index(ic.Message, " "); i > 0 && strings.HasPrefix(ic.Message, "KIA") {
+		return ic.Message[:i]
+	}
+	return ic.Message
+}
+
+// Description returns the message of the check without the Kiali code
+func (ic IstioCheck) Description() string {
+	return strings.TrimSpace(strings.TrimPrefix(ic.Message, ic.Code()))
+}
+
+// String returns the namespace/objectType/name form of the key
+func (ik IstioValidationKey) String() string {
+	return fmt.Sprintf("%s/%s/%s", ik.Namespace, ik.ObjectType, ik.Name)
+}
+
+// SortedKeys returns the keys of the validations sorted by namespace, object type and name
+func (iv IstioValidations) SortedKeys() []IstioValidationKey {
+	keys := make([]IstioValidationKey, 0, len(iv))
+	for k := range iv {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Namespace != keys[j].Namespace {
+			return keys[i].Namespace < keys[j].Namespace
+		}
+		if keys[i].ObjectType != keys[j].ObjectType {
+			return keys[i].ObjectType < keys[j].ObjectType
+		}
+		return keys[i].Name < keys[j].Name
+	})
+	return keys
+}
+
+// ToSarif returns the checks of the validations as a SARIF report.
+// Every check is a result whose rule is the Kiali code of the check and whose location is the Istio object.
+// The YAML path of the check is reported in the result properties.
+func (iv IstioValidations) ToSarif() SarifLog {
+	rules := []SarifRule{}
+	ruleIndex := map[string]bool{}
+	results := []SarifResult{}
+
+	for _, key := range iv.SortedKeys() {
+		for _, check := range iv[key].Checks {
+			code := check.Code()
+			if !ruleIndex[code] {
+				ruleIndex[code] = true
+				rules = append(rules, SarifRule{
+					ID:                   code,
+					ShortDescription:     SarifMessage{Text: check.Description()},
+					DefaultConfiguration: SarifRuleConfiguration{Level: sarifLevel(check.Severity)},
+				})
+			}
+			results = append(results, SarifResult{
+				RuleID:  code,
+				Level:   sarifLevel(check.Severity),
+				Message: SarifMessage{Text: check.Message},
+				Locations: []SarifLocation{{
+					LogicalLocations: []SarifLogicalLocation{{
+						Name:               key.Name,
+						FullyQualifiedName: key.String(),
+						Kind:               key.ObjectType,
+					}},
+				}},
+				Properties: map[string]string{
+					"namespace":  key.Namespace,
+					"objectType": key.ObjectType,
+					"name":       key.Name,
+					"path":       check.Path,
+				},
+			})
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+
+	return SarifLog{
+		Schema:  SarifSchema,
+		Version: SarifVersion,
+		Runs: []SarifRun{{
+			Tool: SarifTool{
+				Driver: SarifDriver{
+					Name:           "Kiali",
+					InformationURI: "https://kiali.io",
+					Rules:          rules,
+				},
+			},
+			Results: results,
+		}},
+	}
+}
+
+func sarifLevel(severity SeverityLevel) string {
+	switch severity {
+	case ErrorSeverity:
+		return "error"
+	case WarningSeverity:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// ToJUnit returns the validations as a JUnit report with a test suite per namespace and object type.
+// Every check is a test case of the object, failing when the check is an error. Objects without checks
+// are reported as a single passing test case.
+func (iv IstioValidations) ToJUnit(name string) JUnitTestSuites {
+	report := JUnitTestSuites{Name: name, Suites: []JUnitTestSuite{}}
+	var suite *JUnitTestSuite
+
+	for _, key := range iv.SortedKeys() {
+		suiteName := fmt.Sprintf("%s/%s", key.Namespace, key.ObjectType)
+		if suite == nil || suite.Name != suiteName {
+			report.Suites = append(report.Suites, JUnitTestSuite{Name: suiteName, TestCases: []JUnitTestCase{}})
+			suite = &report.Suites[len(report.Suites)-1]
+		}
+
+		className := key.String()
+		if len(iv[key].Checks) == 0 {
+			suite.TestCases = append(suite.TestCases, JUnitTestCase{ClassName: className, Name: key.Name})
+		}
+		for _, check := range iv[key].Checks {
+			testCase := JUnitTestCase{
+				ClassName: className,
+				Name:      fmt.Sprintf("%s %s", check.Code(), check.Path),
+			}
+			text := fmt.Sprintf("%s %s: %s", key.String(), check.Path, check.Message)
+			if check.Severity == ErrorSeverity {
+				testCase.Failure = &JUnitFailure{Type: string(check.Severity), Message: check.Message, Text: text}
+				suite.Failures++
+			} else {
+				testCase.SystemOut = fmt.Sprintf("%s: %s", strings.ToUpper(string(check.Severity)), text)
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		suite.Tests = len(suite.TestCases)
+	}
+
+	for _, s := range report.Suites {
+		report.Tests += s.Tests
+		report.Failures += s.Failures
+	}
+	return report
+}
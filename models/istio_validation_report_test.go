@@ -0,0 +1,81 @@
+package models
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func reportValidations() IstioValidations {
+	vsKey := BuildKey("virtualservice", "reviews", "bookinfo")
+	drKey := BuildKey("destinationrule", "reviews", "bookinfo")
+	hostNotFound := Build("virtualservices.nohost.hostnotfound", "spec/http[0]/route[0]/destination/host")
+	subsetNotFound := Build("virtualservices.subsetpresent.subsetnotfound", "spec/http[0]/route[1]/destination")
+	return IstioValidations{
+		vsKey: &IstioValidation{Name: "reviews", ObjectType: "virtualservice", Valid: false, Checks: []*IstioCheck{&hostNotFound, &subsetNotFound}},
+		drKey: &IstioValidation{Name: "reviews", ObjectType: "destinationrule", Valid: true, Checks: []*IstioCheck{}},
+	}
+}
+
+func TestIstioCheckCode(t *testing.T) {
+	assert := assert.New(t)
+
+	check := Build("virtualservices.nohost.hostnotfound", "spec/http[0]")
+	assert.Equal("KIA1101", check.Code())
+	assert.Equal("DestinationWeight on route doesn't have a valid service (host not found)", check.Description())
+
+	custom := IstioCheck{Message: "Not a Kiali check"}
+	assert.Equal("Not a Kiali check", custom.Code())
+}
+
+func TestToSarif(t *testing.T) {
+	assert := assert.New(t)
+
+	report := reportValidations().ToSarif()
+	assert.Equal(SarifVersion, report.Version)
+	assert.Len(report.Runs, 1)
+
+	run := report.Runs[0]
+	assert.Equal("Kiali", run.Tool.Driver.Name)
+	assert.Len(run.Tool.Driver.Rules, 2)
+	assert.Equal("KIA1101", run.Tool.Driver.Rules[0].ID)
+	assert.Equal("error", run.Tool.Driver.Rules[0].DefaultConfiguration.Level)
+	assert.Equal("KIA1107", run.Tool.Driver.Rules[1].ID)
+	assert.Equal("warning", run.Tool.Driver.Rules[1].DefaultConfiguration.Level)
+
+	assert.Len(run.Results, 2)
+	result := run.Results[0]
+	assert.Equal("KIA1101", result.RuleID)
+	assert.Equal("error", result.Level)
+	assert.Equal("bookinfo/virtualservice/reviews", result.Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal("spec/http[0]/route[0]/destination/host", result.Properties["path"])
+	assert.Equal("warning", run.Results[1].Level)
+}
+
+func TestToJUnit(t *testing.T) {
+	assert := assert.New(t)
+
+	report := reportValidations().ToJUnit("bookinfo")
+	assert.Equal(3, report.Tests)
+	assert.Equal(1, report.Failures)
+	assert.Len(report.Suites, 2)
+
+	// Suites are sorted by namespace and object type
+	drSuite := report.Suites[0]
+	assert.Equal("bookinfo/destinationrule", drSuite.Name)
+	assert.Equal(1, drSuite.Tests)
+	assert.Nil(drSuite.TestCases[0].Failure)
+
+	vsSuite := report.Suites[1]
+	assert.Equal("bookinfo/virtualservice", vsSuite.Name)
+	assert.Equal(2, vsSuite.Tests)
+	assert.Equal(1, vsSuite.Failures)
+	assert.Equal("KIA1101 spec/http[0]/route[0]/destination/host", vsSuite.TestCases[0].Name)
+	assert.Equal("error", vsSuite.TestCases[0].Failure.Type)
+	assert.Nil(vsSuite.TestCases[1].Failure)
+	assert.Contains(vsSuite.TestCases[1].SystemOut, "WARNING")
+
+	_, err := xml.Marshal(report)
+	assert.NoError(err)
+}
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// SuppressChecksAnnotation lists the checks of the object that are suppressed, as comma separated
+	// Kiali codes (i.e. KIA0201) or check ids (i.e. generic.multimatch.selectorless)
+	SuppressChecksAnnotation = "kiali.io/suppress-checks"
+	// SuppressChecksReasonAnnotation explains why the checks are suppressed
+	SuppressChecksReasonAnnotation = "kiali.io/suppress-checks-reason"
+	// SuppressChecksExpiryAnnotation is the date (2006-01-02) or timestamp (RFC3339) after which the suppression is ignored
+	SuppressChecksExpiryAnnotation = "kiali.io/suppress-checks-expiry"
+	// SuppressChecksActionAnnotation is either "drop" (default) to remove the checks or "downgrade" to report errors as warnings
+	SuppressChecksActionAnnotation = "kiali.io/suppress-checks-action"
+
+	SuppressionActionDrop      = "drop"
+	SuppressionActionDowngrade = "downgrade"
+)
+
+// CheckSuppression represents the checks of an Istio object suppressed by the user
+// swagger:model
+type CheckSuppression struct {
+	// Kiali codes or check ids suppressed
+	// required: true
+	// example: ["KIA0201"]
+	Codes []string `json:"codes"`
+
+	// Why the checks are suppressed
+	// example: Migration in progress
+	Reason string `json:"reason,omitempty"`
+
+	// Time after which the suppression is ignored
+	Expiry *time.Time `json:"expiry,omitempty"`
+
+	// drop or downgrade
+	// example: drop
+	Action string `json:"action"`
+}
+
+// CheckSuppressions holds the suppressions of a set of Istio objects
+type CheckSuppressions map[IstioValidationKey]*CheckSuppression
+
+// ParseCheckSuppression reads the suppression annotations of an Istio object.
+// It returns nil when there is no suppression or when it has expired at the given time.
+func ParseCheckSuppression(annotations map[string]string, now time.Time) *CheckSuppression {
+	value, found := annotations[SuppressChecksAnnotation]
+	if !found {
+		return nil
+	}
+
+	suppression := &CheckSuppression{
+		Codes:  []string{},
+		Reason: annotations[SuppressChecksReasonAnnotation],
+		Action: SuppressionActionDrop,
+	}
+	for _, code := range strings.Split(value, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			suppression.Codes = append(suppression.Codes, code)
+		}
+	}
+	if len(suppression.Codes) == 0 {
+		return nil
+	}
+
+	if strings.EqualFold(strings.TrimSpace(annotations[SuppressChecksActionAnnotation]), SuppressionActionDowngrade) {
+		suppression.Action = SuppressionActionDowngrade
+	}
+
+	if expiry := strings.TrimSpace(annotations[SuppressChecksExpiryAnnotation]); expiry != "" {
+		expiryTime, err := time.Parse(time.RFC3339, expiry)
+		if err != nil {
+			// Date only: the suppression is valid until the end of that day
+			if expiryTime, err = time.Parse("2006-01-02", expiry); err == nil {
+				expiryTime = expiryTime.Add(24 * time.Hour)
+			}
+		}
+		// An unparseable expiry doesn't suppress anything, so a typo can't hide checks forever
+		if err != nil || !now.Before(expiryTime) {
+			return nil
+		}
+		suppression.Expiry = &expiryTime
+	}
+
+	return suppression
+}
+
+// Suppresses returns true if the check is suppressed by its Kiali code or by its check id
+func (cs CheckSuppression) Suppresses(check *IstioCheck) bool {
+	for _, code := range cs.Codes {
+		if strings.EqualFold(code, check.Code()) {
+			return true
+		}
+		if descriptor, found := checkDescriptors[code]; found && descriptor.Message == check.Message {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplySuppressions drops or downgrades the suppressed checks of the validations.
+// Suppressed checks are kept in SuppressedChecks, as they were reported, to keep them auditable.
+func (iv IstioValidations) ApplySuppressions(suppressions CheckSuppressions) IstioValidations {
+	for key, validation := range iv {
+		suppression, found := suppressions[key]
+		if !found {
+			continue
+		}
+
+		checks := make([]*IstioCheck, 0, len(validation.Checks))
+		for _, check := range validation.Checks {
+			if !suppression.Suppresses(check) {
+				checks = append(checks, check)
+				continue
+			}
+			validation.SuppressedChecks = append(validation.SuppressedChecks, check)
+			if suppression.Action == SuppressionActionDowngrade {
+				downgraded := *check
+				if downgraded.Severity == ErrorSeverity {
+					downgraded.Severity = WarningSeverity
+				}
+				checks = append(checks, &downgraded)
+			}
+		}
+
+		if len(validation.SuppressedChecks) > 0 {
+			validation.Checks = checks
+			validation.Suppression = suppression
+			validation.Valid = true
+			for _, check := range checks {
+				if check.Severity == ErrorSeverity {
+					validation.Valid = false
+				}
+			}
+		}
+	}
+	return iv
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCheckSuppression(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2021, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	assert.Nil(ParseCheckSuppression(nil, now))
+	assert.Nil(ParseCheckSuppression(map[string]string{SuppressChecksAnnotation: " , "}, now))
+
+	suppression := ParseCheckSuppression(map[string]string{
+		SuppressChecksAnnotation:       "KIA0201, generic.multimatch.selectorless",
+		SuppressChecksReasonAnnotation: "Migration in progress",
+	}, now)
+	assert.NotNil(suppression)
+	assert.Equal([]string{"KIA0201", "generic.multimatch.selectorless"}, suppression.Codes)
+	assert.Equal("Migration in progress", suppression.Reason)
+	assert.Equal(SuppressionActionDrop, suppression.Action)
+	assert.Nil(suppression.Expiry)
+
+	suppression = ParseCheckSuppression(map[string]string{
+		SuppressChecksAnnotation:       "KIA0201",
+		SuppressChecksExpiryAnnotation: "2021-03-15",
+		SuppressChecksActionAnnotation: "Downgrade",
+	}, now)
+	assert.NotNil(suppression)
+	assert.Equal(SuppressionActionDowngrade, suppression.Action)
+	assert.Equal(time.Date(2021, 3, 16, 0, 0, 0, 0, time.UTC), *suppression.Expiry)
+
+	// Expired suppressions and wrong expiry dates don't suppress anything
+	assert.Nil(ParseCheckSuppression(map[string]string{
+		SuppressChecksAnnotation:       "KIA0201",
+		SuppressChecksExpiryAnnotation: "2021-03-15T11:59:59Z",
+	}, now))
+	assert.Nil(ParseCheckSuppression(map[string]string{
+		SuppressChecksAnnotation:       "KIA0201",
+		SuppressChecksExpiryAnnotation: "next week",
+	}, now))
+}
+
+func TestApplySuppressions(t *testing.T) {
+	assert := assert.New(t)
+
+	vsKey := BuildKey("virtualservice", "reviews", "bookinfo")
+	drKey := BuildKey("destinationrule", "reviews", "bookinfo")
+	validations := func() IstioValidations {
+		hostNotFound := Build("virtualservices.nohost.hostnotfound", "spec/http[0]/route[0]/destination/host")
+		subsetNotFound := Build("virtualservices.subsetpresent.subsetnotfound", "spec/http[0]/route[1]/destination")
+		multiMatch := Build("destinationrules.multimatch", "spec/host")
+		return IstioValidations{
+			vsKey: &IstioValidation{Name: "reviews", ObjectType: "virtualservice", Valid: false, Checks: []*IstioCheck{&hostNotFound, &subsetNotFound}},
+			drKey: &IstioValidation{Name: "reviews", ObjectType: "destinationrule", Valid: true, Checks: []*IstioCheck{&multiMatch}},
+		}
+	}
+
+	// Drop by code and by check id
+	dropped := validations().ApplySuppressions(CheckSuppressions{
+		vsKey: {Codes: []string{"KIA1101"}, Action: SuppressionActionDrop},
+		drKey: {Codes: []string{"destinationrules.multimatch"}, Action: SuppressionActionDrop},
+	})
+	assert.True(dropped[vsKey].Valid)
+	assert.Len(dropped[vsKey].Checks, 1)
+	assert.Equal("KIA1107 Subset not found", dropped[vsKey].Checks[0].Message)
+	assert.Len(dropped[vsKey].SuppressedChecks, 1)
+	assert.Equal(ErrorSeverity, dropped[vsKey].SuppressedChecks[0].Severity)
+	assert.NotNil(dropped[vsKey].Suppression)
+	assert.Empty(dropped[drKey].Checks)
+	assert.Len(dropped[drKey].SuppressedChecks, 1)
+
+	// Downgrade keeps the check as a warning
+	downgraded := validations().ApplySuppressions(CheckSuppressions{
+		vsKey: {Codes: []string{"KIA1101"}, Action: SuppressionActionDowngrade},
+	})
+	assert.True(downgraded[vsKey].Valid)
+	assert.Len(downgraded[vsKey].Checks, 2)
+	assert.Equal(WarningSeverity, downgraded[vsKey].Checks[0].Severity)
+	assert.Equal(ErrorSeverity, downgraded[vsKey].SuppressedChecks[0].Severity)
+	assert.Nil(downgraded[drKey].SuppressedChecks)
+
+	// Suppressions that don't match any check leave the validation untouched
+	untouched := validations().ApplySuppressions(CheckSuppressions{
+		vsKey: {Codes: []string{"KIA0000"}, Action: SuppressionActionDrop},
+	})
+	assert.False(untouched[vsKey].Valid)
+	assert.Len(untouched[vsKey].Checks, 2)
+	assert.Nil(untouched[vsKey].Suppression)
+}
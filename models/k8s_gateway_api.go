@@ -0,0 +1,103 @@
+package models
+
+import "github.com/kiali/kiali/kubernetes"
+
+// Kubernetes Gateway API objects (gateway.networking.k8s.io)
+
+type K8sGatewayClasses []K8sGatewayClass
+type K8sGatewayClass struct {
+	IstioBase
+	Spec struct {
+		ControllerName interface{} `json:"controllerName"`
+		ParametersRef  interface{} `json:"parametersRef"`
+		Description    interface{} `json:"description"`
+	} `json:"spec"`
+}
+
+func (gcs *K8sGatewayClasses) Parse(gatewayClasses []kubernetes.IstioObject) {
+	for _, gc := range gatewayClasses {
+		gatewayClass := K8sGatewayClass{}
+		gatewayClass.Parse(gc)
+		*gcs = append(*gcs, gatewayClass)
+	}
+}
+
+func (gc *K8sGatewayClass) Parse(gatewayClass kubernetes.IstioObject) {
+	gc.IstioBase.Parse(gatewayClass)
+	gc.Spec.ControllerName = gatewayClass.GetSpec()["controllerName"]
+	gc.Spec.ParametersRef = gatewayClass.GetSpec()["parametersRef"]
+	gc.Spec.Description = gatewayClass.GetSpec()["description"]
+}
+
+type K8sGateways []K8sGateway
+type K8sGateway struct {
+	IstioBase
+	Spec struct {
+		GatewayClassName interface{} `json:"gatewayClassName"`
+		Listeners        interface{} `json:"listeners"`
+		Addresses        interface{} `json:"addresses"`
+	} `json:"spec"`
+}
+
+func (gws *K8sGateways) Parse(gateways []kubernetes.IstioObject) {
+	for _, gw := range gateways {
+		gateway := K8sGateway{}
+		gateway.Parse(gw)
+		*gws = append(*gws, gateway)
+	}
+}
+
+func (gw *K8sGateway) Parse(gateway kubernetes.IstioObject) {
+	gw.IstioBase.Parse(gateway)
+	gw.Spec.GatewayClassName = gateway.GetSpec()["gatewayClassName"]
+	gw.Spec.Listeners = gateway.GetSpec()["listeners"]
+	gw.Spec.Addresses = gateway.GetSpec()["addresses"]
+}
+
+type K8sHTTPRoutes []K8sHTTPRoute
+type K8sHTTPRoute struct {
+	IstioBase
+	Spec struct {
+		ParentRefs interface{} `json:"parentRefs"`
+		Hostnames  interface{} `json:"hostnames"`
+		Rules      interface{} `json:"rules"`
+	} `json:"spec"`
+}
+
+func (rs *K8sHTTPRoutes) Parse(routes []kubernetes.IstioObject) {
+	for _, r := range routes {
+		route := K8sHTTPRoute{}
+		route.Parse(r)
+		*rs = append(*rs, route)
+	}
+}
+
+func (r *K8sHTTPRoute) Parse(route kubernetes.IstioObject) {
+	r.IstioBase.Parse(route)
+	r.Spec.ParentRefs = route.GetSpec()["parentRefs"]
+	r.Spec.Hostnames = route.GetSpec()["hostnames"]
+	r.Spec.Rules = route.GetSpec()["rules"]
+}
+
+type K8sTCPRoutes []K8sTCPRoute
+type K8sTCPRoute struct {
+	IstioBase
+	Spec struct {
+		ParentRefs interface{} `json:"parentRefs"`
+		Rules      interface{} `json:"rules"`
+	} `json:"spec"`
+}
+
+func (rs *K8sTCPRoutes) Parse(routes []kubernetes.IstioObject) {
+	for _, r := range routes {
+		route := K8sTCPRoute{}
+		route.Parse(r)
+		*rs = append(*rs, route)
+	}
+}
+
+func (r *K8sTCPRoute) Parse(route kubernetes.IstioObject) {
+	r.IstioBase.Parse(route)
+	r.Spec.ParentRefs = route.GetSpec()["parentRefs"]
+	r.Spec.Rules = route.GetSpec()["rules"]
+}
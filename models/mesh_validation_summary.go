@@ -0,0 +1,146 @@
+package models
+
+import (
+	"sort"
+)
+
+// MeshValidationSummary represents the validations of all the namespaces of the mesh
+// swagger:model
+type MeshValidationSummary struct {
+	// Validation summary of every namespace
+	// required: true
+	Namespaces map[string]IstioValidationSummary `json:"namespaces"`
+
+	// Validation summary of the whole mesh
+	// required: true
+	Total IstioValidationSummary `json:"total"`
+
+	// Most common checks found in the mesh, from the most to the least common
+	// required: true
+	TopChecks []CheckCodeCount `json:"topChecks"`
+
+	// Objects with more errors and warnings, from the worst to the best
+	// required: true
+	WorstObjects []ObjectValidationCount `json:"worstObjects"`
+}
+
+// CheckCodeCount represents how many times a check is found in the mesh
+type CheckCodeCount struct {
+	// Kiali code of the check
+	// required: true
+	// example: KIA1101
+	Code string `json:"code"`
+
+	// Description of the check
+	// example: DestinationWeight on route doesn't have a valid service (host not found)
+	Message string `json:"message"`
+
+	// Severity of the check
+	// example: error
+	Severity SeverityLevel `json:"severity"`
+
+	// Number of times the check is found
+	// required: true
+	// example: 7
+	Count int `json:"count"`
+
+	// Number of namespaces where the check is found
+	// required: true
+	// example: 2
+	Namespaces int `json:"namespaces"`
+}
+
+// ObjectValidationCount represents the number of errors and warnings of an Istio object
+type ObjectValidationCount struct {
+	IstioValidationKey
+
+	// Number of checks with error severity
+	// required: true
+	// example: 2
+	Errors int `json:"errors"`
+
+	// Number of checks with warning severity
+	// required: true
+	// example: 1
+	Warnings int `json:"warnings"`
+
+	// Kiali codes of the checks of the object
+	// example: ["KIA1101"]
+	Codes []string `json:"codes"`
+}
+
+// SummarizeMeshValidations summarizes the validations of every namespace. The validations of each namespace are
+// expected to hold only objects of that namespace. Up to limit checks and objects are ranked, all of them if limit <= 0.
+func SummarizeMeshValidations(validations NamespaceValidations, limit int) MeshValidationSummary {
+	summary := MeshValidationSummary{
+		Namespaces:   make(map[string]IstioValidationSummary, len(validations)),
+		TopChecks:    []CheckCodeCount{},
+		WorstObjects: []ObjectValidationCount{},
+	}
+
+	checks := map[string]*CheckCodeCount{}
+	checkNamespaces := map[string]map[string]bool{}
+	for ns, nsValidations := range validations {
+		nsSummary := nsValidations.SummarizeValidation(ns)
+		summary.Namespaces[ns] = nsSummary
+		summary.Total.Errors += nsSummary.Errors
+		summary.Total.Warnings += nsSummary.Warnings
+		summary.Total.ObjectCount += nsSummary.ObjectCount
+
+		for key, validation := range nsValidations {
+			object := ObjectValidationCount{IstioValidationKey: key, Codes: []string{}}
+			for _, check := range validation.Checks {
+				code := check.Code()
+				if _, found := checks[code]; !found {
+					checks[code] = &CheckCodeCount{Code: code, Message: check.Description(), Severity: check.Severity}
+					checkNamespaces[code] = map[string]bool{}
+				}
+				checks[code].Count++
+				checkNamespaces[code][ns] = true
+
+				switch check.Severity {
+				case ErrorSeverity:
+					object.Errors++
+				case WarningSeverity:
+					object.Warnings++
+				}
+				object.Codes = append(object.Codes, code)
+			}
+			if object.Errors+object.Warnings > 0 {
+				summary.WorstObjects = append(summary.WorstObjects, object)
+			}
+		}
+	}
+
+	for code, check := range checks {
+		check.Namespaces = len(checkNamespaces[code])
+		summary.TopChecks = append(summary.TopChecks, *check)
+	}
+	sort.Slice(summary.TopChecks, func(i, j int) bool {
+		if summary.TopChecks[i].Count != summary.TopChecks[j].Count {
+			return summary.TopChecks[i].Count > summary.TopChecks[j].Count
+		}
+		return summary.TopChecks[i].Code < summary.TopChecks[j].Code
+	})
+
+	sort.Slice(summary.WorstObjects, func(i, j int) bool {
+		oi, oj := summary.WorstObjects[i], summary.WorstObjects[j]
+		if oi.Errors != oj.Errors {
+			return oi.Errors > oj.Errors
+		}
+		if oi.Warnings != oj.Warnings {
+			return oi.Warnings > oj.Warnings
+		}
+		return oi.IstioValidationKey.String() < oj.IstioValidationKey.String()
+	})
+
+	if limit > 0 {
+		if len(summary.TopChecks) > limit {
+			summary.TopChecks = summary.TopChecks[:limit]
+		}
+		if len(summary.WorstObjects) > limit {
+			summary.WorstObjects = summary.WorstObjects[:limit]
+		}
+	}
+	return summary
+}
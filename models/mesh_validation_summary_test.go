@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeMeshValidations(t *testing.T) {
+	assert := assert.New(t)
+
+	hostNotFound := &IstioCheck{Message: "KIA1101 DestinationWeight on route doesn't have a valid service (host not found)", Severity: ErrorSeverity}
+	subsetNotFound := &IstioCheck{Message: "KIA1107 Subset not found", Severity: WarningSeverity}
+	multiMatch := &IstioCheck{Message: "KIA0201 More than one DestinationRules for the same host subset combination", Severity: WarningSeverity}
+
+	validations := NamespaceValidations{
+		"bookinfo": IstioValidations{
+			BuildKey("virtualservice", "reviews", "bookinfo"):  {Checks: []*IstioCheck{hostNotFound, subsetNotFound}},
+			BuildKey("virtualservice", "ratings", "bookinfo"):  {Checks: []*IstioCheck{subsetNotFound}},
+			BuildKey("destinationrule", "details", "bookinfo"): {Checks: []*IstioCheck{}},
+		},
+		"travels": IstioValidations{
+			BuildKey("virtualservice", "cars", "travels"):    {Checks: []*IstioCheck{hostNotFound, hostNotFound}},
+			BuildKey("destinationrule", "cars", "travels"):   {Checks: []*IstioCheck{multiMatch}},
+			BuildKey("destinationrule", "hotels", "travels"): {Checks: []*IstioCheck{multiMatch, subsetNotFound}},
+		},
+		"empty": IstioValidations{},
+	}
+
+	summary := SummarizeMeshValidations(validations, 0)
+	assert.Equal(IstioValidationSummary{Errors: 1, Warnings: 2, ObjectCount: 3}, summary.Namespaces["bookinfo"])
+	assert.Equal(IstioValidationSummary{Errors: 2, Warnings: 3, ObjectCount: 3}, summary.Namespaces["travels"])
+	assert.Equal(IstioValidationSummary{}, summary.Namespaces["empty"])
+	assert.Equal(IstioValidationSummary{Errors: 3, Warnings: 5, ObjectCount: 6}, summary.Total)
+
+	assert.Len(summary.TopChecks, 3)
+	assert.Equal(CheckCodeCount{Code: "KIA1101", Message: "DestinationWeight on route doesn't have a valid service (host not found)", Severity: ErrorSeverity, Count: 3, Namespaces: 2}, summary.TopChecks[0])
+	assert.Equal("KIA1107", summary.TopChecks[1].Code)
+	assert.Equal(3, summary.TopChecks[1].Count)
+	assert.Equal("KIA0201", summary.TopChecks[2].Code)
+	assert.Equal(1, summary.TopChecks[2].Namespaces)
+
+	assert.Len(summary.WorstObjects, 5)
+	assert.Equal(BuildKey("virtualservice", "cars", "travels"), summary.WorstObjects[0].IstioValidationKey)
+	assert.Equal([]string{"KIA1101", "KIA1101"}, summary.WorstObjects[0].Codes)
+	assert.Equal(BuildKey("virtualservice", "reviews", "bookinfo"), summary.WorstObjects[1].IstioValidationKey)
+	assert.Equal(BuildKey("destinationrule", "hotels", "travels"), summary.WorstObjects[2].IstioValidationKey)
+	assert.Equal(BuildKey("virtualservice", "ratings", "bookinfo"), summary.WorstObjects[3].IstioValidationKey)
+	assert.Equal(BuildKey("destinationrule", "cars", "travels"), summary.WorstObjects[4].IstioValidationKey)
+
+	summary = SummarizeMeshValidations(validations, 2)
+	assert.Len(summary.TopChecks, 2)
+	assert.Len(summary.WorstObjects, 2)
+}
@@ -7,3 +7,100 @@ type MTLSStatus struct {
 	// example: MTLS_ENABLED
 	Status string `json:"status"`
 }
+
+// WorkloadMTLSStatus describes the effective mTLS status of a workload
+type WorkloadMTLSStatus struct {
+	// Name of the workload
+	// required: true
+	// example: reviews-v1
+	Workload string `json:"workload"`
+
+	// Namespace of the workload
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// Overall mTLS status of the ports of the workload: MTLS_ENABLED, MTLS_PARTIALLY_ENABLED, MTLS_NOT_ENABLED, MTLS_DISABLED
+	// required: true
+	// example: MTLS_ENABLED
+	Status string `json:"status"`
+
+	// Whether the workload has an Istio sidecar. Workloads without sidecar don't take part on mTLS.
+	// required: true
+	IstioSidecar bool `json:"istioSidecar"`
+
+	// Effective mTLS status of every port of the services of the workload
+	// required: true
+	Ports []PortMTLSStatus `json:"ports"`
+}
+
+// PortMTLSStatus describes the effective mTLS status of a port of a workload
+type PortMTLSStatus struct {
+	// Service exposing the port. Empty when the workload has no services.
+	// example: reviews
+	Service string `json:"service,omitempty"`
+
+	// Port of the service
+	// example: 9080
+	Port int32 `json:"port,omitempty"`
+
+	// Port of the workload. Empty when the service targets a named port that no container of the workload defines.
+	// example: 9080
+	TargetPort int32 `json:"targetPort,omitempty"`
+
+	// Name of the port of the workload, when the service targets it by name
+	// example: http
+	TargetPortName string `json:"targetPortName,omitempty"`
+
+	// mTLS status of the port: MTLS_ENABLED, MTLS_PARTIALLY_ENABLED, MTLS_NOT_ENABLED, MTLS_DISABLED
+	// required: true
+	// example: MTLS_ENABLED
+	Status string `json:"status"`
+
+	// True when the workload and its clients don't agree on mTLS, so traffic is rejected
+	// required: true
+	Conflict bool `json:"conflict"`
+
+	// Mode accepted by the workload: STRICT, PERMISSIVE or DISABLE
+	// required: true
+	// example: STRICT
+	ServerMode string `json:"serverMode"`
+
+	// PeerAuthentication deciding the mode accepted by the workload. Empty when the Istio default applies.
+	ServerPolicy *MTLSPolicyRef `json:"serverPolicy,omitempty"`
+
+	// TLS mode used by the clients: ISTIO_MUTUAL, MUTUAL, SIMPLE or DISABLE
+	// required: true
+	// example: ISTIO_MUTUAL
+	ClientMode string `json:"clientMode"`
+
+	// DestinationRule deciding the mode used by the clients. Empty when it is decided by auto mTLS.
+	ClientPolicy *MTLSPolicyRef `json:"clientPolicy,omitempty"`
+
+	// True when the mode used by the clients is decided by auto mTLS
+	// required: true
+	AutoMtls bool `json:"autoMtls"`
+}
+
+// MTLSPolicyRef references the Istio object deciding an mTLS mode
+type MTLSPolicyRef struct {
+	// Type of the object: peerauthentication or destinationrule
+	// required: true
+	// example: peerauthentication
+	ObjectType string `json:"objectType"`
+
+	// Name of the object
+	// required: true
+	// example: default
+	Name string `json:"name"`
+
+	// Namespace of the object
+	// required: true
+	// example: istio-system
+	Namespace string `json:"namespace"`
+
+	// Scope where the object applies: mesh, namespace, workload, port or service
+	// required: true
+	// example: mesh
+	Scope string `json:"scope"`
+}
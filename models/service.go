@@ -45,6 +45,8 @@ type ServiceDetails struct {
 	Endpoints         Endpoints         `json:"endpoints"`
 	VirtualServices   VirtualServices   `json:"virtualServices"`
 	DestinationRules  DestinationRules  `json:"destinationRules"`
+	K8sHTTPRoutes     K8sHTTPRoutes     `json:"k8sHTTPRoutes"`
+	K8sTCPRoutes      K8sTCPRoutes      `json:"k8sTCPRoutes"`
 	Workloads         WorkloadOverviews `json:"workloads"`
 	Health            ServiceHealth     `json:"health"`
 	Validations       IstioValidations  `json:"validations"`
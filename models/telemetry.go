@@ -0,0 +1,45 @@
+package models
+
+import (
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// Telemetries telemetries
+//
+// This is used for returning an array of Telemetry
+//
+// swagger:model telemetries
+// An array of telemetry
+// swagger:allOf
+type Telemetries []Telemetry
+
+// Telemetry telemetry
+//
+// This is used for returning a Telemetry
+//
+// swagger:model telemetry
+type Telemetry struct {
+	IstioBase
+	Spec struct {
+		Selector      interface{} `json:"selector"`
+		Tracing       interface{} `json:"tracing"`
+		Metrics       interface{} `json:"metrics"`
+		AccessLogging interface{} `json:"accessLogging"`
+	} `json:"spec"`
+}
+
+func (ts *Telemetries) Parse(telemetries []kubernetes.IstioObject) {
+	for _, t := range telemetries {
+		telemetry := Telemetry{}
+		telemetry.Parse(t)
+		*ts = append(*ts, telemetry)
+	}
+}
+
+func (t *Telemetry) Parse(telemetry kubernetes.IstioObject) {
+	t.IstioBase.Parse(telemetry)
+	t.Spec.Selector = telemetry.GetSpec()["selector"]
+	t.Spec.Tracing = telemetry.GetSpec()["tracing"]
+	t.Spec.Metrics = telemetry.GetSpec()["metrics"]
+	t.Spec.AccessLogging = telemetry.GetSpec()["accessLogging"]
+}
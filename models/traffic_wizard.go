@@ -0,0 +1,108 @@
+package models
+
+const (
+	// Label set in the VirtualServices and DestinationRules generated by a wizard, with the type of wizard as value
+	WizardLabel = "kiali_wizard"
+
+	WizardWeightedRouting = "weighted_routing"
+	WizardRequestRouting  = "request_routing"
+	WizardFaultInjection  = "fault_injection"
+)
+
+// TrafficWizard is the intent to shape the traffic of a service. It generates the VirtualService and DestinationRule
+// of the service, both named after it.
+// swagger:model
+type TrafficWizard struct {
+	// weighted_routing, request_routing or fault_injection
+	// required: true
+	// example: weighted_routing
+	Type string `json:"type"`
+
+	// Split of the traffic between versions. For request_routing it's the route of the requests not matched,
+	// and for fault_injection the route of the requests, which go to every version when it's empty.
+	Routes []TrafficWizardRoute `json:"routes,omitempty"`
+
+	// Requests routed to a version by their headers, in order. Only for request_routing.
+	Matches []TrafficWizardMatch `json:"matches,omitempty"`
+
+	// Faults injected in the requests. Only for fault_injection.
+	Fault *TrafficWizardFault `json:"fault,omitempty"`
+}
+
+// TrafficWizardRoute is the share of traffic of a version of the service
+type TrafficWizardRoute struct {
+	// Value of the version label of the workloads
+	// required: true
+	// example: v1
+	Version string `json:"version"`
+
+	// Percentage of the traffic. Weights of all the routes add up to 100.
+	// required: true
+	// example: 80
+	Weight int `json:"weight"`
+}
+
+// TrafficWizardMatch routes the requests with the given headers to a version of the service
+type TrafficWizardMatch struct {
+	// Exact values of the headers of the request
+	// required: true
+	// example: {"end-user": "jason"}
+	Headers map[string]string `json:"headers"`
+
+	// Value of the version label of the workloads
+	// required: true
+	// example: v2
+	Version string `json:"version"`
+}
+
+// TrafficWizardFault describes the faults injected in the requests. At least one of abort and delay is required.
+type TrafficWizardFault struct {
+	Abort *TrafficWizardAbort `json:"abort,omitempty"`
+	Delay *TrafficWizardDelay `json:"delay,omitempty"`
+}
+
+// TrafficWizardAbort aborts a percentage of the requests with an HTTP status
+type TrafficWizardAbort struct {
+	// Percentage of the requests aborted, from 0 (excluded) to 100
+	// required: true
+	// example: 5
+	Percentage float64 `json:"percentage"`
+
+	// HTTP status returned
+	// required: true
+	// example: 503
+	HttpStatus int `json:"httpStatus"`
+}
+
+// TrafficWizardDelay delays a percentage of the requests
+type TrafficWizardDelay struct {
+	// Percentage of the requests delayed, from 0 (excluded) to 100
+	// required: true
+	// example: 10
+	Percentage float64 `json:"percentage"`
+
+	// Delay, as a duration
+	// required: true
+	// example: 5s
+	FixedDelay string `json:"fixedDelay"`
+}
+
+// TrafficWizardResult is the result of applying a wizard to a service
+// swagger:model
+type TrafficWizardResult struct {
+	// Wizard applied
+	// required: true
+	Wizard TrafficWizard `json:"wizard"`
+
+	// Generated DestinationRule
+	// required: true
+	DestinationRule map[string]interface{} `json:"destinationRule"`
+
+	// Generated VirtualService
+	// required: true
+	VirtualService map[string]interface{} `json:"virtualService"`
+
+	// Result of applying the generated objects. Nothing is applied when Kiali validations find errors in them.
+	// required: true
+	Import IstioConfigImport `json:"import"`
+}
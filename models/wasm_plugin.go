@@ -0,0 +1,55 @@
+package models
+
+import (
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// WasmPlugins wasmPlugins
+//
+// This is used for returning an array of WasmPlugin
+//
+// swagger:model wasmPlugins
+// An array of wasmPlugin
+// swagger:allOf
+type WasmPlugins []WasmPlugin
+
+// WasmPlugin wasmPlugin
+//
+// This is used for returning a WasmPlugin
+//
+// swagger:model wasmPlugin
+type WasmPlugin struct {
+	IstioBase
+	Spec struct {
+		Selector        interface{} `json:"selector"`
+		Url             interface{} `json:"url"`
+		Sha256          interface{} `json:"sha256"`
+		ImagePullPolicy interface{} `json:"imagePullPolicy"`
+		ImagePullSecret interface{} `json:"imagePullSecret"`
+		PluginConfig    interface{} `json:"pluginConfig"`
+		PluginName      interface{} `json:"pluginName"`
+		Phase           interface{} `json:"phase"`
+		Priority        interface{} `json:"priority"`
+	} `json:"spec"`
+}
+
+func (wps *WasmPlugins) Parse(wasmPlugins []kubernetes.IstioObject) {
+	for _, wp := range wasmPlugins {
+		wasmPlugin := WasmPlugin{}
+		wasmPlugin.Parse(wp)
+		*wps = append(*wps, wasmPlugin)
+	}
+}
+
+func (wp *WasmPlugin) Parse(wasmPlugin kubernetes.IstioObject) {
+	wp.IstioBase.Parse(wasmPlugin)
+	wp.Spec.Selector = wasmPlugin.GetSpec()["selector"]
+	wp.Spec.Url = wasmPlugin.GetSpec()["url"]
+	wp.Spec.Sha256 = wasmPlugin.GetSpec()["sha256"]
+	wp.Spec.ImagePullPolicy = wasmPlugin.GetSpec()["imagePullPolicy"]
+	wp.Spec.ImagePullSecret = wasmPlugin.GetSpec()["imagePullSecret"]
+	wp.Spec.PluginConfig = wasmPlugin.GetSpec()["pluginConfig"]
+	wp.Spec.PluginName = wasmPlugin.GetSpec()["pluginName"]
+	wp.Spec.Phase = wasmPlugin.GetSpec()["phase"]
+	wp.Spec.Priority = wasmPlugin.GetSpec()["priority"]
+}
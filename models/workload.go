@@ -112,6 +112,15 @@ type Workload struct {
 
 	// Additional details to display, such as configured annotations
 	AdditionalDetails []AdditionalItem `json:"additionalDetails"`
+
+	// Effective mTLS status of the workload
+	MTLSStatus *WorkloadMTLSStatus `json:"mtlsStatus,omitempty"`
+
+	// Validations of the workload
+	Validations IstioValidations `json:"validations,omitempty"`
+
+	// Kubernetes Gateway API Gateway deployed by this workload
+	K8sGateway *K8sGateway `json:"k8sGateway,omitempty"`
 }
 
 type Workloads []*Workload
@@ -24,12 +24,17 @@ type ClientInterface interface {
 	FetchRange(metricName, labels, grouping, aggregator string, q *RangeQuery) Metric
 	FetchRateRange(metricName string, labels []string, grouping string, q *RangeQuery) Metric
 	GetAllRequestRates(namespace, ratesInterval string, queryTime time.Time) (model.Vector, error)
+	GetAllRequestRatesRange(namespace, ratesInterval string, bounds prom_v1.Range) (model.Matrix, error)
 	GetAppRequestRates(namespace, app, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error)
+	GetAppRequestRatesRange(namespace, app, ratesInterval string, bounds prom_v1.Range) (model.Matrix, model.Matrix, error)
 	GetConfiguration() (prom_v1.ConfigResult, error)
 	GetFlags() (prom_v1.FlagsResult, error)
 	GetNamespaceServicesRequestRates(namespace, ratesInterval string, queryTime time.Time) (model.Vector, error)
+	GetNamespaceServicesRequestRatesRange(namespace, ratesInterval string, bounds prom_v1.Range) (model.Matrix, error)
 	GetServiceRequestRates(namespace, service, ratesInterval string, queryTime time.Time) (model.Vector, error)
+	GetServiceRequestRatesRange(namespace, service, ratesInterval string, bounds prom_v1.Range) (model.Matrix, error)
 	GetWorkloadRequestRates(namespace, workload, ratesInterval string, queryTime time.Time) (model.Vector, model.Vector, error)
+	GetWorkloadRequestRatesRange(namespace, workload, ratesInterval string, bounds prom_v1.Range) (model.Matrix, model.Matrix, error)
 	GetMetricsForLabels(labels []string) ([]string, error)
 }
 
@@ -210,6 +215,44 @@ func (in *Client) GetWorkloadRequestRates(namespace, workload, ratesInterval str
 	return inResult, outResult, nil
 }
 
+// GetAllRequestRatesRange queries Prometheus to fetch the request counter rates of GetAllRequestRates at each step of
+// a time range, each rate computed over ratesInterval. Results are not cached.
+func (in *Client) GetAllRequestRatesRange(namespace, ratesInterval string, bounds prom_v1.Range) (model.Matrix, error) {
+	log.Tracef("GetAllRequestRatesRange [namespace: %s] [ratesInterval: %s] [bounds: %v]", namespace, ratesInterval, bounds)
+	return getAllRequestRatesRange(in.api, namespace, bounds, ratesInterval)
+}
+
+// GetNamespaceServicesRequestRatesRange queries Prometheus to fetch the request counter rates of
+// GetNamespaceServicesRequestRates at each step of a time range, each rate computed over ratesInterval.
+// Results are not cached.
+func (in *Client) GetNamespaceServicesRequestRatesRange(namespace, ratesInterval string, bounds prom_v1.Range) (model.Matrix, error) {
+	log.Tracef("GetNamespaceServicesRequestRatesRange [namespace: %s] [ratesInterval: %s] [bounds: %v]", namespace, ratesInterval, bounds)
+	return getNamespaceServicesRequestRatesRange(in.api, namespace, bounds, ratesInterval)
+}
+
+// GetServiceRequestRatesRange queries Prometheus to fetch the request counter rates of GetServiceRequestRates at each
+// step of a time range, each rate computed over ratesInterval. Results are not cached.
+func (in *Client) GetServiceRequestRatesRange(namespace, service, ratesInterval string, bounds prom_v1.Range) (model.Matrix, error) {
+	log.Tracef("GetServiceRequestRatesRange [namespace: %s] [service: %s] [ratesInterval: %s] [bounds: %v]", namespace, service, ratesInterval, bounds)
+	return getServiceRequestRatesRange(in.api, namespace, service, bounds, ratesInterval)
+}
+
+// GetAppRequestRatesRange queries Prometheus to fetch the request counter rates of GetAppRequestRates at each step of
+// a time range, each rate computed over ratesInterval. Results are not cached.
+// Returns (in, out, error)
+func (in *Client) GetAppRequestRatesRange(namespace, app, ratesInterval string, bounds prom_v1.Range) (model.Matrix, model.Matrix, error) {
+	log.Tracef("GetAppRequestRatesRange [namespace: %s] [app: %s] [ratesInterval: %s] [bounds: %v]", namespace, app, ratesInterval, bounds)
+	return getItemRequestRatesRange(in.api, namespace, app, "app", bounds, ratesInterval)
+}
+
+// GetWorkloadRequestRatesRange queries Prometheus to fetch the request counter rates of GetWorkloadRequestRates at
+// each step of a time range, each rate computed over ratesInterval. Results are not cached.
+// Returns (in, out, error)
+func (in *Client) GetWorkloadRequestRatesRange(namespace, workload, ratesInterval string, bounds prom_v1.Range) (model.Matrix, model.Matrix, error) {
+	log.Tracef("GetWorkloadRequestRatesRange [namespace: %s] [workload: %s] [ratesInterval: %s] [bounds: %v]", namespace, workload, ratesInterval, bounds)
+	return getItemRequestRatesRange(in.api, namespace, workload, "workload", bounds, ratesInterval)
+}
+
 // FetchRange fetches a simple metric (gauge or counter) in given range
 func (in *Client) FetchRange(metricName, labels, grouping, aggregator string, q *RangeQuery) Metric {
 	query := fmt.Sprintf("%s(%s%s)", aggregator, metricName, labels)
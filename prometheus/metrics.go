@@ -173,6 +173,59 @@ func getRequestRatesForLabel(api prom_v1.API, time time.Time, labels, ratesInter
 	return result.(model.Vector), nil
 }
 
+// getAllRequestRatesRange retrieves, at each step of the range, the traffic rates of getAllRequestRates
+func getAllRequestRatesRange(api prom_v1.API, namespace string, bounds prom_v1.Range, ratesInterval string) (model.Matrix, error) {
+	lbl := fmt.Sprintf(`destination_service_namespace="%s",source_workload_namespace!="%s"`, namespace, namespace)
+	fromOutside, err := getRequestRatesRangeForLabel(api, bounds, lbl, ratesInterval)
+	if err != nil {
+		return model.Matrix{}, err
+	}
+	lbl = fmt.Sprintf(`source_workload_namespace="%s"`, namespace)
+	fromInside, err := getRequestRatesRangeForLabel(api, bounds, lbl, ratesInterval)
+	if err != nil {
+		return model.Matrix{}, err
+	}
+	return append(fromOutside, fromInside...), nil
+}
+
+// getNamespaceServicesRequestRatesRange retrieves, at each step of the range, the traffic rates of getNamespaceServicesRequestRates
+func getNamespaceServicesRequestRatesRange(api prom_v1.API, namespace string, bounds prom_v1.Range, ratesInterval string) (model.Matrix, error) {
+	lblNs := fmt.Sprintf(`destination_service_namespace="%s"`, namespace)
+	return getRequestRatesRangeForLabel(api, bounds, lblNs, ratesInterval)
+}
+
+// getServiceRequestRatesRange retrieves, at each step of the range, the traffic rates of getServiceRequestRates
+func getServiceRequestRatesRange(api prom_v1.API, namespace, service string, bounds prom_v1.Range, ratesInterval string) (model.Matrix, error) {
+	lbl := fmt.Sprintf(`destination_service_name="%s",destination_service_namespace="%s"`, service, namespace)
+	return getRequestRatesRangeForLabel(api, bounds, lbl, ratesInterval)
+}
+
+// getItemRequestRatesRange retrieves, at each step of the range, the traffic rates of getItemRequestRates
+func getItemRequestRatesRange(api prom_v1.API, namespace, item, itemLabelSuffix string, bounds prom_v1.Range, ratesInterval string) (model.Matrix, model.Matrix, error) {
+	lblIn := fmt.Sprintf(`destination_workload_namespace="%s",destination_%s="%s"`, namespace, itemLabelSuffix, item)
+	lblOut := fmt.Sprintf(`source_workload_namespace="%s",source_%s="%s"`, namespace, itemLabelSuffix, item)
+	in, err := getRequestRatesRangeForLabel(api, bounds, lblIn, ratesInterval)
+	if err != nil {
+		return model.Matrix{}, model.Matrix{}, err
+	}
+	out, err := getRequestRatesRangeForLabel(api, bounds, lblOut, ratesInterval)
+	if err != nil {
+		return model.Matrix{}, model.Matrix{}, err
+	}
+	return in, out, nil
+}
+
+func getRequestRatesRangeForLabel(api prom_v1.API, bounds prom_v1.Range, labels, ratesInterval string) (model.Matrix, error) {
+	query := fmt.Sprintf("rate(istio_requests_total{%s}[%s]) > 0", labels, ratesInterval)
+	promtimer := internalmetrics.GetPrometheusProcessingTimePrometheusTimer("Metrics-GetRequestRatesRange")
+	result, err := api.QueryRange(context.Background(), query, bounds)
+	if err != nil {
+		return model.Matrix{}, err
+	}
+	promtimer.ObserveDuration() // notice we only collect metrics for successful prom queries
+	return result.(model.Matrix), nil
+}
+
 // roundSignificant will output promQL that performs rounding only if the resulting value is significant, that is, higher than the requested precision
 func roundSignificant(innerQuery string, precision float64) string {
 	return fmt.Sprintf("round(%s, %f) > %f or %s", innerQuery, precision, precision, innerQuery)
@@ -281,6 +281,31 @@ func (o *PromClientMock) GetWorkloadRequestRates(namespace, workload, ratesInter
 	return args.Get(0).(model.Vector), args.Get(1).(model.Vector), args.Error(2)
 }
 
+func (o *PromClientMock) GetAllRequestRatesRange(namespace, ratesInterval string, bounds prom_v1.Range) (model.Matrix, error) {
+	args := o.Called(namespace, ratesInterval, bounds)
+	return args.Get(0).(model.Matrix), args.Error(1)
+}
+
+func (o *PromClientMock) GetNamespaceServicesRequestRatesRange(namespace, ratesInterval string, bounds prom_v1.Range) (model.Matrix, error) {
+	args := o.Called(namespace, ratesInterval, bounds)
+	return args.Get(0).(model.Matrix), args.Error(1)
+}
+
+func (o *PromClientMock) GetServiceRequestRatesRange(namespace, service, ratesInterval string, bounds prom_v1.Range) (model.Matrix, error) {
+	args := o.Called(namespace, service, ratesInterval, bounds)
+	return args.Get(0).(model.Matrix), args.Error(1)
+}
+
+func (o *PromClientMock) GetAppRequestRatesRange(namespace, app, ratesInterval string, bounds prom_v1.Range) (model.Matrix, model.Matrix, error) {
+	args := o.Called(namespace, app, ratesInterval, bounds)
+	return args.Get(0).(model.Matrix), args.Get(1).(model.Matrix), args.Error(2)
+}
+
+func (o *PromClientMock) GetWorkloadRequestRatesRange(namespace, workload, ratesInterval string, bounds prom_v1.Range) (model.Matrix, model.Matrix, error) {
+	args := o.Called(namespace, workload, ratesInterval, bounds)
+	return args.Get(0).(model.Matrix), args.Get(1).(model.Matrix), args.Error(2)
+}
+
 func (o *PromClientMock) FetchRange(metricName, labels, grouping, aggregator string, q *prometheus.RangeQuery) prometheus.Metric {
 	args := o.Called(metricName, labels, grouping, aggregator, q)
 	return args.Get(0).(prometheus.Metric)
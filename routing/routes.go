@@ -221,6 +221,27 @@ func NewRoutes() (r *Routes) {
 			handlers.IstioConfigPermissions,
 			true,
 		},
+		// swagger:route GET /istio/search config istioConfigSearch
+		// ---
+		// Endpoint to search the Istio objects of all the accessible namespaces by host, label selector, workload selector or spec fields
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200: istioConfigSearchResponse
+		//
+		{
+			"IstioConfigSearch",
+			"GET",
+			"/api/istio/search",
+			handlers.IstioConfigSearch,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/istio config istioConfigList
 		// ---
 		// Endpoint to get the list of Istio Config of a namespace
@@ -266,6 +287,7 @@ func NewRoutes() (r *Routes) {
 		// swagger:route DELETE /namespaces/{namespace}/istio/{object_type}/{object} config istioConfigDelete
 		// ---
 		// Endpoint to delete the Istio Config of an (arbitrary) Istio object
+		// With resourceVersion set, the object is only deleted if it wasn't modified since that version.
 		//
 		//     Produces:
 		//     - application/json
@@ -274,6 +296,7 @@ func NewRoutes() (r *Routes) {
 		//
 		// responses:
 		//      404: notFoundError
+		//      409: istioConfigConflictResponse
 		//      500: internalError
 		//      200
 		//
@@ -287,6 +310,9 @@ func NewRoutes() (r *Routes) {
 		// swagger:route PATCH /namespaces/{namespace}/istio/{object_type}/{object} config istioConfigUpdate
 		// ---
 		// Endpoint to update the Istio Config of an Istio object used for templates and adapters using Json Merge Patch strategy.
+		// With dryRun=true the patch is only validated and the dry-run result is returned.
+		// With resourceVersion set, the patch is only applied if the object wasn't modified since that version.
+		// With apply=true the body is the full object, applied with server-side apply by the kiali field manager.
 		//
 		//     Consumes:
 		//	   - application/json
@@ -299,6 +325,7 @@ func NewRoutes() (r *Routes) {
 		// responses:
 		//      400: badRequestError
 		//      404: notFoundError
+		//      409: istioConfigConflictResponse
 		//      500: internalError
 		//      200: istioConfigDetailsResponse
 		//
@@ -309,9 +336,216 @@ func NewRoutes() (r *Routes) {
 			handlers.IstioConfigUpdate,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/istio/{object_type}/{object}/references config istioConfigReferences
+		// ---
+		// Endpoint to get the Istio objects, services and workloads referenced by an Istio object, and the Istio objects referencing it
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: istioConfigReferencesResponse
+		//
+		{
+			"IstioConfigReferences",
+			"GET",
+			"/api/namespaces/{namespace}/istio/{object_type}/{object}/references",
+			handlers.IstioConfigReferences,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/istio/{object_type}/{object}/impact config istioConfigImpact
+		// ---
+		// Endpoint to get the workloads, pods, services and gateways affected by an Istio object, with the current request rates through them
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: istioConfigImpactResponse
+		//
+		{
+			"IstioConfigImpact",
+			"GET",
+			"/api/namespaces/{namespace}/istio/{object_type}/{object}/impact",
+			handlers.IstioConfigImpact,
+			true,
+		},
+		// swagger:route POST /namespaces/{namespace}/istio/{object_type}/impact config istioConfigProposedImpact
+		// ---
+		// Endpoint to get the workloads, pods, services and gateways that would be affected by the Istio object of the request body, if it was created or replaced the object of the same name
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: istioConfigImpactResponse
+		//
+		{
+			"IstioConfigProposedImpact",
+			"POST",
+			"/api/namespaces/{namespace}/istio/{object_type}/impact",
+			handlers.IstioConfigProposedImpact,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/istio/{object_type}/{object}/history config istioConfigHistory
+		// ---
+		// Endpoint to list the revisions recorded for the changes of an Istio object made through Kiali
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: istioConfigHistoryResponse
+		//
+		{
+			"IstioConfigHistory",
+			"GET",
+			"/api/namespaces/{namespace}/istio/{object_type}/{object}/history",
+			handlers.IstioConfigHistory,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/istio/{object_type}/{object}/history/diff config istioConfigRevisionsDiff
+		// ---
+		// Endpoint to compare an Istio object as it was left by two of its revisions
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: istioConfigRevisionDiffResponse
+		//
+		{
+			"IstioConfigRevisionsDiff",
+			"GET",
+			"/api/namespaces/{namespace}/istio/{object_type}/{object}/history/diff",
+			handlers.IstioConfigRevisionsDiff,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/istio/{object_type}/{object}/history/{revision} config istioConfigRevision
+		// ---
+		// Endpoint to get a revision of an Istio object, with the whole object before and after the change
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: istioConfigRevisionResponse
+		//
+		{
+			"IstioConfigRevision",
+			"GET",
+			"/api/namespaces/{namespace}/istio/{object_type}/{object}/history/{revision:[0-9]+}",
+			handlers.IstioConfigRevision,
+			true,
+		},
+		// swagger:route POST /namespaces/{namespace}/istio/{object_type}/{object}/history/{revision}/rollback config istioConfigRollback
+		// ---
+		// Endpoint to restore an Istio object as it was left by one of its revisions. The rollback is recorded as a new revision.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: istioConfigDetailsResponse
+		//
+		{
+			"IstioConfigRollback",
+			"POST",
+			"/api/namespaces/{namespace}/istio/{object_type}/{object}/history/{revision:[0-9]+}/rollback",
+			handlers.IstioConfigRollback,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/istio/export config istioConfigExport
+		// ---
+		// Endpoint to export the Istio objects of a namespace as apply-ready YAML or as a tar archive with a file per object.
+		// Fields populated by the server (resourceVersion, uid, managedFields, status...) are removed.
+		//
+		//     Produces:
+		//     - application/yaml
+		//     - application/x-tar
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200
+		//
+		{
+			"IstioConfigExport",
+			"GET",
+			"/api/namespaces/{namespace}/istio/export",
+			handlers.IstioConfigExport,
+			true,
+		},
+		// swagger:route POST /namespaces/{namespace}/istio/import config istioConfigImport
+		// ---
+		// Endpoint to create or update the Istio objects of a multi-document YAML bundle in a namespace.
+		// Objects are applied in dependency order: Gateways, ServiceEntries, DestinationRules, VirtualServices and then the rest.
+		// Nothing is applied when any document is invalid. With dryRun=true the bundle is only previewed.
+		//
+		//     Consumes:
+		//     - application/yaml
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200: istioConfigImportResponse
+		//
+		{
+			"IstioConfigImport",
+			"POST",
+			"/api/namespaces/{namespace}/istio/import",
+			handlers.IstioConfigImport,
+			true,
+		},
 		// swagger:route POST /namespaces/{namespace}/istio/{object_type} config istioConfigCreate
 		// ---
 		// Endpoint to create an Istio object by using an Istio Config item
+		// With dryRun=true the object is only validated and the dry-run result is returned.
 		//
 		//     Produces:
 		//     - application/json
@@ -373,6 +607,149 @@ func NewRoutes() (r *Routes) {
 			handlers.ServiceDetails,
 			true,
 		},
+		// swagger:route PUT /namespaces/{namespace}/services/{service}/traffic services serviceTrafficWizard
+		// ---
+		// Endpoint to generate, validate and apply the VirtualService and DestinationRule of a service from a traffic
+		// management wizard: weighted routing, request routing or fault injection.
+		// Objects not generated by a wizard are never overwritten. With dryRun=true the objects are only previewed.
+		//
+		//     Consumes:
+		//     - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      409: conflictError
+		//      500: internalError
+		//      200: trafficWizardResponse
+		//
+		{
+			"ServiceTrafficWizard",
+			"PUT",
+			"/api/namespaces/{namespace}/services/{service}/traffic",
+			handlers.ServiceTrafficWizard,
+			true,
+		},
+		// swagger:route DELETE /namespaces/{namespace}/services/{service}/traffic services serviceTrafficWizardDelete
+		// ---
+		// Endpoint to delete the VirtualService and DestinationRule generated by a traffic management wizard for a service
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      404: notFoundError
+		//      409: conflictError
+		//      500: internalError
+		//      200
+		//
+		{
+			"ServiceTrafficWizardDelete",
+			"DELETE",
+			"/api/namespaces/{namespace}/services/{service}/traffic",
+			handlers.ServiceTrafficWizardDelete,
+			true,
+		},
+		// swagger:route POST /namespaces/{namespace}/services/{service}/faultexperiments services serviceFaultExperimentStart
+		// ---
+		// Endpoint to inject faults in the requests to a service for a limited time. The faults are removed when the
+		// duration expires or when the error rate seen by the callers of the service crosses its threshold.
+		//
+		//     Consumes:
+		//     - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      409: conflictError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: faultExperimentResponse
+		//
+		{
+			"ServiceFaultExperimentStart",
+			"POST",
+			"/api/namespaces/{namespace}/services/{service}/faultexperiments",
+			handlers.ServiceFaultExperimentStart,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/services/{service}/faultexperiments services serviceFaultExperimentList
+		// ---
+		// Endpoint to get the fault experiments of a service, oldest first
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      404: notFoundError
+		//      500: internalError
+		//      200: faultExperimentsResponse
+		//
+		{
+			"ServiceFaultExperimentList",
+			"GET",
+			"/api/namespaces/{namespace}/services/{service}/faultexperiments",
+			handlers.ServiceFaultExperimentList,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/services/{service}/faultexperiments/{experiment} services serviceFaultExperimentDetails
+		// ---
+		// Endpoint to get a fault experiment of a service, with the traffic before, during and after it
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      404: notFoundError
+		//      500: internalError
+		//      200: faultExperimentResponse
+		//
+		{
+			"ServiceFaultExperimentDetails",
+			"GET",
+			"/api/namespaces/{namespace}/services/{service}/faultexperiments/{experiment}",
+			handlers.ServiceFaultExperimentDetails,
+			true,
+		},
+		// swagger:route DELETE /namespaces/{namespace}/services/{service}/faultexperiments/{experiment} services serviceFaultExperimentStop
+		// ---
+		// Endpoint to remove the faults of a running experiment before its duration expires
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      409: conflictError
+		//      500: internalError
+		//      200: faultExperimentResponse
+		//
+		{
+			"ServiceFaultExperimentStop",
+			"DELETE",
+			"/api/namespaces/{namespace}/services/{service}/faultexperiments/{experiment}",
+			handlers.ServiceFaultExperimentStop,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/apps/{app}/spans traces appSpans
 		// ---
 		// Endpoint to get Jaeger spans for a given app
@@ -960,12 +1337,125 @@ func NewRoutes() (r *Routes) {
 			handlers.NamespaceHealth,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/health/status namespaces namespaceHealthStatus
+		// ---
+		// Get the health status of the given namespace, the worst of the statuses of its apps, services or workloads,
+		// with the entities which are not healthy
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: namespaceHealthStatusResponse
+		//      400: badRequestError
+		//      500: internalError
+		//
+		{
+			"NamespaceHealthStatus",
+			"GET",
+			"/api/namespaces/{namespace}/health/status",
+			handlers.NamespaceHealthStatus,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/services/{service}/health/history services serviceHealthHistory
+		// ---
+		// Get the health status over time of the given service, evaluated from its error rates in buckets of the given step
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: healthHistoryResponse
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//
+		{
+			"ServiceHealthHistory",
+			"GET",
+			"/api/namespaces/{namespace}/services/{service}/health/history",
+			handlers.ServiceHealthHistory,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/apps/{app}/health/history apps appHealthHistory
+		// ---
+		// Get the health status over time of the given app, evaluated from its error rates in buckets of the given step
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: healthHistoryResponse
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//
+		{
+			"AppHealthHistory",
+			"GET",
+			"/api/namespaces/{namespace}/apps/{app}/health/history",
+			handlers.AppHealthHistory,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/workloads/{workload}/health/history workloads workloadHealthHistory
+		// ---
+		// Get the health status over time of the given workload, evaluated from its error rates in buckets of the given step
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: healthHistoryResponse
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//
+		{
+			"WorkloadHealthHistory",
+			"GET",
+			"/api/namespaces/{namespace}/workloads/{workload}/health/history",
+			handlers.WorkloadHealthHistory,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/health/history namespaces namespaceHealthHistory
+		// ---
+		// Get the health status over time of the given namespace, the worst of the statuses of its apps, services or workloads in
+		// buckets of the given step, with the periods of every status
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: healthHistoryResponse
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//
+		{
+			"NamespaceHealthHistory",
+			"GET",
+			"/api/namespaces/{namespace}/health/history",
+			handlers.NamespaceHealthHistory,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/validations namespaces namespaceValidations
 		// ---
-		// Get validation summary for all objects in the given namespace
+		// Get validation summary for all objects in the given namespace.
+		// With format=sarif or format=junit, the checks of every object are returned as a SARIF or JUnit report.
 		//
 		//     Produces:
 		//     - application/json
+		//     - application/xml
 		//
 		//     Schemes: http, https
 		//
@@ -981,6 +1471,27 @@ func NewRoutes() (r *Routes) {
 			handlers.NamespaceValidationSummary,
 			true,
 		},
+		// swagger:route GET /mesh/validations namespaces meshValidations
+		// ---
+		// Get validation summary for all the namespaces of the mesh, with the most common checks and the objects with more errors and warnings.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: meshValidationSummaryResponse
+		//      400: badRequestError
+		//      500: internalError
+		//
+		{
+			"MeshValidationSummary",
+			"GET",
+			"/api/mesh/validations",
+			handlers.MeshValidationSummary,
+			true,
+		},
 		// swagger:route GET /mesh/tls tls meshTls
 		// ---
 		// Get TLS status for the whole mesh
@@ -1023,6 +1534,27 @@ func NewRoutes() (r *Routes) {
 			handlers.NamespaceTls,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/tls/workloads tls namespaceWorkloadsTls
+		// ---
+		// Get the effective mTLS status of every workload of the given namespace, per port, with the policies deciding it
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: namespaceWorkloadsTlsResponse
+		//      404: notFoundError
+		//      500: internalError
+		//
+		{
+			"NamespaceWorkloadsTls",
+			"GET",
+			"/api/namespaces/{namespace}/tls/workloads",
+			handlers.NamespaceWorkloadsTls,
+			true,
+		},
 		// swagger:route GET /istio/status status istioStatus
 		// ---
 		// Get the status of each components needed in the control plane
@@ -1044,6 +1576,28 @@ func NewRoutes() (r *Routes) {
 			handlers.IstioStatus,
 			true,
 		},
+		// swagger:route GET /istio/upgrade status istioUpgradeReadiness
+		// ---
+		// Get the deprecated and removed API versions, fields and features used by the mesh, and the skewed proxies,
+		// before upgrading Istio to a target version
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: istioUpgradeReadinessResponse
+		//      400: badRequestError
+		//      500: internalError
+		//
+		{
+			"IstioUpgradeReadiness",
+			"GET",
+			"/api/istio/upgrade",
+			handlers.IstioUpgradeReadiness,
+			true,
+		},
 		// swagger:route GET /namespaces/graph graphs graphNamespaces
 		// ---
 		// The backing JSON for a namespaces graph.
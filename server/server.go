@@ -87,6 +87,12 @@ func (s *Server) Start() {
 	if conf.Server.MetricsEnabled {
 		StartMetricsServer()
 	}
+
+	// Start the background promotion of the canaries, if enabled
+	business.StartCanaryController()
+
+	// Start removing the faults of the experiments once they end
+	business.StartFaultExperimentWatcher()
 }
 
 // Stop the HTTP server
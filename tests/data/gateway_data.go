@@ -56,3 +56,15 @@ func AddGatewaysToVirtualService(gateways []string, vs kubernetes.IstioObject) k
 	vs.GetSpec()["gateways"] = gates
 	return vs
 }
+
+func AddTLSToServer(mode, credentialName string, server map[string]interface{}) map[string]interface{} {
+	tls := map[string]interface{}{}
+	if mode != "" {
+		tls["mode"] = mode
+	}
+	if credentialName != "" {
+		tls["credentialName"] = credentialName
+	}
+	server["tls"] = tls
+	return server
+}
@@ -0,0 +1,95 @@
+package data
+
+import (
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func CreateK8sGatewayClass(name, controllerName string) kubernetes.IstioObject {
+	gatewayClass := kubernetes.GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: name,
+		},
+		Spec: map[string]interface{}{
+			"controllerName": controllerName,
+		},
+	}
+	return &gatewayClass
+}
+
+func CreateK8sGateway(name, namespace, gatewayClassName string) kubernetes.IstioObject {
+	gateway := kubernetes.GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: map[string]interface{}{
+			"gatewayClassName": gatewayClassName,
+			"listeners":        []interface{}{},
+		},
+	}
+	return &gateway
+}
+
+func AddListenerToK8sGateway(name, hostname string, port int, protocol string, gw kubernetes.IstioObject) kubernetes.IstioObject {
+	listener := map[string]interface{}{
+		"name":     name,
+		"port":     port,
+		"protocol": protocol,
+	}
+	if hostname != "" {
+		listener["hostname"] = hostname
+	}
+	gw.GetSpec()["listeners"] = append(gw.GetSpec()["listeners"].([]interface{}), listener)
+	return gw
+}
+
+func CreateK8sRoute(name, namespace string) kubernetes.IstioObject {
+	route := kubernetes.GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: map[string]interface{}{
+			"parentRefs": []interface{}{},
+			"rules":      []interface{}{},
+		},
+	}
+	return &route
+}
+
+func AddParentRefToK8sRoute(name, namespace string, route kubernetes.IstioObject) kubernetes.IstioObject {
+	parentRef := map[string]interface{}{
+		"name": name,
+	}
+	if namespace != "" {
+		parentRef["namespace"] = namespace
+	}
+	route.GetSpec()["parentRefs"] = append(route.GetSpec()["parentRefs"].([]interface{}), parentRef)
+	return route
+}
+
+// AddRuleToK8sRoute adds a rule sending the traffic to the services, given as name or namespace/name
+func AddRuleToK8sRoute(backends []map[string]interface{}, route kubernetes.IstioObject) kubernetes.IstioObject {
+	backendRefs := make([]interface{}, 0, len(backends))
+	for _, b := range backends {
+		backendRefs = append(backendRefs, b)
+	}
+	rule := map[string]interface{}{
+		"backendRefs": backendRefs,
+	}
+	route.GetSpec()["rules"] = append(route.GetSpec()["rules"].([]interface{}), rule)
+	return route
+}
+
+func CreateK8sBackendRef(name, namespace string, port int) map[string]interface{} {
+	backendRef := map[string]interface{}{
+		"name": name,
+		"port": port,
+	}
+	if namespace != "" {
+		backendRef["namespace"] = namespace
+	}
+	return backendRef
+}
@@ -0,0 +1,51 @@
+package data
+
+import (
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func CreateTelemetry(name, namespace string, selector map[string]interface{}) kubernetes.IstioObject {
+	telemetry := kubernetes.GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: map[string]interface{}{
+			"metrics": []interface{}{},
+		},
+	}
+	if selector != nil {
+		telemetry.Spec["selector"] = map[string]interface{}{
+			"matchLabels": selector,
+		}
+	}
+	return &telemetry
+}
+
+// AddMetricsToTelemetry adds a metrics configuration for the providers, where every override disables a metric.
+// An empty metric name disables all of them.
+func AddMetricsToTelemetry(providers []string, disabledMetrics []string, telemetry kubernetes.IstioObject) kubernetes.IstioObject {
+	metrics := map[string]interface{}{}
+	if len(providers) > 0 {
+		ps := make([]interface{}, 0, len(providers))
+		for _, p := range providers {
+			ps = append(ps, map[string]interface{}{"name": p})
+		}
+		metrics["providers"] = ps
+	}
+	overrides := make([]interface{}, 0, len(disabledMetrics))
+	for _, m := range disabledMetrics {
+		override := map[string]interface{}{
+			"disabled": true,
+		}
+		if m != "" {
+			override["match"] = map[string]interface{}{"metric": m}
+		}
+		overrides = append(overrides, override)
+	}
+	metrics["overrides"] = overrides
+	telemetry.GetSpec()["metrics"] = append(telemetry.GetSpec()["metrics"].([]interface{}), metrics)
+	return telemetry
+}
@@ -0,0 +1,351 @@
+package mtls
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	// Istio uses PERMISSIVE when no PeerAuthentication sets the mode
+	defaultPeerAuthnMode = "PERMISSIVE"
+
+	PolicyScopeMesh      = "mesh"
+	PolicyScopeNamespace = "namespace"
+	PolicyScopeWorkload  = "workload"
+	PolicyScopePort      = "port"
+	PolicyScopeService   = "service"
+)
+
+// WorkloadMtls resolves the effective mTLS of the workloads of a namespace
+type WorkloadMtls struct {
+	Namespace string
+	// PeerAuthentications of the Istio root namespace
+	MeshPeerAuthentications []kubernetes.IstioObject
+	// PeerAuthentications of the namespace
+	PeerAuthentications []kubernetes.IstioObject
+	// DestinationRules of the namespace and of the Istio root namespace
+	DestinationRules []kubernetes.IstioObject
+	AutoMtlsEnabled  bool
+}
+
+// WorkloadStatus returns the effective mTLS of the workload on every port of the services that select it.
+// The mode the workload accepts is resolved from port-level, workload, namespace and mesh PeerAuthentications,
+// in that order. The mode clients of the namespace use is resolved from the DestinationRules of the service host visible
+// to them or, when they don't set any TLS mode, from auto mTLS. Named target ports are resolved from the containers
+// of the pods of the workload.
+func (w WorkloadMtls) WorkloadStatus(name string, workloadLabels map[string]string, istioSidecar bool, services []core_v1.Service, pods []core_v1.Pod) models.WorkloadMTLSStatus {
+	status := models.WorkloadMTLSStatus{
+		Workload:     name,
+		Namespace:    w.Namespace,
+		IstioSidecar: istioSidecar,
+		Ports:        []models.PortMTLSStatus{},
+	}
+	if !istioSidecar {
+		// Without a proxy the workload can't take part on mTLS
+		status.Status = MTLSNotEnabled
+		return status
+	}
+
+	workloadMode, workloadPolicy, workloadPA := w.workloadPeerAuthnMode(workloadLabels)
+	for _, svc := range services {
+		for _, port := range svc.Spec.Ports {
+			portStatus := models.PortMTLSStatus{
+				Service:      svc.Name,
+				Port:         port.Port,
+				ServerMode:   workloadMode,
+				ServerPolicy: workloadPolicy,
+			}
+			if port.TargetPort.Type == intstr.String {
+				portStatus.TargetPortName = port.TargetPort.StrVal
+			}
+			portStatus.TargetPort = targetPort(port, pods)
+			// Port-level modes of an unresolved named port are unknown, the workload mode is shown
+			if mode := peerAuthnPortMode(workloadPA, portStatus.TargetPort); portStatus.TargetPort > 0 && mode != "" {
+				portStatus.ServerMode = mode
+				portStatus.ServerPolicy = policyRef(models.ObjectTypeSingular[kubernetes.PeerAuthentications], workloadPA, PolicyScopePort)
+			}
+			w.resolveClientMode(&portStatus, svc, port.Port)
+			resolvePortStatus(&portStatus)
+			status.Ports = append(status.Ports, portStatus)
+		}
+	}
+
+	// Workloads without services are only reached through their pods, by clients that use auto mTLS
+	if len(status.Ports) == 0 {
+		portStatus := models.PortMTLSStatus{ServerMode: workloadMode, ServerPolicy: workloadPolicy}
+		w.resolveClientMode(&portStatus, core_v1.Service{}, 0)
+		resolvePortStatus(&portStatus)
+		status.Ports = append(status.Ports, portStatus)
+	}
+
+	for i, port := range status.Ports {
+		if i == 0 {
+			status.Status = port.Status
+		} else if status.Status != port.Status {
+			status.Status = MTLSPartiallyEnabled
+		}
+	}
+	return status
+}
+
+// workloadPeerAuthnMode returns the mode set by the most specific PeerAuthentication that applies to the workload,
+// the reference to that PeerAuthentication and the workload PeerAuthentication, if any, for port-level modes.
+// UNSET modes inherit from the parent scope.
+func (w WorkloadMtls) workloadPeerAuthnMode(workloadLabels map[string]string) (string, *models.MTLSPolicyRef, kubernetes.IstioObject) {
+	var workloadPA kubernetes.IstioObject
+	for _, pa := range w.PeerAuthentications {
+		if selector := peerAuthnSelector(pa); selector != nil && selector.Matches(labels.Set(workloadLabels)) {
+			workloadPA = pa
+			break
+		}
+	}
+
+	candidates := []struct {
+		scope string
+		pa    kubernetes.IstioObject
+	}{
+		{PolicyScopeWorkload, workloadPA},
+		{PolicyScopeNamespace, namespacePeerAuthn(w.PeerAuthentications)},
+		{PolicyScopeMesh, namespacePeerAuthn(w.MeshPeerAuthentications)},
+	}
+	for _, candidate := range candidates {
+		if candidate.pa == nil {
+			continue
+		}
+		if mode := peerAuthnMode(candidate.pa.GetSpec()["mtls"]); mode != "" {
+			return mode, policyRef(models.ObjectTypeSingular[kubernetes.PeerAuthentications], candidate.pa, candidate.scope), workloadPA
+		}
+	}
+	return defaultPeerAuthnMode, nil, workloadPA
+}
+
+// resolveClientMode sets the TLS mode of the clients of the service port, from auto mTLS or from the most specific
+// DestinationRule with a TLS mode for the service host. Like Istio, DestinationRules of the namespace of the clients,
+// i.e. the one of the service, are looked up before the ones of the root namespace, and only those exported to the
+// clients are used.
+func (w WorkloadMtls) resolveClientMode(portStatus *models.PortMTLSStatus, svc core_v1.Service, port int32) {
+	if svc.Name != "" {
+		domain := config.Get().ExternalServices.Istio.IstioIdentityDomain
+		hosts := []struct {
+			scope string
+			match func(host, namespace string) bool
+		}{
+			{PolicyScopeService, func(host, namespace string) bool { return serviceHost(host, namespace, svc) }},
+			{PolicyScopeNamespace, func(host, _ string) bool { return host == fmt.Sprintf("*.%s.%s", svc.Namespace, domain) }},
+			{PolicyScopeMesh, func(host, _ string) bool { return host == "*.local" || host == "*" }},
+		}
+		for _, namespace := range []string{svc.Namespace, config.Get().IstioNamespace} {
+			for _, h := range hosts {
+				for _, dr := range w.DestinationRules {
+					host, _ := dr.GetSpec()["host"].(string)
+					if dr.GetObjectMeta().Namespace != namespace || !exportedTo(dr, svc.Namespace) || !h.match(host, namespace) {
+						continue
+					}
+					if mode := destinationRuleTLSMode(dr, port); mode != "" {
+						portStatus.ClientMode = mode
+						portStatus.ClientPolicy = policyRef(models.ObjectTypeSingular[kubernetes.DestinationRules], dr, h.scope)
+						return
+					}
+				}
+			}
+		}
+	}
+
+	// Auto mTLS sends mTLS to workloads with proxies, unless they don't accept it
+	portStatus.AutoMtls = w.AutoMtlsEnabled
+	if w.AutoMtlsEnabled && portStatus.ServerMode != "DISABLE" {
+		portStatus.ClientMode = "ISTIO_MUTUAL"
+	} else {
+		portStatus.ClientMode = "DISABLE"
+	}
+}
+
+// targetPort returns the port of the workload targeted by the service port. Named ports are looked up in the
+// containers of the pods, 0 is returned when none of them defines it.
+func targetPort(port core_v1.ServicePort, pods []core_v1.Pod) int32 {
+	if port.TargetPort.Type == intstr.String {
+		for _, pod := range pods {
+			for _, container := range pod.Spec.Containers {
+				for _, containerPort := range container.Ports {
+					if containerPort.Name == port.TargetPort.StrVal {
+						return containerPort.ContainerPort
+					}
+				}
+			}
+		}
+		return 0
+	}
+	if port.TargetPort.IntValue() > 0 {
+		return int32(port.TargetPort.IntValue())
+	}
+	return port.Port
+}
+
+// serviceHost returns true when the host of a DestinationRule of the namespace is the service. Short names are
+// resolved in the namespace of the DestinationRule.
+func serviceHost(host, namespace string, svc core_v1.Service) bool {
+	if !strings.Contains(host, ".") {
+		return host == svc.Name && namespace == svc.Namespace
+	}
+	return kubernetes.FilterByHost(host, svc.Name, svc.Namespace)
+}
+
+// exportedTo returns true when the DestinationRule is visible to the namespace. It is exported to all the namespaces
+// when exportTo is not set.
+func exportedTo(dr kubernetes.IstioObject, namespace string) bool {
+	exportTo, ok := dr.GetSpec()["exportTo"].([]interface{})
+	if !ok || len(exportTo) == 0 {
+		return true
+	}
+	for _, e := range exportTo {
+		switch e {
+		case "*", namespace:
+			return true
+		case ".":
+			if dr.GetObjectMeta().Namespace == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolvePortStatus sets the status of the port from the modes used by the workload and its clients.
+// There is a conflict when they don't agree, as traffic is rejected.
+func resolvePortStatus(portStatus *models.PortMTLSStatus) {
+	mutual := portStatus.ClientMode == "ISTIO_MUTUAL" || portStatus.ClientMode == "MUTUAL"
+	switch portStatus.ServerMode {
+	case "STRICT":
+		if mutual {
+			portStatus.Status = MTLSEnabled
+		} else {
+			portStatus.Status = MTLSPartiallyEnabled
+			portStatus.Conflict = true
+		}
+	case "DISABLE":
+		if mutual {
+			portStatus.Status = MTLSPartiallyEnabled
+			portStatus.Conflict = true
+		} else {
+			portStatus.Status = MTLSDisabled
+		}
+	default:
+		if mutual {
+			portStatus.Status = MTLSPartiallyEnabled
+		} else {
+			portStatus.Status = MTLSNotEnabled
+		}
+	}
+}
+
+// namespacePeerAuthn returns the PeerAuthentication without selector, which applies to the whole namespace
+func namespacePeerAuthn(peerAuthns []kubernetes.IstioObject) kubernetes.IstioObject {
+	for _, pa := range peerAuthns {
+		if !pa.HasMatchLabelsSelector() {
+			return pa
+		}
+	}
+	return nil
+}
+
+func peerAuthnSelector(pa kubernetes.IstioObject) labels.Selector {
+	if !pa.HasMatchLabelsSelector() {
+		return nil
+	}
+	matchLabels := map[string]string{}
+	selector := pa.GetSpec()["selector"].(map[string]interface{})
+	for k, v := range selector["matchLabels"].(map[string]interface{}) {
+		if value, ok := v.(string); ok {
+			matchLabels[k] = value
+		}
+	}
+	return labels.SelectorFromSet(matchLabels)
+}
+
+// peerAuthnMode returns the mode of an mtls field of a PeerAuthentication, or "" when it is not set or UNSET
+func peerAuthnMode(mtls interface{}) string {
+	mtlsMap, ok := mtls.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	mode, _ := mtlsMap["mode"].(string)
+	if mode == "UNSET" {
+		return ""
+	}
+	return mode
+}
+
+func peerAuthnPortMode(pa kubernetes.IstioObject, port int32) string {
+	if pa == nil {
+		return ""
+	}
+	portLevelMtls, ok := pa.GetSpec()["portLevelMtls"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	return peerAuthnMode(portLevelMtls[strconv.Itoa(int(port))])
+}
+
+// destinationRuleTLSMode returns the TLS mode of the DestinationRule for the port, where port-level settings
+// override the traffic policy of the DestinationRule
+func destinationRuleTLSMode(dr kubernetes.IstioObject, port int32) string {
+	trafficPolicy, ok := dr.GetSpec()["trafficPolicy"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if portSettings, ok := trafficPolicy["portLevelSettings"].([]interface{}); ok && port > 0 {
+		for _, ps := range portSettings {
+			psMap, ok := ps.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			psPort, _ := psMap["port"].(map[string]interface{})
+			if portNumber(psPort["number"]) == port {
+				if mode := tlsMode(psMap["tls"]); mode != "" {
+					return mode
+				}
+			}
+		}
+	}
+	return tlsMode(trafficPolicy["tls"])
+}
+
+// portNumber returns the number of a port parsed from JSON or YAML, or 0 if it isn't a number
+func portNumber(number interface{}) int32 {
+	switch n := number.(type) {
+	case float64:
+		return int32(n)
+	case int64:
+		return int32(n)
+	case int:
+		return int32(n)
+	}
+	return 0
+}
+
+func tlsMode(tls interface{}) string {
+	tlsMap, ok := tls.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	mode, _ := tlsMap["mode"].(string)
+	return mode
+}
+
+func policyRef(objectType string, o kubernetes.IstioObject, scope string) *models.MTLSPolicyRef {
+	return &models.MTLSPolicyRef{
+		ObjectType: objectType,
+		Name:       o.GetObjectMeta().Name,
+		Namespace:  o.GetObjectMeta().Namespace,
+		Scope:      scope,
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// ValidateCommand is the name of the command that validates Istio config offline
+const ValidateCommand = "validate"
+
+// stringsFlag is a flag that can be repeated and/or contain comma separated values
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*f = append(*f, v)
+		}
+	}
+	return nil
+}
+
+// runValidate implements "kiali validate": it loads the Istio and Kubernetes objects found in the given
+// files or directories into an in-memory client and runs all the Kiali validations over them, without a cluster.
+// It returns the exit code: 0 when no errors are found, 1 when validation errors are found and 2 on failure.
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	var files stringsFlag
+	flags := flag.NewFlagSet(ValidateCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Var(&files, "f", "File or directory with the YAML/JSON manifests to validate. Directories are read recursively. Can be repeated.")
+	namespace := flags.String("n", "default", "Namespace of the objects that don't define one.")
+	output := flags.String("o", "text", "Output format: text, json, sarif or junit.")
+	failOnWarnings := flags.Bool("fail-on-warnings", false, "Exit with non-zero code when warnings are found.")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if len(files) == 0 {
+		fmt.Fprintln(stderr, "At least one file or directory is required (-f)")
+		flags.Usage()
+		return 2
+	}
+	switch *output {
+	case "text", "json", "sarif", "junit":
+	default:
+		fmt.Fprintf(stderr, "Unknown output format [%s]\n", *output)
+		return 2
+	}
+
+	client := kubernetes.NewMemoryClient()
+	if err := loadManifests(client, files, *namespace); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	validations, err := validateManifests(client)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error running validations: %v\n", err)
+		return 2
+	}
+
+	switch *output {
+	case "json":
+		err = printValidationsJSON(stdout, validations)
+	case "sarif":
+		err = printJSON(stdout, validations.ToSarif())
+	case "junit":
+		err = printJUnit(stdout, validations.ToJUnit("Kiali validations"))
+	default:
+		err = printValidationsText(stdout, validations)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	errors, warnings := countChecks(validations)
+	if errors > 0 || (*failOnWarnings && warnings > 0) {
+		return 1
+	}
+	return 0
+}
+
+// loadManifests loads into the client all the .yaml, .yml and .json files found in paths
+func loadManifests(client *kubernetes.MemoryClient, paths []string, namespace string) error {
+	for _, path := range paths {
+		err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(file)) {
+			case ".yaml", ".yml", ".json":
+			default:
+				// Explicit files are always loaded, only files found walking directories are filtered
+				if file != path {
+					return nil
+				}
+			}
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if err = client.LoadYAML(f, namespace); err != nil {
+				return fmt.Errorf("Error loading [%s]: %v", file, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Istio mesh config is read by some validations, use Istio defaults when it is not provided
+	conf := config.Get()
+	if !client.HasConfigMap(conf.IstioNamespace, conf.ExternalServices.Istio.ConfigMapName) {
+		client.AddConfigMap(core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{Name: conf.ExternalServices.Istio.ConfigMapName, Namespace: conf.IstioNamespace},
+		})
+	}
+	return nil
+}
+
+// validateManifests runs the validations of every namespace found in the client.
+// Validations of each namespace contribute only with the objects of that namespace.
+func validateManifests(client kubernetes.ClientInterface) (models.IstioValidations, error) {
+	layer := business.NewWithBackends(client, nil, nil)
+	namespaces, err := layer.Namespace.GetNamespaces()
+	if err != nil {
+		return nil, err
+	}
+
+	validations := models.IstioValidations{}
+	for _, ns := range namespaces {
+		nsValidations, err := layer.Validations.GetValidations(ns.Name, "")
+		if err != nil {
+			return nil, err
+		}
+		for key, validation := range nsValidations.FilterByNamespace(ns.Name) {
+			validations[key] = validation
+		}
+	}
+	return validations, nil
+}
+
+func countChecks(validations models.IstioValidations) (errors, warnings int) {
+	for _, validation := range validations {
+		for _, check := range validation.Checks {
+			switch check.Severity {
+			case models.ErrorSeverity:
+				errors++
+			case models.WarningSeverity:
+				warnings++
+			}
+		}
+	}
+	return errors, warnings
+}
+
+func printValidationsText(w io.Writer, validations models.IstioValidations) error {
+	keys := validations.SortedKeys()
+	for _, key := range keys {
+		for _, check := range validations[key].Checks {
+			if _, err := fmt.Fprintf(w, "%-7s %s %s: %s\n", strings.ToUpper(string(check.Severity)), key, check.Path, check.Message); err != nil {
+				return err
+			}
+		}
+	}
+	errors, warnings := countChecks(validations)
+	suppressed := 0
+	for _, validation := range validations {
+		suppressed += len(validation.SuppressedChecks)
+	}
+	_, err := fmt.Fprintf(w, "%d object(s) validated: %d error(s), %d warning(s), %d suppressed check(s)\n", len(keys), errors, warnings, suppressed)
+	return err
+}
+
+// printValidationsJSON prints the validations grouped by namespace, in the same format used by the API
+func printValidationsJSON(w io.Writer, validations models.IstioValidations) error {
+	perNamespace := map[string]models.IstioValidations{}
+	for key, validation := range validations {
+		if _, found := perNamespace[key.Namespace]; !found {
+			perNamespace[key.Namespace] = models.IstioValidations{}
+		}
+		perNamespace[key.Namespace][key] = validation
+	}
+	return printJSON(w, perNamespace)
+}
+
+func printJSON(w io.Writer, payload interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(payload)
+}
+
+func printJUnit(w io.Writer, report models.JUnitTestSuites) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
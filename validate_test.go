@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+)
+
+func TestValidateWithErrors(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := runValidate([]string{"-f", "tests/data/validations/offline/bookinfo.yaml"}, stdout, stderr)
+
+	assert.Equal(1, code)
+	assert.Empty(stderr.String())
+	assert.Contains(stdout.String(), "ERROR   bookinfo/virtualservice/reviews spec/http[0]/route[1]/destination/host: KIA1101")
+	assert.Contains(stdout.String(), "WARNING bookinfo/virtualservice/reviews spec/http[0]/route[0]/destination: KIA1107")
+	assert.Contains(stdout.String(), "1 error(s), 1 warning(s)")
+}
+
+func TestValidateFailOnWarnings(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := runValidate([]string{"-f", "tests/data/validations/offline/bookinfo-fixed.yaml", "-n", "bookinfo"}, stdout, stderr)
+	assert.Equal(0, code)
+	assert.Contains(stdout.String(), "0 error(s), 0 warning(s)")
+
+	code = runValidate([]string{"-f", "tests/data/validations/offline/bookinfo.yaml", "-fail-on-warnings"}, stdout, stderr)
+	assert.Equal(1, code)
+}
+
+func TestValidateJSONOutput(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := runValidate([]string{"-f", "tests/data/validations/offline/bookinfo-fixed.yaml", "-n", "bookinfo", "-o", "json"}, stdout, stderr)
+	assert.Equal(0, code)
+
+	result := map[string]map[string]map[string]interface{}{}
+	assert.NoError(json.Unmarshal(stdout.Bytes(), &result))
+	assert.Contains(result["bookinfo"], "virtualservice")
+	assert.Contains(result["bookinfo"], "destinationrule")
+}
+
+func TestValidateMissingFiles(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	assert.Equal(2, runValidate([]string{}, stdout, stderr))
+	assert.Equal(2, runValidate([]string{"-f", "tests/data/validations/offline/not-found.yaml"}, stdout, stderr))
+}
+
+// TestValidateCommandStdout runs the real command, through the test binary, to check that nothing else than the
+// results is written to stdout
+func TestValidateCommandStdout(t *testing.T) {
+	assert := assert.New(t)
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestSystem$", "-systemTest", ValidateCommand,
+		"-f", "tests/data/validations/offline/bookinfo-fixed.yaml", "-n", "bookinfo", "-o", "json")
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	assert.NoError(cmd.Run())
+
+	result := map[string]map[string]map[string]interface{}{}
+	assert.NoError(json.Unmarshal(stdout.Bytes(), &result), stdout.String())
+	assert.Contains(result["bookinfo"], "virtualservice")
+	assert.Contains(stderr.String(), "No configuration file specified")
+}